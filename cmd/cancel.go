@@ -15,13 +15,16 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/pulumi/pulumi/pkg/util/result"
 
 	"github.com/spf13/cobra"
 
+	"github.com/pulumi/pulumi/pkg/backend"
 	"github.com/pulumi/pulumi/pkg/backend/display"
+	"github.com/pulumi/pulumi/pkg/backend/filestate"
 	"github.com/pulumi/pulumi/pkg/backend/httpstate"
 	"github.com/pulumi/pulumi/pkg/diag/colors"
 	"github.com/pulumi/pulumi/pkg/util/cmdutil"
@@ -37,6 +40,8 @@ func newCancelCmd() *cobra.Command {
 		Long: "Cancel a stack's currently running update, if any.\n" +
 			"\n" +
 			"This command cancels the update currently being applied to a stack if any exists.\n" +
+			"For stacks managed by a local or cloud storage backend, this command instead removes\n" +
+			"any locks held on the stack, allowing another update to proceed.\n" +
 			"Note that this operation is _very dangerous_, and may leave the stack in an\n" +
 			"inconsistent state if a resource operation was pending when the update was canceled.\n" +
 			"\n" +
@@ -61,29 +66,36 @@ func newCancelCmd() *cobra.Command {
 				return result.FromError(err)
 			}
 
-			// Ensure that we are targeting the Pulumi cloud.
-			backend, ok := s.Backend().(httpstate.Backend)
-			if !ok {
-				return result.Error("the `cancel` command is not supported for local stacks")
+			// The cloud backend cancels the update itself, while a local backend can only remove the stack's locks.
+			var cancelCurrentUpdate func(ctx context.Context, stackRef backend.StackReference) error
+			var prompt, msg string
+			stackName := string(s.Ref().Name())
+			switch be := s.Backend().(type) {
+			case httpstate.Backend:
+				cancelCurrentUpdate = be.CancelCurrentUpdate
+				prompt = fmt.Sprintf("This will irreversibly cancel the currently running update for '%s'!", stackName)
+				msg = fmt.Sprintf("The currently running update for '%s' has been canceled!", stackName)
+			case filestate.Backend:
+				cancelCurrentUpdate = be.CancelCurrentUpdate
+				prompt = fmt.Sprintf("This will forcibly remove all locks on '%s', even if an update is running!",
+					stackName)
+				msg = fmt.Sprintf("All locks on '%s' have been removed!", stackName)
+			default:
+				return result.Errorf("the `cancel` command is not supported for stack '%s'", stackName)
 			}
 
 			// Ensure the user really wants to do this.
-			stackName := string(s.Ref().Name())
-			prompt := fmt.Sprintf("This will irreversibly cancel the currently running update for '%s'!", stackName)
 			if !yes && !confirmPrompt(prompt, stackName, opts) {
 				fmt.Println("confirmation declined")
 				return result.Bail()
 			}
 
 			// Cancel the update.
-			if err := backend.CancelCurrentUpdate(commandContext(), s.Ref()); err != nil {
+			if err := cancelCurrentUpdate(commandContext(), s.Ref()); err != nil {
 				return result.FromError(err)
 			}
 
-			msg := fmt.Sprintf(
-				"%sThe currently running update for '%s' has been canceled!%s",
-				colors.SpecAttention, stackName, colors.Reset)
-			fmt.Println(opts.Color.Colorize(msg))
+			fmt.Println(opts.Color.Colorize(colors.SpecAttention + msg + colors.Reset))
 
 			return nil
 		}),
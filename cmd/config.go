@@ -40,6 +40,7 @@ import (
 func newConfigCmd() *cobra.Command {
 	var stack string
 	var showSecrets bool
+	var showOrigin bool
 	var jsonOut bool
 
 	cmd := &cobra.Command{
@@ -47,7 +48,15 @@ func newConfigCmd() *cobra.Command {
 		Short: "Manage configuration",
 		Long: "Lists all configuration values for a specific stack. To add a new configuration value, run\n" +
 			"'pulumi config set'. To remove and existing value run 'pulumi config rm'. To get the value of\n" +
-			"for a specific configuration key, use 'pulumi config get <key-name>'.",
+			"for a specific configuration key, use 'pulumi config get <key-name>'.\n" +
+			"\n" +
+			"A stack's settings file may import shared config layers, such as 'env/prod-common.yaml', by listing\n" +
+			"their paths under 'imports'. Each layer has the same format as a stack's settings file and encrypts\n" +
+			"its secrets with its own secrets provider. Values set by later imports override those set by earlier\n" +
+			"ones, and values set by the stack's settings file override them all. Every stack of a project also\n" +
+			"implicitly imports the project's defaults layer, 'Pulumi.defaults.yaml' alongside the stack settings\n" +
+			"files, if it exists; its values are overridden by all others. Pass --show-origin to see the layer\n" +
+			"from which each value was taken.",
 		Args: cmdutil.NoArgs,
 		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
 			opts := display.Options{
@@ -59,13 +68,16 @@ func newConfigCmd() *cobra.Command {
 				return err
 			}
 
-			return listConfig(stack, showSecrets, jsonOut)
+			return listConfig(stack, showSecrets, showOrigin, jsonOut)
 		}),
 	}
 
 	cmd.Flags().BoolVar(
 		&showSecrets, "show-secrets", false,
 		"Show secret values when listing config instead of displaying blinded values")
+	cmd.Flags().BoolVar(
+		&showOrigin, "show-origin", false,
+		"Show the config layer from which each value was taken")
 	cmd.Flags().BoolVarP(
 		&jsonOut, "json", "j", false,
 		"Emit output as JSON")
@@ -128,6 +140,7 @@ func newConfigGetCmd(stack *string) *cobra.Command {
 
 func newConfigRmCmd(stack *string) *cobra.Command {
 	var path bool
+	var layer string
 
 	rmCmd := &cobra.Command{
 		Use:   "rm <key>",
@@ -154,7 +167,7 @@ func newConfigRmCmd(stack *string) *cobra.Command {
 				return errors.Wrap(err, "invalid configuration key")
 			}
 
-			ps, err := loadProjectStack(s)
+			ps, err := loadConfigLayer(s, layer)
 			if err != nil {
 				return err
 			}
@@ -164,12 +177,15 @@ func newConfigRmCmd(stack *string) *cobra.Command {
 				return err
 			}
 
-			return saveProjectStack(s, ps)
+			return saveConfigLayer(s, layer, ps)
 		}),
 	}
 	rmCmd.PersistentFlags().BoolVar(
 		&path, "path", false,
 		"The key contains a path to a property in a map or list to remove")
+	rmCmd.PersistentFlags().StringVar(
+		&layer, "layer", "",
+		"Remove the value from the given config layer file rather than from the stack's settings file")
 
 	return rmCmd
 }
@@ -249,6 +265,7 @@ func newConfigSetCmd(stack *string) *cobra.Command {
 	var plaintext bool
 	var secret bool
 	var path bool
+	var layer string
 
 	setCmd := &cobra.Command{
 		Use:   "set <key> [value]",
@@ -300,10 +317,19 @@ func newConfigSetCmd(stack *string) *cobra.Command {
 				}
 			}
 
+			// Check the value against the project's config schema, if any.
+			proj, _, err := readProject()
+			if err != nil {
+				return err
+			}
+			if err = checkConfigSchema(proj, key, value, secret, path); err != nil {
+				return err
+			}
+
 			// Encrypt the config value if needed.
 			var v config.Value
 			if secret {
-				c, cerr := getStackEncrypter(s)
+				c, cerr := getConfigLayerEncrypter(s, layer)
 				if cerr != nil {
 					return cerr
 				}
@@ -324,7 +350,7 @@ func newConfigSetCmd(stack *string) *cobra.Command {
 				}
 			}
 
-			ps, err := loadProjectStack(s)
+			ps, err := loadConfigLayer(s, layer)
 			if err != nil {
 				return err
 			}
@@ -334,7 +360,7 @@ func newConfigSetCmd(stack *string) *cobra.Command {
 				return err
 			}
 
-			return saveProjectStack(s, ps)
+			return saveConfigLayer(s, layer, ps)
 		}),
 	}
 
@@ -347,6 +373,10 @@ func newConfigSetCmd(stack *string) *cobra.Command {
 	setCmd.PersistentFlags().BoolVar(
 		&secret, "secret", false,
 		"Encrypt the value instead of storing it in plaintext")
+	setCmd.PersistentFlags().StringVar(
+		&layer, "layer", "",
+		"Set the value in the given config layer file rather than in the stack's settings file; "+
+			"secrets are encrypted with the layer's own secrets provider")
 
 	return setCmd
 }
@@ -414,20 +444,24 @@ type configValueJSON struct {
 	Value       *string     `json:"value,omitempty"`
 	ObjectValue interface{} `json:"objectValue,omitempty"`
 	Secret      bool        `json:"secret"`
+	// When --show-origin was passed, Origin is the config layer from which the value was taken.
+	Origin string `json:"origin,omitempty"`
 }
 
-func listConfig(stack backend.Stack, showSecrets bool, jsonOut bool) error {
-	ps, err := loadProjectStack(stack)
+func listConfig(stack backend.Stack, showSecrets, showOrigin, jsonOut bool) error {
+	layers, err := loadStackConfigLayers(stack)
 	if err != nil {
 		return err
 	}
 
-	cfg := ps.Config
+	cfg, origins := workspace.MergeConfigLayers(layers)
 
 	// By default, we will use a blinding decrypter to show "[secret]". If requested, display secrets in plaintext.
 	decrypter := config.NewBlindingDecrypter()
 	if cfg.HasSecureValue() && showSecrets {
-		dec, decerr := getStackDencrypter(stack)
+		dec, decerr := newConfigLayersDecrypter(stack, layers, cfg, origins, func() (config.Decrypter, error) {
+			return getStackDencrypter(stack)
+		})
 		if decerr != nil {
 			return decerr
 		}
@@ -448,6 +482,9 @@ func listConfig(stack backend.Stack, showSecrets bool, jsonOut bool) error {
 			entry := configValueJSON{
 				Secret: cfg[key].Secure(),
 			}
+			if showOrigin {
+				entry.Origin = configOrigin(origins[key])
+			}
 
 			decrypted, err := cfg[key].Value(decrypter)
 			if err != nil {
@@ -486,11 +523,19 @@ func listConfig(stack backend.Stack, showSecrets bool, jsonOut bool) error {
 				return errors.Wrap(err, "could not decrypt configuration value")
 			}
 
-			rows = append(rows, cmdutil.TableRow{Columns: []string{prettyKey(key), decrypted}})
+			columns := []string{prettyKey(key), decrypted}
+			if showOrigin {
+				columns = append(columns, configOrigin(origins[key]))
+			}
+			rows = append(rows, cmdutil.TableRow{Columns: columns})
 		}
 
+		headers := []string{"KEY", "VALUE"}
+		if showOrigin {
+			headers = append(headers, "ORIGIN")
+		}
 		cmdutil.PrintTable(cmdutil.Table{
-			Headers: []string{"KEY", "VALUE"},
+			Headers: headers,
 			Rows:    rows,
 		})
 	}
@@ -499,12 +544,12 @@ func listConfig(stack backend.Stack, showSecrets bool, jsonOut bool) error {
 }
 
 func getConfig(stack backend.Stack, key config.Key, path, jsonOut bool) error {
-	ps, err := loadProjectStack(stack)
+	layers, err := loadStackConfigLayers(stack)
 	if err != nil {
 		return err
 	}
 
-	cfg := ps.Config
+	cfg, origins := workspace.MergeConfigLayers(layers)
 
 	v, ok, err := cfg.Get(key, path)
 	if err != nil {
@@ -514,7 +559,10 @@ func getConfig(stack backend.Stack, key config.Key, path, jsonOut bool) error {
 		var d config.Decrypter
 		if v.Secure() {
 			var err error
-			if d, err = getStackDencrypter(stack); err != nil {
+			d, err = newConfigLayersDecrypter(stack, layers, cfg, origins, func() (config.Decrypter, error) {
+				return getStackDencrypter(stack)
+			})
+			if err != nil {
 				return errors.Wrap(err, "could not create a decrypter")
 			}
 		} else {
@@ -589,31 +637,59 @@ func looksLikeSecret(k config.Key, v string) bool {
 		(info.Entropy >= (entropyThreshold/2) && entropyPerChar >= entropyPerCharThreshold))
 }
 
+// checkConfigSchema checks a value that is about to be set for the given key against the project's config schema. If
+// the key is a path, only the root of the path is checked; its whole value is checked before the program runs.
+func checkConfigSchema(proj *workspace.Project, key config.Key, value string, secret, path bool) error {
+	if !path {
+		return proj.ValidateConfigValue(key, value, secret)
+	}
+
+	name := key.Name()
+	if i := strings.IndexAny(name, ".["); i > 0 {
+		name = name[:i]
+	}
+	root := config.MustMakeKey(key.Namespace(), name)
+	schema, ok, err := proj.LookupConfigKey(root)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		return proj.ValidateConfigValue(root, value, secret)
+	case schema.ValueType() != workspace.ConfigTypeArray && schema.ValueType() != workspace.ConfigTypeObject:
+		return errors.Errorf("configuration key '%s' is declared with type %s and cannot be set with --path",
+			root, schema.ValueType())
+	case schema.Secret && !secret:
+		return errors.Errorf("configuration key '%s' must be a secret; set it with --secret", root)
+	}
+	return nil
+}
+
 // getStackConfiguration loads configuration information for a given stack. If stackConfigFile is non empty,
 // it is uses instead of the default configuration file for the stack
 func getStackConfiguration(stack backend.Stack, sm secrets.Manager) (backend.StackConfiguration, error) {
-	workspaceStack, err := loadProjectStack(stack)
+	layers, err := loadStackConfigLayers(stack)
 	if err != nil {
 		return backend.StackConfiguration{}, errors.Wrap(err, "loading stack configuration")
 	}
+	cfg, origins := workspace.MergeConfigLayers(layers)
 
 	// If there are no secrets in the configuration, we should never use the decrypter, so it is safe to return
 	// one which panics if it is used. This provides for some nice UX in the common case (since, for example, building
 	// the correct decrypter for the local backend would involve prompting for a passphrase)
-	if !workspaceStack.Config.HasSecureValue() {
+	if !cfg.HasSecureValue() {
 		return backend.StackConfiguration{
-			Config:    workspaceStack.Config,
+			Config:    cfg,
 			Decrypter: config.NewPanicCrypter(),
 		}, nil
 	}
 
-	crypter, err := sm.Decrypter()
+	crypter, err := newConfigLayersDecrypter(stack, layers, cfg, origins, sm.Decrypter)
 	if err != nil {
 		return backend.StackConfiguration{}, errors.Wrap(err, "getting configuration decrypter")
 	}
 
 	return backend.StackConfiguration{
-		Config:    workspaceStack.Config,
+		Config:    cfg,
 		Decrypter: crypter,
 	}, nil
 }
@@ -0,0 +1,120 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"path/filepath"
+
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/backend"
+	"github.com/pulumi/pulumi/pkg/resource/config"
+	"github.com/pulumi/pulumi/pkg/resource/stack"
+	"github.com/pulumi/pulumi/pkg/secrets"
+	"github.com/pulumi/pulumi/pkg/workspace"
+)
+
+// loadStackConfigLayers loads the config layers of the given stack: the layers imported by its settings file, followed
+// by the settings file itself.
+func loadStackConfigLayers(stack backend.Stack) ([]workspace.ConfigLayer, error) {
+	path, err := getProjectStackPath(stack)
+	if err != nil {
+		return nil, err
+	}
+	return workspace.LoadConfigLayers(path)
+}
+
+// getConfigLayerSecretsManager returns the secrets manager for the config layer file at the given path. Layers are
+// shared between stacks, so a layer's secrets are never managed by the service: a layer uses the cloud secrets provider
+// named by its settings, if any, and a passphrase otherwise. A key is created for the layer if it does not have one.
+func getConfigLayerSecretsManager(s backend.Stack, path string) (secrets.Manager, error) {
+	settings, err := workspace.LoadProjectStack(path)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case stack.IsCloudSecretsProvider(settings.SecretsProvider) && settings.EncryptedKey == "":
+		return newCloudSecretsManager(s.Ref().Name(), path, settings.SecretsProvider)
+	case !stack.IsCloudSecretsProvider(settings.SecretsProvider) && settings.EncryptionSalt == "":
+		return newPassphraseSecretsManager(s.Ref().Name(), path)
+	default:
+		return unlockSettingsSecretsManager(settings)
+	}
+}
+
+// configOrigin describes the config layer from which a value was taken, relative to the project's directory if
+// possible.
+func configOrigin(layer *workspace.ConfigLayer) string {
+	projPath, err := workspace.DetectProjectPath()
+	if err != nil || projPath == "" {
+		return layer.Path
+	}
+	rel, err := filepath.Rel(filepath.Dir(projPath), layer.Path)
+	if err != nil {
+		return layer.Path
+	}
+	return rel
+}
+
+// newConfigLayersDecrypter returns a decrypter for the secrets in a stack's effective configuration, each of which may
+// come from any of the stack's config layers. Secrets from other layers are decrypted by the secrets managers of their
+// layers, and the stack's own secrets by the decrypter returned by getStackDecrypter.
+func newConfigLayersDecrypter(stack backend.Stack, layers []workspace.ConfigLayer, cfg config.Map,
+	origins map[config.Key]*workspace.ConfigLayer,
+	getStackDecrypter func() (config.Decrypter, error)) (config.Decrypter, error) {
+
+	return workspace.NewConfigLayersDecrypter(layers, cfg, origins,
+		func(layer *workspace.ConfigLayer) (config.Decrypter, error) {
+			if layer == &layers[len(layers)-1] {
+				return getStackDecrypter()
+			}
+			sm, err := getConfigLayerSecretsManager(stack, layer.Path)
+			if err != nil {
+				return nil, errors.Wrapf(err, "getting secrets manager for config layer %s", configOrigin(layer))
+			}
+			return sm.Decrypter()
+		})
+}
+
+// getConfigLayerEncrypter returns the encrypter for the config layer file at the given path, or for the given stack's
+// own settings file if the path is empty.
+func getConfigLayerEncrypter(stack backend.Stack, layer string) (config.Encrypter, error) {
+	if layer == "" {
+		return getStackEncrypter(stack)
+	}
+	sm, err := getConfigLayerSecretsManager(stack, layer)
+	if err != nil {
+		return nil, err
+	}
+	return sm.Encrypter()
+}
+
+// loadConfigLayer loads the config layer file at the given path, or the given stack's own settings file if the path
+// is empty.
+func loadConfigLayer(stack backend.Stack, layer string) (*workspace.ProjectStack, error) {
+	if layer == "" {
+		return loadProjectStack(stack)
+	}
+	return workspace.LoadProjectStack(layer)
+}
+
+// saveConfigLayer saves the config layer file at the given path, or the given stack's own settings file if the path
+// is empty.
+func saveConfigLayer(stack backend.Stack, layer string, ps *workspace.ProjectStack) error {
+	if layer == "" {
+		return saveProjectStack(stack, ps)
+	}
+	return ps.Save(layer)
+}
@@ -17,6 +17,7 @@ package cmd
 import (
 	"encoding/base64"
 
+	"github.com/pulumi/pulumi/pkg/resource/stack"
 	"github.com/pulumi/pulumi/pkg/secrets"
 	"github.com/pulumi/pulumi/pkg/secrets/cloud"
 	"github.com/pulumi/pulumi/pkg/tokens"
@@ -40,7 +41,6 @@ func newCloudSecretsManager(stackName tokens.QName, configFile, secretsProvider
 		return nil, err
 	}
 
-	var secretsManager *cloud.Manager
 	if info.EncryptedKey == "" {
 		dataKey, err := cloud.GenerateNewDataKey(secretsProvider)
 		if err != nil {
@@ -53,14 +53,5 @@ func newCloudSecretsManager(stackName tokens.QName, configFile, secretsProvider
 		return nil, err
 	}
 
-	dataKey, err := base64.StdEncoding.DecodeString(info.EncryptedKey)
-	if err != nil {
-		return nil, err
-	}
-	secretsManager, err = cloud.NewCloudSecretsManager(secretsProvider, dataKey)
-	if err != nil {
-		return nil, err
-	}
-
-	return secretsManager, nil
+	return stack.NewSettingsSecretsManager(info, nil)
 }
@@ -15,14 +15,11 @@
 package cmd
 
 import (
-	cryptorand "crypto/rand"
-	"encoding/base64"
 	"errors"
-	"fmt"
 	"os"
 
 	"github.com/pulumi/pulumi/pkg/diag"
-	"github.com/pulumi/pulumi/pkg/resource/config"
+	"github.com/pulumi/pulumi/pkg/resource/stack"
 	"github.com/pulumi/pulumi/pkg/secrets"
 	"github.com/pulumi/pulumi/pkg/secrets/passphrase"
 	"github.com/pulumi/pulumi/pkg/tokens"
@@ -41,6 +38,31 @@ func readPassphrase(prompt string) (string, error) {
 	return cmdutil.ReadConsoleNoEcho(prompt)
 }
 
+// readUnlockPassphrase reads the passphrase that unlocks existing config and secrets.
+func readUnlockPassphrase() (string, error) {
+	return readPassphrase("Enter your passphrase to unlock config/secrets\n" +
+		"    (set PULUMI_CONFIG_PASSPHRASE to remember)")
+}
+
+// unlockSettingsSecretsManager returns the secrets manager described by the given stack or config layer settings,
+// which must already record a data key or salt. If the settings use a passphrase, it is read with
+// readUnlockPassphrase, and read again for as long as it is incorrect.
+func unlockSettingsSecretsManager(settings *workspace.ProjectStack) (secrets.Manager, error) {
+	for {
+		sm, err := stack.NewSettingsSecretsManager(settings, readUnlockPassphrase)
+		switch {
+		case err == passphrase.ErrIncorrectPassphrase:
+			cmdutil.Diag().Errorf(diag.Message("", "incorrect passphrase"))
+			continue
+		case err != nil:
+			return nil, err
+		default:
+			contract.Assertf(sm != nil, "settings do not record a data key or salt")
+			return sm, nil
+		}
+	}
+}
+
 func newPassphraseSecretsManager(stackName tokens.QName, configFile string) (secrets.Manager, error) {
 	contract.Assertf(stackName != "", "stackName %s", "!= \"\"")
 
@@ -59,24 +81,7 @@ func newPassphraseSecretsManager(stackName tokens.QName, configFile string) (sec
 
 	// If we have a salt, we can just use it.
 	if info.EncryptionSalt != "" {
-		for {
-			phrase, phraseErr := readPassphrase("Enter your passphrase to unlock config/secrets\n" +
-				"    (set PULUMI_CONFIG_PASSPHRASE to remember)")
-			if phraseErr != nil {
-				return nil, phraseErr
-			}
-
-			sm, smerr := passphrase.NewPassphaseSecretsManager(phrase, info.EncryptionSalt)
-			switch {
-			case smerr == passphrase.ErrIncorrectPassphrase:
-				cmdutil.Diag().Errorf(diag.Message("", "incorrect passphrase"))
-				continue
-			case smerr != nil:
-				return nil, smerr
-			default:
-				return sm, nil
-			}
-		}
+		return unlockSettingsSecretsManager(info)
 	}
 
 	var phrase string
@@ -101,18 +106,12 @@ func newPassphraseSecretsManager(stackName tokens.QName, configFile string) (sec
 		cmdutil.Diag().Errorf(diag.Message("", "passphrases do not match"))
 	}
 
-	// Produce a new salt.
-	salt := make([]byte, 8)
-	_, err = cryptorand.Read(salt)
-	contract.Assertf(err == nil, "could not read from system random")
-
-	// Encrypt a message and store it with the salt so we can test if the password is correct later.
-	crypter := config.NewSymmetricCrypterFromPassphrase(phrase, salt)
-	msg, err := crypter.EncryptValue("pulumi")
-	contract.AssertNoError(err)
-
-	// Now store the result and save it.
-	info.EncryptionSalt = fmt.Sprintf("v1:%s:%s", base64.StdEncoding.EncodeToString(salt), msg)
+	// Produce a new salt, and store it so we can test if the password is correct later.
+	state, err := passphrase.GenerateNewSalt(phrase)
+	if err != nil {
+		return nil, err
+	}
+	info.EncryptionSalt = state
 	if err = info.Save(configFile); err != nil {
 		return nil, err
 	}
@@ -95,6 +95,8 @@ type updateInfoJSON struct {
 	Environment map[string]string          `json:"environment"`
 	Config      map[string]configValueJSON `json:"config"`
 	Result      string                     `json:"result,omitempty"`
+	Version     int                        `json:"version,omitempty"`
+	Checkpoint  string                     `json:"checkpoint,omitempty"`
 
 	// These values are only present once the update finishes
 	EndTime         *string         `json:"endTime,omitempty"`
@@ -113,6 +115,8 @@ func displayUpdatesJSON(updates []backend.UpdateInfo, decrypter config.Decrypter
 			StartTime:   time.Unix(update.StartTime, 0).UTC().Format(timeFormat),
 			Message:     update.Message,
 			Environment: update.Environment,
+			Version:     update.Version,
+			Checkpoint:  update.Checkpoint,
 		}
 
 		info.Config = make(map[string]configValueJSON)
@@ -164,6 +168,7 @@ func displayUpdatesConsole(updates []backend.UpdateInfo, opts display.Options) e
 
 	for _, update := range updates {
 
+		fmt.Printf("Version: %v\n", update.Version)
 		fmt.Printf("UpdateKind: %v\n", update.Kind)
 		if update.Result == "succeeded" {
 			fmt.Print(opts.Color.Colorize(fmt.Sprintf("%sStatus: %v%s\n", colors.Green, update.Result, colors.Reset)))
@@ -171,6 +176,9 @@ func displayUpdatesConsole(updates []backend.UpdateInfo, opts display.Options) e
 			fmt.Print(opts.Color.Colorize(fmt.Sprintf("%sStatus: %v%s\n", colors.Red, update.Result, colors.Reset)))
 		}
 		fmt.Printf("Message: %v\n", update.Message)
+		if update.Checkpoint != "" {
+			fmt.Printf("Checkpoint: %v\n", update.Checkpoint)
+		}
 
 		printResourceChanges(colors.GreenBackground, colors.Black, "+", colors.Reset, update.ResourceChanges["create"])
 		printResourceChanges(colors.RedBackground, colors.Black, "-", colors.Reset, update.ResourceChanges["delete"])
@@ -0,0 +1,331 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/blang/semver"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/backend"
+	"github.com/pulumi/pulumi/pkg/backend/display"
+	"github.com/pulumi/pulumi/pkg/codegen/importer"
+	"github.com/pulumi/pulumi/pkg/encoding"
+	"github.com/pulumi/pulumi/pkg/engine"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/tokens"
+	"github.com/pulumi/pulumi/pkg/util/cmdutil"
+	"github.com/pulumi/pulumi/pkg/util/contract"
+	"github.com/pulumi/pulumi/pkg/util/result"
+)
+
+// importSpec is a single entry in an import manifest.
+type importSpec struct {
+	Type     string `json:"type" yaml:"type"`
+	Name     string `json:"name" yaml:"name"`
+	ID       string `json:"id" yaml:"id"`
+	Parent   string `json:"parent,omitempty" yaml:"parent,omitempty"`
+	Provider string `json:"provider,omitempty" yaml:"provider,omitempty"`
+	Version  string `json:"version,omitempty" yaml:"version,omitempty"`
+	Protect  bool   `json:"protect,omitempty" yaml:"protect,omitempty"`
+}
+
+// readImportManifest reads the list of resources to import from the JSON or YAML file at the given path.
+func readImportManifest(path string) ([]deploy.Import, error) {
+	m, _ := encoding.Detect(path)
+	if m == nil {
+		return nil, errors.Errorf("unrecognized import manifest format for '%v'", path)
+	}
+
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading import manifest")
+	}
+	var specs []importSpec
+	if err = m.Unmarshal(b, &specs); err != nil {
+		return nil, errors.Wrap(err, "parsing import manifest")
+	}
+
+	imports := make([]deploy.Import, len(specs))
+	for i, spec := range specs {
+		if spec.Type == "" || spec.Name == "" || spec.ID == "" {
+			return nil, errors.Errorf("import %d: each resource must specify a type, name, and id", i)
+		}
+
+		var version *semver.Version
+		if spec.Version != "" {
+			v, err := semver.ParseTolerant(spec.Version)
+			if err != nil {
+				return nil, errors.Wrapf(err, "import %d: parsing provider version", i)
+			}
+			version = &v
+		}
+
+		imports[i] = deploy.Import{
+			Type:     tokens.Type(spec.Type),
+			Name:     tokens.QName(spec.Name),
+			ID:       resource.ID(spec.ID),
+			Parent:   resource.URN(spec.Parent),
+			Provider: resource.URN(spec.Provider),
+			Version:  version,
+			Protect:  spec.Protect,
+		}
+	}
+	return imports, nil
+}
+
+// importedResources returns the resources in the given snapshot that correspond to the given imports, in snapshot
+// order.
+func importedResources(resources []*resource.State, imports []deploy.Import) []*resource.State {
+	type key struct {
+		t    tokens.Type
+		name tokens.QName
+		id   resource.ID
+	}
+	wanted := make(map[key]bool)
+	for _, imp := range imports {
+		wanted[key{imp.Type, imp.Name, imp.ID}] = true
+	}
+
+	var imported []*resource.State
+	for _, r := range resources {
+		if !r.Delete && wanted[key{r.Type, r.URN.Name(), r.ID}] {
+			imported = append(imported, r)
+		}
+	}
+	return imported
+}
+
+func newImportCmd() *cobra.Command {
+	var debug bool
+	var file string
+	var message string
+	var outputFile string
+	var protect bool
+	var stack string
+
+	// Flags for engine.UpdateOptions.
+	var diffDisplay bool
+	var eventLogPath string
+	var parallel int
+	var showConfig bool
+	var skipPreview bool
+	var suppressOutputs bool
+	var yes bool
+
+	var cmd = &cobra.Command{
+		Use:   "import",
+		Short: "Import existing cloud resources into a stack",
+		Long: "Import existing cloud resources into a stack.\n" +
+			"\n" +
+			"This command reads the state of each resource listed in an import manifest from its provider\n" +
+			"and adds the resource to the stack, after which the resource is managed by Pulumi. The manifest\n" +
+			"is a JSON or YAML list of resources, each of which specifies the resource's type, name, and ID,\n" +
+			"and optionally the URNs of its parent and provider and the version of its default provider:\n" +
+			"\n" +
+			"    [\n" +
+			"        { \"type\": \"aws:s3/bucket:Bucket\", \"name\": \"my-bucket\", \"id\": \"my-bucket-d3f12a\" }\n" +
+			"    ]\n" +
+			"\n" +
+			"Once the resources have been imported, a skeleton of program code that declares them is\n" +
+			"printed. This code should be added to the stack's program so that subsequent updates do not\n" +
+			"delete the imported resources.\n" +
+			"\n" +
+			"The program's project is loaded from the current directory. Use the `-C` or `--cwd` flag to\n" +
+			"use a different directory.",
+		Args: cmdutil.NoArgs,
+		Run: cmdutil.RunResultFunc(func(cmd *cobra.Command, args []string) result.Result {
+			interactive := cmdutil.Interactive()
+			if !interactive {
+				yes = true // auto-approve changes, since we cannot prompt.
+			}
+
+			if file == "" {
+				return result.FromError(errors.New("an import manifest must be specified with --file"))
+			}
+			imports, err := readImportManifest(file)
+			if err != nil {
+				return result.FromError(err)
+			}
+			if protect {
+				for i := range imports {
+					imports[i].Protect = true
+				}
+			}
+
+			opts, err := updateFlagsToOptions(interactive, skipPreview, yes)
+			if err != nil {
+				return result.FromError(err)
+			}
+
+			var displayType = display.DisplayProgress
+			if diffDisplay {
+				displayType = display.DisplayDiff
+			}
+
+			opts.Display = display.Options{
+				Color:           cmdutil.GetGlobalColorization(),
+				ShowConfig:      showConfig,
+				SuppressOutputs: suppressOutputs,
+				IsInteractive:   interactive,
+				Type:            displayType,
+				EventLogPath:    eventLogPath,
+				Debug:           debug,
+			}
+
+			s, err := requireStack(stack, true, opts.Display, true /*setCurrent*/)
+			if err != nil {
+				return result.FromError(err)
+			}
+
+			proj, root, err := readProject()
+			if err != nil {
+				return result.FromError(err)
+			}
+
+			m, err := getUpdateMetadata(message, root)
+			if err != nil {
+				return result.FromError(errors.Wrap(err, "gathering environment metadata"))
+			}
+
+			sm, err := getStackSecretsManager(s)
+			if err != nil {
+				return result.FromError(errors.Wrap(err, "getting secrets manager"))
+			}
+
+			cfg, err := getStackConfiguration(s, sm)
+			if err != nil {
+				return result.FromError(errors.Wrap(err, "getting stack configuration"))
+			}
+
+			opts.Engine = engine.UpdateOptions{
+				Parallel:      parallel,
+				Debug:         debug,
+				UseLegacyDiff: useLegacyDiff(),
+			}
+
+			_, res := s.Import(commandContext(), backend.UpdateOperation{
+				Proj:               proj,
+				Root:               root,
+				M:                  m,
+				Opts:               opts,
+				StackConfiguration: cfg,
+				SecretsManager:     sm,
+				Scopes:             cancellationScopes,
+				Imports:            imports,
+			})
+			switch {
+			case res != nil && res.Error() == context.Canceled:
+				return result.FromError(errors.New("import cancelled"))
+			case res != nil:
+				return PrintEngineResult(res)
+			}
+
+			// Now that the resources have been imported, print the code that declares them.
+			runtime := proj.Runtime.Name()
+			if !importer.IsSupportedLanguage(runtime) {
+				fmt.Printf("Code cannot yet be generated for the %v runtime; the imported resources must be declared "+
+					"in the stack's program by hand.\n", runtime)
+				return nil
+			}
+
+			snap, err := s.Snapshot(commandContext())
+			if err != nil {
+				return result.FromError(err)
+			}
+			if snap == nil {
+				return nil
+			}
+			imported := importedResources(snap.Resources, imports)
+			if len(imported) == 0 {
+				return nil
+			}
+
+			var w io.Writer = os.Stdout
+			if outputFile != "" {
+				f, err := os.Create(outputFile)
+				if err != nil {
+					return result.FromError(errors.Wrap(err, "creating output file"))
+				}
+				defer contract.IgnoreClose(f)
+				w = f
+			} else {
+				fmt.Printf("\nPlease copy the following code into your Pulumi application. Not doing so\n" +
+					"will cause Pulumi to delete the imported resources on the next update.\n\n")
+			}
+			if err = importer.GenerateSkeleton(w, runtime, imported); err != nil {
+				return result.FromError(errors.Wrap(err, "generating code"))
+			}
+			return nil
+		}),
+	}
+
+	cmd.PersistentFlags().BoolVarP(
+		&debug, "debug", "d", false,
+		"Print detailed debugging output during resource operations")
+	cmd.PersistentFlags().StringVarP(
+		&file, "file", "f", "",
+		"The path to a JSON or YAML file that lists the resources to import")
+	cmd.PersistentFlags().StringVarP(
+		&outputFile, "out", "o", "",
+		"The path to a file to which the generated code should be written, instead of printing it")
+	cmd.PersistentFlags().BoolVar(
+		&protect, "protect", false,
+		"Protect each of the imported resources from deletion")
+	cmd.PersistentFlags().StringVarP(
+		&stack, "stack", "s", "",
+		"The name of the stack to operate on. Defaults to the current stack")
+	cmd.PersistentFlags().StringVar(
+		&stackConfigFile, "config-file", "",
+		"Use the configuration values in the specified file rather than detecting the file name")
+
+	cmd.PersistentFlags().StringVarP(
+		&message, "message", "m", "",
+		"Optional message to associate with the update operation")
+
+	// Flags for engine.UpdateOptions.
+	cmd.PersistentFlags().BoolVar(
+		&diffDisplay, "diff", false,
+		"Display operation as a rich diff showing the overall change")
+	cmd.PersistentFlags().IntVarP(
+		&parallel, "parallel", "p", defaultParallel,
+		"Allow P resource operations to run in parallel at once (1 for no parallelism). Defaults to unbounded.")
+	cmd.PersistentFlags().BoolVar(
+		&showConfig, "show-config", false,
+		"Show configuration keys and variables")
+	cmd.PersistentFlags().BoolVar(
+		&skipPreview, "skip-preview", false,
+		"Do not perform a preview before performing the import")
+	cmd.PersistentFlags().BoolVar(
+		&suppressOutputs, "suppress-outputs", false,
+		"Suppress display of stack outputs (in case they contain sensitive values)")
+	cmd.PersistentFlags().BoolVarP(
+		&yes, "yes", "y", false,
+		"Automatically approve and perform the import after previewing it")
+
+	if hasDebugCommands() {
+		cmd.PersistentFlags().StringVar(
+			&eventLogPath, "event-log", "",
+			"Log events to a file at this path")
+	}
+	return cmd
+}
@@ -0,0 +1,118 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/engine"
+	"github.com/pulumi/pulumi/pkg/util/cmdutil"
+)
+
+const (
+	perfReportTable = "table"
+	perfReportJSON  = "json"
+
+	// perfReportSlowestResources is the number of resources listed in the table of slowest resources.
+	perfReportSlowestResources = 10
+)
+
+// newPerformanceReport returns a report in which to record an update's timing if the given report format is valid,
+// or nil if no report was requested.
+func newPerformanceReport(format string) (*engine.PerformanceReport, error) {
+	switch format {
+	case "":
+		return nil, nil
+	case perfReportTable, perfReportJSON:
+		return &engine.PerformanceReport{}, nil
+	default:
+		return nil, errors.Errorf("unsupported performance report format %q; must be %s or %s",
+			format, perfReportTable, perfReportJSON)
+	}
+}
+
+// printPerformanceReport prints the given report in the given format.
+func printPerformanceReport(report *engine.PerformanceReport, format string) error {
+	if format == perfReportJSON {
+		return printJSON(report)
+	}
+
+	fmt.Printf("Performance:\n")
+	fmt.Printf("    Duration: %v\n", perfSeconds(report.DurationSeconds))
+	fmt.Printf("    Time executing steps: %v\n", perfSeconds(report.ExecutingSeconds))
+	fmt.Printf("    Parallelism: %.2f (of %d)\n", report.EffectiveParallelism, report.Parallel)
+	fmt.Printf("    Critical path: %v over %d steps\n", perfSeconds(report.CriticalPathSeconds),
+		len(report.CriticalPath))
+	if len(report.Resources) == 0 {
+		return nil
+	}
+
+	fmt.Printf("\nProviders:\n")
+	var providerRows []cmdutil.TableRow
+	for _, p := range report.Providers {
+		providerRows = append(providerRows, cmdutil.TableRow{Columns: []string{
+			p.Provider, strconv.Itoa(p.Steps), perfSeconds(p.ExecutingSeconds), perfSeconds(p.MaxSeconds),
+		}})
+	}
+	cmdutil.PrintTable(cmdutil.Table{
+		Headers: []string{"PROVIDER", "STEPS", "EXECUTING", "SLOWEST STEP"},
+		Rows:    providerRows,
+		Prefix:  "    ",
+	})
+
+	slowest := make([]engine.ResourceTiming, len(report.Resources))
+	copy(slowest, report.Resources)
+	sort.SliceStable(slowest, func(i, j int) bool {
+		return slowest[i].ExecutingSeconds > slowest[j].ExecutingSeconds
+	})
+	if len(slowest) > perfReportSlowestResources {
+		slowest = slowest[:perfReportSlowestResources]
+	}
+	fmt.Printf("\nSlowest steps:\n")
+	printResourceTimings(slowest)
+
+	fmt.Printf("\nCritical path:\n")
+	printResourceTimings(report.CriticalPath)
+	return nil
+}
+
+func printResourceTimings(timings []engine.ResourceTiming) {
+	var rows []cmdutil.TableRow
+	for _, r := range timings {
+		op := string(r.Op)
+		if r.Failed {
+			op += " (failed)"
+		}
+		rows = append(rows, cmdutil.TableRow{Columns: []string{
+			string(r.URN), op, perfSeconds(r.StartSeconds), perfSeconds(r.WaitingSeconds),
+			perfSeconds(r.ExecutingSeconds),
+		}})
+	}
+	cmdutil.PrintTable(cmdutil.Table{
+		Headers: []string{"URN", "OP", "START", "WAITING", "EXECUTING"},
+		Rows:    rows,
+		Prefix:  "    ",
+	})
+}
+
+// perfSeconds formats a number of seconds as a duration rounded to the millisecond.
+func perfSeconds(seconds float64) string {
+	return (time.Duration(seconds * float64(time.Second))).Round(time.Millisecond).String()
+}
@@ -0,0 +1,84 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/apitype"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/resource/stack"
+	"github.com/pulumi/pulumi/pkg/secrets"
+	"github.com/pulumi/pulumi/pkg/tokens"
+	"github.com/pulumi/pulumi/pkg/version"
+)
+
+// newUpdatePlan creates an empty update plan into which a preview can record its steps.
+func newUpdatePlan() *deploy.UpdatePlan {
+	manifest := deploy.Manifest{
+		Time:    time.Now(),
+		Version: version.Version,
+	}
+	manifest.Magic = manifest.NewMagic()
+	return deploy.NewUpdatePlan(manifest)
+}
+
+// writePlan serializes the given update plan and writes it to the file at the given path.
+func writePlan(path string, stackName tokens.QName, plan *deploy.UpdatePlan, sm secrets.Manager) error {
+	serialized, err := stack.SerializePlan(stackName, plan, sm)
+	if err != nil {
+		return errors.Wrap(err, "serializing plan")
+	}
+
+	b, err := json.MarshalIndent(serialized, "", "    ")
+	if err != nil {
+		return errors.Wrap(err, "serializing plan")
+	}
+	if err = ioutil.WriteFile(path, b, 0600); err != nil {
+		return errors.Wrap(err, "could not write plan")
+	}
+	return nil
+}
+
+// readPlan reads an update plan from the file at the given path and ensures that it was recorded for the given stack.
+func readPlan(path string, stackName tokens.QName) (*deploy.UpdatePlan, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read plan")
+	}
+
+	var serialized apitype.UpdatePlanV1
+	if err = json.Unmarshal(b, &serialized); err != nil {
+		return nil, errors.Wrap(err, "could not read plan")
+	}
+	if serialized.Stack != stackName {
+		return nil, fmt.Errorf("the plan was recorded for stack '%s', not '%s'", serialized.Stack, stackName)
+	}
+
+	plan, err := stack.DeserializePlan(serialized, stack.DefaultSecretsProvider)
+	if err != nil {
+		if err == stack.ErrUpdatePlanSchemaVersionTooNew {
+			return nil, errors.New("the plan is newer than what this version of the Pulumi CLI understands. " +
+				"Please update your version of the Pulumi CLI")
+		}
+		return nil, errors.Wrap(err, "could not deserialize plan")
+	}
+	return plan, nil
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/pulumi/pulumi/pkg/resource/plugin"
 	"github.com/pulumi/pulumi/pkg/util/cmdutil"
 	"github.com/pulumi/pulumi/pkg/util/contract"
+	"github.com/pulumi/pulumi/pkg/util/logging"
 	"github.com/pulumi/pulumi/pkg/workspace"
 )
 
@@ -44,7 +45,9 @@ func newPluginCmd() *cobra.Command {
 	}
 
 	cmd.AddCommand(newPluginInstallCmd())
+	cmd.AddCommand(newPluginLockCmd())
 	cmd.AddCommand(newPluginLsCmd())
+	cmd.AddCommand(newPluginMirrorCmd())
 	cmd.AddCommand(newPluginRmCmd())
 
 	return cmd
@@ -87,3 +90,33 @@ func getProjectPlugins() ([]workspace.PluginInfo, error) {
 	}
 	return results, nil
 }
+
+// getPluginSources returns the plugin sources configured by the environment and by the current project, if any.
+func getPluginSources() workspace.PluginSources {
+	proj, err := workspace.DetectProject()
+	if err != nil {
+		logging.V(7).Infof("getPluginSources(): no project: %v", err)
+		proj = nil
+	}
+	return workspace.GetPluginSources(proj)
+}
+
+// parsePluginPlatforms parses the given <os>-<arch> platforms. If none are given, the current platform is returned.
+func parsePluginPlatforms(args []string) ([]workspace.PluginPlatform, error) {
+	var platforms []workspace.PluginPlatform
+	for _, arg := range args {
+		platform, err := workspace.ParsePluginPlatform(arg)
+		if err != nil {
+			return nil, err
+		}
+		platforms = append(platforms, platform)
+	}
+	if len(platforms) == 0 {
+		platform, err := workspace.CurrentPluginPlatform()
+		if err != nil {
+			return nil, err
+		}
+		platforms = append(platforms, platform)
+	}
+	return platforms, nil
+}
@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strings"
 
 	"github.com/blang/semver"
 	"github.com/pkg/errors"
@@ -67,6 +68,12 @@ func newPluginInstallCmd() *cobra.Command {
 				serverURL = cloudURL + "/releases/plugins"
 			}
 
+			// Plugins are downloaded from the configured plugin sources, if any, unless a server was given explicitly.
+			var sources workspace.PluginSources
+			if serverURL == "" {
+				sources = getPluginSources()
+			}
+
 			// Note we don't presently set this as the default value for `--server` so we can play games like the above
 			// where we want to ensure at most one of `--server` or `--cloud-url` is set.
 			if serverURL == "" {
@@ -103,10 +110,21 @@ func newPluginInstallCmd() *cobra.Command {
 				if err != nil {
 					return err
 				}
+
+				// If the project has a plugin lockfile, install exactly the locked plugins.
+				lock, err := workspace.DetectPluginLock(".")
+				if err != nil {
+					return err
+				}
+				platform, _ := workspace.CurrentPluginPlatform()
+
 				for _, plugin := range plugins {
 					// Skip language plugins; by definition, we already have one installed.
 					// TODO[pulumi/pulumi#956]: eventually we will want to honor and install these in the usual way.
 					if plugin.Kind != workspace.LanguagePlugin {
+						if err := lock.Verify(&plugin, platform); err != nil {
+							return err
+						}
 						installs = append(installs, plugin)
 					}
 				}
@@ -148,12 +166,27 @@ func newPluginInstallCmd() *cobra.Command {
 				var err error
 				if file == "" {
 					if verbose {
+						from := install.ServerURL
+						if len(sources) > 0 {
+							from = strings.Join(sources, ", ")
+						}
 						cmdutil.Diag().Infoerrf(
-							diag.Message("", "%s downloading from %s"), label, install.ServerURL)
+							diag.Message("", "%s downloading from %s"), label, from)
+					}
+					var platform workspace.PluginPlatform
+					if platform, err = workspace.CurrentPluginPlatform(); err != nil {
+						return err
 					}
 					var size int64
-					if tarball, size, err = install.Download(); err != nil {
-						return errors.Wrapf(err, "%s downloading from %s", label, install.ServerURL)
+					if tarball, size, err = sources.Download(install, platform); err != nil {
+						return errors.Wrapf(err, "%s downloading", label)
+					}
+					// If the plugin's checksum isn't locked, verify it against the checksum published by its sources.
+					if install.Checksum == "" {
+						if install.Checksum, err = sources.PublishedChecksum(install, platform); err != nil {
+							contract.IgnoreClose(tarball)
+							return errors.Wrapf(err, "%s fetching checksum", label)
+						}
 					}
 					tarball = workspace.ReadCloserProgressBar(tarball, size, "Downloading plugin", displayOpts.Color)
 				} else {
@@ -0,0 +1,101 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/util/cmdutil"
+	"github.com/pulumi/pulumi/pkg/workspace"
+)
+
+func newPluginLockCmd() *cobra.Command {
+	var platformArgs []string
+
+	var cmd = &cobra.Command{
+		Use:   "lock",
+		Args:  cmdutil.NoArgs,
+		Short: "Create or refresh the plugin lockfile for the current project",
+		Long: "Create or refresh the plugin lockfile for the current project.\n" +
+			"\n" +
+			"This command computes the set of plugins required by the current project and\n" +
+			"records the exact version of each in " + workspace.PluginLockFile + ", next to the\n" +
+			"project's Pulumi.yaml.  For each plugin that is downloaded rather than bundled\n" +
+			"with the CLI, the SHA-256 of its tarball is recorded for each platform given by\n" +
+			"--platform (by default, the current platform).  Each tarball is verified against\n" +
+			"the checksum manifest published by the plugin's server, if any.\n" +
+			"\n" +
+			"Once the lockfile exists, updates fail if the program requires a different version\n" +
+			"of a locked plugin, and plugin downloads are verified against the locked checksums.\n" +
+			"Commit the lockfile alongside the project so that builds are reproducible.",
+		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			platforms, err := parsePluginPlatforms(platformArgs)
+			if err != nil {
+				return err
+			}
+
+			projPath, err := workspace.DetectProjectPath()
+			if err != nil {
+				return err
+			}
+			if projPath == "" {
+				return errors.New("no Pulumi.yaml project file found; " +
+					"run this command in a project directory or one of its subdirectories")
+			}
+			lockPath := workspace.PluginLockPath(projPath)
+
+			existing, err := workspace.LoadPluginLock(lockPath)
+			if err != nil {
+				return err
+			}
+
+			plugins, err := getProjectPlugins()
+			if err != nil {
+				return errors.Wrap(err, "computing the project's plugins")
+			}
+			sources := getPluginSources()
+
+			lock := &workspace.PluginLock{Plugins: []workspace.LockedPlugin{}}
+			for _, plugin := range plugins {
+				var previous *workspace.LockedPlugin
+				if p, ok := existing.Lookup(plugin.Kind, plugin.Name); ok {
+					previous = &p
+				}
+
+				locked, err := workspace.LockPlugin(plugin, platforms, sources, previous)
+				if err != nil {
+					return errors.Wrapf(err, "locking %s plugin %s", plugin.Kind, plugin)
+				}
+				lock.Plugins = append(lock.Plugins, locked)
+				fmt.Printf("Locked %s plugin %s\n", plugin.Kind, plugin)
+			}
+
+			if err := lock.Save(lockPath); err != nil {
+				return errors.Wrapf(err, "saving plugin lockfile %s", lockPath)
+			}
+			fmt.Printf("Wrote %d plugins to %s\n", len(lock.Plugins), lockPath)
+			return nil
+		}),
+	}
+
+	cmd.PersistentFlags().StringSliceVar(&platformArgs,
+		"platform", nil, "Record checksums for the given <os>-<arch> platform (may be repeated); "+
+			"defaults to the current platform")
+
+	return cmd
+}
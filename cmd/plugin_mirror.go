@@ -0,0 +1,98 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/util/cmdutil"
+	"github.com/pulumi/pulumi/pkg/workspace"
+)
+
+func newPluginMirrorCmd() *cobra.Command {
+	var platformArgs []string
+
+	var cmd = &cobra.Command{
+		Use:   "mirror <dir>",
+		Args:  cmdutil.ExactArgs(1),
+		Short: "Download the plugins required by the current project into a mirror directory",
+		Long: "Download the plugins required by the current project into a mirror directory.\n" +
+			"\n" +
+			"This command downloads the tarball of each plugin required by the current project,\n" +
+			"for each platform given by --platform (by default, the current platform), into the\n" +
+			"given directory, along with a checksum manifest for each plugin.  Downloads are\n" +
+			"verified against the project's plugin lockfile, if any.  The directory may be served\n" +
+			"by any static file server, or used directly, as a plugin source for machines that\n" +
+			"cannot reach the plugins' servers.\n" +
+			"\n" +
+			"Plugin sources are consulted in order when installing plugins.  They are listed in\n" +
+			"the comma-separated " + workspace.PluginSourcesEnvVar + " environment variable and the\n" +
+			"'pluginSources' list in Pulumi.yaml.  Each source is an HTTP(S) URL or the absolute\n" +
+			"path of a local directory.  For example:\n" +
+			"\n" +
+			"    pulumi plugin mirror /mnt/plugins --platform linux-amd64 --platform windows-amd64\n" +
+			"    " + workspace.PluginSourcesEnvVar + "=/mnt/plugins pulumi up",
+		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			dir := args[0]
+			platforms, err := parsePluginPlatforms(platformArgs)
+			if err != nil {
+				return err
+			}
+
+			projPath, err := workspace.DetectProjectPath()
+			if err != nil {
+				return err
+			}
+			if projPath == "" {
+				return errors.New("no Pulumi.yaml project file found; " +
+					"run this command in a project directory or one of its subdirectories")
+			}
+			lock, err := workspace.LoadPluginLock(workspace.PluginLockPath(projPath))
+			if err != nil {
+				return err
+			}
+
+			plugins, err := getProjectPlugins()
+			if err != nil {
+				return errors.Wrap(err, "computing the project's plugins")
+			}
+			sources := getPluginSources()
+
+			count := 0
+			for _, plugin := range plugins {
+				if plugin.Kind == workspace.LanguagePlugin || plugin.Version == nil {
+					continue
+				}
+				if err := workspace.MirrorPlugin(plugin, platforms, sources, lock, dir); err != nil {
+					return errors.Wrapf(err, "mirroring %s plugin %s", plugin.Kind, plugin)
+				}
+				fmt.Printf("Mirrored %s plugin %s\n", plugin.Kind, plugin)
+				count++
+			}
+
+			fmt.Printf("Mirrored %d plugins to %s\n", count, dir)
+			return nil
+		}),
+	}
+
+	cmd.PersistentFlags().StringSliceVar(&platformArgs,
+		"platform", nil, "Download plugins for the given <os>-<arch> platform (may be repeated); "+
+			"defaults to the current platform")
+
+	return cmd
+}
@@ -29,6 +29,7 @@ func newPreviewCmd() *cobra.Command {
 	var debug bool
 	var expectNop bool
 	var message string
+	var planFilePath string
 	var stack string
 	var configArray []string
 	var configPath bool
@@ -38,6 +39,8 @@ func newPreviewCmd() *cobra.Command {
 	var diffDisplay bool
 	var eventLogPath string
 	var jsonDisplay bool
+	var markdownDisplay bool
+	var junitDisplay bool
 	var parallel int
 	var showConfig bool
 	var showReplacementSteps bool
@@ -63,9 +66,9 @@ func newPreviewCmd() *cobra.Command {
 			"`--cwd` flag to use a different directory.",
 		Args: cmdutil.NoArgs,
 		Run: cmdutil.RunResultFunc(func(cmd *cobra.Command, args []string) result.Result {
-			var displayType = display.DisplayProgress
-			if diffDisplay {
-				displayType = display.DisplayDiff
+			displayType, err := displayTypeFromFlags(diffDisplay, jsonDisplay, markdownDisplay, junitDisplay)
+			if err != nil {
+				return result.FromError(err)
 			}
 
 			opts := backend.UpdateOptions{
@@ -95,6 +98,11 @@ func newPreviewCmd() *cobra.Command {
 				return result.FromError(err)
 			}
 
+			// If we've been asked to save a plan, record the preview's steps into a fresh one.
+			if planFilePath != "" {
+				opts.Engine.GeneratedPlan = newUpdatePlan()
+			}
+
 			// Save any config values passed via flags.
 			if err := parseAndSaveConfigArray(s, configArray, configPath); err != nil {
 				return result.FromError(err)
@@ -119,6 +127,9 @@ func newPreviewCmd() *cobra.Command {
 			if err != nil {
 				return result.FromError(errors.Wrap(err, "getting stack configuration"))
 			}
+			if err = backend.ApplyConfigSchema(proj, &cfg); err != nil {
+				return result.FromError(err)
+			}
 
 			changes, res := s.Preview(commandContext(), backend.UpdateOperation{
 				Proj:               proj,
@@ -135,6 +146,11 @@ func newPreviewCmd() *cobra.Command {
 				return PrintEngineResult(res)
 			case expectNop && changes != nil && changes.HasChanges():
 				return result.FromError(errors.New("error: no changes were expected but changes were proposed"))
+			case planFilePath != "":
+				if err = writePlan(planFilePath, s.Ref().Name(), opts.Engine.GeneratedPlan, sm); err != nil {
+					return result.FromError(err)
+				}
+				return nil
 			default:
 				return nil
 			}
@@ -163,6 +179,10 @@ func newPreviewCmd() *cobra.Command {
 	cmd.PersistentFlags().StringVarP(
 		&message, "message", "m", "",
 		"Optional message to associate with the preview operation")
+	cmd.PersistentFlags().StringVar(
+		&planFilePath, "save-plan", "",
+		"Save the operations proposed by the preview to a plan file at the given path, "+
+			"for use with `pulumi up --plan`")
 
 	// Flags for engine.UpdateOptions.
 	if hasDebugCommands() || hasExperimentalCommands() {
@@ -176,6 +196,12 @@ func newPreviewCmd() *cobra.Command {
 	cmd.Flags().BoolVarP(
 		&jsonDisplay, "json", "j", false,
 		"Serialize the preview diffs, operations, and overall output as JSON")
+	cmd.PersistentFlags().BoolVar(
+		&markdownDisplay, "markdown", false,
+		"Display a Markdown summary of the preview, e.g. for posting to a pull request")
+	cmd.PersistentFlags().BoolVar(
+		&junitDisplay, "junit", false,
+		"Display a JUnit XML report of the preview with a test case for each resource")
 	cmd.PersistentFlags().IntVarP(
 		&parallel, "parallel", "p", defaultParallel,
 		"Allow P resource operations to run in parallel at once (1 for no parallelism). Defaults to unbounded.")
@@ -183,6 +183,7 @@ func NewPulumiCmd() *cobra.Command {
 	cmd.AddCommand(newWhoAmICmd())
 	//     - Advanced Commands:
 	cmd.AddCommand(newCancelCmd())
+	cmd.AddCommand(newImportCmd())
 	cmd.AddCommand(newRefreshCmd())
 	cmd.AddCommand(newStateCmd())
 	//     - Other Commands:
@@ -214,6 +215,7 @@ func NewPulumiCmd() *cobra.Command {
 			"Include the tracing header with the given contents.")
 		//     - Diagnostic Commands:
 		cmd.AddCommand(newViewTraceCmd())
+		cmd.AddCommand(newViewEventsCmd())
 
 		// For legacy reasons, we make these two commands available also under PULUMI_DEBUG_COMMANDS, though
 		// PULUMI_EXPERIMENTAL should be preferred.
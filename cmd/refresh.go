@@ -16,6 +16,8 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
+	"io/ioutil"
 
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
@@ -30,6 +32,7 @@ import (
 
 func newRefreshCmd() *cobra.Command {
 	var debug bool
+	var driftReportPath string
 	var expectNop bool
 	var message string
 	var stack string
@@ -56,6 +59,11 @@ func newRefreshCmd() *cobra.Command {
 			"the program text isn't updated accordingly, subsequent updates may still appear to be out of\n" +
 			"synch with respect to the cloud provider's source of truth.\n" +
 			"\n" +
+			"To detect drift without modifying the stack, pass `--drift-report` with the path of a file.\n" +
+			"The refresh is then only previewed, a JSON report that lists each resource whose live state\n" +
+			"differs from the stack's state is written to the file, and the command fails if any resource\n" +
+			"has drifted. Secret values are masked in the report.\n" +
+			"\n" +
 			"The program to run is loaded from the project in the current directory. Use the `-C` or\n" +
 			"`--cwd` flag to use a different directory.",
 		Args: cmdutil.NoArgs,
@@ -65,6 +73,10 @@ func newRefreshCmd() *cobra.Command {
 				yes = true // auto-approve changes, since we cannot prompt.
 			}
 
+			if driftReportPath != "" && skipPreview {
+				return result.FromError(errors.New("--drift-report and --skip-preview cannot be used together"))
+			}
+
 			opts, err := updateFlagsToOptions(interactive, skipPreview, yes)
 			if err != nil {
 				return result.FromError(err)
@@ -87,6 +99,14 @@ func newRefreshCmd() *cobra.Command {
 				Debug:                debug,
 			}
 
+			// In drift detection mode, the refresh is only previewed, and its changes are recorded in a report.
+			var driftReport *display.DriftReport
+			if driftReportPath != "" {
+				driftReport = &display.DriftReport{Resources: []display.ResourceDrift{}}
+				opts.PreviewOnly = true
+				opts.Display.DriftReport = driftReport
+			}
+
 			s, err := requireStack(stack, true, opts.Display, true /*setCurrent*/)
 			if err != nil {
 				return result.FromError(err)
@@ -139,6 +159,15 @@ func newRefreshCmd() *cobra.Command {
 				return result.FromError(errors.New("refresh cancelled"))
 			case res != nil:
 				return PrintEngineResult(res)
+			case driftReport != nil:
+				if err = writeDriftReport(driftReportPath, driftReport); err != nil {
+					return result.FromError(err)
+				}
+				if driftReport.HasDrift() {
+					return result.FromError(errors.Errorf("drift detected in %d resource(s); see %s",
+						len(driftReport.Resources), driftReportPath))
+				}
+				return nil
 			case expectNop && changes != nil && changes.HasChanges():
 				return result.FromError(errors.New("error: no changes were expected but changes occurred"))
 			default:
@@ -150,6 +179,9 @@ func newRefreshCmd() *cobra.Command {
 	cmd.PersistentFlags().BoolVarP(
 		&debug, "debug", "d", false,
 		"Print detailed debugging output during resource operations")
+	cmd.PersistentFlags().StringVar(
+		&driftReportPath, "drift-report", "",
+		"Detect drift without modifying the stack, writing a JSON report of drifted resources to this path")
 	cmd.PersistentFlags().BoolVar(
 		&expectNop, "expect-no-changes", false,
 		"Return an error if any changes occur during this update")
@@ -198,3 +230,15 @@ func newRefreshCmd() *cobra.Command {
 	}
 	return cmd
 }
+
+// writeDriftReport writes the given drift report to the file at the given path as JSON.
+func writeDriftReport(path string, report *display.DriftReport) error {
+	b, err := json.MarshalIndent(report, "", "    ")
+	if err != nil {
+		return errors.Wrap(err, "marshaling drift report")
+	}
+	if err = ioutil.WriteFile(path, append(b, '\n'), 0600); err != nil {
+		return errors.Wrap(err, "writing drift report")
+	}
+	return nil
+}
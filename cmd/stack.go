@@ -152,13 +152,18 @@ func newStackCmd() *cobra.Command {
 	cmd.PersistentFlags().BoolVar(
 		&showSecrets, "show-secrets", false, "Display stack outputs which are marked as secret in plaintext")
 
+	cmd.AddCommand(newStackChangeSecretsProviderCmd())
 	cmd.AddCommand(newStackExportCmd())
 	cmd.AddCommand(newStackGraphCmd())
+	cmd.AddCommand(newStackImpactCmd())
 	cmd.AddCommand(newStackImportCmd())
 	cmd.AddCommand(newStackInitCmd())
 	cmd.AddCommand(newStackLsCmd())
 	cmd.AddCommand(newStackOutputCmd())
 	cmd.AddCommand(newStackRmCmd())
+	cmd.AddCommand(newStackRollbackCmd())
+	cmd.AddCommand(newStackRotateSecretsKeyCmd())
+	cmd.AddCommand(newStackScanSecretsCmd())
 	cmd.AddCommand(newStackSelectCmd())
 	cmd.AddCommand(newStackTagCmd())
 	cmd.AddCommand(newStackRenameCmd())
@@ -0,0 +1,261 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/apitype"
+	"github.com/pulumi/pulumi/pkg/backend"
+	"github.com/pulumi/pulumi/pkg/backend/display"
+	"github.com/pulumi/pulumi/pkg/backend/filestate"
+	"github.com/pulumi/pulumi/pkg/backend/httpstate"
+	"github.com/pulumi/pulumi/pkg/resource/config"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/resource/stack"
+	"github.com/pulumi/pulumi/pkg/secrets"
+	"github.com/pulumi/pulumi/pkg/secrets/passphrase"
+	"github.com/pulumi/pulumi/pkg/util/cmdutil"
+	"github.com/pulumi/pulumi/pkg/util/contract"
+	"github.com/pulumi/pulumi/pkg/workspace"
+)
+
+func newStackChangeSecretsProviderCmd() *cobra.Command {
+	var stackName string
+	cmd := &cobra.Command{
+		Use:   "change-secrets-provider <new-secrets-provider>",
+		Args:  cmdutil.ExactArgs(1),
+		Short: "Change the secrets provider for a stack",
+		Long: "Change the secrets provider for a stack.\n" +
+			"\n" +
+			"This command decrypts every secret in the stack's configuration and in its most recent\n" +
+			"deployment with the stack's current secrets provider, re-encrypts them with the new secrets\n" +
+			"provider, and saves both. Valid secrets providers are the same as for `pulumi stack init`:\n" +
+			"`default`, `passphrase`, `awskms`, `azurekeyvault`, `gcpkms` and `hashivault`. For example:\n" +
+			"\n" +
+			"    $ pulumi stack change-secrets-provider \"awskms://alias/ExampleAlias?region=us-east-1\"\n" +
+			"\n" +
+			"The stack's earlier deployments in its history remain encrypted with the previous provider.",
+		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			opts := display.Options{
+				Color: cmdutil.GetGlobalColorization(),
+			}
+
+			newSecretsProvider := args[0]
+			if err := validateSecretsProvider(newSecretsProvider); err != nil {
+				return err
+			}
+
+			s, err := requireStack(stackName, false, opts, true /*setCurrent*/)
+			if err != nil {
+				return err
+			}
+			return changeSecretsProvider(s, newSecretsProvider)
+		}),
+	}
+
+	cmd.PersistentFlags().StringVarP(
+		&stackName, "stack", "s", "", "The name of the stack to operate on. Defaults to the current stack")
+
+	return cmd
+}
+
+// changeSecretsProvider re-encrypts the configuration and the most recent deployment of the given stack with a new
+// secrets manager for the given secrets provider. The new stack settings are written to a temporary file, which only
+// replaces the stack's settings file once the re-encrypted deployment has been imported into the backend.
+func changeSecretsProvider(s backend.Stack, newSecretsProvider string) (err error) {
+	configFile, err := getProjectStackPath(s)
+	if err != nil {
+		return err
+	}
+	ps, err := workspace.LoadProjectStack(configFile)
+	if err != nil {
+		return err
+	}
+
+	current := stackSecretsProvider(s.Backend(), ps)
+	if current == normalizeSecretsProvider(s.Backend(), newSecretsProvider) {
+		return errors.Errorf("stack '%s' already uses the %s secrets provider; "+
+			"to rotate its key, run `pulumi stack rotate-secrets-key`", s.Ref(), current)
+	}
+
+	// Decrypt the stack's secrets with its current secrets manager.
+	currentSecretsManager, err := getStackSecretsManager(s)
+	if err != nil {
+		return err
+	}
+	decrypter, err := currentSecretsManager.Decrypter()
+	if err != nil {
+		return err
+	}
+	snap, err := s.Snapshot(commandContext())
+	if err != nil {
+		return err
+	}
+
+	// Configure the new secrets provider in a copy of the stack settings that no longer refers to the current one.
+	tempFile := stackSettingsTempFile(configFile)
+	defer func() { removeStackSettingsTempFile(tempFile, err) }()
+	cfg := ps.Config
+	ps.SecretsProvider, ps.EncryptionSalt, ps.EncryptedKey = "", "", ""
+	ps.SecretsKeyGeneration, ps.PreviousEncryptionSalts, ps.PreviousEncryptedKeys = 0, nil, nil
+	if err = ps.Save(tempFile); err != nil {
+		return err
+	}
+	newSecretsManager, err := configureSecretsProvider(s.Backend(), s.Ref(), tempFile, newSecretsProvider)
+	if err != nil {
+		return err
+	}
+	if newSecretsManager == nil {
+		httpStack, ok := s.(httpstate.Stack)
+		contract.Assertf(ok, "only stacks of the Pulumi service backend use the service secrets provider")
+		if newSecretsManager, err = newServiceSecretsManager(httpStack); err != nil {
+			return err
+		}
+	}
+
+	newPS, err := saveReencryptedStack(s, snap, cfg, decrypter, newSecretsManager, tempFile, configFile)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Changed the secrets provider of stack '%s' from %s to %s.\n",
+		s.Ref(), current, stackSecretsProvider(s.Backend(), newPS))
+	return nil
+}
+
+// stackSettingsTempFile returns the path of the temporary file to which new settings for the stack settings file at
+// configFile are written. It lives next to the settings file so that it can be renamed over it.
+func stackSettingsTempFile(configFile string) string {
+	return filepath.Join(filepath.Dir(configFile), ".tmp-"+filepath.Base(configFile))
+}
+
+// removeIfExists removes the file at the given path, if there is one.
+func removeIfExists(path string) error {
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// removeStackSettingsTempFile removes the temporary stack settings file at tempFile once the command that wrote it
+// has finished with the given error, unless that error reports that the file holds the stack's only usable settings.
+func removeStackSettingsTempFile(tempFile string, err error) {
+	if _, ok := err.(*stackSettingsNotSavedError); ok {
+		return
+	}
+	contract.IgnoreError(removeIfExists(tempFile))
+}
+
+// stackSettingsNotSavedError is returned when a stack's checkpoint has been re-encrypted with a new secrets manager,
+// but the new settings that configure it could not replace the stack settings file. The new settings are left in
+// the temporary file, which must be moved over the settings file by hand before the stack can be used again.
+type stackSettingsNotSavedError struct {
+	tempFile   string
+	configFile string
+	err        error
+}
+
+func (e *stackSettingsNotSavedError) Error() string {
+	return fmt.Sprintf("the stack's checkpoint was re-encrypted, but its new settings could not be saved to %s: %v; "+
+		"they have been kept in %s, which must be moved to %s before the stack can be used",
+		e.configFile, e.err, e.tempFile, e.configFile)
+}
+
+// saveReencryptedStack re-encrypts a stack's secrets with a new secrets manager and saves them. The configuration cfg,
+// whose secure values are decrypted with decrypter, is re-encrypted into the new stack settings at tempFile, which
+// already configure the new secrets manager. The snapshot, if any, is re-encrypted and imported into the backend as
+// the stack's most recent deployment. Only then does tempFile replace the stack settings file at configFile, so that
+// the settings never refer to a key with which the stack's checkpoint cannot be decrypted. If that last step fails
+// after the checkpoint was imported, a *stackSettingsNotSavedError is returned and tempFile must be kept.
+func saveReencryptedStack(s backend.Stack, snap *deploy.Snapshot, cfg config.Map, decrypter config.Decrypter,
+	sm secrets.Manager, tempFile, configFile string) (*workspace.ProjectStack, error) {
+
+	encrypter, err := sm.Encrypter()
+	if err != nil {
+		return nil, err
+	}
+	ps, err := workspace.LoadProjectStack(tempFile)
+	if err != nil {
+		return nil, err
+	}
+	if ps.Config, err = cfg.Copy(decrypter, encrypter); err != nil {
+		return nil, errors.Wrap(err, "re-encrypting configuration")
+	}
+	if err = ps.Save(tempFile); err != nil {
+		return nil, err
+	}
+
+	if snap != nil {
+		if err = importDeploymentWithSecretsManager(s, snap, sm); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = os.Rename(tempFile, configFile); err != nil {
+		if snap != nil {
+			return nil, &stackSettingsNotSavedError{tempFile: tempFile, configFile: configFile, err: err}
+		}
+		return nil, errors.Wrapf(err, "saving %s", configFile)
+	}
+	return ps, nil
+}
+
+// importDeploymentWithSecretsManager serializes the given snapshot, encrypting its secrets with the given secrets
+// manager, and imports it into the backend as the stack's most recent deployment.
+func importDeploymentWithSecretsManager(s backend.Stack, snap *deploy.Snapshot, sm secrets.Manager) error {
+	sdep, err := stack.SerializeDeployment(snap, sm)
+	if err != nil {
+		return errors.Wrap(err, "serializing deployment")
+	}
+	bytes, err := json.Marshal(sdep)
+	if err != nil {
+		return err
+	}
+	return s.ImportDeployment(commandContext(), &apitype.UntypedDeployment{
+		Version:    apitype.DeploymentSchemaVersionCurrent,
+		Deployment: bytes,
+	})
+}
+
+// stackSecretsProvider returns the secrets provider that the given stack settings select, using the same rules as
+// getStackSecretsManager: `passphrase`, `default` for the Pulumi service's secrets provider, or a cloud provider URL.
+func stackSecretsProvider(b backend.Backend, ps *workspace.ProjectStack) string {
+	if stack.IsCloudSecretsProvider(ps.SecretsProvider) {
+		return ps.SecretsProvider
+	}
+	if ps.EncryptionSalt != "" {
+		return passphrase.Type
+	}
+	return normalizeSecretsProvider(b, "default")
+}
+
+// normalizeSecretsProvider returns the secrets provider that a stack of the given backend uses when configured with
+// the given secrets provider: the filestate backend's default secrets provider is `passphrase`.
+func normalizeSecretsProvider(b backend.Backend, secretsProvider string) string {
+	if secretsProvider == "" || secretsProvider == "default" {
+		if _, ok := b.(filestate.Backend); ok {
+			return passphrase.Type
+		}
+		return "default"
+	}
+	return secretsProvider
+}
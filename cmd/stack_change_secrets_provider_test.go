@@ -0,0 +1,50 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRemoveStackSettingsTempFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "stack-settings")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	configFile := filepath.Join(dir, "Pulumi.dev.yaml")
+	tempFile := stackSettingsTempFile(configFile)
+	assert.Equal(t, filepath.Join(dir, ".tmp-Pulumi.dev.yaml"), tempFile)
+
+	// The temporary settings are kept, and named in the error, if they could not replace the stack's settings after
+	// its checkpoint was re-encrypted.
+	assert.NoError(t, ioutil.WriteFile(tempFile, []byte("config: {}\n"), 0600))
+	notSaved := &stackSettingsNotSavedError{tempFile: tempFile, configFile: configFile, err: errors.New("denied")}
+	assert.Contains(t, notSaved.Error(), "kept in "+tempFile)
+	removeStackSettingsTempFile(tempFile, notSaved)
+	_, err = os.Stat(tempFile)
+	assert.NoError(t, err)
+
+	// Otherwise, they are removed whether or not the command succeeded.
+	removeStackSettingsTempFile(tempFile, errors.New("failed"))
+	_, err = os.Stat(tempFile)
+	assert.True(t, os.IsNotExist(err))
+	removeStackSettingsTempFile(tempFile, nil)
+}
@@ -15,14 +15,22 @@
 package cmd
 
 import (
+	"io"
 	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
 	"strings"
 
+	"github.com/pkg/errors"
 	"github.com/pulumi/pulumi/pkg/backend/display"
 	"github.com/pulumi/pulumi/pkg/graph"
 	"github.com/pulumi/pulumi/pkg/graph/dotconv"
+	"github.com/pulumi/pulumi/pkg/graph/graphmlconv"
+	"github.com/pulumi/pulumi/pkg/graph/jsonconv"
+	"github.com/pulumi/pulumi/pkg/graph/mermaidconv"
 	"github.com/pulumi/pulumi/pkg/resource"
-	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/resource/deploy/providers"
 	"github.com/pulumi/pulumi/pkg/util/cmdutil"
 	"github.com/spf13/cobra"
 )
@@ -39,8 +47,32 @@ var dependencyEdgeColor string
 // The color of parent edges in the graph. Defaults to #AA6639, an orange.
 var parentEdgeColor string
 
+// Whether or not we should group the children of component resources into clusters.
+var clusterComponents bool
+
+// graphPrinters maps the name of each supported graph format to the function that prints graphs in that format.
+var graphPrinters = map[string]func(g graph.Graph, w io.Writer) error{
+	"dot":     dotconv.Print,
+	"mermaid": mermaidconv.Print,
+	"graphml": graphmlconv.Print,
+	"json":    jsonconv.Print,
+}
+
+// graphFormatExts maps file extensions to the graph formats that they imply.
+var graphFormatExts = map[string]string{
+	".dot":     "dot",
+	".gv":      "dot",
+	".mmd":     "mermaid",
+	".mermaid": "mermaid",
+	".graphml": "graphml",
+	".json":    "json",
+}
+
 func newStackGraphCmd() *cobra.Command {
 	var stackName string
+	var format string
+	var filter stackGraphFilter
+	var focus string
 
 	cmd := &cobra.Command{
 		Use:   "graph [filename]",
@@ -49,13 +81,36 @@ func newStackGraphCmd() *cobra.Command {
 		Long: "Export a stack's dependency graph to a file.\n" +
 			"\n" +
 			"This command can be used to view the dependency graph that a Pulumi program\n" +
-			"admitted when it was ran. This graph is output in the DOT format. This command operates\n" +
-			"on your stack's most recent deployment.",
+			"admitted when it was ran. This command operates on your stack's most recent deployment.\n" +
+			"\n" +
+			"The graph is output in the format given by --format: DOT (the default), Mermaid, GraphML,\n" +
+			"or a JSON adjacency list. If no format is given, it is inferred from the file's extension.\n" +
+			"\n" +
+			"The graph can be limited to the resources whose types, URNs, or providers match the globs\n" +
+			"given by --type, --urn, and --provider, and to the resources within a number of dependency\n" +
+			"edges of a chosen resource by --focus and --depth. When --cluster-components is given, the\n" +
+			"children of each component resource are drawn together within a box.",
 		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
 			opts := display.Options{
 				Color: cmdutil.GetGlobalColorization(),
 			}
 
+			if format == "" {
+				format = "dot"
+				if f, ok := graphFormatExts[strings.ToLower(filepath.Ext(args[0]))]; ok {
+					format = f
+				}
+			}
+			printGraph, ok := graphPrinters[format]
+			if !ok {
+				return errors.Errorf("unsupported graph format '%s'; expected one of dot, mermaid, graphml, or json",
+					format)
+			}
+			if filter.depth >= 0 && focus == "" {
+				return errors.New("--depth requires --focus")
+			}
+			filter.focus = resource.URN(focus)
+
 			s, err := requireStack(stackName, false, opts, true /*setCurrent*/)
 			if err != nil {
 				return err
@@ -65,13 +120,21 @@ func newStackGraphCmd() *cobra.Command {
 				return err
 			}
 
-			dg := makeDependencyGraph(snap)
+			var resources []*resource.State
+			if snap != nil {
+				resources = snap.Resources
+			}
+			if resources, err = filter.apply(resources); err != nil {
+				return err
+			}
+
+			dg := makeDependencyGraph(resources)
 			file, err := os.Create(args[0])
 			if err != nil {
 				return err
 			}
 
-			if err := dotconv.Print(dg, file); err != nil {
+			if err := printGraph(dg, file); err != nil {
 				_ = file.Close()
 				return err
 			}
@@ -91,9 +154,132 @@ func newStackGraphCmd() *cobra.Command {
 		"Sets the color of dependency edges in the graph")
 	cmd.PersistentFlags().StringVar(&parentEdgeColor, "parent-edge-color", "#AA6639",
 		"Sets the color of parent edges in the graph")
+	cmd.PersistentFlags().StringVar(&format, "format", "",
+		"The format of the graph: dot, mermaid, graphml, or json. Defaults to the format implied by the file's "+
+			"extension, or dot")
+	cmd.PersistentFlags().StringSliceVar(&filter.types, "type", nil,
+		"Only include resources whose types match this glob, such as 'aws:s3/*'. May be repeated")
+	cmd.PersistentFlags().StringSliceVar(&filter.urns, "urn", nil,
+		"Only include resources whose URNs match this glob. May be repeated")
+	cmd.PersistentFlags().StringSliceVar(&filter.providers, "provider", nil,
+		"Only include resources managed by providers whose packages, such as 'aws', or URNs match this glob. "+
+			"May be repeated")
+	cmd.PersistentFlags().StringVar(&focus, "focus", "",
+		"Only include the resource with this URN and the resources connected to it by dependency edges")
+	cmd.PersistentFlags().IntVar(&filter.depth, "depth", -1,
+		"With --focus, only include resources within this many dependency edges of the focused resource")
+	cmd.PersistentFlags().BoolVar(&clusterComponents, "cluster-components", false,
+		"Draws the children of each component resource together within a box")
 	return cmd
 }
 
+// stackGraphFilter selects the resources that are included in a stack's graph.
+type stackGraphFilter struct {
+	types     []string     // globs, one of which each resource's type must match, if any.
+	urns      []string     // globs, one of which each resource's URN must match, if any.
+	providers []string     // globs, one of which each resource's provider package or URN must match, if any.
+	focus     resource.URN // if non-empty, only this resource and those connected to it by dependencies are included.
+	depth     int          // the maximum number of dependency edges from the focused resource, or -1 for no limit.
+}
+
+// apply returns the resources that the filter selects, in their original order.
+func (f *stackGraphFilter) apply(resources []*resource.State) ([]*resource.State, error) {
+	var near map[resource.URN]bool
+	if f.focus != "" {
+		var err error
+		if near, err = dependencyNeighborhood(resources, f.focus, f.depth); err != nil {
+			return nil, err
+		}
+	}
+
+	var result []*resource.State
+	for _, res := range resources {
+		if near != nil && !near[res.URN] {
+			continue
+		}
+		if len(f.types) > 0 && !matchAnyGlob(f.types, string(res.Type)) {
+			continue
+		}
+		if len(f.urns) > 0 && !matchAnyGlob(f.urns, string(res.URN)) {
+			continue
+		}
+		if len(f.providers) > 0 && !matchProvider(f.providers, res) {
+			continue
+		}
+		result = append(result, res)
+	}
+	return result, nil
+}
+
+// dependencyNeighborhood returns the URNs of the given resources that are connected to the focused resource by at
+// most depth dependency edges, followed in either direction. A negative depth places no limit on the distance.
+func dependencyNeighborhood(resources []*resource.State, focus resource.URN, depth int) (map[resource.URN]bool,
+	error) {
+
+	neighbors := make(map[resource.URN][]resource.URN)
+	found := false
+	for _, res := range resources {
+		if res.URN == focus {
+			found = true
+		}
+		for _, dep := range res.Dependencies {
+			neighbors[res.URN] = append(neighbors[res.URN], dep)
+			neighbors[dep] = append(neighbors[dep], res.URN)
+		}
+	}
+	if !found {
+		return nil, errors.Errorf("no resource with URN %s exists in the stack", focus)
+	}
+
+	near := map[resource.URN]bool{focus: true}
+	frontier := []resource.URN{focus}
+	for distance := 0; len(frontier) > 0 && (depth < 0 || distance < depth); distance++ {
+		var next []resource.URN
+		for _, urn := range frontier {
+			for _, n := range neighbors[urn] {
+				if !near[n] {
+					near[n] = true
+					next = append(next, n)
+				}
+			}
+		}
+		frontier = next
+	}
+	return near, nil
+}
+
+// matchProvider returns true if the given resource is managed by a provider whose package or URN matches one of the
+// given globs. A provider resource is considered to be managed by itself.
+func matchProvider(globs []string, res *resource.State) bool {
+	urn := res.URN
+	if !providers.IsProviderType(res.Type) {
+		if res.Provider == "" {
+			return false
+		}
+		ref, err := providers.ParseReference(res.Provider)
+		if err != nil {
+			return false
+		}
+		urn = ref.URN()
+	}
+	pkg := providers.GetProviderPackage(urn.Type())
+	return matchAnyGlob(globs, string(pkg)) || matchAnyGlob(globs, string(urn))
+}
+
+// matchAnyGlob returns true if the given string matches any of the given globs, in which '*' matches any sequence of
+// characters and '?' matches any single character.
+func matchAnyGlob(globs []string, s string) bool {
+	for _, glob := range globs {
+		pattern := regexp.QuoteMeta(glob)
+		pattern = strings.Replace(pattern, `\*`, ".*", -1)
+		pattern = strings.Replace(pattern, `\?`, ".", -1)
+		if regexp.MustCompile("^" + pattern + "$").MatchString(s) {
+			return true
+		}
+	}
+	return false
+}
+
 // All of the types and code within this file are to provide implementations of the interfaces
 // in the `graph` package, so that we can use the `dotconv` package to output our graph in the
 // DOT format.
@@ -185,6 +371,19 @@ func (vertex *dependencyVertex) Outs() []graph.Edge {
 	return vertex.outgoingEdges
 }
 
+// The cluster of a resource is that of its parent, if its parent is a component resource that is in the graph and
+// components are being clustered.
+func (vertex *dependencyVertex) Cluster() graph.Vertex {
+	if !clusterComponents || vertex.resource.Parent == "" {
+		return nil
+	}
+	parent, ok := vertex.graph.vertices[vertex.resource.Parent]
+	if !ok || parent.resource.Custom {
+		return nil
+	}
+	return parent
+}
+
 // A dependencyGraph is a thin wrapper around a map of URNs to vertices in
 // the graph. It is constructed directly from a snapshot.
 type dependencyGraph struct {
@@ -193,11 +392,18 @@ type dependencyGraph struct {
 
 // Roots are edges that point to the root set of our graph. In our case,
 // for simplicity, we define the root set of our dependency graph to be everything.
+// The roots are sorted by URN so that the graph is printed deterministically.
 func (dg *dependencyGraph) Roots() []graph.Edge {
+	urns := make([]string, 0, len(dg.vertices))
+	for urn := range dg.vertices {
+		urns = append(urns, string(urn))
+	}
+	sort.Strings(urns)
+
 	rootEdges := []graph.Edge{}
-	for _, vertex := range dg.vertices {
+	for _, urn := range urns {
 		edge := &dependencyEdge{
-			to:   vertex,
+			to:   dg.vertices[resource.URN(urn)],
 			from: nil,
 		}
 
@@ -207,14 +413,14 @@ func (dg *dependencyGraph) Roots() []graph.Edge {
 	return rootEdges
 }
 
-// Makes a dependency graph from a deployment snapshot, allocating a vertex
-// for every resource in the graph.
-func makeDependencyGraph(snapshot *deploy.Snapshot) *dependencyGraph {
+// Makes a dependency graph from the resources in a deployment snapshot, allocating a vertex
+// for every resource in the graph. Edges to resources that are not in the graph are omitted.
+func makeDependencyGraph(resources []*resource.State) *dependencyGraph {
 	dg := &dependencyGraph{
 		vertices: make(map[resource.URN]*dependencyVertex),
 	}
 
-	for _, resource := range snapshot.Resources {
+	for _, resource := range resources {
 		vertex := &dependencyVertex{
 			graph:    dg,
 			resource: resource,
@@ -223,7 +429,14 @@ func makeDependencyGraph(snapshot *deploy.Snapshot) *dependencyGraph {
 		dg.vertices[resource.URN] = vertex
 	}
 
-	for _, vertex := range dg.vertices {
+	// Visit the vertices in the order of their resources so that edges are added deterministically.
+	for _, res := range resources {
+		vertex := dg.vertices[res.URN]
+		if vertex.resource != res {
+			// This resource was replaced by a later one with the same URN.
+			continue
+		}
+
 		if !ignoreDependencyEdges {
 			// If we have per-property dependency information, annotate the dependency edges
 			// we generate with the names of the properties associated with each dependency.
@@ -237,7 +450,10 @@ func makeDependencyGraph(snapshot *deploy.Snapshot) *dependencyGraph {
 			// Incoming edges are directly stored within the checkpoint file; they represent
 			// resources on which this vertex immediately depends upon.
 			for _, dep := range vertex.resource.Dependencies {
-				vertexWeDependOn := vertex.graph.vertices[dep]
+				vertexWeDependOn, ok := vertex.graph.vertices[dep]
+				if !ok {
+					continue
+				}
 				edge := &dependencyEdge{to: vertex, from: vertexWeDependOn, labels: depBlame[dep]}
 				vertex.incomingEdges = append(vertex.incomingEdges, edge)
 				vertexWeDependOn.outgoingEdges = append(vertexWeDependOn.outgoingEdges, edge)
@@ -248,8 +464,7 @@ func makeDependencyGraph(snapshot *deploy.Snapshot) *dependencyGraph {
 		// is also displayed as part of this graph, although with different colored
 		// edges.
 		if !ignoreParentEdges {
-			if parent := vertex.resource.Parent; parent != resource.URN("") {
-				parentVertex := dg.vertices[parent]
+			if parentVertex, ok := dg.vertices[vertex.resource.Parent]; ok {
 				vertex.outgoingEdges = append(vertex.outgoingEdges, &parentEdge{
 					to:   parentVertex,
 					from: vertex,
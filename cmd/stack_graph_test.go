@@ -0,0 +1,127 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/graph/dotconv"
+	"github.com/pulumi/pulumi/pkg/graph/jsonconv"
+	"github.com/pulumi/pulumi/pkg/graph/mermaidconv"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/tokens"
+)
+
+func graphTestState(typ tokens.Type, name string, custom bool, parent resource.URN,
+	deps ...resource.URN) *resource.State {
+
+	return &resource.State{
+		URN:          resource.NewURN("test", "proj", "", typ, tokens.QName(name)),
+		Type:         typ,
+		Custom:       custom,
+		Parent:       parent,
+		Dependencies: deps,
+	}
+}
+
+func graphTestResources() []*resource.State {
+	stack := graphTestState("pulumi:pulumi:Stack", "proj-test", false, "")
+	aws := graphTestState("pulumi:providers:aws", "default", true, "")
+	gcp := graphTestState("pulumi:providers:gcp", "default", true, "")
+	comp := graphTestState("my:index:Component", "comp", false, stack.URN)
+	bucket := graphTestState("aws:s3/bucket:Bucket", "bucket", true, comp.URN)
+	bucket.Provider = string(aws.URN) + "::id"
+	object := graphTestState("aws:s3/bucketObject:BucketObject", "object", true, comp.URN, bucket.URN)
+	object.Provider = string(aws.URN) + "::id"
+	topic := graphTestState("gcp:pubsub/topic:Topic", "topic", true, stack.URN, object.URN)
+	topic.Provider = string(gcp.URN) + "::id"
+	return []*resource.State{stack, aws, gcp, comp, bucket, object, topic}
+}
+
+func TestStackGraphFilter(t *testing.T) {
+	resources := graphTestResources()
+	names := func(filter stackGraphFilter) []string {
+		filtered, err := filter.apply(resources)
+		assert.NoError(t, err)
+		var result []string
+		for _, res := range filtered {
+			result = append(result, string(res.URN.Name()))
+		}
+		return result
+	}
+
+	assert.Equal(t, []string{"bucket", "object"}, names(stackGraphFilter{types: []string{"aws:s3/*"}, depth: -1}))
+	assert.Equal(t, []string{"comp", "topic"},
+		names(stackGraphFilter{urns: []string{"*::comp", "*Topic::*"}, depth: -1}))
+	assert.Equal(t, []string{"default", "bucket", "object"},
+		names(stackGraphFilter{providers: []string{"aws"}, depth: -1}))
+	assert.Equal(t, []string{"default", "topic"},
+		names(stackGraphFilter{providers: []string{"urn:pulumi:*::pulumi:providers:gcp::*"}, depth: -1}))
+
+	// Dependencies are followed in both directions from the focused resource.
+	assert.Equal(t, []string{"bucket", "object", "topic"},
+		names(stackGraphFilter{focus: resources[5].URN, depth: 1}))
+	assert.Equal(t, []string{"bucket", "object"}, names(stackGraphFilter{focus: resources[4].URN, depth: 1}))
+	assert.Equal(t, []string{"bucket", "object", "topic"}, names(stackGraphFilter{focus: resources[4].URN, depth: -1}))
+	assert.Equal(t, []string{"bucket"},
+		names(stackGraphFilter{focus: resources[5].URN, depth: -1, types: []string{"*Bucket"}}))
+
+	missing := stackGraphFilter{focus: "urn:pulumi:test::proj::a:b:c::missing", depth: -1}
+	_, err := missing.apply(resources)
+	assert.Error(t, err)
+}
+
+func TestStackGraphFormats(t *testing.T) {
+	clusterComponents, dependencyEdgeColor, parentEdgeColor = true, "#246C60", "#AA6639"
+	defer func() { clusterComponents, dependencyEdgeColor, parentEdgeColor = false, "", "" }()
+
+	dg := makeDependencyGraph(graphTestResources())
+
+	var dot bytes.Buffer
+	assert.NoError(t, dotconv.Print(dg, &dot))
+	assert.Contains(t, dot.String(), "subgraph cluster_")
+	assert.Contains(t, dot.String(), "label = \"urn:pulumi:test::proj::my:index:Component::comp\";")
+
+	var mermaid bytes.Buffer
+	assert.NoError(t, mermaidconv.Print(dg, &mermaid))
+	assert.Contains(t, mermaid.String(), "graph TD\n")
+	assert.Contains(t, mermaid.String(), "subgraph cluster_")
+	assert.Contains(t, mermaid.String(), "linkStyle 0 stroke:")
+
+	var out bytes.Buffer
+	assert.NoError(t, jsonconv.Print(dg, &out))
+	var result jsonconv.Graph
+	assert.NoError(t, json.Unmarshal(out.Bytes(), &result))
+	ids := make(map[string]jsonconv.Vertex)
+	for _, v := range result.Vertices {
+		ids[v.ID] = v
+	}
+	clusters := make(map[string]string)
+	for _, v := range result.Vertices {
+		if v.Cluster != "" {
+			clusters[string(resource.URN(v.Label).Name())] = string(resource.URN(ids[v.Cluster].Label).Name())
+		}
+	}
+	assert.Equal(t, map[string]string{
+		"comp":   "proj-test",
+		"topic":  "proj-test",
+		"bucket": "comp",
+		"object": "comp",
+	}, clusters)
+}
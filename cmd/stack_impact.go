@@ -0,0 +1,192 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/backend/display"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/graph"
+	"github.com/pulumi/pulumi/pkg/tokens"
+	"github.com/pulumi/pulumi/pkg/util/cmdutil"
+)
+
+func newStackImpactCmd() *cobra.Command {
+	var stackName string
+	var jsonOut bool
+	var showDependencies bool
+	cmd := &cobra.Command{
+		Use:   "impact <urn>",
+		Args:  cmdutil.ExactArgs(1),
+		Short: "Show the resources that depend on a resource",
+		Long: "Show the resources that depend on a resource.\n" +
+			"\n" +
+			"This command lists every resource in the stack's most recent deployment that depends on\n" +
+			"the given resource, directly or indirectly, through its dependencies, its provider, or its\n" +
+			"parent. For each dependent, it shows whether the dependent would be replaced if the given\n" +
+			"resource were replaced: dependents whose provider would be replaced are always replaced, and\n" +
+			"dependents with inputs that depend on replaced resources may be replaced, as decided by\n" +
+			"their providers during an update. Components and external resources are never replaced.\n" +
+			"\n" +
+			"Pass --dependencies to also list the resources on which the given resource depends.",
+		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			opts := display.Options{
+				Color: cmdutil.GetGlobalColorization(),
+			}
+
+			s, err := requireStack(stackName, false, opts, true /*setCurrent*/)
+			if err != nil {
+				return err
+			}
+			snap, err := s.Snapshot(commandContext())
+			if err != nil {
+				return err
+			}
+			if snap == nil {
+				return errors.Errorf("stack '%s' has no resources", s.Ref())
+			}
+			res, err := locateStackResource(opts, snap, resource.URN(args[0]))
+			if err != nil {
+				return err
+			}
+
+			dg := graph.NewDependencyGraph(snap.Resources)
+			impacts, err := dg.ImpactOf(res)
+			if err != nil {
+				return err
+			}
+			var dependencies []*resource.State
+			if showDependencies {
+				dependencies = dg.TransitiveDependenciesOf(res)
+			}
+
+			if jsonOut {
+				return printJSON(makeImpactJSON(res, impacts, dependencies, showDependencies))
+			}
+			printImpact(res, impacts, dependencies, showDependencies)
+			return nil
+		}),
+	}
+
+	cmd.PersistentFlags().StringVarP(
+		&stackName, "stack", "s", "", "The name of the stack to operate on. Defaults to the current stack")
+	cmd.PersistentFlags().BoolVarP(
+		&jsonOut, "json", "j", false, "Emit output as JSON")
+	cmd.PersistentFlags().BoolVar(
+		&showDependencies, "dependencies", false, "Also list the resources on which the resource depends")
+
+	return cmd
+}
+
+// impactJSON is the shape of the --json output of `pulumi stack impact`.
+type impactJSON struct {
+	URN          resource.URN          `json:"urn"`
+	Dependents   []impactDependentJSON `json:"dependents"`
+	Dependencies []impactResourceJSON  `json:"dependencies,omitempty"`
+}
+
+// impactDependentJSON describes a resource that depends on the resource whose impact is shown.
+type impactDependentJSON struct {
+	URN  resource.URN `json:"urn"`
+	Type tokens.Type  `json:"type"`
+	// Via lists the resources through which this resource directly depends on the resource whose impact is shown.
+	Via []resource.URN `json:"via"`
+	// Replacement is one of "replaced", "may-be-replaced", or "not-replaced".
+	Replacement string `json:"replacement"`
+}
+
+// impactResourceJSON describes a resource on which the resource whose impact is shown depends.
+type impactResourceJSON struct {
+	URN  resource.URN `json:"urn"`
+	Type tokens.Type  `json:"type"`
+}
+
+// impactReplacementJSON returns the JSON representation of a replacement.
+func impactReplacementJSON(r graph.Replacement) string {
+	switch r {
+	case graph.Replaced:
+		return "replaced"
+	case graph.MaybeReplaced:
+		return "may-be-replaced"
+	default:
+		return "not-replaced"
+	}
+}
+
+func makeImpactJSON(res *resource.State, impacts []graph.Impact, dependencies []*resource.State,
+	showDependencies bool) impactJSON {
+
+	result := impactJSON{URN: res.URN, Dependents: []impactDependentJSON{}}
+	for _, impact := range impacts {
+		result.Dependents = append(result.Dependents, impactDependentJSON{
+			URN:         impact.Resource.URN,
+			Type:        impact.Resource.Type,
+			Via:         impact.Via,
+			Replacement: impactReplacementJSON(impact.Replacement),
+		})
+	}
+	if showDependencies {
+		result.Dependencies = []impactResourceJSON{}
+		for _, dep := range dependencies {
+			result.Dependencies = append(result.Dependencies, impactResourceJSON{URN: dep.URN, Type: dep.Type})
+		}
+	}
+	return result
+}
+
+func printImpact(res *resource.State, impacts []graph.Impact, dependencies []*resource.State, showDependencies bool) {
+	if len(impacts) == 0 {
+		fmt.Printf("No resources depend on %s.\n", res.URN)
+	} else {
+		replaced, maybeReplaced := 0, 0
+		var rows []cmdutil.TableRow
+		for _, impact := range impacts {
+			switch impact.Replacement {
+			case graph.Replaced:
+				replaced++
+			case graph.MaybeReplaced:
+				maybeReplaced++
+			}
+			rows = append(rows, cmdutil.TableRow{Columns: []string{
+				string(impact.Resource.URN), impact.Replacement.String(),
+			}})
+		}
+
+		fmt.Printf("Resources that depend on %s:\n", res.URN)
+		cmdutil.PrintTable(cmdutil.Table{
+			Headers: []string{"URN", "IF REPLACED"},
+			Rows:    rows,
+			Prefix:  "    ",
+		})
+		fmt.Printf("\n%d dependents; if this resource were replaced, %d would be replaced and %d may be replaced.\n",
+			len(impacts), replaced, maybeReplaced)
+	}
+
+	if showDependencies {
+		fmt.Println()
+		if len(dependencies) == 0 {
+			fmt.Printf("%s depends on no other resources.\n", res.URN)
+			return
+		}
+		fmt.Printf("Resources on which %s depends:\n", res.URN)
+		for _, dep := range dependencies {
+			fmt.Printf("    %s\n", dep.URN)
+		}
+	}
+}
@@ -0,0 +1,204 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/backend"
+	"github.com/pulumi/pulumi/pkg/backend/display"
+	"github.com/pulumi/pulumi/pkg/diag/colors"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/util/cmdutil"
+	"github.com/pulumi/pulumi/pkg/util/result"
+)
+
+func newStackRollbackCmd() *cobra.Command {
+	var stackName string
+	var version int
+	var yes bool
+	cmd := &cobra.Command{
+		Use:   "rollback",
+		Args:  cmdutil.NoArgs,
+		Short: "Restore a stack's state to the checkpoint written by a previous update",
+		Long: "Restore a stack's state to the checkpoint written by a previous update.\n" +
+			"\n" +
+			"The update is identified by its version, as listed by `pulumi stack history`. The\n" +
+			"changes that the rollback will make to the stack's state are displayed before it\n" +
+			"proceeds. Rolling back only changes the stack's state, not its resources; run\n" +
+			"`pulumi refresh` afterwards to reconcile the restored state with the live state of\n" +
+			"the stack's resources.\n" +
+			"\n" +
+			"A rollback is refused if the checkpoint contains pending operations. The rollback\n" +
+			"itself is recorded in the stack's history.",
+		Run: cmdutil.RunResultFunc(func(cmd *cobra.Command, args []string) result.Result {
+			opts := display.Options{
+				Color: cmdutil.GetGlobalColorization(),
+			}
+
+			if version <= 0 {
+				return result.Error("a positive --version is required")
+			}
+			if !yes && !cmdutil.Interactive() {
+				return result.Error("--yes must be passed in to proceed when running in non-interactive mode")
+			}
+
+			s, err := requireStack(stackName, false, opts, true /*setCurrent*/)
+			if err != nil {
+				return result.FromError(err)
+			}
+			b := s.Backend()
+
+			// Load the target checkpoint, refusing it if it has pending operations.
+			if _, err = backend.GetHistoryVersion(commandContext(), s, version); err != nil {
+				return result.FromError(err)
+			}
+			deployment, err := b.ExportDeploymentForVersion(commandContext(), s, version)
+			if err != nil {
+				return result.FromError(errors.Wrapf(err, "exporting version %d", version))
+			}
+			target, err := backend.DeserializeRollbackTarget(deployment)
+			if err != nil {
+				return result.FromError(errors.Wrapf(err, "loading version %d", version))
+			}
+
+			// Show the changes that the rollback will make to the current state.
+			current, err := s.Snapshot(commandContext())
+			if err != nil {
+				return result.FromError(err)
+			}
+			changes, same := diffRollback(current, target)
+			if len(changes) == 0 {
+				fmt.Printf("The state of stack '%s' already matches version %d; nothing to do.\n", s.Ref(), version)
+				return nil
+			}
+			fmt.Printf("Rolling back stack '%s' to version %d will make the following changes to its state:\n",
+				s.Ref(), version)
+			for _, change := range changes {
+				fmt.Println(opts.Color.Colorize(fmt.Sprintf("    %s%s %s%s",
+					change.Op.Prefix(), change.URN, rollbackChangeDescription(change.Op), colors.Reset)))
+			}
+			if same > 0 {
+				fmt.Printf("    %d unchanged\n", same)
+			}
+			fmt.Println()
+
+			prompt := fmt.Sprintf("This will replace the state of the '%s' stack with version %d.", s.Ref(), version)
+			if !yes && !confirmPrompt(prompt, s.Ref().String(), opts) {
+				fmt.Println("confirmation declined")
+				return result.Bail()
+			}
+
+			if err = b.RollbackDeployment(commandContext(), s, version); err != nil {
+				return result.FromError(errors.Wrap(err, "could not roll back"))
+			}
+			fmt.Printf("Rolled back stack '%s' to version %d.\n", s.Ref(), version)
+			return nil
+		}),
+	}
+
+	cmd.PersistentFlags().StringVarP(
+		&stackName, "stack", "s", "", "The name of the stack to operate on. Defaults to the current stack")
+	cmd.PersistentFlags().IntVar(
+		&version, "version", 0, "The version of the update whose checkpoint should be restored")
+	cmd.PersistentFlags().BoolVarP(
+		&yes, "yes", "y", false, "Skip confirmation prompts, and proceed with the rollback anyway")
+
+	return cmd
+}
+
+// rollbackChange describes how a rollback changes the state of a single resource.
+type rollbackChange struct {
+	URN resource.URN
+	// Op is OpCreate if the resource's state is restored, OpDelete if it is removed, and OpUpdate if it is changed.
+	Op deploy.StepOp
+}
+
+// rollbackChangeDescription returns a short description of the given kind of rollback change.
+func rollbackChangeDescription(op deploy.StepOp) string {
+	switch op {
+	case deploy.OpCreate:
+		return "(restored)"
+	case deploy.OpDelete:
+		return "(removed)"
+	default:
+		return "(changed)"
+	}
+}
+
+// rollbackKey identifies a resource state within a snapshot. Resources that are pending deletion may share a URN with
+// their replacements.
+type rollbackKey struct {
+	urn           resource.URN
+	pendingDelete bool
+}
+
+// diffRollback returns the changes to the state of the resources in the current snapshot that result from replacing
+// it with the target snapshot, sorted by URN, and the number of resources whose state does not change.
+func diffRollback(current, target *deploy.Snapshot) ([]rollbackChange, int) {
+	currentStates := make(map[rollbackKey]*resource.State)
+	if current != nil {
+		for _, res := range current.Resources {
+			currentStates[rollbackKey{urn: res.URN, pendingDelete: res.Delete}] = res
+		}
+	}
+
+	var changes []rollbackChange
+	same := 0
+	for _, res := range target.Resources {
+		key := rollbackKey{urn: res.URN, pendingDelete: res.Delete}
+		old, has := currentStates[key]
+		delete(currentStates, key)
+		switch {
+		case !has:
+			changes = append(changes, rollbackChange{URN: res.URN, Op: deploy.OpCreate})
+		case resourceStateChanged(old, res):
+			changes = append(changes, rollbackChange{URN: res.URN, Op: deploy.OpUpdate})
+		default:
+			same++
+		}
+	}
+	for key := range currentStates {
+		changes = append(changes, rollbackChange{URN: key.urn, Op: deploy.OpDelete})
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].URN != changes[j].URN {
+			return changes[i].URN < changes[j].URN
+		}
+		return changes[i].Op < changes[j].Op
+	})
+	return changes, same
+}
+
+// resourceStateChanged returns true if the current and restored states of a resource differ.
+func resourceStateChanged(current, restored *resource.State) bool {
+	return current.ID != restored.ID ||
+		current.Type != restored.Type ||
+		current.Custom != restored.Custom ||
+		current.Parent != restored.Parent ||
+		current.Protect != restored.Protect ||
+		current.External != restored.External ||
+		current.Provider != restored.Provider ||
+		!current.Inputs.DeepEquals(restored.Inputs) ||
+		!current.Outputs.DeepEquals(restored.Outputs) ||
+		!reflect.DeepEqual(current.Dependencies, restored.Dependencies)
+}
@@ -0,0 +1,74 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+)
+
+func rollbackTestState(name string, value string) *resource.State {
+	return &resource.State{
+		URN:    resource.URN("urn:pulumi:test::test::pkg:index:typ::" + name),
+		Type:   "pkg:index:typ",
+		Custom: true,
+		ID:     resource.ID(name),
+		Inputs: resource.PropertyMap{"value": resource.NewStringProperty(value)},
+	}
+}
+
+func TestDiffRollback(t *testing.T) {
+	current := &deploy.Snapshot{Resources: []*resource.State{
+		rollbackTestState("a", "1"),
+		rollbackTestState("b", "1"),
+		rollbackTestState("d", "1"),
+	}}
+	target := &deploy.Snapshot{Resources: []*resource.State{
+		rollbackTestState("c", "1"),
+		rollbackTestState("b", "2"),
+		rollbackTestState("a", "1"),
+	}}
+
+	changes, same := diffRollback(current, target)
+	assert.Equal(t, 1, same)
+	assert.Equal(t, []rollbackChange{
+		{URN: target.Resources[1].URN, Op: deploy.OpUpdate},
+		{URN: target.Resources[0].URN, Op: deploy.OpCreate},
+		{URN: current.Resources[2].URN, Op: deploy.OpDelete},
+	}, changes)
+}
+
+func TestDiffRollbackPendingDelete(t *testing.T) {
+	old := rollbackTestState("a", "1")
+	old.Delete = true
+	current := &deploy.Snapshot{Resources: []*resource.State{rollbackTestState("a", "2"), old}}
+	target := &deploy.Snapshot{Resources: []*resource.State{rollbackTestState("a", "2")}}
+
+	changes, same := diffRollback(current, target)
+	assert.Equal(t, 1, same)
+	assert.Equal(t, []rollbackChange{{URN: old.URN, Op: deploy.OpDelete}}, changes)
+}
+
+func TestDiffRollbackNoCurrentSnapshot(t *testing.T) {
+	target := &deploy.Snapshot{Resources: []*resource.State{rollbackTestState("a", "1")}}
+
+	changes, same := diffRollback(nil, target)
+	assert.Equal(t, 0, same)
+	assert.Equal(t, []rollbackChange{{URN: target.Resources[0].URN, Op: deploy.OpCreate}}, changes)
+}
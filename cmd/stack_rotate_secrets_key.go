@@ -0,0 +1,163 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"encoding/base64"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/backend"
+	"github.com/pulumi/pulumi/pkg/backend/display"
+	"github.com/pulumi/pulumi/pkg/secrets"
+	"github.com/pulumi/pulumi/pkg/secrets/cloud"
+	"github.com/pulumi/pulumi/pkg/secrets/passphrase"
+	"github.com/pulumi/pulumi/pkg/util/cmdutil"
+	"github.com/pulumi/pulumi/pkg/workspace"
+)
+
+func newStackRotateSecretsKeyCmd() *cobra.Command {
+	var stackName string
+	cmd := &cobra.Command{
+		Use:   "rotate-secrets-key",
+		Args:  cmdutil.NoArgs,
+		Short: "Rotate the key with which a stack's secrets are encrypted",
+		Long: "Rotate the key with which a stack's secrets are encrypted.\n" +
+			"\n" +
+			"This command generates a fresh key for the stack's secrets provider, re-encrypts every\n" +
+			"secret in the stack's configuration and in its most recent deployment with it, and saves\n" +
+			"both. For cloud secrets providers, a new data key is generated and encrypted with the same\n" +
+			"key management service key; for the passphrase secrets provider, the passphrase is kept and\n" +
+			"a new salt is generated. Keys of the Pulumi service's secrets provider are managed by the\n" +
+			"service and cannot be rotated with this command.\n" +
+			"\n" +
+			"Earlier keys are kept in the stack's settings and checkpoint, so that values encrypted with\n" +
+			"them can still be read, and the stack's earlier deployments in its history remain readable.\n" +
+			"Each rotation increments the key generation that is recorded alongside them.",
+		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			opts := display.Options{
+				Color: cmdutil.GetGlobalColorization(),
+			}
+
+			s, err := requireStack(stackName, false, opts, true /*setCurrent*/)
+			if err != nil {
+				return err
+			}
+			return rotateSecretsKey(s)
+		}),
+	}
+
+	cmd.PersistentFlags().StringVarP(
+		&stackName, "stack", "s", "", "The name of the stack to operate on. Defaults to the current stack")
+
+	return cmd
+}
+
+// rotateSecretsKey re-encrypts the configuration and the most recent deployment of the given stack with a freshly
+// generated key for its current secrets provider, keeping the previous key in the stack's settings.
+func rotateSecretsKey(s backend.Stack) (err error) {
+	configFile, err := getProjectStackPath(s)
+	if err != nil {
+		return err
+	}
+	ps, err := workspace.LoadProjectStack(configFile)
+	if err != nil {
+		return err
+	}
+
+	provider := stackSecretsProvider(s.Backend(), ps)
+	if provider == "default" {
+		return errors.Errorf("the key of stack '%s' is managed by the Pulumi service's secrets provider "+
+			"and cannot be rotated", s.Ref())
+	}
+
+	// Decrypt the stack's secrets with its current secrets manager, and generate a new key for the same provider.
+	var currentSecretsManager secrets.Manager
+	var rotate func() (secrets.Manager, error)
+	tempFile := stackSettingsTempFile(configFile)
+	defer func() { removeStackSettingsTempFile(tempFile, err) }()
+	if provider == passphrase.Type {
+		if ps.EncryptionSalt == "" {
+			return errors.Errorf("stack '%s' has no secrets key to rotate", s.Ref())
+		}
+
+		// We construct the passphrase secrets managers here, rather than through newPassphraseSecretsManager,
+		// so that the passphrase is only requested once for both.
+		phrase, phraseErr := readPassphrase("Enter your passphrase to unlock config/secrets\n" +
+			"    (set PULUMI_CONFIG_PASSPHRASE to remember)")
+		if phraseErr != nil {
+			return phraseErr
+		}
+		currentSecretsManager, err = passphrase.NewPassphaseSecretsManagerWithHistory(
+			phrase, ps.EncryptionSalt, ps.SecretsKeyGeneration, ps.PreviousEncryptionSalts)
+		if err != nil {
+			return err
+		}
+		rotate = func() (secrets.Manager, error) {
+			state, err := passphrase.GenerateNewSalt(phrase)
+			if err != nil {
+				return nil, err
+			}
+			ps.PreviousEncryptionSalts = append([]string{ps.EncryptionSalt}, ps.PreviousEncryptionSalts...)
+			ps.EncryptionSalt = state
+			ps.SecretsKeyGeneration++
+			if err = ps.Save(tempFile); err != nil {
+				return nil, err
+			}
+			return passphrase.NewPassphaseSecretsManagerWithHistory(
+				phrase, ps.EncryptionSalt, ps.SecretsKeyGeneration, ps.PreviousEncryptionSalts)
+		}
+	} else {
+		if currentSecretsManager, err = getStackSecretsManager(s); err != nil {
+			return err
+		}
+		rotate = func() (secrets.Manager, error) {
+			dataKey, err := cloud.GenerateNewDataKey(provider)
+			if err != nil {
+				return nil, err
+			}
+			ps.PreviousEncryptedKeys = append([]string{ps.EncryptedKey}, ps.PreviousEncryptedKeys...)
+			ps.EncryptedKey = base64.StdEncoding.EncodeToString(dataKey)
+			ps.SecretsKeyGeneration++
+			if err = ps.Save(tempFile); err != nil {
+				return nil, err
+			}
+			return newCloudSecretsManager(s.Ref().Name(), tempFile, provider)
+		}
+	}
+	decrypter, err := currentSecretsManager.Decrypter()
+	if err != nil {
+		return err
+	}
+	snap, err := s.Snapshot(commandContext())
+	if err != nil {
+		return err
+	}
+
+	cfg := ps.Config
+	newSecretsManager, err := rotate()
+	if err != nil {
+		return errors.Wrap(err, "generating a new key")
+	}
+	if _, err = saveReencryptedStack(s, snap, cfg, decrypter, newSecretsManager, tempFile, configFile); err != nil {
+		return err
+	}
+
+	fmt.Printf("Rotated the secrets key of stack '%s'; its key generation is now %d.\n",
+		s.Ref(), ps.SecretsKeyGeneration)
+	return nil
+}
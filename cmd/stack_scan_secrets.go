@@ -0,0 +1,227 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/backend"
+	"github.com/pulumi/pulumi/pkg/backend/display"
+	"github.com/pulumi/pulumi/pkg/diag"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/resource/stack"
+	"github.com/pulumi/pulumi/pkg/util/cmdutil"
+)
+
+// defaultSecretLeakMinLength is the default length below which secret values are not searched for, since short values
+// commonly occur by chance.
+const defaultSecretLeakMinLength = 4
+
+func newStackScanSecretsCmd() *cobra.Command {
+	var stackName string
+	var jsonOut bool
+	var markSecret bool
+	var minLength int
+	cmd := &cobra.Command{
+		Use:   "scan-secrets",
+		Args:  cmdutil.NoArgs,
+		Short: "Find plaintext copies of secret values in a stack's state",
+		Long: "Find plaintext copies of secret values in a stack's state.\n" +
+			"\n" +
+			"Secrets can leak into a stack's checkpoint in plaintext, for example when a provider echoes a\n" +
+			"secret input into an output that is not marked secret, or when a program interpolates a\n" +
+			"secret into a string. This command searches the inputs and outputs of every resource in the\n" +
+			"stack's most recent deployment, including the stack's outputs, for the values of the stack's\n" +
+			"secret configuration and of the secrets in the deployment, and reports each property that\n" +
+			"contains one in plaintext. The command fails if any are found, unless --mark-secret is given,\n" +
+			"in which case the properties are marked secret so that they are encrypted in the checkpoint.\n" +
+			"\n" +
+			"To scan the stack after each update, pass --scan-secrets to `pulumi up`.",
+		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			opts := display.Options{
+				Color: cmdutil.GetGlobalColorization(),
+			}
+
+			s, err := requireStack(stackName, false, opts, true /*setCurrent*/)
+			if err != nil {
+				return err
+			}
+			snap, err := s.Snapshot(commandContext())
+			if err != nil {
+				return err
+			}
+			if snap == nil {
+				return errors.Errorf("stack '%s' has no resources", s.Ref())
+			}
+
+			leaks, err := findStackSecretLeaks(s, snap, minLength)
+			if err != nil {
+				return err
+			}
+
+			if jsonOut {
+				if err = printJSON(makeSecretLeaksJSON(leaks)); err != nil {
+					return err
+				}
+			} else {
+				printSecretLeaks(leaks)
+			}
+			if len(leaks) == 0 {
+				return nil
+			}
+
+			if !markSecret {
+				return errors.Errorf("found %d plaintext %s of secret values; "+
+					"pass --mark-secret to mark them secret", len(leaks), pluralize("copy", "copies", len(leaks)))
+			}
+			// A deployment that had no secrets may not record a secrets manager, in which case we encrypt the newly
+			// marked secrets with the stack's.
+			sm := snap.SecretsManager
+			if sm == nil {
+				if sm, err = getStackSecretsManager(s); err != nil {
+					return err
+				}
+			}
+			stack.MarkSecretLeaks(leaks)
+			if err = importDeploymentWithSecretsManager(s, snap, sm); err != nil {
+				return err
+			}
+			if !jsonOut {
+				fmt.Printf("Marked %d %s secret.\n", len(leaks), pluralize("property", "properties", len(leaks)))
+			}
+			return nil
+		}),
+	}
+
+	cmd.PersistentFlags().StringVarP(
+		&stackName, "stack", "s", "", "The name of the stack to operate on. Defaults to the current stack")
+	cmd.PersistentFlags().BoolVarP(
+		&jsonOut, "json", "j", false, "Emit output as JSON")
+	cmd.PersistentFlags().BoolVar(
+		&markSecret, "mark-secret", false, "Mark the properties that contain secret values secret in the stack's state")
+	cmd.PersistentFlags().IntVar(
+		&minLength, "min-length", defaultSecretLeakMinLength,
+		"Ignore secret values shorter than this many characters, which commonly occur by chance")
+
+	return cmd
+}
+
+// findStackSecretLeaks returns the properties of the resources in the given snapshot of the stack that contain, in
+// plaintext, the values of the stack's secure configuration or of the secrets in the snapshot.
+func findStackSecretLeaks(s backend.Stack, snap *deploy.Snapshot, minLength int) ([]stack.SecretLeak, error) {
+	sm, err := getStackSecretsManager(s)
+	if err != nil {
+		return nil, errors.Wrap(err, "getting secrets manager")
+	}
+	cfg, err := getStackConfiguration(s, sm)
+	if err != nil {
+		return nil, errors.Wrap(err, "getting stack configuration")
+	}
+
+	secretValues := stack.SnapshotSecretValues(snap)
+	for key, value := range cfg.Config {
+		if !value.Secure() {
+			continue
+		}
+		values, err := value.SecureValues(cfg.Decrypter)
+		if err != nil {
+			return nil, errors.Wrapf(err, "decrypting config value %s", key)
+		}
+		secretValues = append(secretValues, values...)
+	}
+
+	return stack.FindSecretLeaks(snap, secretValues, minLength), nil
+}
+
+// warnSecretLeaks scans the state of the given stack after an update, and warns about each property that contains a
+// secret value in plaintext.
+func warnSecretLeaks(s backend.Stack) error {
+	snap, err := s.Snapshot(commandContext())
+	if err != nil || snap == nil {
+		return err
+	}
+	leaks, err := findStackSecretLeaks(s, snap, defaultSecretLeakMinLength)
+	if err != nil {
+		return errors.Wrap(err, "scanning for plaintext secret values")
+	}
+	for _, leak := range leaks {
+		cmdutil.Diag().Warningf(diag.RawMessage(leak.Resource.URN,
+			fmt.Sprintf("%s contains a secret value in plaintext", secretLeakProperty(leak))))
+	}
+	if len(leaks) > 0 {
+		cmdutil.Diag().Warningf(diag.Message("", "run `pulumi stack scan-secrets --mark-secret` to mark "+
+			"the properties that contain plaintext secret values secret"))
+	}
+	return nil
+}
+
+// secretLeakProperty returns a description of the property that a secret leak was found in, such as `outputs.arn`.
+func secretLeakProperty(leak stack.SecretLeak) string {
+	prefix := "inputs"
+	if leak.Output {
+		prefix = "outputs"
+	}
+	path := leak.Path.String()
+	if strings.HasPrefix(path, "[") {
+		return prefix + path
+	}
+	return prefix + "." + path
+}
+
+// secretLeakJSON is the shape of each element of the --json output of `pulumi stack scan-secrets`.
+type secretLeakJSON struct {
+	URN resource.URN `json:"urn"`
+	// Property is the property that contains a secret value, such as `outputs.arn` or `inputs.tags.note`.
+	Property string `json:"property"`
+}
+
+func makeSecretLeaksJSON(leaks []stack.SecretLeak) []secretLeakJSON {
+	result := []secretLeakJSON{}
+	for _, leak := range leaks {
+		result = append(result, secretLeakJSON{URN: leak.Resource.URN, Property: secretLeakProperty(leak)})
+	}
+	return result
+}
+
+func printSecretLeaks(leaks []stack.SecretLeak) {
+	if len(leaks) == 0 {
+		fmt.Println("No plaintext secret values found.")
+		return
+	}
+
+	var rows []cmdutil.TableRow
+	for _, leak := range leaks {
+		rows = append(rows, cmdutil.TableRow{Columns: []string{string(leak.Resource.URN), secretLeakProperty(leak)}})
+	}
+	fmt.Println("Properties that contain plaintext secret values:")
+	cmdutil.PrintTable(cmdutil.Table{
+		Headers: []string{"URN", "PROPERTY"},
+		Rows:    rows,
+		Prefix:  "    ",
+	})
+	fmt.Println()
+}
+
+func pluralize(singular, plural string, n int) string {
+	if n == 1 {
+		return singular
+	}
+	return plural
+}
@@ -15,7 +15,6 @@
 package cmd
 
 import (
-	"encoding/json"
 	"fmt"
 
 	"github.com/pulumi/pulumi/pkg/util/result"
@@ -23,13 +22,11 @@ import (
 	"github.com/pulumi/pulumi/pkg/util/contract"
 
 	"github.com/pkg/errors"
-	"github.com/pulumi/pulumi/pkg/apitype"
 	"github.com/pulumi/pulumi/pkg/backend/display"
 	"github.com/pulumi/pulumi/pkg/diag/colors"
 	"github.com/pulumi/pulumi/pkg/resource"
 	"github.com/pulumi/pulumi/pkg/resource/deploy"
 	"github.com/pulumi/pulumi/pkg/resource/edit"
-	"github.com/pulumi/pulumi/pkg/resource/stack"
 	"github.com/pulumi/pulumi/pkg/util/cmdutil"
 	"github.com/spf13/cobra"
 	survey "gopkg.in/AlecAivazis/survey.v1"
@@ -48,6 +45,9 @@ troubleshooting a stack or when performing specific edits that otherwise would r
 	}
 
 	cmd.AddCommand(newStateDeleteCommand())
+	cmd.AddCommand(newStateMoveCommand())
+	cmd.AddCommand(newStateRenameCommand())
+	cmd.AddCommand(newStateReparentCommand())
 	cmd.AddCommand(newStateUnprotectCommand())
 	return cmd
 }
@@ -167,19 +167,6 @@ func runTotalStateEdit(
 		contract.AssertNoErrorf(snap.VerifyIntegrity(), "state edit produced an invalid snapshot")
 	}
 
-	sdep, err := stack.SerializeDeployment(snap, snap.SecretsManager)
-	if err != nil {
-		return result.FromError(errors.Wrap(err, "serializing deployment"))
-	}
-
 	// Once we've mutated the snapshot, import it back into the backend so that it can be persisted.
-	bytes, err := json.Marshal(sdep)
-	if err != nil {
-		return result.FromError(err)
-	}
-	dep := apitype.UntypedDeployment{
-		Version:    apitype.DeploymentSchemaVersionCurrent,
-		Deployment: bytes,
-	}
-	return result.WrapIfNonNil(s.ImportDeployment(commandContext(), &dep))
+	return result.WrapIfNonNil(importDeploymentWithSecretsManager(s, snap, snap.SecretsManager))
 }
@@ -0,0 +1,286 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	survey "gopkg.in/AlecAivazis/survey.v1"
+	surveycore "gopkg.in/AlecAivazis/survey.v1/core"
+
+	"github.com/pulumi/pulumi/pkg/backend"
+	"github.com/pulumi/pulumi/pkg/backend/display"
+	"github.com/pulumi/pulumi/pkg/diag/colors"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/resource/edit"
+	"github.com/pulumi/pulumi/pkg/secrets"
+	"github.com/pulumi/pulumi/pkg/tokens"
+	"github.com/pulumi/pulumi/pkg/util/cmdutil"
+	"github.com/pulumi/pulumi/pkg/util/result"
+	"github.com/pulumi/pulumi/pkg/version"
+)
+
+func newStateMoveCommand() *cobra.Command {
+	var stack string
+	var destStack string
+	var destProject string
+	var urns []string
+	var types []string
+	var subtrees []string
+	var yes bool
+
+	cmd := &cobra.Command{
+		Use:   "move",
+		Short: "Move resources from one stack's state to another's",
+		Long: `Move resources from one stack's state to another's
+
+This command moves resources from the state of one stack to the state of another, without changing the resources
+themselves, for example to split a large stack into smaller ones. The resources to move are selected by URN with
+--urn, by type with --type, or, with --subtree, as a component resource together with all of its descendants.
+
+The URNs of the moved resources are rewritten for the destination stack and project. The providers that the moved
+resources use are carried along with them, and are copied rather than moved if resources that remain in the source
+stack still use them. Secrets are re-encrypted with the destination stack's secrets provider. The move fails, and
+neither stack's state is changed, if a resource that remains in the source stack depends on or is a child of a moved
+resource, or if a moved resource depends on or is a child of a resource that is not moved.
+
+The destination stack's state is saved before the source stack's. If saving the source stack's state fails, the moved
+resources are left in both stacks, and should be deleted from one of them with 'pulumi state delete'.`,
+		Args: cmdutil.NoArgs,
+		Run: cmdutil.RunResultFunc(func(cmd *cobra.Command, args []string) result.Result {
+			if destStack == "" {
+				return result.Error("must provide a destination stack with --dest")
+			}
+			if len(urns) == 0 && len(types) == 0 && len(subtrees) == 0 {
+				return result.Error("must select the resources to move with --urn, --type or --subtree")
+			}
+
+			// Show the confirmation prompt if the user didn't pass the --yes parameter to skip it.
+			return moveResources(stack, destStack, tokens.PackageName(destProject), resourceSelection{
+				urns:     urns,
+				types:    types,
+				subtrees: subtrees,
+			}, !yes)
+		}),
+	}
+
+	cmd.PersistentFlags().StringVarP(
+		&stack, "stack", "s", "",
+		"The name of the stack to move resources from. Defaults to the current stack")
+	cmd.PersistentFlags().StringVar(
+		&destStack, "dest", "",
+		"The name of the stack to move resources to")
+	cmd.PersistentFlags().StringVar(
+		&destProject, "dest-project", "",
+		"The name of the destination stack's project. Defaults to the project of the destination stack's existing "+
+			"resources, or to the current project")
+	cmd.PersistentFlags().StringSliceVar(
+		&urns, "urn", nil,
+		"The URN of a resource to move. May be specified multiple times")
+	cmd.PersistentFlags().StringSliceVar(
+		&types, "type", nil,
+		"Move all resources of the given type. May be specified multiple times")
+	cmd.PersistentFlags().StringSliceVar(
+		&subtrees, "subtree", nil,
+		"Move the resource with the given URN and all of its descendants. May be specified multiple times")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Skip confirmation prompts")
+
+	return cmd
+}
+
+// resourceSelection selects the resources of a snapshot to operate upon.
+type resourceSelection struct {
+	urns     []string // the URNs of resources to select.
+	types    []string // the types of resources to select.
+	subtrees []string // the URNs of resources to select along with all of their descendants.
+}
+
+// selectResources returns the resources in the given snapshot that the selection selects, in snapshot order.
+func (sel resourceSelection) selectResources(snap *deploy.Snapshot) ([]*resource.State, error) {
+	selected := make(map[resource.URN]bool)
+	for _, urn := range append(append([]string{}, sel.urns...), sel.subtrees...) {
+		if len(edit.LocateResource(snap, resource.URN(urn))) == 0 {
+			return nil, errors.Errorf("No such resource %q exists in the current state", urn)
+		}
+		selected[resource.URN(urn)] = true
+	}
+	typeSet := make(map[tokens.Type]bool)
+	for _, typ := range sel.types {
+		typeSet[tokens.Type(typ)] = true
+	}
+	subtreeRoots := make(map[resource.URN]bool)
+	for _, urn := range sel.subtrees {
+		subtreeRoots[resource.URN(urn)] = true
+	}
+
+	// Resources are sorted topologically, so the parent of a resource is always selected before it.
+	inSubtree := make(map[resource.URN]bool)
+	var result []*resource.State
+	for _, res := range snap.Resources {
+		if subtreeRoots[res.URN] || inSubtree[res.Parent] {
+			inSubtree[res.URN] = true
+		}
+		if selected[res.URN] || typeSet[res.Type] || inSubtree[res.URN] {
+			result = append(result, res)
+		}
+	}
+	if len(result) == 0 {
+		return nil, errors.New("no resources matched the selection")
+	}
+	return result, nil
+}
+
+func moveResources(sourceName, destName string, destProject tokens.PackageName, sel resourceSelection,
+	showPrompt bool) result.Result {
+
+	opts := display.Options{
+		Color: cmdutil.GetGlobalColorization(),
+	}
+	source, err := requireStack(sourceName, true, opts, true /*setCurrent*/)
+	if err != nil {
+		return result.FromError(err)
+	}
+	dest, err := requireStack(destName, false, opts, false /*setCurrent*/)
+	if err != nil {
+		return result.FromError(err)
+	}
+	if source.Ref().String() == dest.Ref().String() {
+		return result.Errorf("cannot move resources from stack '%s' to itself", source.Ref())
+	}
+
+	sourceSnap, err := source.Snapshot(commandContext())
+	if err != nil {
+		return result.FromError(err)
+	}
+	if sourceSnap == nil {
+		return result.Errorf("stack '%s' has no resources", source.Ref())
+	}
+	destSnap, destSM, err := loadMoveDestination(dest)
+	if err != nil {
+		return result.FromError(err)
+	}
+	if destProject == "" {
+		if destProject, err = moveDestinationProject(destSnap); err != nil {
+			return result.FromError(err)
+		}
+	}
+
+	resources, err := sel.selectResources(sourceSnap)
+	if err != nil {
+		return result.FromError(err)
+	}
+
+	if showPrompt && cmdutil.Interactive() {
+		fmt.Printf("This command will move the following resources from stack '%s' to stack '%s':\n",
+			source.Ref(), dest.Ref())
+		for _, res := range resources {
+			fmt.Printf("    %s\n", res.URN)
+		}
+		fmt.Println()
+
+		confirm := false
+		surveycore.DisableColor = true
+		surveycore.QuestionIcon = ""
+		surveycore.SelectFocusIcon = opts.Color.Colorize(colors.BrightGreen + ">" + colors.Reset)
+		prompt := opts.Color.Colorize(colors.Yellow + "warning" + colors.Reset + ": ")
+		prompt += "This command will edit the state of both stacks directly. Confirm?"
+		if err = survey.AskOne(&survey.Confirm{
+			Message: prompt,
+		}, &confirm, nil); err != nil || !confirm {
+			fmt.Println("confirmation declined")
+			return result.Bail()
+		}
+	}
+
+	carried, err := edit.MoveResources(sourceSnap, destSnap, resources, dest.Ref().Name(), destProject)
+	if err != nil {
+		return result.FromError(err)
+	}
+
+	// A deployment that had no secrets may not record a secrets manager, in which case we use the stack's.
+	sourceSM := sourceSnap.SecretsManager
+	if sourceSM == nil {
+		if sourceSM, err = getStackSecretsManager(source); err != nil {
+			return result.FromError(err)
+		}
+	}
+
+	// Save the destination first, so that a failure part way through duplicates the moved resources rather than
+	// losing them.
+	if err = importDeploymentWithSecretsManager(dest, destSnap, destSM); err != nil {
+		return result.FromError(errors.Wrapf(err, "saving the state of stack '%s'", dest.Ref()))
+	}
+	if err = importDeploymentWithSecretsManager(source, sourceSnap, sourceSM); err != nil {
+		return result.FromError(errors.Wrapf(err, "saving the state of stack '%s'; the moved resources are now "+
+			"in both stacks", source.Ref()))
+	}
+
+	fmt.Printf("Moved %d %s from stack '%s' to stack '%s'.\n", len(resources),
+		pluralize("resource", "resources", len(resources)), source.Ref(), dest.Ref())
+	if len(carried) > 0 {
+		fmt.Println("The following providers were carried along with them:")
+		for _, prov := range carried {
+			fmt.Printf("    %s\n", prov.URN)
+		}
+	}
+	return nil
+}
+
+// loadMoveDestination returns the snapshot of the destination stack of a move, which is empty if the stack has no
+// resources yet, and the secrets manager to encrypt it with.
+func loadMoveDestination(dest backend.Stack) (*deploy.Snapshot, secrets.Manager, error) {
+	snap, err := dest.Snapshot(commandContext())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var sm secrets.Manager
+	if snap != nil {
+		sm = snap.SecretsManager
+	}
+	if sm == nil {
+		if sm, err = getStackSecretsManager(dest); err != nil {
+			return nil, nil, errors.Wrapf(err, "getting the secrets manager of stack '%s'", dest.Ref())
+		}
+	}
+
+	if snap == nil {
+		manifest := deploy.Manifest{
+			Time:    time.Now(),
+			Version: version.Version,
+		}
+		manifest.Magic = manifest.NewMagic()
+		snap = deploy.NewSnapshot(manifest, sm, nil, nil)
+	}
+	return snap, sm, nil
+}
+
+// moveDestinationProject returns the project of the destination stack of a move: the project of its existing
+// resources if it has any, and the current project otherwise.
+func moveDestinationProject(snap *deploy.Snapshot) (tokens.PackageName, error) {
+	if len(snap.Resources) > 0 {
+		return snap.Resources[0].URN.Project(), nil
+	}
+
+	proj, _, err := readProject()
+	if err != nil {
+		return "", err
+	}
+	return proj.Name, nil
+}
@@ -0,0 +1,88 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/backend/display"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/resource/edit"
+	"github.com/pulumi/pulumi/pkg/util/cmdutil"
+	"github.com/pulumi/pulumi/pkg/util/result"
+)
+
+func newStateReparentCommand() *cobra.Command {
+	var stack string
+	var yes bool
+
+	cmd := &cobra.Command{
+		Use:   "reparent <resource URN> <new parent URN>",
+		Short: "Change the parent of a resource in a stack's state",
+		Long: `Change the parent of a resource in a stack's state
+
+This command changes the parent of a resource in a stack's state, so that moving the resource into or out of a
+component in a program does not cause it to be replaced. The URNs of the resource and of all of its descendants are
+regenerated, and every reference to them in the stack's state is updated. The resource and its new parent are
+specified by their Pulumi URNs (use 'pulumi stack --show-urns' to get them). To parent a resource directly to the
+stack, pass the URN of the stack's pulumi:pulumi:Stack resource as the new parent.
+
+The new parent must have been created before the resource, and so cannot be one of its descendants.
+
+Make sure that URNs are single-quoted to avoid having characters unexpectedly interpreted by the shell.
+
+Example:
+pulumi state reparent 'urn:pulumi:stage::demo::aws:s3/bucket:Bucket::logs' 'urn:pulumi:stage::demo::my:Storage::storage'
+`,
+		Args: cmdutil.ExactArgs(2),
+		Run: cmdutil.RunResultFunc(func(cmd *cobra.Command, args []string) result.Result {
+			urn := resource.URN(args[0])
+			parentURN := resource.URN(args[1])
+			// Show the confirmation prompt if the user didn't pass the --yes parameter to skip it.
+			showPrompt := !yes
+
+			var newURN resource.URN
+			res := runTotalStateEdit(stack, showPrompt, func(opts display.Options, snap *deploy.Snapshot) error {
+				res, err := locateStackResource(opts, snap, urn)
+				if err != nil {
+					return err
+				}
+				parent, err := locateStackResource(opts, snap, parentURN)
+				if err != nil {
+					return err
+				}
+				if err = edit.ReparentResource(snap, res, parent); err != nil {
+					return err
+				}
+				newURN = res.URN
+				return nil
+			})
+			if res != nil {
+				return res
+			}
+			fmt.Printf("Resource reparented successfully; its URN is now %s\n", newURN)
+			return nil
+		}),
+	}
+
+	cmd.PersistentFlags().StringVarP(
+		&stack, "stack", "s", "",
+		"The name of the stack to operate on. Defaults to the current stack")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Skip confirmation prompts")
+	return cmd
+}
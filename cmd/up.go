@@ -56,6 +56,8 @@ func newUpCmd() *cobra.Command {
 	// Flags for engine.UpdateOptions.
 	var policyPackPaths []string
 	var diffDisplay bool
+	var markdownDisplay bool
+	var junitDisplay bool
 	var eventLogPath string
 	var parallel int
 	var refresh bool
@@ -71,9 +73,12 @@ func newUpCmd() *cobra.Command {
 	var replaces []string
 	var targetReplaces []string
 	var targetDependents bool
+	var planFilePath string
+	var perfReportFormat string
+	var scanSecrets bool
 
 	// up implementation used when the source of the Pulumi program is in the current working directory.
-	upWorkingDirectory := func(opts backend.UpdateOptions) result.Result {
+	upWorkingDirectory := func(opts backend.UpdateOptions, perfReport *engine.PerformanceReport) result.Result {
 		s, err := requireStack(stack, true, opts.Display, true /*setCurrent*/)
 		if err != nil {
 			return result.FromError(err)
@@ -103,6 +108,9 @@ func newUpCmd() *cobra.Command {
 		if err != nil {
 			return result.FromError(errors.Wrap(err, "getting stack configuration"))
 		}
+		if err = backend.ApplyConfigSchema(proj, &cfg); err != nil {
+			return result.FromError(err)
+		}
 
 		targetURNs := []resource.URN{}
 		for _, t := range targets {
@@ -119,6 +127,13 @@ func newUpCmd() *cobra.Command {
 			replaceURNs = append(replaceURNs, resource.URN(tr))
 		}
 
+		var plan *deploy.UpdatePlan
+		if planFilePath != "" {
+			if plan, err = readPlan(planFilePath, s.Ref().Name()); err != nil {
+				return result.FromError(err)
+			}
+		}
+
 		opts.Engine = engine.UpdateOptions{
 			LocalPolicyPackPaths: policyPackPaths,
 			Parallel:             parallel,
@@ -128,6 +143,8 @@ func newUpCmd() *cobra.Command {
 			UseLegacyDiff:        useLegacyDiff(),
 			UpdateTargets:        targetURNs,
 			TargetDependents:     targetDependents,
+			Plan:                 plan,
+			PerformanceReport:    perfReport,
 		}
 
 		changes, res := s.Update(commandContext(), backend.UpdateOperation{
@@ -139,6 +156,11 @@ func newUpCmd() *cobra.Command {
 			SecretsManager:     sm,
 			Scopes:             cancellationScopes,
 		})
+		if perfReport != nil && len(perfReport.Resources) != 0 {
+			if err := printPerformanceReport(perfReport, perfReportFormat); err != nil {
+				return result.FromError(err)
+			}
+		}
 		switch {
 		case res != nil && res.Error() == context.Canceled:
 			return result.FromError(errors.New("update cancelled"))
@@ -146,13 +168,17 @@ func newUpCmd() *cobra.Command {
 			return PrintEngineResult(res)
 		case expectNop && changes != nil && changes.HasChanges():
 			return result.FromError(errors.New("error: no changes were expected but changes occurred"))
+		case scanSecrets:
+			return result.WrapIfNonNil(warnSecretLeaks(s))
 		default:
 			return nil
 		}
 	}
 
 	// up implementation used when the source of the Pulumi program is a template name or a URL to a template.
-	upTemplateNameOrURL := func(templateNameOrURL string, opts backend.UpdateOptions) result.Result {
+	upTemplateNameOrURL := func(templateNameOrURL string, opts backend.UpdateOptions,
+		perfReport *engine.PerformanceReport) result.Result {
+
 		// Retrieve the template repo.
 		repo, err := workspace.RetrieveTemplates(templateNameOrURL, false, workspace.TemplateKindPulumiProject)
 		if err != nil {
@@ -279,12 +305,16 @@ func newUpCmd() *cobra.Command {
 		if err != nil {
 			return result.FromError(errors.Wrap(err, "getting stack configuration"))
 		}
+		if err = backend.ApplyConfigSchema(proj, &cfg); err != nil {
+			return result.FromError(err)
+		}
 
 		opts.Engine = engine.UpdateOptions{
 			LocalPolicyPackPaths: policyPackPaths,
 			Parallel:             parallel,
 			Debug:                debug,
 			Refresh:              refresh,
+			PerformanceReport:    perfReport,
 		}
 
 		// TODO for the URL case:
@@ -301,6 +331,11 @@ func newUpCmd() *cobra.Command {
 			SecretsManager:     sm,
 			Scopes:             cancellationScopes,
 		})
+		if perfReport != nil && len(perfReport.Resources) != 0 {
+			if err := printPerformanceReport(perfReport, perfReportFormat); err != nil {
+				return result.FromError(err)
+			}
+		}
 		switch {
 		case res != nil && res.Error() == context.Canceled:
 			return result.FromError(errors.New("update cancelled"))
@@ -341,9 +376,9 @@ func newUpCmd() *cobra.Command {
 				return result.FromError(err)
 			}
 
-			var displayType = display.DisplayProgress
-			if diffDisplay {
-				displayType = display.DisplayDiff
+			displayType, err := displayTypeFromFlags(diffDisplay, false /*jsonDisplay*/, markdownDisplay, junitDisplay)
+			if err != nil {
+				return result.FromError(err)
 			}
 
 			opts.Display = display.Options{
@@ -359,11 +394,19 @@ func newUpCmd() *cobra.Command {
 				Debug:                debug,
 			}
 
+			perfReport, err := newPerformanceReport(perfReportFormat)
+			if err != nil {
+				return result.FromError(err)
+			}
+
 			if len(args) > 0 {
-				return upTemplateNameOrURL(args[0], opts)
+				if planFilePath != "" {
+					return result.FromError(errors.New("--plan cannot be used when updating from a template"))
+				}
+				return upTemplateNameOrURL(args[0], opts, perfReport)
 			}
 
-			return upWorkingDirectory(opts)
+			return upWorkingDirectory(opts, perfReport)
 		}),
 	}
 
@@ -408,6 +451,15 @@ func newUpCmd() *cobra.Command {
 	cmd.PersistentFlags().BoolVar(
 		&targetDependents, "target-dependents", false,
 		"Allows updating of dependent targets discovered but not specified in --target list")
+	cmd.PersistentFlags().StringVar(
+		&planFilePath, "plan", "",
+		"Constrain the update to the operations in a plan file saved by `pulumi preview --save-plan`")
+	cmd.PersistentFlags().StringVar(
+		&perfReportFormat, "perf-report", "",
+		"Print a report of where the update's time was spent, as a `table` or as `json`")
+	cmd.PersistentFlags().BoolVar(
+		&scanSecrets, "scan-secrets", false,
+		"After the update, warn about resource properties and stack outputs that contain secret values in plaintext")
 
 	// Flags for engine.UpdateOptions.
 	if hasDebugCommands() || hasExperimentalCommands() {
@@ -418,6 +470,12 @@ func newUpCmd() *cobra.Command {
 	cmd.PersistentFlags().BoolVar(
 		&diffDisplay, "diff", false,
 		"Display operation as a rich diff showing the overall change")
+	cmd.PersistentFlags().BoolVar(
+		&markdownDisplay, "markdown", false,
+		"Display a Markdown summary of the update, e.g. for posting to a pull request")
+	cmd.PersistentFlags().BoolVar(
+		&junitDisplay, "junit", false,
+		"Display a JUnit XML report of the update with a test case for each resource")
 	cmd.PersistentFlags().IntVarP(
 		&parallel, "parallel", "p", defaultParallel,
 		"Allow P resource operations to run in parallel at once (1 for no parallelism). Defaults to unbounded.")
@@ -42,6 +42,7 @@ import (
 	"github.com/pulumi/pulumi/pkg/backend/state"
 	"github.com/pulumi/pulumi/pkg/diag/colors"
 	"github.com/pulumi/pulumi/pkg/engine"
+	"github.com/pulumi/pulumi/pkg/secrets"
 	"github.com/pulumi/pulumi/pkg/secrets/passphrase"
 	"github.com/pulumi/pulumi/pkg/util/cancel"
 	"github.com/pulumi/pulumi/pkg/util/ciutil"
@@ -103,24 +104,21 @@ func commandContext() context.Context {
 	return ctx
 }
 
-// createStack creates a stack with the given name, and optionally selects it as the current.
-func createStack(
-	b backend.Backend, stackRef backend.StackReference, opts interface{}, setCurrent bool,
-	secretsProvider string) (backend.Stack, error) {
+// configureSecretsProvider configures the given secrets provider in the stack settings file at configFile, generating
+// a new passphrase salt or data key if the file does not already contain one, and returns the resulting secrets
+// manager. We need to do this configuration step for cases where we will be using the passphrase secrets provider or
+// one of the cloud-backed secrets providers. We do not need to do this for the Pulumi service backend secrets
+// provider, for which a nil manager is returned.
+func configureSecretsProvider(b backend.Backend, stackRef backend.StackReference, configFile,
+	secretsProvider string) (secrets.Manager, error) {
 
-	// As part of creating the stack, we also need to configure the secrets provider for the stack.
-	// We need to do this configuration step for cases where we will be using with the passphrase
-	// secrets provider or one of the cloud-backed secrets providers.  We do not need to do this
-	// for the Pulumi service backend secrets provider.
 	isDefaultSecretsProvider := secretsProvider == "" || secretsProvider == "default"
 	if _, ok := b.(filestate.Backend); ok && isDefaultSecretsProvider {
 		// The default when using the filestate backend is the passphrase secrets provider
 		secretsProvider = passphrase.Type
 	}
 	if secretsProvider == passphrase.Type {
-		if _, pharseErr := newPassphraseSecretsManager(stackRef.Name(), stackConfigFile); pharseErr != nil {
-			return nil, pharseErr
-		}
+		return newPassphraseSecretsManager(stackRef.Name(), configFile)
 	} else if !isDefaultSecretsProvider {
 		// All other non-default secrets providers are handled by the cloud secrets provider which
 		// uses a URL schema to identify the provider
@@ -139,9 +137,19 @@ func createStack(
 			}
 		}
 
-		if _, secretsErr := newCloudSecretsManager(stackRef.Name(), stackConfigFile, secretsProvider); secretsErr != nil {
-			return nil, secretsErr
-		}
+		return newCloudSecretsManager(stackRef.Name(), configFile, secretsProvider)
+	}
+	return nil, nil
+}
+
+// createStack creates a stack with the given name, and optionally selects it as the current.
+func createStack(
+	b backend.Backend, stackRef backend.StackReference, opts interface{}, setCurrent bool,
+	secretsProvider string) (backend.Stack, error) {
+
+	// As part of creating the stack, we also need to configure the secrets provider for the stack.
+	if _, err := configureSecretsProvider(b, stackRef, stackConfigFile, secretsProvider); err != nil {
+		return nil, err
 	}
 
 	stack, err := b.CreateStack(commandContext(), stackRef, opts)
@@ -693,3 +701,28 @@ func updateFlagsToOptions(interactive, skipPreview, yes bool) (backend.UpdateOpt
 		SkipPreview: skipPreview,
 	}, nil
 }
+
+// displayTypeFromFlags returns the display type selected by the given display flags. At most one of the flags may
+// be set.
+func displayTypeFromFlags(diffDisplay, jsonDisplay, markdownDisplay, junitDisplay bool) (display.Type, error) {
+	count := 0
+	for _, set := range []bool{diffDisplay, jsonDisplay, markdownDisplay, junitDisplay} {
+		if set {
+			count++
+		}
+	}
+	if count > 1 {
+		return display.DisplayProgress, errors.New("only one of --diff, --json, --markdown, and --junit may be passed")
+	}
+
+	switch {
+	case diffDisplay:
+		return display.DisplayDiff, nil
+	case markdownDisplay:
+		return display.DisplayMarkdown, nil
+	case junitDisplay:
+		return display.DisplayJUnit, nil
+	default:
+		return display.DisplayProgress, nil
+	}
+}
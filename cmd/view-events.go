@@ -0,0 +1,144 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/apitype"
+	"github.com/pulumi/pulumi/pkg/backend"
+	"github.com/pulumi/pulumi/pkg/backend/display"
+	"github.com/pulumi/pulumi/pkg/diag/colors"
+	"github.com/pulumi/pulumi/pkg/util/cmdutil"
+	"github.com/pulumi/pulumi/pkg/util/contract"
+)
+
+func newViewEventsCmd() *cobra.Command {
+	var kind string
+	var speed float64
+
+	var debug bool
+	var diffDisplay bool
+	var jsonDisplay bool
+	var showConfig bool
+	var showReplacementSteps bool
+	var showSames bool
+	var showReads bool
+	var suppressOutputs bool
+
+	var cmd = &cobra.Command{
+		Use:   "view-events [event-log]",
+		Short: "Replay an event log recorded by a previous update",
+		Long: "Replay an event log recorded by a previous update.\n" +
+			"\n" +
+			"This command reads the events logged by a prior invocation of the Pulumi CLI with\n" +
+			"the --event-log flag and displays them exactly as they were originally displayed.\n" +
+			"By default, the events are replayed at the speed at which they occurred; use the\n" +
+			"--speed flag to accelerate the replay, or pass --speed=0 to display the events\n" +
+			"without delay.\n" +
+			"\n" +
+			"The event log does not record the kind of operation that produced it, so the --kind\n" +
+			"flag may be used to label the replay as a refresh, destroy, or import.",
+		Args: cmdutil.ExactArgs(1),
+		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			action := apitype.UpdateKind(kind)
+			switch action {
+			case apitype.UpdateUpdate, apitype.RefreshUpdate, apitype.DestroyUpdate, apitype.ImportUpdate:
+			default:
+				return errors.Errorf("unsupported operation kind %q; must be one of update, refresh, destroy, "+
+					"or import", kind)
+			}
+			if speed < 0 {
+				return errors.New("--speed must not be negative")
+			}
+
+			f, err := os.Open(args[0])
+			if err != nil {
+				return err
+			}
+			defer contract.IgnoreClose(f)
+
+			log, err := display.ReadEventLog(f)
+			if err != nil {
+				return errors.Wrapf(err, "reading event log %s", args[0])
+			}
+
+			var displayType = display.DisplayProgress
+			if diffDisplay {
+				displayType = display.DisplayDiff
+			}
+			opts := display.Options{
+				Color:                cmdutil.GetGlobalColorization(),
+				ShowConfig:           showConfig,
+				ShowReplacementSteps: showReplacementSteps,
+				ShowSameResources:    showSames,
+				ShowReads:            showReads,
+				SuppressOutputs:      suppressOutputs,
+				IsInteractive:        cmdutil.Interactive(),
+				Type:                 displayType,
+				JSONDisplay:          jsonDisplay,
+				Debug:                debug,
+			}
+
+			actionLabel := backend.ActionLabel(action, log.IsPreview)
+			if !jsonDisplay && log.Stack != "" {
+				fmt.Printf(opts.Color.Colorize(
+					colors.SpecHeadline+"%s (%s):"+colors.Reset+"\n"), actionLabel, log.Stack)
+			}
+
+			display.ReplayEvents(strings.ToLower(actionLabel), action, log, opts, speed)
+			return nil
+		}),
+	}
+
+	cmd.PersistentFlags().StringVar(
+		&kind, "kind", string(apitype.UpdateUpdate),
+		"The kind of operation that recorded the event log: update, refresh, destroy, or import")
+	cmd.PersistentFlags().Float64Var(
+		&speed, "speed", 1,
+		"The speed at which to replay the events relative to the original update, or 0 to replay without delay")
+
+	cmd.PersistentFlags().BoolVarP(
+		&debug, "debug", "d", false,
+		"Print detailed debugging output during resource operations")
+	cmd.PersistentFlags().BoolVar(
+		&diffDisplay, "diff", false,
+		"Display operation as a rich diff showing the overall change")
+	cmd.PersistentFlags().BoolVarP(
+		&jsonDisplay, "json", "j", false,
+		"Serialize the events as a JSON document summarizing the operation")
+	cmd.PersistentFlags().BoolVar(
+		&showConfig, "show-config", false,
+		"Show configuration keys and variables")
+	cmd.PersistentFlags().BoolVar(
+		&showReplacementSteps, "show-replacement-steps", false,
+		"Show detailed resource replacement creates and deletes instead of a single step")
+	cmd.PersistentFlags().BoolVar(
+		&showSames, "show-sames", false,
+		"Show resources that needn't be updated because they haven't changed, alongside those that do")
+	cmd.PersistentFlags().BoolVar(
+		&showReads, "show-reads", false,
+		"Show resources that are being read in, alongside those being managed directly in the stack")
+	cmd.PersistentFlags().BoolVar(
+		&suppressOutputs, "suppress-outputs", false,
+		"Suppress display of stack outputs (in case they contain sensitive values)")
+
+	return cmd
+}
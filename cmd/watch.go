@@ -105,6 +105,9 @@ func newWatchCmd() *cobra.Command {
 			if err != nil {
 				return result.FromError(errors.Wrap(err, "getting stack configuration"))
 			}
+			if err = backend.ApplyConfigSchema(proj, &cfg); err != nil {
+				return result.FromError(err)
+			}
 
 			opts.Engine = engine.UpdateOptions{
 				LocalPolicyPackPaths: policyPackPaths,
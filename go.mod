@@ -17,6 +17,7 @@ require (
 	github.com/cpuguy83/go-md2man v1.0.8 // indirect
 	github.com/djherbis/times v1.0.1
 	github.com/docker/docker v0.0.0-20170504205632-89658bed64c2
+	github.com/dsnet/compress v0.0.1
 	github.com/dustin/go-humanize v1.0.0
 	github.com/gofrs/flock v0.7.0
 	github.com/golang/glog v0.0.0-20160126235308-23def4e6c14b
@@ -28,6 +29,7 @@ require (
 	github.com/hashicorp/go-multierror v1.0.0
 	github.com/ijc/Gotty v0.0.0-20170406111628-a8b993ba6abd
 	github.com/inconshreveable/mousetrap v1.0.0 // indirect
+	github.com/klauspost/compress v1.9.4
 	github.com/mattn/go-runewidth v0.0.3 // indirect
 	github.com/mgutz/ansi v0.0.0-20170206155736-9520e82c474b // indirect
 	github.com/mitchellh/copystructure v1.0.0
@@ -53,6 +55,7 @@ require (
 	github.com/texttheater/golang-levenshtein v0.0.0-20180516184445-d188e65d659e
 	github.com/uber/jaeger-client-go v2.15.0+incompatible
 	github.com/uber/jaeger-lib v1.5.0 // indirect
+	github.com/ulikunitz/xz v0.5.6
 	gocloud.dev v0.18.0
 	gocloud.dev/secrets/hashivault v0.18.0
 	golang.org/x/crypto v0.0.0-20190605123033-f99c8df09eb5
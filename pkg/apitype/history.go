@@ -33,6 +33,10 @@ const (
 	DestroyUpdate UpdateKind = "destroy"
 	// ImportUpdate is an update that entails importing a raw checkpoint file.
 	ImportUpdate UpdateKind = "import"
+	// ResourceImportUpdate is an update that entails importing one or more existing resources.
+	ResourceImportUpdate UpdateKind = "resource-import"
+	// RollbackUpdate is an update that restores the checkpoint written by a previous update.
+	RollbackUpdate UpdateKind = "rollback"
 )
 
 // UpdateResult is an enum for the result of the update.
@@ -0,0 +1,53 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apitype
+
+import (
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/tokens"
+)
+
+const (
+	// UpdatePlanSchemaVersionCurrent is the current version of the `UpdatePlan` schema.
+	// Any plans newer than this version will be rejected.
+	UpdatePlanSchemaVersionCurrent = 1
+)
+
+// UpdatePlanV1 is a serialized record of the steps that a preview decided to perform. An update may be asked to
+// conform to a plan, in which case it fails if it attempts to perform any step that the plan does not allow.
+type UpdatePlanV1 struct {
+	// Version indicates the schema of the encoded plan.
+	Version int `json:"version" yaml:"version"`
+	// Stack is the name of the stack for which the plan was recorded.
+	Stack tokens.QName `json:"stack" yaml:"stack"`
+	// Manifest contains metadata about the preview that produced this plan.
+	Manifest ManifestV1 `json:"manifest" yaml:"manifest"`
+	// SecretsProviders is the secrets provider configuration used to encrypt secret values in the plan.
+	SecretsProviders *SecretsProvidersV1 `json:"secrets_providers,omitempty" yaml:"secrets_providers,omitempty"`
+	// Resources contains the planned steps for each resource, keyed by URN.
+	Resources map[resource.URN]ResourcePlanV1 `json:"resources,omitempty" yaml:"resources,omitempty"`
+}
+
+// ResourcePlanV1 records the steps planned for a single resource.
+type ResourcePlanV1 struct {
+	// Ops is the list of operations planned for this resource, each a deploy.StepOp.
+	Ops []string `json:"ops" yaml:"ops"`
+	// Inputs are the inputs supplied by the program for this resource at the time the plan was recorded.
+	Inputs map[string]interface{} `json:"inputs,omitempty" yaml:"inputs,omitempty"`
+	// ReplaceKeys are the keys that were expected to cause a replacement of this resource, if any.
+	ReplaceKeys []resource.PropertyKey `json:"replaceKeys,omitempty" yaml:"replaceKeys,omitempty"`
+	// ChangedKeys are the keys that were expected to change for this resource, if any.
+	ChangedKeys []resource.PropertyKey `json:"changedKeys,omitempty" yaml:"changedKeys,omitempty"`
+}
@@ -0,0 +1,133 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package automation
+
+import (
+	"context"
+
+	"github.com/pulumi/pulumi/pkg/resource/config"
+	"github.com/pulumi/pulumi/pkg/resource/plugin"
+	"github.com/pulumi/pulumi/pkg/util/cmdutil"
+	"github.com/pulumi/pulumi/pkg/util/contract"
+	"github.com/pulumi/pulumi/pkg/workspace"
+	"github.com/pulumi/pulumi/sdk/go/pulumi"
+)
+
+// inlineHost is a plugin host that runs an inline Go program in-process in place of the project's language runtime.
+// All other plugins are loaded by a default plugin host.
+//
+// The engine closes its plugin host at the end of each planning pass, but a single backend operation may consist of
+// several passes (e.g. a preview followed by an update), so closing an inlineHost is a no-op. The host's owner must
+// call closeHost once the operation has completed.
+type inlineHost struct {
+	plugin.Host
+
+	ctx     *plugin.Context // the plugin context that owns the default host.
+	runtime *inlineRuntime  // the in-process language runtime.
+}
+
+// newInlineHost creates a new plugin host that runs the given program in-process. Diagnostics logged by the program
+// and by plugins via the host's RPC interface are reported to the default diagnostics sink.
+func newInlineHost(proj *workspace.Project, root string, program pulumi.RunFunc) (*inlineHost, error) {
+	schema, err := proj.ConfigSchema()
+	if err != nil {
+		return nil, err
+	}
+	ctx, err := plugin.NewContext(cmdutil.Diag(), cmdutil.Diag(), nil, nil, root, proj.Runtime.Options(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &inlineHost{
+		Host: ctx.Host,
+		ctx:  ctx,
+		runtime: &inlineRuntime{
+			program:    program,
+			engineAddr: ctx.Host.ServerAddr(),
+			schema:     schema,
+		},
+	}, nil
+}
+
+func (h *inlineHost) LanguageRuntime(runtime string) (plugin.LanguageRuntime, error) {
+	return h.runtime, nil
+}
+
+func (h *inlineHost) GetRequiredPlugins(info plugin.ProgInfo, kinds plugin.Flags) ([]workspace.PluginInfo, error) {
+	// The in-process language runtime is always available, and inline programs cannot declare their required
+	// plugins, so there is nothing to report.
+	return nil, nil
+}
+
+func (h *inlineHost) EnsurePlugins(plugins []workspace.PluginInfo, kinds plugin.Flags) error {
+	// The in-process language runtime stands in for every language plugin, so only load other kinds of plugins.
+	return h.Host.EnsurePlugins(plugins, kinds&^plugin.LanguagePlugins)
+}
+
+func (h *inlineHost) Close() error {
+	return nil
+}
+
+// closeHost closes the default host and any plugins it has loaded.
+func (h *inlineHost) closeHost() error {
+	return h.ctx.Close()
+}
+
+// inlineRuntime is a language runtime that runs an inline Go program in-process.
+type inlineRuntime struct {
+	program    pulumi.RunFunc                           // the program to run.
+	engineAddr string                                   // the address of the engine's RPC interface.
+	schema     map[config.Key]workspace.ConfigKeySchema // the project's config schema.
+}
+
+func (r *inlineRuntime) Close() error {
+	return nil
+}
+
+func (r *inlineRuntime) GetRequiredPlugins(info plugin.ProgInfo) ([]workspace.PluginInfo, error) {
+	// Inline programs cannot declare their required plugins. Resource plugins are instead loaded on demand.
+	return nil, nil
+}
+
+func (r *inlineRuntime) Run(info plugin.RunInfo) (string, bool, error) {
+	cfg := make(map[string]string)
+	for k, v := range info.Config {
+		cfg[k.String()] = v
+	}
+
+	ctx, err := pulumi.NewContext(context.Background(), pulumi.RunInfo{
+		Project:      info.Project,
+		Stack:        info.Stack,
+		Config:       cfg,
+		Parallel:     info.Parallel,
+		DryRun:       info.DryRun,
+		MonitorAddr:  info.MonitorAddress,
+		EngineAddr:   r.engineAddr,
+		ConfigSchema: r.schema,
+	})
+	if err != nil {
+		return "", false, err
+	}
+	defer contract.IgnoreClose(ctx)
+
+	if progerr := pulumi.RunWithContext(ctx, r.program); progerr != nil {
+		return progerr.Error(), false, nil
+	}
+	return "", false, nil
+}
+
+func (r *inlineRuntime) GetPluginInfo() (workspace.PluginInfo, error) {
+	return workspace.PluginInfo{Name: "inline"}, nil
+}
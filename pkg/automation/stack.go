@@ -0,0 +1,457 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package automation
+
+import (
+	"context"
+	"encoding/base64"
+	"os"
+
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/apitype"
+	"github.com/pulumi/pulumi/pkg/backend"
+	"github.com/pulumi/pulumi/pkg/backend/display"
+	"github.com/pulumi/pulumi/pkg/backend/httpstate"
+	"github.com/pulumi/pulumi/pkg/diag/colors"
+	"github.com/pulumi/pulumi/pkg/engine"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/config"
+	"github.com/pulumi/pulumi/pkg/resource/stack"
+	"github.com/pulumi/pulumi/pkg/secrets"
+	"github.com/pulumi/pulumi/pkg/secrets/cloud"
+	"github.com/pulumi/pulumi/pkg/secrets/passphrase"
+	"github.com/pulumi/pulumi/pkg/secrets/service"
+	"github.com/pulumi/pulumi/pkg/util/cancel"
+	"github.com/pulumi/pulumi/pkg/util/contract"
+	"github.com/pulumi/pulumi/pkg/util/result"
+	"github.com/pulumi/pulumi/pkg/workspace"
+)
+
+// Stack is a stack of a workspace's project.
+type Stack struct {
+	workspace *Workspace    // the workspace that owns the stack.
+	stack     backend.Stack // the underlying backend stack.
+}
+
+// Options controls a stack operation.
+type Options struct {
+	// Engine contains the engine options for the operation. The plugin host is always replaced with one that runs the
+	// workspace's program in-process.
+	Engine engine.UpdateOptions
+	// Message is an optional message to associate with the operation.
+	Message string
+	// Events, if non-nil, receives each engine event emitted during the operation. Events are sent synchronously, so
+	// the engine blocks until each one is received: the caller must drain the channel (or buffer it sufficiently)
+	// until the operation returns. The channel is not closed when the operation completes.
+	Events chan<- engine.Event
+}
+
+// Name returns the stack's name.
+func (s *Stack) Name() string {
+	return string(s.stack.Ref().Name())
+}
+
+// Ref returns a reference to the stack.
+func (s *Stack) Ref() backend.StackReference {
+	return s.stack.Ref()
+}
+
+// Preview computes the changes that an update of the stack would make without making them.
+func (s *Stack) Preview(ctx context.Context, opts Options) (engine.ResourceChanges, error) {
+	return s.run(ctx, apitype.PreviewUpdate, opts)
+}
+
+// Up updates the stack's resources to match the state described by the workspace's program.
+func (s *Stack) Up(ctx context.Context, opts Options) (engine.ResourceChanges, error) {
+	return s.run(ctx, apitype.UpdateUpdate, opts)
+}
+
+// Refresh updates the stack's state to match the live state of its resources.
+func (s *Stack) Refresh(ctx context.Context, opts Options) (engine.ResourceChanges, error) {
+	return s.run(ctx, apitype.RefreshUpdate, opts)
+}
+
+// Destroy deletes all of the stack's resources.
+func (s *Stack) Destroy(ctx context.Context, opts Options) (engine.ResourceChanges, error) {
+	return s.run(ctx, apitype.DestroyUpdate, opts)
+}
+
+// Outputs returns the stack's outputs as of its most recent update.
+func (s *Stack) Outputs(ctx context.Context) (resource.PropertyMap, error) {
+	// Backend stacks cache their snapshots, so fetch the stack anew in order to observe the latest update.
+	latest, err := s.workspace.backend.GetStack(ctx, s.stack.Ref())
+	if err != nil {
+		return nil, errors.Wrapf(err, "getting stack '%s'", s.Name())
+	}
+	if latest == nil {
+		return nil, errors.Errorf("no stack named '%s' found", s.Name())
+	}
+	snap, err := latest.Snapshot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	res, err := stack.GetRootStackResource(snap)
+	if err != nil {
+		return nil, errors.Wrap(err, "getting root stack resource")
+	}
+	if res == nil {
+		return resource.PropertyMap{}, nil
+	}
+	return res.Outputs, nil
+}
+
+// GetConfig returns the plaintext value of the given configuration key, and whether the key is set. The key may be
+// set by the stack's own settings file or by any config layer that it imports.
+func (s *Stack) GetConfig(key config.Key) (string, bool, error) {
+	layers, err := workspace.LoadConfigLayers(s.settingsPath())
+	if err != nil {
+		return "", false, errors.Wrap(err, "loading stack settings")
+	}
+	cfg, _ := workspace.MergeConfigLayers(layers)
+	v, ok := cfg[key]
+	if !ok {
+		return "", false, nil
+	}
+
+	var decrypter config.Decrypter = config.NewBlindingDecrypter()
+	if v.Secure() {
+		sm, err := s.secretsManager()
+		if err != nil {
+			return "", false, err
+		}
+		stackConfig, err := s.configuration(sm)
+		if err != nil {
+			return "", false, err
+		}
+		decrypter = stackConfig.Decrypter
+	}
+	value, err := v.Value(decrypter)
+	if err != nil {
+		return "", false, errors.Wrapf(err, "decrypting configuration key '%s'", key)
+	}
+	return value, true, nil
+}
+
+// GetAllConfig returns the configuration set by the stack's own settings file, excluding any config layers that it
+// imports. Secret values remain encrypted.
+func (s *Stack) GetAllConfig() (config.Map, error) {
+	ps, err := s.loadSettings()
+	if err != nil {
+		return nil, err
+	}
+	return ps.Config, nil
+}
+
+// SetConfig sets the value of the given configuration key. If secret is true, the value is encrypted using the
+// stack's secrets manager.
+func (s *Stack) SetConfig(key config.Key, value string, secret bool) error {
+	ps, err := s.loadSettings()
+	if err != nil {
+		return err
+	}
+
+	v := config.NewValue(value)
+	if secret {
+		sm, err := s.secretsManager()
+		if err != nil {
+			return err
+		}
+		encrypter, err := sm.Encrypter()
+		if err != nil {
+			return errors.Wrap(err, "getting configuration encrypter")
+		}
+		ciphertext, err := encrypter.EncryptValue(value)
+		if err != nil {
+			return errors.Wrapf(err, "encrypting configuration key '%s'", key)
+		}
+		v = config.NewSecureValue(ciphertext)
+
+		// The secrets manager may have updated the stack's settings (e.g. with a new salt), so reload them.
+		if ps, err = s.loadSettings(); err != nil {
+			return err
+		}
+	}
+
+	ps.Config[key] = v
+	return ps.Save(s.settingsPath())
+}
+
+// RemoveConfig removes the given configuration key.
+func (s *Stack) RemoveConfig(key config.Key) error {
+	ps, err := s.loadSettings()
+	if err != nil {
+		return err
+	}
+	delete(ps.Config, key)
+	return ps.Save(s.settingsPath())
+}
+
+// run performs an operation of the given kind on the stack.
+func (s *Stack) run(ctx context.Context, kind apitype.UpdateKind, opts Options) (engine.ResourceChanges, error) {
+	w := s.workspace
+
+	sm, err := s.secretsManager()
+	if err != nil {
+		return nil, errors.Wrap(err, "getting secrets manager")
+	}
+	cfg, err := s.configuration(sm)
+	if err != nil {
+		return nil, errors.Wrap(err, "getting stack configuration")
+	}
+	// As with the CLI, check the configuration against the project's config schema and fill in its defaults before
+	// the program or providers see it. Destroy must work even if the configuration no longer matches the schema.
+	if kind != apitype.DestroyUpdate {
+		if err = backend.ApplyConfigSchema(w.project, &cfg); err != nil {
+			return nil, err
+		}
+	}
+
+	host, err := newInlineHost(w.project, w.root, w.program)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating plugin host")
+	}
+	defer func() { contract.IgnoreError(host.closeHost()) }()
+
+	engineOpts := opts.Engine
+	engineOpts.Host = host
+
+	op := backend.UpdateOperation{
+		Proj: w.project,
+		Root: w.root,
+		M: &backend.UpdateMetadata{
+			Message:     opts.Message,
+			Environment: make(map[string]string),
+		},
+		Opts: backend.UpdateOptions{
+			Engine: engineOpts,
+			Display: display.Options{
+				Color:  colors.Never,
+				Type:   display.DisplayNone,
+				Events: opts.Events,
+			},
+			AutoApprove: true,
+			SkipPreview: true,
+		},
+		SecretsManager:     sm,
+		StackConfiguration: cfg,
+		Scopes:             cancellationScopeSource{ctx: ctx},
+	}
+
+	var changes engine.ResourceChanges
+	var res result.Result
+	switch kind {
+	case apitype.PreviewUpdate:
+		changes, res = backend.PreviewStack(ctx, s.stack, op)
+	case apitype.UpdateUpdate:
+		changes, res = backend.UpdateStack(ctx, s.stack, op)
+	case apitype.RefreshUpdate:
+		changes, res = backend.RefreshStack(ctx, s.stack, op)
+	case apitype.DestroyUpdate:
+		changes, res = backend.DestroyStack(ctx, s.stack, op)
+	default:
+		contract.Failf("Unrecognized update kind: %s", kind)
+	}
+	if res != nil {
+		if res.IsBail() {
+			return changes, errors.Errorf("%s of stack '%s' failed", kind, s.Name())
+		}
+		return changes, res.Error()
+	}
+	return changes, nil
+}
+
+// settingsPath returns the path of the stack's settings file.
+func (s *Stack) settingsPath() string {
+	return s.workspace.stackSettingsPath(s.stack.Ref().Name())
+}
+
+// loadSettings loads the stack's settings file.
+func (s *Stack) loadSettings() (*workspace.ProjectStack, error) {
+	ps, err := workspace.LoadProjectStack(s.settingsPath())
+	if err != nil {
+		return nil, errors.Wrap(err, "loading stack settings")
+	}
+	return ps, nil
+}
+
+// configuration returns the stack's effective configuration, including any config layers imported by its settings
+// file, and a decrypter for its secret values.
+func (s *Stack) configuration(sm secrets.Manager) (backend.StackConfiguration, error) {
+	layers, err := workspace.LoadConfigLayers(s.settingsPath())
+	if err != nil {
+		return backend.StackConfiguration{}, errors.Wrap(err, "loading stack settings")
+	}
+	cfg, origins := workspace.MergeConfigLayers(layers)
+
+	// As with the CLI, only fetch a real decrypter if there are secrets to decrypt.
+	if !cfg.HasSecureValue() {
+		return backend.StackConfiguration{
+			Config:    cfg,
+			Decrypter: config.NewPanicCrypter(),
+		}, nil
+	}
+
+	decrypter, err := workspace.NewConfigLayersDecrypter(layers, cfg, origins,
+		func(layer *workspace.ConfigLayer) (config.Decrypter, error) {
+			if layer == &layers[len(layers)-1] {
+				return sm.Decrypter()
+			}
+			lsm, err := layerSecretsManager(layer.Settings)
+			if err != nil {
+				return nil, errors.Wrapf(err, "getting secrets manager for config layer %s", layer.Path)
+			}
+			return lsm.Decrypter()
+		})
+	if err != nil {
+		return backend.StackConfiguration{}, errors.Wrap(err, "getting configuration decrypter")
+	}
+	return backend.StackConfiguration{
+		Config:    cfg,
+		Decrypter: decrypter,
+	}, nil
+}
+
+// layerSecretsManager returns the secrets manager for a config layer with the given settings. As with the CLI, a
+// layer uses the cloud secrets provider named by its settings, if any, and a passphrase otherwise.
+func layerSecretsManager(settings *workspace.ProjectStack) (secrets.Manager, error) {
+	sm, err := stack.NewSettingsSecretsManager(settings, readPassphrase)
+	if err != nil {
+		return nil, err
+	}
+	if sm == nil {
+		return nil, errors.New("config layer has secrets but no secrets key")
+	}
+	return sm, nil
+}
+
+// readPassphrase reads the passphrase for passphrase-based secrets managers from the PULUMI_CONFIG_PASSPHRASE
+// environment variable.
+func readPassphrase() (string, error) {
+	phrase, ok := os.LookupEnv("PULUMI_CONFIG_PASSPHRASE")
+	if !ok {
+		return "", errors.New("passphrase must be set with PULUMI_CONFIG_PASSPHRASE environment variable")
+	}
+	return phrase, nil
+}
+
+// secretsManager returns the secrets manager for the stack.
+func (s *Stack) secretsManager() (secrets.Manager, error) {
+	if s.workspace.SecretsManager != nil {
+		return s.workspace.SecretsManager, nil
+	}
+
+	// As with the CLI, use the secrets provider recorded by the stack's settings if they have a key for it, and
+	// otherwise the service's secrets manager for service stacks or a new passphrase or cloud key for the rest.
+	ps, err := s.loadSettings()
+	if err != nil {
+		return nil, err
+	}
+	sm, err := func() (secrets.Manager, error) {
+		sm, err := stack.NewSettingsSecretsManager(ps, readPassphrase)
+		if err != nil || sm != nil {
+			return sm, err
+		}
+		if stack.IsCloudSecretsProvider(ps.SecretsProvider) {
+			return s.cloudSecretsManager(ps)
+		}
+		if httpStack, ok := s.stack.(httpstate.Stack); ok {
+			client := httpStack.Backend().(httpstate.Backend).Client()
+			return service.NewServiceSecretsManager(client, httpStack.StackIdentifier())
+		}
+		return s.passphraseSecretsManager(ps)
+	}()
+	if err != nil {
+		return nil, err
+	}
+	return stack.NewCachingSecretsManager(sm), nil
+}
+
+// cloudSecretsManager returns a secrets manager for the stack that uses the cloud secrets provider named by its
+// settings, which do not yet record a data key. A new data key is generated and saved.
+func (s *Stack) cloudSecretsManager(ps *workspace.ProjectStack) (secrets.Manager, error) {
+	dataKey, err := cloud.GenerateNewDataKey(ps.SecretsProvider)
+	if err != nil {
+		return nil, err
+	}
+	ps.EncryptedKey = base64.StdEncoding.EncodeToString(dataKey)
+	if err = ps.Save(s.settingsPath()); err != nil {
+		return nil, err
+	}
+	sm, err := cloud.NewCloudSecretsManager(ps.SecretsProvider, dataKey)
+	if err != nil {
+		return nil, err
+	}
+	return sm, nil
+}
+
+// passphraseSecretsManager returns a passphrase-based secrets manager for the stack, whose settings do not yet record
+// a salt. The passphrase is read from the PULUMI_CONFIG_PASSPHRASE environment variable, and a new salt is generated
+// and saved.
+func (s *Stack) passphraseSecretsManager(ps *workspace.ProjectStack) (secrets.Manager, error) {
+	phrase, err := readPassphrase()
+	if err != nil {
+		return nil, err
+	}
+	if ps.EncryptionSalt, err = passphrase.GenerateNewSalt(phrase); err != nil {
+		return nil, err
+	}
+	if err = ps.Save(s.settingsPath()); err != nil {
+		return nil, err
+	}
+	return passphrase.NewPassphaseSecretsManager(phrase, ps.EncryptionSalt)
+}
+
+// cancellationScopeSource creates cancellation scopes that cancel their operations when a context is done.
+type cancellationScopeSource struct {
+	ctx context.Context
+}
+
+func (s cancellationScopeSource) NewScope(events chan<- engine.Event, isPreview bool) backend.CancellationScope {
+	cancelContext, cancelSource := cancel.NewContext(context.Background())
+
+	c := &cancellationScope{
+		context: cancelContext,
+		closed:  make(chan bool),
+		done:    make(chan bool),
+	}
+
+	go func() {
+		defer close(c.done)
+
+		select {
+		case <-s.ctx.Done():
+			cancelSource.Cancel()
+		case <-c.closed:
+		}
+	}()
+
+	return c
+}
+
+type cancellationScope struct {
+	context *cancel.Context
+	closed  chan bool
+	done    chan bool
+}
+
+func (s *cancellationScope) Context() *cancel.Context {
+	return s.context
+}
+
+func (s *cancellationScope) Close() {
+	close(s.closed)
+	<-s.done
+}
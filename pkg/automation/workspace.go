@@ -0,0 +1,145 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package automation drives Pulumi stacks programmatically, without the CLI. A Workspace pairs a backend with a
+// project whose program is an inline Go function; the program is run in-process, and the results of each operation
+// are reported as structured engine events and resource changes rather than as terminal output.
+package automation
+
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/backend"
+	"github.com/pulumi/pulumi/pkg/secrets"
+	"github.com/pulumi/pulumi/pkg/tokens"
+	"github.com/pulumi/pulumi/pkg/workspace"
+	"github.com/pulumi/pulumi/sdk/go/pulumi"
+)
+
+// Workspace manages the stacks of a single project whose program is an inline Go function.
+type Workspace struct {
+	// SecretsManager, if non-nil, is used to encrypt and decrypt the configuration and state of all of the
+	// workspace's stacks. Otherwise, as with the CLI, stacks whose settings name a cloud secrets provider use it,
+	// stacks managed by the Pulumi service use the service's secrets manager, and all other stacks use a
+	// passphrase-based secrets manager that reads its passphrase from the PULUMI_CONFIG_PASSPHRASE environment
+	// variable.
+	SecretsManager secrets.Manager
+
+	backend backend.Backend    // the backend that manages the workspace's stacks.
+	project *workspace.Project // the workspace's project.
+	root    string             // the workspace's root directory.
+	program pulumi.RunFunc     // the workspace's program.
+}
+
+// NewWorkspace creates a workspace for the given project whose stacks are managed by the given backend. The project's
+// program is the given function. Stack settings files are read from and written to the given root directory.
+//
+// The program runs in the host process, so its working directory is the process's, not the root directory; programs
+// should not rely on relative paths.
+func NewWorkspace(b backend.Backend, proj *workspace.Project, root string,
+	program pulumi.RunFunc) (*Workspace, error) {
+
+	if b == nil {
+		return nil, errors.New("missing backend")
+	}
+	if proj == nil {
+		return nil, errors.New("missing project")
+	}
+	if err := proj.Validate(); err != nil {
+		return nil, errors.Wrap(err, "validating project")
+	}
+	if program == nil {
+		return nil, errors.New("missing program")
+	}
+
+	root, err := filepath.Abs(root)
+	if err != nil {
+		return nil, errors.Wrap(err, "resolving root directory")
+	}
+
+	return &Workspace{
+		backend: b,
+		project: proj,
+		root:    root,
+		program: program,
+	}, nil
+}
+
+// Backend returns the backend that manages the workspace's stacks.
+func (w *Workspace) Backend() backend.Backend {
+	return w.backend
+}
+
+// Project returns the workspace's project.
+func (w *Workspace) Project() *workspace.Project {
+	return w.project
+}
+
+// Root returns the workspace's root directory.
+func (w *Workspace) Root() string {
+	return w.root
+}
+
+// CreateStack creates a new stack with the given name.
+func (w *Workspace) CreateStack(ctx context.Context, name string) (*Stack, error) {
+	ref, err := w.backend.ParseStackReference(name)
+	if err != nil {
+		return nil, err
+	}
+	s, err := w.backend.CreateStack(ctx, ref, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "creating stack '%s'", name)
+	}
+	return &Stack{workspace: w, stack: s}, nil
+}
+
+// SelectStack selects the existing stack with the given name.
+func (w *Workspace) SelectStack(ctx context.Context, name string) (*Stack, error) {
+	ref, err := w.backend.ParseStackReference(name)
+	if err != nil {
+		return nil, err
+	}
+	s, err := w.backend.GetStack(ctx, ref)
+	if err != nil {
+		return nil, errors.Wrapf(err, "getting stack '%s'", name)
+	}
+	if s == nil {
+		return nil, errors.Errorf("no stack named '%s' found", name)
+	}
+	return &Stack{workspace: w, stack: s}, nil
+}
+
+// RemoveStack removes the stack with the given name. Unless force is true, the stack must not contain any resources.
+func (w *Workspace) RemoveStack(ctx context.Context, name string, force bool) error {
+	s, err := w.SelectStack(ctx, name)
+	if err != nil {
+		return err
+	}
+	hasResources, err := w.backend.RemoveStack(ctx, s.stack, force)
+	if err != nil {
+		if hasResources {
+			return errors.Errorf("'%s' still has resources; removal rejected", name)
+		}
+		return err
+	}
+	return nil
+}
+
+// stackSettingsPath returns the path of the settings file for the stack with the given name.
+func (w *Workspace) stackSettingsPath(name tokens.QName) string {
+	return workspace.ProjectStackPath(filepath.Join(w.root, workspace.ProjectFile+".yaml"), w.project, name)
+}
@@ -0,0 +1,332 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package automation
+
+import (
+	"context"
+	"encoding/base64"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	_ "gocloud.dev/secrets/localsecrets"
+
+	"github.com/pulumi/pulumi/pkg/backend/filestate"
+	"github.com/pulumi/pulumi/pkg/engine"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/config"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/secrets/b64"
+	"github.com/pulumi/pulumi/pkg/secrets/cloud"
+	"github.com/pulumi/pulumi/pkg/util/cmdutil"
+	"github.com/pulumi/pulumi/pkg/workspace"
+	"github.com/pulumi/pulumi/sdk/go/pulumi"
+	pulumiconfig "github.com/pulumi/pulumi/sdk/go/pulumi/config"
+)
+
+type testComponent struct {
+	pulumi.ResourceState
+}
+
+func testProgram(ctx *pulumi.Context) error {
+	var comp testComponent
+	if err := ctx.RegisterComponentResource("test:index:Component", "comp", &comp); err != nil {
+		return err
+	}
+
+	cfg := pulumiconfig.New(ctx, "")
+	ctx.Export("greeting", pulumi.String(cfg.Require("greeting")))
+	ctx.Export("password", pulumi.ToSecret(cfg.Require("password")))
+	ctx.Export("count", pulumi.Int(cfg.RequireTypedInt("count")))
+	return nil
+}
+
+func collectEvents() (chan<- engine.Event, func() []engine.Event) {
+	events, done := make(chan engine.Event), make(chan []engine.Event)
+	go func() {
+		var collected []engine.Event
+		for e := range events {
+			collected = append(collected, e)
+		}
+		done <- collected
+	}()
+	return events, func() []engine.Event {
+		close(events)
+		return <-done
+	}
+}
+
+func TestWorkspaceLifecycle(t *testing.T) {
+	dir, err := ioutil.TempDir("", "automation")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	oldPassphrase, hadPassphrase := os.LookupEnv("PULUMI_CONFIG_PASSPHRASE")
+	assert.NoError(t, os.Setenv("PULUMI_CONFIG_PASSPHRASE", "password"))
+	defer func() {
+		if hadPassphrase {
+			os.Setenv("PULUMI_CONFIG_PASSPHRASE", oldPassphrase)
+		} else {
+			os.Unsetenv("PULUMI_CONFIG_PASSPHRASE")
+		}
+	}()
+
+	b, err := filestate.New(cmdutil.Diag(), "file://"+dir)
+	assert.NoError(t, err)
+
+	// The program reads a typed value that is declared, with a default, by the project's config schema. The
+	// schema is supplied to the program with its context, since the project has no Pulumi.yaml on disk.
+	proj := &workspace.Project{
+		Name:    "automation",
+		Runtime: workspace.NewProjectRuntimeInfo("go", nil),
+		Config: &workspace.ProjectConfig{
+			Keys: map[string]workspace.ConfigKeySchema{
+				"greeting": {},
+				"password": {Secret: true},
+				"unused":   {},
+				"count":    {Type: workspace.ConfigTypeInteger, Default: 3},
+			},
+		},
+	}
+	w, err := NewWorkspace(b, proj, dir, testProgram)
+	assert.NoError(t, err)
+
+	ctx := context.Background()
+	s, err := w.CreateStack(ctx, "dev")
+	assert.NoError(t, err)
+	assert.Equal(t, "dev", s.Name())
+
+	_, err = w.CreateStack(ctx, "dev")
+	assert.Error(t, err)
+
+	// Set some configuration, including a secret.
+	assert.NoError(t, s.SetConfig(config.MustMakeKey("automation", "greeting"), "hello", false))
+	assert.NoError(t, s.SetConfig(config.MustMakeKey("automation", "password"), "hunter2", true))
+	assert.NoError(t, s.SetConfig(config.MustMakeKey("automation", "unused"), "value", false))
+	assert.NoError(t, s.RemoveConfig(config.MustMakeKey("automation", "unused")))
+
+	all, err := s.GetAllConfig()
+	assert.NoError(t, err)
+	assert.Len(t, all, 2)
+	assert.True(t, all[config.MustMakeKey("automation", "password")].Secure())
+
+	v, ok, err := s.GetConfig(config.MustMakeKey("automation", "password"))
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "hunter2", v)
+
+	_, ok, err = s.GetConfig(config.MustMakeKey("automation", "unused"))
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	// Preview the stack. Nothing should be persisted.
+	events, collected := collectEvents()
+	changes, err := s.Preview(ctx, Options{Events: events})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, changes[deploy.OpCreate])
+	assert.NotEmpty(t, collected())
+
+	outputs, err := s.Outputs(ctx)
+	assert.NoError(t, err)
+	assert.Empty(t, outputs)
+
+	// Update the stack and check its outputs and the events we received.
+	events, collected = collectEvents()
+	changes, err = s.Up(ctx, Options{Events: events, Message: "first"})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, changes[deploy.OpCreate])
+
+	var sawPrelude, sawSummary bool
+	for _, e := range collected() {
+		switch e.Type {
+		case engine.PreludeEvent:
+			sawPrelude = true
+		case engine.SummaryEvent:
+			sawSummary = true
+		case engine.CancelEvent:
+			assert.Fail(t, "unexpected cancel event")
+		}
+	}
+	assert.True(t, sawPrelude)
+	assert.True(t, sawSummary)
+
+	outputs, err = s.Outputs(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, resource.NewStringProperty("hello"), outputs["greeting"])
+	assert.Equal(t, resource.MakeSecret(resource.NewStringProperty("hunter2")), outputs["password"])
+	assert.Equal(t, resource.NewNumberProperty(3), outputs["count"])
+
+	// A second update should make no changes.
+	selected, err := w.SelectStack(ctx, "dev")
+	assert.NoError(t, err)
+	changes, err = selected.Up(ctx, Options{})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, changes[deploy.OpSame])
+
+	changes, err = selected.Refresh(ctx, Options{})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, changes[deploy.OpUpdate]+changes[deploy.OpDelete])
+
+	// The stack cannot be removed until it has been destroyed.
+	assert.Error(t, w.RemoveStack(ctx, "dev", false))
+	changes, err = selected.Destroy(ctx, Options{})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, changes[deploy.OpDelete])
+	assert.NoError(t, w.RemoveStack(ctx, "dev", false))
+
+	_, err = w.SelectStack(ctx, "dev")
+	assert.Error(t, err)
+}
+
+// TestCloudSecretsProvider checks that a stack whose settings name a cloud secrets provider uses it, rather than a
+// passphrase, to encrypt its secrets.
+func TestCloudSecretsProvider(t *testing.T) {
+	dir, err := ioutil.TempDir("", "automation")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	oldPassphrase, hadPassphrase := os.LookupEnv("PULUMI_CONFIG_PASSPHRASE")
+	assert.NoError(t, os.Unsetenv("PULUMI_CONFIG_PASSPHRASE"))
+	defer func() {
+		if hadPassphrase {
+			os.Setenv("PULUMI_CONFIG_PASSPHRASE", oldPassphrase)
+		}
+	}()
+
+	b, err := filestate.New(cmdutil.Diag(), "file://"+dir)
+	assert.NoError(t, err)
+
+	proj := &workspace.Project{
+		Name:    "automation",
+		Runtime: workspace.NewProjectRuntimeInfo("go", nil),
+	}
+	w, err := NewWorkspace(b, proj, dir, testProgram)
+	assert.NoError(t, err)
+
+	ctx := context.Background()
+	s, err := w.CreateStack(ctx, "dev")
+	assert.NoError(t, err)
+
+	const keeperURL = "base64key://smGbjm71Nxd1Ig5FS0wj9SlbzAIrnolCz9bQQ6uAhl4="
+	dataKey, err := cloud.GenerateNewDataKey(keeperURL)
+	assert.NoError(t, err)
+	settings := &workspace.ProjectStack{
+		SecretsProvider: keeperURL,
+		EncryptedKey:    base64.StdEncoding.EncodeToString(dataKey),
+	}
+	assert.NoError(t, settings.Save(s.settingsPath()))
+
+	key := config.MustMakeKey("automation", "password")
+	assert.NoError(t, s.SetConfig(key, "hunter2", true))
+	v, ok, err := s.GetConfig(key)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "hunter2", v)
+
+	// The settings keep their data key, and no passphrase salt is added to them.
+	saved, err := workspace.LoadProjectStack(s.settingsPath())
+	assert.NoError(t, err)
+	assert.Equal(t, settings.EncryptedKey, saved.EncryptedKey)
+	assert.Empty(t, saved.EncryptionSalt)
+}
+
+func TestConfigSchema(t *testing.T) {
+	dir, err := ioutil.TempDir("", "automation")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	b, err := filestate.New(cmdutil.Diag(), "file://"+dir)
+	assert.NoError(t, err)
+
+	// The program reads its configuration untyped, so it only sees the default if the workspace applies it.
+	proj := &workspace.Project{
+		Name:    "automation",
+		Runtime: workspace.NewProjectRuntimeInfo("go", nil),
+		Config: &workspace.ProjectConfig{
+			Keys: map[string]workspace.ConfigKeySchema{
+				"name":     {Required: true},
+				"greeting": {Default: "hello"},
+			},
+		},
+	}
+	w, err := NewWorkspace(b, proj, dir, func(ctx *pulumi.Context) error {
+		cfg := pulumiconfig.New(ctx, "")
+		ctx.Export("greeting", pulumi.String(cfg.Require("greeting")+", "+cfg.Require("name")))
+		return nil
+	})
+	assert.NoError(t, err)
+	w.SecretsManager = b64.NewBase64SecretsManager()
+
+	ctx := context.Background()
+	s, err := w.CreateStack(ctx, "dev")
+	assert.NoError(t, err)
+
+	// A missing required key is reported before the program runs.
+	_, err = s.Preview(ctx, Options{})
+	assert.EqualError(t, err, "stack configuration does not match the project's config schema:\n"+
+		"    missing required configuration key 'automation:name'; run `pulumi config set name <value>` to set it")
+	_, err = s.Up(ctx, Options{})
+	assert.Error(t, err)
+	_, err = s.Refresh(ctx, Options{})
+	assert.Error(t, err)
+
+	// Once it is set, the program sees both it and the declared default.
+	assert.NoError(t, s.SetConfig(config.MustMakeKey("automation", "name"), "world", false))
+	_, err = s.Up(ctx, Options{})
+	assert.NoError(t, err)
+	outputs, err := s.Outputs(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, resource.NewStringProperty("hello, world"), outputs["greeting"])
+
+	// Destroy works even if the configuration no longer matches the schema.
+	assert.NoError(t, s.RemoveConfig(config.MustMakeKey("automation", "name")))
+	_, err = s.Destroy(ctx, Options{})
+	assert.NoError(t, err)
+}
+
+func TestProgramFailure(t *testing.T) {
+	dir, err := ioutil.TempDir("", "automation")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	b, err := filestate.New(cmdutil.Diag(), "file://"+dir)
+	assert.NoError(t, err)
+
+	proj := &workspace.Project{
+		Name:    "automation",
+		Runtime: workspace.NewProjectRuntimeInfo("go", nil),
+	}
+	w, err := NewWorkspace(b, proj, dir, func(ctx *pulumi.Context) error {
+		return assert.AnError
+	})
+	assert.NoError(t, err)
+	w.SecretsManager = b64.NewBase64SecretsManager()
+
+	s, err := w.CreateStack(context.Background(), "dev")
+	assert.NoError(t, err)
+
+	events, collected := collectEvents()
+	_, err = s.Up(context.Background(), Options{Events: events})
+	assert.Error(t, err)
+
+	var sawError bool
+	for _, e := range collected() {
+		if e.Type == engine.DiagEvent && e.Payload.(engine.DiagEventPayload).Severity == "error" {
+			sawError = true
+		}
+	}
+	assert.True(t, sawError)
+}
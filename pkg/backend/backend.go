@@ -150,6 +150,8 @@ type Backend interface {
 	Refresh(ctx context.Context, stack Stack, op UpdateOperation) (engine.ResourceChanges, result.Result)
 	// Destroy destroys all of this stack's resources.
 	Destroy(ctx context.Context, stack Stack, op UpdateOperation) (engine.ResourceChanges, result.Result)
+	// Import imports the resources listed in the operation's Imports into the stack.
+	Import(ctx context.Context, stack Stack, op UpdateOperation) (engine.ResourceChanges, result.Result)
 	// Watch watches the project's working directory for changes and automatically updates the active stack.
 	Watch(ctx context.Context, stack Stack, op UpdateOperation) result.Result
 
@@ -174,6 +176,13 @@ type Backend interface {
 	ExportDeployment(ctx context.Context, stack Stack) (*apitype.UntypedDeployment, error)
 	// ImportDeployment imports the given deployment into the indicated stack.
 	ImportDeployment(ctx context.Context, stack Stack, deployment *apitype.UntypedDeployment) error
+	// ExportDeploymentForVersion exports the deployment written by the update of the given stack with the given
+	// version as an opaque JSON message.
+	ExportDeploymentForVersion(ctx context.Context, stack Stack, version int) (*apitype.UntypedDeployment, error)
+	// RollbackDeployment restores the deployment written by the update of the given stack with the given version
+	// and records the rollback in the stack's history. The rollback is refused if that deployment contains pending
+	// operations.
+	RollbackDeployment(ctx context.Context, stack Stack, version int) error
 	// Logout logs you out of the backend and removes any stored credentials.
 	Logout() error
 	// Returns the identity of the current user for the backend.
@@ -189,6 +198,7 @@ type UpdateOperation struct {
 	SecretsManager     secrets.Manager
 	StackConfiguration StackConfiguration
 	Scopes             CancellationScopeSource
+	Imports            []deploy.Import // the resources to import, if this is an import operation.
 }
 
 // QueryOperation configures a query operation.
@@ -207,6 +217,20 @@ type StackConfiguration struct {
 	Decrypter config.Decrypter
 }
 
+// ApplyConfigSchema checks the given stack configuration against the project's config schema, and fills in the
+// defaults declared for any keys that are not set.
+func ApplyConfigSchema(proj *workspace.Project, cfg *StackConfiguration) error {
+	if err := proj.ValidateConfig(cfg.Config, cfg.Decrypter); err != nil {
+		return err
+	}
+	withDefaults, err := proj.ApplyConfigDefaults(cfg.Config)
+	if err != nil {
+		return err
+	}
+	cfg.Config = withDefaults
+	return nil
+}
+
 // UpdateOptions is the full set of update options, including backend and engine options.
 type UpdateOptions struct {
 	// Engine contains all of the engine-specific options.
@@ -218,6 +242,8 @@ type UpdateOptions struct {
 	AutoApprove bool
 	// SkipPreview, when true, causes the preview step to be skipped.
 	SkipPreview bool
+	// PreviewOnly, when true, causes only the preview step to be run; the operation itself is not performed.
+	PreviewOnly bool
 }
 
 // QueryOptions configures a query to operate against a backend and the engine.
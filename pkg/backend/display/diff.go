@@ -291,27 +291,30 @@ func renderDiff(
 
 	indent := engine.GetIndent(metadata, seen)
 	summary := engine.GetResourcePropertiesSummary(metadata, indent)
-
-	var details string
-	if metadata.DetailedDiff != nil {
-		var buf bytes.Buffer
-		if diff := translateDetailedDiff(metadata); diff != nil {
-			engine.PrintObjectDiff(&buf, *diff, nil /*include*/, planning, indent+1, opts.SummaryDiff, debug)
-		} else {
-			engine.PrintObject(
-				&buf, metadata.Old.Inputs, planning, indent+1, deploy.OpSame, true /*prefix*/, debug)
-		}
-		details = buf.String()
-	} else {
-		details = engine.GetResourcePropertiesDetails(
-			metadata, indent, planning, opts.SummaryDiff, debug)
-	}
+	details := renderDiffDetails(metadata, planning, debug, indent, opts)
 
 	fprintIgnoreError(out, opts.Color.Colorize(summary))
 	fprintIgnoreError(out, opts.Color.Colorize(details))
 	fprintIgnoreError(out, opts.Color.Colorize(colors.Reset))
 }
 
+// renderDiffDetails renders the properties of the given step's resource, along with any changes to them, at the given
+// indentation level. The result is not colorized.
+func renderDiffDetails(metadata engine.StepEventMetadata, planning, debug bool, indent int, opts Options) string {
+	if metadata.DetailedDiff == nil {
+		return engine.GetResourcePropertiesDetails(metadata, indent, planning, opts.SummaryDiff, debug)
+	}
+
+	var buf bytes.Buffer
+	if diff := translateDetailedDiff(metadata); diff != nil {
+		engine.PrintObjectDiff(&buf, *diff, nil /*include*/, planning, indent+1, opts.SummaryDiff, debug)
+	} else {
+		engine.PrintObject(
+			&buf, metadata.Old.Inputs, planning, indent+1, deploy.OpSame, true /*prefix*/, debug)
+	}
+	return buf.String()
+}
+
 func renderDiffResourcePreEvent(
 	payload engine.ResourcePreEventPayload,
 	seen map[resource.URN]engine.StepEventMetadata,
@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"time"
 
 	"github.com/pulumi/pulumi/pkg/apitype"
 	"github.com/pulumi/pulumi/pkg/engine"
@@ -39,6 +40,12 @@ func ShowEvents(
 	if opts.EventLogPath != "" {
 		events, done = startEventLogger(events, done, opts.EventLogPath)
 	}
+	if opts.DriftReport != nil {
+		events, done = startDriftRecorder(events, done, opts.DriftReport)
+	}
+	if opts.Events != nil {
+		events, done = startEventForwarder(events, done, opts.Events)
+	}
 
 	if opts.JSONDisplay {
 		// TODO[pulumi/pulumi#2390]: enable JSON display for real deployments.
@@ -57,6 +64,12 @@ func ShowEvents(
 			"directly instead of through ShowEvents")
 	case DisplayWatch:
 		ShowWatchEvents(op, action, events, done, opts)
+	case DisplayNone:
+		discardEvents(events, done)
+	case DisplayMarkdown:
+		ShowMarkdownEvents(op, stack, events, done, opts, isPreview)
+	case DisplayJUnit:
+		ShowJUnitEvents(op, stack, events, done, opts)
 	default:
 		contract.Failf("Unknown display type %d", opts.Type)
 	}
@@ -77,12 +90,18 @@ func startEventLogger(events <-chan engine.Event, done chan<- bool, path string)
 			contract.IgnoreError(logFile.Close())
 		}()
 
+		// Each logged event records its sequence number and the time at which it was logged so that the log may be
+		// replayed at the speed at which the events originally occurred.
+		sequence := 0
 		encoder := json.NewEncoder(logFile)
 		logEvent := func(e engine.Event) error {
 			apiEvent, err := ConvertEngineEvent(e)
 			if err != nil {
 				return err
 			}
+			apiEvent.Sequence = sequence
+			apiEvent.Timestamp = int(time.Now().Unix())
+			sequence++
 			return encoder.Encode(apiEvent)
 		}
 
@@ -104,6 +123,44 @@ func startEventLogger(events <-chan engine.Event, done chan<- bool, path string)
 	return outEvents, outDone
 }
 
+// startEventForwarder forwards the events that flow through the returned channel to the given channel. The final
+// CancelEvent, which only marks the end of the event stream, is not forwarded.
+func startEventForwarder(events <-chan engine.Event, done chan<- bool,
+	forward chan<- engine.Event) (<-chan engine.Event, chan<- bool) {
+
+	outEvents, outDone := make(chan engine.Event), make(chan bool)
+	go func() {
+		defer close(done)
+
+		for e := range events {
+			if e.Type != engine.CancelEvent {
+				forward <- e
+			}
+
+			outEvents <- e
+
+			if e.Type == engine.CancelEvent {
+				break
+			}
+		}
+
+		<-outDone
+	}()
+
+	return outEvents, outDone
+}
+
+// discardEvents consumes events without displaying them until the event stream ends.
+func discardEvents(events <-chan engine.Event, done chan<- bool) {
+	defer close(done)
+
+	for e := range events {
+		if e.Type == engine.CancelEvent {
+			break
+		}
+	}
+}
+
 type nopSpinner struct {
 }
 
@@ -134,8 +191,10 @@ func shouldShow(step engine.StepEventMetadata, opts Options) bool {
 	}
 
 	// For logical replacement operations, only show them during progress-style updates (since this is integrated
-	// into the resource status update), or if it is requested explicitly (for diffs and JSON outputs).
-	if (opts.Type == DisplayDiff || opts.JSONDisplay) && !step.Logical && !opts.ShowReplacementSteps {
+	// into the resource status update), or if it is requested explicitly (for diffs and JSON, Markdown, and JUnit
+	// outputs).
+	if (opts.Type == DisplayDiff || opts.Type == DisplayMarkdown || opts.Type == DisplayJUnit || opts.JSONDisplay) &&
+		!step.Logical && !opts.ShowReplacementSteps {
 		return false
 	}
 
@@ -0,0 +1,212 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package display
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/pulumi/pulumi/pkg/engine"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/tokens"
+)
+
+// DriftReport records the differences between the state of a stack's resources and their live state, as observed by
+// a refresh.
+type DriftReport struct {
+	// Resources lists the resources whose live state differs from their recorded state, sorted by URN.
+	Resources []ResourceDrift `json:"resources"`
+}
+
+// ResourceDrift describes the drift of a single resource.
+type ResourceDrift struct {
+	// URN is the URN of the drifted resource.
+	URN resource.URN `json:"urn"`
+	// Type is the type of the drifted resource.
+	Type tokens.Type `json:"type"`
+	// Op is the operation that a refresh would apply to the resource's state: "update" if the resource's live
+	// properties differ from its recorded properties, or "delete" if the resource no longer exists.
+	Op deploy.StepOp `json:"op"`
+	// Properties lists the properties whose live values differ from their recorded values, sorted by path.
+	Properties []PropertyDrift `json:"properties,omitempty"`
+}
+
+// PropertyDrift describes the drift of a single property. Secret values are masked.
+type PropertyDrift struct {
+	// Path is the path of the property, e.g. `tags.owner` or `ports[0]`.
+	Path string `json:"path"`
+	// Old is the recorded value of the property, if any.
+	Old interface{} `json:"old,omitempty"`
+	// Live is the live value of the property, if any.
+	Live interface{} `json:"live,omitempty"`
+}
+
+// HasDrift returns true if any resources have drifted.
+func (r *DriftReport) HasDrift() bool {
+	return len(r.Resources) > 0
+}
+
+// recordEvent records the drift described by the given engine event, if any.
+func (r *DriftReport) recordEvent(e engine.Event) {
+	if e.Type != engine.ResourceOutputsEvent {
+		return
+	}
+	metadata := e.Payload.(engine.ResourceOutputsEventPayload).Metadata
+	if metadata.Old == nil || metadata.Old.State == nil {
+		return
+	}
+
+	switch metadata.Op {
+	case deploy.OpUpdate:
+		if metadata.New == nil || metadata.New.State == nil {
+			return
+		}
+		var properties []PropertyDrift
+		if diff := metadata.Old.State.Outputs.Diff(metadata.New.State.Outputs); diff != nil {
+			properties = objectDrift("", diff, properties)
+		}
+		sort.Slice(properties, func(i, j int) bool { return properties[i].Path < properties[j].Path })
+		r.addResource(ResourceDrift{
+			URN:        metadata.URN,
+			Type:       metadata.Type,
+			Op:         deploy.OpUpdate,
+			Properties: properties,
+		})
+	case deploy.OpDelete:
+		r.addResource(ResourceDrift{URN: metadata.URN, Type: metadata.Type, Op: deploy.OpDelete})
+	}
+}
+
+// addResource adds the given resource to the report, maintaining the report's order.
+func (r *DriftReport) addResource(drift ResourceDrift) {
+	i := sort.Search(len(r.Resources), func(i int) bool { return r.Resources[i].URN >= drift.URN })
+	r.Resources = append(r.Resources, ResourceDrift{})
+	copy(r.Resources[i+1:], r.Resources[i:])
+	r.Resources[i] = drift
+}
+
+// objectDrift appends the drift of each of the properties in the given object diff to the given list.
+func objectDrift(path string, diff *resource.ObjectDiff, properties []PropertyDrift) []PropertyDrift {
+	for k, v := range diff.Adds {
+		properties = append(properties, PropertyDrift{Path: propertyPath(path, string(k)), Live: driftValue(v)})
+	}
+	for k, v := range diff.Deletes {
+		properties = append(properties, PropertyDrift{Path: propertyPath(path, string(k)), Old: driftValue(v)})
+	}
+	for k, v := range diff.Updates {
+		properties = valueDrift(propertyPath(path, string(k)), v, properties)
+	}
+	return properties
+}
+
+// valueDrift appends the drift described by the given value diff to the given list. Objects and arrays are diffed
+// element-wise; secrets are always treated as opaque values.
+func valueDrift(path string, diff resource.ValueDiff, properties []PropertyDrift) []PropertyDrift {
+	if !diff.Old.IsSecret() && !diff.New.IsSecret() {
+		switch {
+		case diff.Object != nil:
+			return objectDrift(path, diff.Object, properties)
+		case diff.Array != nil:
+			for i, v := range diff.Array.Adds {
+				properties = append(properties, PropertyDrift{Path: indexPath(path, i), Live: driftValue(v)})
+			}
+			for i, v := range diff.Array.Deletes {
+				properties = append(properties, PropertyDrift{Path: indexPath(path, i), Old: driftValue(v)})
+			}
+			for i, v := range diff.Array.Updates {
+				properties = valueDrift(indexPath(path, i), v, properties)
+			}
+			return properties
+		}
+	}
+	return append(properties, PropertyDrift{Path: path, Old: driftValue(diff.Old), Live: driftValue(diff.New)})
+}
+
+// simplePropertyKey matches property keys that do not need to be quoted in property paths.
+var simplePropertyKey = regexp.MustCompile(`^[a-zA-Z_$][a-zA-Z0-9_$]*$`)
+
+// propertyPath returns the path of the given property of the value at the given path.
+func propertyPath(path, key string) string {
+	if !simplePropertyKey.MatchString(key) {
+		return fmt.Sprintf("%s[%s]", path, strconv.Quote(key))
+	}
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// indexPath returns the path of the given element of the array at the given path.
+func indexPath(path string, index int) string {
+	return fmt.Sprintf("%s[%d]", path, index)
+}
+
+// driftValue returns a JSON-friendly representation of the given value with any secrets masked.
+func driftValue(v resource.PropertyValue) interface{} {
+	switch {
+	case v.IsNull():
+		return nil
+	case v.IsSecret():
+		return "[secret]"
+	case v.IsComputed() || v.IsOutput():
+		return "[unknown]"
+	case v.IsArray():
+		arr := make([]interface{}, len(v.ArrayValue()))
+		for i, e := range v.ArrayValue() {
+			arr[i] = driftValue(e)
+		}
+		return arr
+	case v.IsObject():
+		obj := make(map[string]interface{})
+		for k, e := range v.ObjectValue() {
+			obj[string(k)] = driftValue(e)
+		}
+		return obj
+	case v.IsAsset():
+		return map[string]interface{}{"hash": v.AssetValue().Hash}
+	case v.IsArchive():
+		return map[string]interface{}{"hash": v.ArchiveValue().Hash}
+	default:
+		return v.V
+	}
+}
+
+// startDriftRecorder records the drift described by the events that flow through the returned channel in the given
+// report.
+func startDriftRecorder(events <-chan engine.Event, done chan<- bool,
+	report *DriftReport) (<-chan engine.Event, chan<- bool) {
+
+	outEvents, outDone := make(chan engine.Event), make(chan bool)
+	go func() {
+		defer close(done)
+
+		for e := range events {
+			report.recordEvent(e)
+
+			outEvents <- e
+
+			if e.Type == engine.CancelEvent {
+				break
+			}
+		}
+
+		<-outDone
+	}()
+
+	return outEvents, outDone
+}
@@ -0,0 +1,109 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package display
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/engine"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+)
+
+func refreshEvent(op deploy.StepOp, urn resource.URN, old, live resource.PropertyMap) engine.Event {
+	metadata := engine.StepEventMetadata{
+		Op:   op,
+		URN:  urn,
+		Type: urn.Type(),
+		Old: &engine.StepEventStateMetadata{
+			State: &resource.State{URN: urn, Type: urn.Type(), Outputs: old},
+		},
+	}
+	if live != nil {
+		metadata.New = &engine.StepEventStateMetadata{
+			State: &resource.State{URN: urn, Type: urn.Type(), Outputs: live},
+		}
+	}
+	return engine.Event{
+		Type:    engine.ResourceOutputsEvent,
+		Payload: engine.ResourceOutputsEventPayload{Metadata: metadata, Planning: true},
+	}
+}
+
+func TestDriftReport(t *testing.T) {
+	urnA := resource.NewURN("stack", "proj", "", "pkgA:m:typA", "resA")
+	urnB := resource.NewURN("stack", "proj", "", "pkgA:m:typA", "resB")
+	urnC := resource.NewURN("stack", "proj", "", "pkgA:m:typA", "resC")
+
+	old := resource.NewPropertyMapFromMap(map[string]interface{}{
+		"same": "value",
+		"size": 1,
+		"tags": map[string]interface{}{
+			"owner":    "me",
+			"cost.ctr": "a",
+		},
+		"ports":   []interface{}{80, 443},
+		"removed": true,
+	})
+	old["password"] = resource.MakeSecret(resource.NewStringProperty("hunter2"))
+
+	live := resource.NewPropertyMapFromMap(map[string]interface{}{
+		"same": "value",
+		"size": 2,
+		"tags": map[string]interface{}{
+			"owner":    "you",
+			"cost.ctr": "a",
+		},
+		"ports": []interface{}{80, 8443},
+		"added": "new",
+	})
+	live["password"] = resource.MakeSecret(resource.NewStringProperty("hunter3"))
+
+	var report DriftReport
+	report.recordEvent(refreshEvent(deploy.OpUpdate, urnB, old, live))
+	report.recordEvent(refreshEvent(deploy.OpSame, urnC, old, old))
+	report.recordEvent(refreshEvent(deploy.OpDelete, urnA, old, nil))
+
+	assert.True(t, report.HasDrift())
+	assert.Equal(t, []ResourceDrift{
+		{
+			URN:  urnA,
+			Type: "pkgA:m:typA",
+			Op:   deploy.OpDelete,
+		},
+		{
+			URN:  urnB,
+			Type: "pkgA:m:typA",
+			Op:   deploy.OpUpdate,
+			Properties: []PropertyDrift{
+				{Path: "added", Live: "new"},
+				{Path: "password", Old: "[secret]", Live: "[secret]"},
+				{Path: "ports[1]", Old: float64(443), Live: float64(8443)},
+				{Path: "removed", Old: true},
+				{Path: "size", Old: float64(1), Live: float64(2)},
+				{Path: "tags.owner", Old: "me", Live: "you"},
+			},
+		},
+	}, report.Resources)
+
+	assert.Equal(t, `tags["cost.ctr"]`, propertyPath("tags", "cost.ctr"))
+	assert.Equal(t, `["a b"]`, propertyPath("", "a b"))
+
+	var empty DriftReport
+	empty.recordEvent(refreshEvent(deploy.OpSame, urnC, old, old))
+	assert.False(t, empty.HasDrift())
+}
@@ -1,10 +1,17 @@
 package display
 
 import (
+	"time"
+
 	"github.com/pkg/errors"
 	"github.com/pulumi/pulumi/pkg/apitype"
+	"github.com/pulumi/pulumi/pkg/diag"
+	"github.com/pulumi/pulumi/pkg/diag/colors"
 	"github.com/pulumi/pulumi/pkg/engine"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
 	"github.com/pulumi/pulumi/pkg/resource/plugin"
+	"github.com/pulumi/pulumi/pkg/tokens"
 	"github.com/pulumi/pulumi/pkg/util/contract"
 )
 
@@ -210,3 +217,301 @@ func convertStepEventStateMetadata(md *engine.StepEventStateMetadata) *apitype.S
 		InitErrors: md.InitErrors,
 	}
 }
+
+// ConvertJSONEvent converts an apitype.EngineEvent, such as one read from an event log, back into a raw engine.Event.
+// The conversion is lossy: fields that ConvertEngineEvent does not record are left empty, and computed and output
+// property values are both converted to computed values. Returns an error if the event is empty or malformed.
+func ConvertJSONEvent(apiEvent apitype.EngineEvent) (engine.Event, error) {
+	var event engine.Event
+
+	switch {
+	case apiEvent.CancelEvent != nil:
+		event.Type = engine.CancelEvent
+
+	case apiEvent.StdoutEvent != nil:
+		p := apiEvent.StdoutEvent
+		event.Type = engine.StdoutColorEvent
+		event.Payload = engine.StdoutEventPayload{
+			Message: p.Message,
+			Color:   colors.Colorization(p.Color),
+		}
+
+	case apiEvent.DiagnosticEvent != nil:
+		p := apiEvent.DiagnosticEvent
+		event.Type = engine.DiagEvent
+		event.Payload = engine.DiagEventPayload{
+			URN:       resource.URN(p.URN),
+			Prefix:    p.Prefix,
+			Message:   p.Message,
+			Color:     colors.Colorization(p.Color),
+			Severity:  diag.Severity(p.Severity),
+			StreamID:  int32(p.StreamID),
+			Ephemeral: p.Ephemeral,
+		}
+
+	case apiEvent.PolicyEvent != nil:
+		p := apiEvent.PolicyEvent
+		event.Type = engine.PolicyViolationEvent
+		event.Payload = engine.PolicyViolationEventPayload{
+			ResourceURN:       resource.URN(p.ResourceURN),
+			Message:           p.Message,
+			Color:             colors.Colorization(p.Color),
+			PolicyName:        p.PolicyName,
+			PolicyPackName:    p.PolicyPackName,
+			PolicyPackVersion: p.PolicyPackVersion,
+			EnforcementLevel:  apitype.EnforcementLevel(p.EnforcementLevel),
+		}
+
+	case apiEvent.PreludeEvent != nil:
+		event.Type = engine.PreludeEvent
+		event.Payload = engine.PreludeEventPayload{
+			Config: apiEvent.PreludeEvent.Config,
+		}
+
+	case apiEvent.SummaryEvent != nil:
+		p := apiEvent.SummaryEvent
+		changes := make(engine.ResourceChanges)
+		for op, count := range p.ResourceChanges {
+			changes[deploy.StepOp(op)] = count
+		}
+		event.Type = engine.SummaryEvent
+		event.Payload = engine.SummaryEventPayload{
+			MaybeCorrupt:    p.MaybeCorrupt,
+			Duration:        time.Duration(p.DurationSeconds) * time.Second,
+			ResourceChanges: changes,
+			PolicyPacks:     p.PolicyPacks,
+		}
+
+	case apiEvent.ResourcePreEvent != nil:
+		p := apiEvent.ResourcePreEvent
+		md, err := convertJSONStepEventMetadata(p.Metadata)
+		if err != nil {
+			return event, err
+		}
+		event.Type = engine.ResourcePreEvent
+		event.Payload = engine.ResourcePreEventPayload{
+			Metadata: md,
+			Planning: p.Planning,
+		}
+
+	case apiEvent.ResOutputsEvent != nil:
+		p := apiEvent.ResOutputsEvent
+		md, err := convertJSONStepEventMetadata(p.Metadata)
+		if err != nil {
+			return event, err
+		}
+		event.Type = engine.ResourceOutputsEvent
+		event.Payload = engine.ResourceOutputsEventPayload{
+			Metadata: md,
+			Planning: p.Planning,
+		}
+
+	case apiEvent.ResOpFailedEvent != nil:
+		p := apiEvent.ResOpFailedEvent
+		md, err := convertJSONStepEventMetadata(p.Metadata)
+		if err != nil {
+			return event, err
+		}
+		event.Type = engine.ResourceOperationFailed
+		event.Payload = engine.ResourceOperationFailedPayload{
+			Metadata: md,
+			Status:   resource.Status(p.Status),
+			Steps:    p.Steps,
+		}
+
+	default:
+		return event, errors.Errorf("event %d has no payload", apiEvent.Sequence)
+	}
+
+	return event, nil
+}
+
+func convertJSONStepEventMetadata(md apitype.StepEventMetadata) (engine.StepEventMetadata, error) {
+	keys := make([]resource.PropertyKey, len(md.Keys))
+	for i, v := range md.Keys {
+		keys[i] = resource.PropertyKey(v)
+	}
+	var diffs []resource.PropertyKey
+	for _, v := range md.Diffs {
+		diffs = append(diffs, resource.PropertyKey(v))
+	}
+	var detailedDiff map[string]plugin.PropertyDiff
+	if md.DetailedDiff != nil {
+		detailedDiff = make(map[string]plugin.PropertyDiff)
+		for k, v := range md.DetailedDiff {
+			var d plugin.DiffKind
+			switch v.Kind {
+			case apitype.DiffAdd:
+				d = plugin.DiffAdd
+			case apitype.DiffAddReplace:
+				d = plugin.DiffAddReplace
+			case apitype.DiffDelete:
+				d = plugin.DiffDelete
+			case apitype.DiffDeleteReplace:
+				d = plugin.DiffDeleteReplace
+			case apitype.DiffUpdate:
+				d = plugin.DiffUpdate
+			case apitype.DiffUpdateReplace:
+				d = plugin.DiffUpdateReplace
+			default:
+				return engine.StepEventMetadata{}, errors.Errorf("unrecognized diff kind %q", v.Kind)
+			}
+			detailedDiff[k] = plugin.PropertyDiff{
+				Kind:      d,
+				InputDiff: v.InputDiff,
+			}
+		}
+	}
+
+	oldState, err := convertJSONStepEventStateMetadata(md.Old)
+	if err != nil {
+		return engine.StepEventMetadata{}, errors.Wrapf(err, "converting old state of %s", md.URN)
+	}
+	newState, err := convertJSONStepEventStateMetadata(md.New)
+	if err != nil {
+		return engine.StepEventMetadata{}, errors.Wrapf(err, "converting new state of %s", md.URN)
+	}
+
+	// The engine reports the latest known state of the resource alongside its old and new states.
+	res := newState
+	if res == nil {
+		res = oldState
+	}
+
+	return engine.StepEventMetadata{
+		Op:   deploy.StepOp(md.Op),
+		URN:  resource.URN(md.URN),
+		Type: tokens.Type(md.Type),
+
+		Old: oldState,
+		New: newState,
+		Res: res,
+
+		Keys:         keys,
+		Diffs:        diffs,
+		DetailedDiff: detailedDiff,
+		Logical:      md.Logical,
+		Provider:     md.Provider,
+	}, nil
+}
+
+func convertJSONStepEventStateMetadata(md *apitype.StepEventStateMetadata) (*engine.StepEventStateMetadata, error) {
+	if md == nil {
+		return nil, nil
+	}
+
+	inputs, err := convertJSONPropertyMap(md.Inputs)
+	if err != nil {
+		return nil, errors.Wrap(err, "converting inputs")
+	}
+	outputs, err := convertJSONPropertyMap(md.Outputs)
+	if err != nil {
+		return nil, errors.Wrap(err, "converting outputs")
+	}
+
+	urn, typ := resource.URN(md.URN), tokens.Type(md.Type)
+	id, parent := resource.ID(md.ID), resource.URN(md.Parent)
+	return &engine.StepEventStateMetadata{
+		State: &resource.State{
+			URN:        urn,
+			Type:       typ,
+			Custom:     md.Custom,
+			Delete:     md.Delete,
+			ID:         id,
+			Parent:     parent,
+			Protect:    md.Protect,
+			Inputs:     inputs,
+			Outputs:    outputs,
+			InitErrors: md.InitErrors,
+		},
+		Type: typ,
+		URN:  urn,
+
+		Custom:     md.Custom,
+		Delete:     md.Delete,
+		ID:         id,
+		Parent:     parent,
+		Protect:    md.Protect,
+		Inputs:     inputs,
+		Outputs:    outputs,
+		InitErrors: md.InitErrors,
+	}, nil
+}
+
+// convertJSONPropertyMap converts a JSON-decoded resource.PropertyMap back into a property map.
+func convertJSONPropertyMap(m map[string]interface{}) (resource.PropertyMap, error) {
+	props := make(resource.PropertyMap)
+	for k, v := range m {
+		pv, err := convertJSONPropertyValue(v)
+		if err != nil {
+			return nil, errors.Wrapf(err, "property %q", k)
+		}
+		props[resource.PropertyKey(k)] = pv
+	}
+	return props, nil
+}
+
+// convertJSONPropertyValue converts a JSON-decoded resource.PropertyValue, which is encoded as an object with a single
+// "V" field, back into a property value.
+func convertJSONPropertyValue(v interface{}) (resource.PropertyValue, error) {
+	obj, ok := v.(map[string]interface{})
+	if !ok || len(obj) != 1 {
+		return resource.PropertyValue{}, errors.Errorf("malformed property value %v", v)
+	}
+	inner, ok := obj["V"]
+	if !ok {
+		return resource.PropertyValue{}, errors.Errorf("malformed property value %v", v)
+	}
+
+	switch inner := inner.(type) {
+	case nil:
+		return resource.NewNullProperty(), nil
+	case bool:
+		return resource.NewBoolProperty(inner), nil
+	case float64:
+		return resource.NewNumberProperty(inner), nil
+	case string:
+		return resource.NewStringProperty(inner), nil
+	case []interface{}:
+		arr := make([]resource.PropertyValue, len(inner))
+		for i, e := range inner {
+			pv, err := convertJSONPropertyValue(e)
+			if err != nil {
+				return resource.PropertyValue{}, err
+			}
+			arr[i] = pv
+		}
+		return resource.NewArrayProperty(arr), nil
+	case map[string]interface{}:
+		// Computed and output values are both encoded as an object with a single "Element" field. The two are
+		// displayed identically, so we treat them both as computed values.
+		if elem, has := inner["Element"]; has && len(inner) == 1 {
+			pv, err := convertJSONPropertyValue(elem)
+			if err != nil {
+				return resource.PropertyValue{}, err
+			}
+			return resource.MakeComputed(pv), nil
+		}
+
+		asset, isAsset, err := resource.DeserializeAsset(inner)
+		if err != nil {
+			return resource.PropertyValue{}, err
+		} else if isAsset {
+			return resource.NewAssetProperty(asset), nil
+		}
+		archive, isArchive, err := resource.DeserializeArchive(inner)
+		if err != nil {
+			return resource.PropertyValue{}, err
+		} else if isArchive {
+			return resource.NewArchiveProperty(archive), nil
+		}
+
+		props, err := convertJSONPropertyMap(inner)
+		if err != nil {
+			return resource.PropertyValue{}, err
+		}
+		return resource.NewObjectProperty(props), nil
+	default:
+		return resource.PropertyValue{}, errors.Errorf("unexpected property value of type %T", inner)
+	}
+}
@@ -0,0 +1,249 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package display
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/pulumi/pulumi/pkg/apitype"
+	"github.com/pulumi/pulumi/pkg/diag"
+	"github.com/pulumi/pulumi/pkg/diag/colors"
+	"github.com/pulumi/pulumi/pkg/engine"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/tokens"
+	"github.com/pulumi/pulumi/pkg/util/contract"
+)
+
+// ShowJUnitEvents reads events from the `events` channel until it is closed or a CancelEvent is received, and then
+// renders a JUnit XML report of the update to stdout. Each resource step is reported as a test case, which fails if the
+// step failed, reported an error, or violated a mandatory policy.
+func ShowJUnitEvents(op string, stack tokens.QName, events <-chan engine.Event, done chan<- bool, opts Options) {
+	// Ensure we close the done channel before exiting.
+	defer func() { close(done) }()
+
+	report := newJUnitReport(op, stack, opts, time.Now)
+	for e := range events {
+		if e.Type == engine.CancelEvent {
+			break
+		}
+		report.process(e)
+	}
+
+	out, err := report.render()
+	contract.IgnoreError(err)
+	fprintfIgnoreError(os.Stdout, "%s\n", out)
+}
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Time     string           `xml:"time,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Time      string          `xml:"time,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// junitStep is a step that is reported as a JUnit test case.
+type junitStep struct {
+	metadata engine.StepEventMetadata
+	start    time.Time
+	end      time.Time
+	failures []string
+	output   []string
+}
+
+// junitReport accumulates the events of an update for rendering as a JUnit report.
+type junitReport struct {
+	op    string
+	stack tokens.QName
+	opts  Options
+	now   func() time.Time
+
+	start    time.Time
+	steps    []*junitStep
+	lastStep map[resource.URN]*junitStep
+}
+
+func newJUnitReport(op string, stack tokens.QName, opts Options, now func() time.Time) *junitReport {
+	return &junitReport{
+		op:       op,
+		stack:    stack,
+		opts:     opts,
+		now:      now,
+		start:    now(),
+		lastStep: make(map[resource.URN]*junitStep),
+	}
+}
+
+func (r *junitReport) process(e engine.Event) {
+	switch e.Type {
+	case engine.ResourcePreEvent:
+		p := e.Payload.(engine.ResourcePreEventPayload)
+		if shouldShow(p.Metadata, r.opts) || isRootStack(p.Metadata) {
+			now := r.now()
+			step := &junitStep{metadata: p.Metadata, start: now, end: now}
+			r.steps = append(r.steps, step)
+			r.lastStep[p.Metadata.URN] = step
+		}
+	case engine.ResourceOutputsEvent:
+		p := e.Payload.(engine.ResourceOutputsEventPayload)
+		if step, ok := r.lastStep[p.Metadata.URN]; ok {
+			step.end = r.now()
+		}
+	case engine.ResourceOperationFailed:
+		p := e.Payload.(engine.ResourceOperationFailedPayload)
+		if step, ok := r.lastStep[p.Metadata.URN]; ok {
+			step.end = r.now()
+			step.failures = append(step.failures, fmt.Sprintf("%s failed", p.Metadata.Op))
+		}
+	case engine.DiagEvent:
+		// Skip any ephemeral, debug, or informational messages.
+		p := e.Payload.(engine.DiagEventPayload)
+		if p.Ephemeral || (p.Severity != diag.Error && p.Severity != diag.Warning) {
+			return
+		}
+		step := r.stepFor(p.URN)
+		message := strings.TrimRight(colors.Never.Colorize(p.Prefix+p.Message), "\n")
+		if p.Severity == diag.Error {
+			step.failures = append(step.failures, message)
+		} else {
+			step.output = append(step.output, message)
+		}
+	case engine.PolicyViolationEvent:
+		p := e.Payload.(engine.PolicyViolationEventPayload)
+		step := r.stepFor(p.ResourceURN)
+		message := fmt.Sprintf("%s: [%s] %s@%s: %s", p.EnforcementLevel, p.PolicyName, p.PolicyPackName,
+			p.PolicyPackVersion, strings.TrimRight(colors.Never.Colorize(p.Message), "\n"))
+		if p.EnforcementLevel == apitype.Mandatory {
+			step.failures = append(step.failures, message)
+		} else {
+			step.output = append(step.output, message)
+		}
+	case engine.PreludeEvent, engine.SummaryEvent, engine.StdoutColorEvent:
+		// These events do not contribute to the report.
+	default:
+		contract.Failf("unknown event type '%s'", e.Type)
+	}
+}
+
+// stepFor returns the most recent step for the given resource. If the resource has no step--e.g. because the URN is
+// empty--the step for the root stack resource is returned instead, and is created if necessary.
+func (r *junitReport) stepFor(urn resource.URN) *junitStep {
+	if step, ok := r.lastStep[urn]; ok && urn != "" {
+		return step
+	}
+	for _, step := range r.steps {
+		if step.metadata.Type == resource.RootStackType {
+			return step
+		}
+	}
+
+	now := r.now()
+	step := &junitStep{metadata: engine.StepEventMetadata{Type: resource.RootStackType}, start: now, end: now}
+	r.steps = append(r.steps, step)
+	return step
+}
+
+func (r *junitReport) render() (string, error) {
+	// Test cases are named after their resources. If a resource has more than one step, e.g. because it was replaced,
+	// its test cases are distinguished by their operations.
+	counts := make(map[string]int)
+	for _, step := range r.steps {
+		counts[r.testCaseName(step)]++
+	}
+
+	suite := junitTestSuite{
+		Name: r.op,
+		Time: junitSeconds(r.now().Sub(r.start)),
+	}
+	if r.stack != "" {
+		suite.Name = fmt.Sprintf("%s (%s)", r.op, r.stack)
+	}
+	for _, step := range r.steps {
+		name := r.testCaseName(step)
+		if counts[name] > 1 {
+			name = fmt.Sprintf("%s (%s)", name, step.metadata.Op)
+		}
+
+		testCase := junitTestCase{
+			Name:      name,
+			ClassName: string(step.metadata.Type),
+			Time:      junitSeconds(step.end.Sub(step.start)),
+			SystemOut: strings.Join(step.output, "\n"),
+		}
+		if len(step.failures) > 0 {
+			testCase.Failure = &junitFailure{
+				Message: step.failures[0],
+				Type:    "error",
+				Text:    strings.Join(step.failures, "\n"),
+			}
+			suite.Failures++
+		}
+		suite.Tests++
+		suite.TestCases = append(suite.TestCases, testCase)
+	}
+
+	suites := junitTestSuites{
+		Name:     suite.Name,
+		Tests:    suite.Tests,
+		Failures: suite.Failures,
+		Time:     suite.Time,
+		Suites:   []junitTestSuite{suite},
+	}
+	b, err := xml.MarshalIndent(suites, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return xml.Header + string(b), nil
+}
+
+// testCaseName returns the name of the test case for the given step.
+func (r *junitReport) testCaseName(step *junitStep) string {
+	if step.metadata.URN == "" {
+		return string(r.stack)
+	}
+	return string(step.metadata.URN.Name())
+}
+
+// junitSeconds formats a duration as a number of seconds, as JUnit reports expect.
+func junitSeconds(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
@@ -0,0 +1,104 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package display
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/apitype"
+	"github.com/pulumi/pulumi/pkg/diag"
+	"github.com/pulumi/pulumi/pkg/diag/colors"
+	"github.com/pulumi/pulumi/pkg/engine"
+	"github.com/pulumi/pulumi/pkg/resource"
+)
+
+func TestJUnitReport(t *testing.T) {
+	// Advance the clock by one second each time it is read.
+	clock := time.Unix(0, 0)
+	now := func() time.Time {
+		clock = clock.Add(time.Second)
+		return clock
+	}
+
+	report := newJUnitReport("update", "dev", Options{Color: colors.Never}, now)
+	for _, e := range testUpdateEvents(false) {
+		report.process(e)
+	}
+	out, err := report.render()
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(out, xml.Header))
+
+	var suites junitTestSuites
+	assert.NoError(t, xml.Unmarshal([]byte(out), &suites))
+	assert.Equal(t, "update (dev)", suites.Name)
+	assert.Equal(t, 3, suites.Tests)
+	assert.Equal(t, 2, suites.Failures)
+	if !assert.Len(t, suites.Suites, 1) || !assert.Len(t, suites.Suites[0].TestCases, 3) {
+		return
+	}
+
+	// URN-less errors are reported against the root stack.
+	stack := suites.Suites[0].TestCases[0]
+	assert.Equal(t, "proj-dev", stack.Name)
+	assert.Equal(t, string(resource.RootStackType), stack.ClassName)
+	assert.Equal(t, "5.000", stack.Time)
+	if assert.NotNil(t, stack.Failure) {
+		assert.Equal(t, "update failed", stack.Failure.Message)
+	}
+
+	a := suites.Suites[0].TestCases[1]
+	assert.Equal(t, "resA", a.Name)
+	assert.Equal(t, "pkgA:m:typA", a.ClassName)
+	assert.Equal(t, "1.000", a.Time)
+	assert.Nil(t, a.Failure)
+
+	b := suites.Suites[0].TestCases[2]
+	assert.Equal(t, "resB", b.Name)
+	assert.Equal(t, "1.000", b.Time)
+	if assert.NotNil(t, b.Failure) {
+		assert.Equal(t, "mandatory: [max-size] sizes@1: size must be\nat most 2 | 1", b.Failure.Message)
+		assert.Equal(t, "mandatory: [max-size] sizes@1: size must be\nat most 2 | 1\ncreation failed\ncreate failed",
+			b.Failure.Text)
+	}
+}
+
+func TestJUnitReportWithoutStack(t *testing.T) {
+	report := newJUnitReport("preview", "dev", Options{Color: colors.Never}, time.Now)
+	report.process(engine.Event{Type: engine.DiagEvent, Payload: engine.DiagEventPayload{
+		Message: "a warning\n", Color: colors.Never, Severity: diag.Warning,
+	}})
+	report.process(engine.Event{Type: engine.PolicyViolationEvent, Payload: engine.PolicyViolationEventPayload{
+		Message: "advice", Color: colors.Never, PolicyName: "p", PolicyPackName: "pack", PolicyPackVersion: "2",
+		EnforcementLevel: apitype.Advisory,
+	}})
+	out, err := report.render()
+	assert.NoError(t, err)
+
+	var suites junitTestSuites
+	assert.NoError(t, xml.Unmarshal([]byte(out), &suites))
+	assert.Equal(t, 1, suites.Tests)
+	assert.Equal(t, 0, suites.Failures)
+	if assert.Len(t, suites.Suites, 1) && assert.Len(t, suites.Suites[0].TestCases, 1) {
+		testCase := suites.Suites[0].TestCases[0]
+		assert.Equal(t, "dev", testCase.Name)
+		assert.Nil(t, testCase.Failure)
+		assert.Equal(t, "a warning\nadvisory: [p] pack@2: advice", testCase.SystemOut)
+	}
+}
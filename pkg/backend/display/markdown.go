@@ -0,0 +1,258 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package display
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/pulumi/pulumi/pkg/diag"
+	"github.com/pulumi/pulumi/pkg/diag/colors"
+	"github.com/pulumi/pulumi/pkg/engine"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/tokens"
+	"github.com/pulumi/pulumi/pkg/util/contract"
+)
+
+// ShowMarkdownEvents reads events from the `events` channel until it is closed or a CancelEvent is received, and then
+// renders a Markdown summary of the update to stdout. The summary groups the update's steps by operation, includes the
+// detailed diff of each step in a collapsible section, and highlights any policy violations and errors.
+func ShowMarkdownEvents(op string, stack tokens.QName, events <-chan engine.Event, done chan<- bool, opts Options,
+	isPreview bool) {
+
+	// Ensure we close the done channel before exiting.
+	defer func() { close(done) }()
+
+	summary := newMarkdownSummary(op, stack, isPreview, opts)
+	for e := range events {
+		if e.Type == engine.CancelEvent {
+			break
+		}
+		summary.process(e)
+	}
+
+	fmt.Print(summary.render())
+}
+
+// markdownStep is a step that is rendered in a Markdown summary.
+type markdownStep struct {
+	metadata engine.StepEventMetadata
+	planning bool
+	debug    bool
+	failed   bool
+}
+
+// markdownSummary accumulates the events of an update for rendering as Markdown.
+type markdownSummary struct {
+	op        string
+	stack     tokens.QName
+	isPreview bool
+	opts      Options
+
+	steps       []*markdownStep
+	lastStep    map[resource.URN]*markdownStep
+	diagnostics []engine.DiagEventPayload
+	violations  []engine.PolicyViolationEventPayload
+	summary     *engine.SummaryEventPayload
+}
+
+func newMarkdownSummary(op string, stack tokens.QName, isPreview bool, opts Options) *markdownSummary {
+	return &markdownSummary{
+		op:        op,
+		stack:     stack,
+		isPreview: isPreview,
+		opts:      opts,
+		lastStep:  make(map[resource.URN]*markdownStep),
+	}
+}
+
+func (s *markdownSummary) process(e engine.Event) {
+	switch e.Type {
+	case engine.ResourcePreEvent:
+		p := e.Payload.(engine.ResourcePreEventPayload)
+		if shouldShow(p.Metadata, s.opts) || isRootStack(p.Metadata) {
+			step := &markdownStep{metadata: p.Metadata, planning: p.Planning, debug: p.Debug}
+			s.steps = append(s.steps, step)
+			s.lastStep[p.Metadata.URN] = step
+		}
+	case engine.ResourceOperationFailed:
+		p := e.Payload.(engine.ResourceOperationFailedPayload)
+		if step, ok := s.lastStep[p.Metadata.URN]; ok {
+			step.failed = true
+		}
+	case engine.DiagEvent:
+		// Skip any ephemeral, debug, or informational messages.
+		p := e.Payload.(engine.DiagEventPayload)
+		if !p.Ephemeral && (p.Severity == diag.Error || p.Severity == diag.Warning) {
+			s.diagnostics = append(s.diagnostics, p)
+		}
+	case engine.PolicyViolationEvent:
+		s.violations = append(s.violations, e.Payload.(engine.PolicyViolationEventPayload))
+	case engine.SummaryEvent:
+		p := e.Payload.(engine.SummaryEventPayload)
+		s.summary = &p
+	case engine.PreludeEvent, engine.StdoutColorEvent, engine.ResourceOutputsEvent:
+		// These events do not contribute to the summary.
+	default:
+		contract.Failf("unknown event type '%s'", e.Type)
+	}
+}
+
+func (s *markdownSummary) render() string {
+	out := &bytes.Buffer{}
+
+	title := strings.ToUpper(s.op[:1]) + s.op[1:]
+	if s.stack != "" {
+		title = fmt.Sprintf("%s (%s)", title, s.stack)
+	}
+	fprintfIgnoreError(out, "### %s\n\n", title)
+
+	if s.summary != nil {
+		s.renderChanges(out)
+	}
+	if len(s.violations) > 0 {
+		s.renderViolations(out)
+	}
+	s.renderDiagnostics(out, diag.Error, "Errors")
+	s.renderDiagnostics(out, diag.Warning, "Warnings")
+	s.renderSteps(out)
+
+	return out.String()
+}
+
+// renderChanges renders the count of each kind of change made by the update.
+func (s *markdownSummary) renderChanges(out *bytes.Buffer) {
+	var pieces []string
+	for _, op := range deploy.StepOps {
+		if op == deploy.OpSame || op == deploy.OpRead || op == deploy.OpReadDiscard || op == deploy.OpReadReplacement {
+			continue
+		}
+		if c := s.summary.ResourceChanges[op]; c > 0 {
+			pieces = append(pieces, fmt.Sprintf("%d %s", c, s.opDescription(op)))
+		}
+	}
+	if c := s.summary.ResourceChanges[deploy.OpSame]; c > 0 {
+		pieces = append(pieces, fmt.Sprintf("%d unchanged", c))
+	}
+	if len(pieces) == 0 {
+		pieces = append(pieces, "no changes")
+	}
+	fprintfIgnoreError(out, "**Resources:** %s\n\n", strings.Join(pieces, ", "))
+
+	if !s.isPreview {
+		duration := time.Duration(math.Ceil(s.summary.Duration.Seconds())) * time.Second
+		fprintfIgnoreError(out, "**Duration:** %s\n\n", duration)
+	}
+}
+
+// renderViolations renders a table of the policy violations reported by the update.
+func (s *markdownSummary) renderViolations(out *bytes.Buffer) {
+	fprintfIgnoreError(out, "#### Policy violations\n\n")
+	fprintfIgnoreError(out, "| Level | Policy | Resource | Message |\n")
+	fprintfIgnoreError(out, "| --- | --- | --- | --- |\n")
+	for _, v := range s.violations {
+		resourceName := ""
+		if v.ResourceURN != "" {
+			resourceName = fmt.Sprintf("`%s`", v.ResourceURN.Name())
+		}
+		fprintfIgnoreError(out, "| **%s** | `%s@%s`: `%s` | %s | %s |\n",
+			v.EnforcementLevel, v.PolicyPackName, v.PolicyPackVersion, v.PolicyName, resourceName,
+			markdownTableCell(colors.Never.Colorize(v.Message)))
+	}
+	fprintfIgnoreError(out, "\n")
+}
+
+// renderDiagnostics renders the diagnostics reported by the update with the given severity.
+func (s *markdownSummary) renderDiagnostics(out *bytes.Buffer, severity diag.Severity, heading string) {
+	var diagnostics []engine.DiagEventPayload
+	for _, d := range s.diagnostics {
+		if d.Severity == severity {
+			diagnostics = append(diagnostics, d)
+		}
+	}
+	if len(diagnostics) == 0 {
+		return
+	}
+
+	fprintfIgnoreError(out, "#### %s\n\n", heading)
+	for _, d := range diagnostics {
+		if d.URN != "" {
+			fprintfIgnoreError(out, "`%s` **%s**\n\n", d.URN.Type(), d.URN.Name())
+		}
+		fprintfIgnoreError(out, "```\n%s\n```\n\n",
+			strings.TrimRight(colors.Never.Colorize(d.Prefix+d.Message), "\n"))
+	}
+}
+
+// renderSteps renders the update's steps, grouped by operation.
+func (s *markdownSummary) renderSteps(out *bytes.Buffer) {
+	for _, op := range deploy.StepOps {
+		var steps []*markdownStep
+		for _, step := range s.steps {
+			if step.metadata.Op == op {
+				steps = append(steps, step)
+			}
+		}
+		if len(steps) == 0 {
+			continue
+		}
+
+		heading := "Unchanged"
+		if op != deploy.OpSame {
+			heading = s.opDescription(op)
+			heading = strings.ToUpper(heading[:1]) + heading[1:]
+		}
+		fprintfIgnoreError(out, "#### %s (%d)\n\n", heading, len(steps))
+
+		for _, step := range steps {
+			urn := step.metadata.URN
+			fprintfIgnoreError(out, "- `%s` **%s**", urn.Type(), urn.Name())
+			if step.failed {
+				fprintfIgnoreError(out, " (failed)")
+			}
+			fprintfIgnoreError(out, "\n")
+
+			details := colors.Never.Colorize(
+				renderDiffDetails(step.metadata, step.planning, step.debug, 0 /*indent*/, s.opts))
+			if details = strings.TrimRight(details, "\n"); strings.TrimSpace(details) != "" {
+				fprintfIgnoreError(out, "  <details><summary>Details</summary>\n\n  ```diff\n")
+				for _, line := range strings.Split(details, "\n") {
+					fprintfIgnoreError(out, "  %s\n", line)
+				}
+				fprintfIgnoreError(out, "  ```\n\n  </details>\n")
+			}
+		}
+		fprintfIgnoreError(out, "\n")
+	}
+}
+
+// opDescription describes the given operation as a planned operation for previews, or in the past tense otherwise.
+func (s *markdownSummary) opDescription(op deploy.StepOp) string {
+	if s.isPreview {
+		return "to " + string(op)
+	}
+	return op.PastTense()
+}
+
+// markdownTableCell escapes the given text for display in a cell of a Markdown table.
+func markdownTableCell(text string) string {
+	text = strings.TrimRight(text, "\n")
+	text = strings.Replace(text, "|", "\\|", -1)
+	return strings.Replace(text, "\n", "<br>", -1)
+}
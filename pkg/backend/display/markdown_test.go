@@ -0,0 +1,110 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package display
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/apitype"
+	"github.com/pulumi/pulumi/pkg/diag"
+	"github.com/pulumi/pulumi/pkg/diag/colors"
+	"github.com/pulumi/pulumi/pkg/engine"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/resource/plugin"
+)
+
+// testUpdateEvents returns the events for an update that updates one resource, fails to create another, and reports
+// a policy violation for the failed resource.
+func testUpdateEvents(planning bool) []engine.Event {
+	stackURN := resource.NewURN("dev", "proj", "", resource.RootStackType, "proj-dev")
+	urnA := resource.NewURN("dev", "proj", "", "pkgA:m:typA", "resA")
+	urnB := resource.NewURN("dev", "proj", "", "pkgA:m:typB", "resB")
+
+	stackState := &engine.StepEventStateMetadata{Type: resource.RootStackType, URN: stackURN}
+	stack := engine.StepEventMetadata{Op: deploy.OpSame, URN: stackURN, Type: resource.RootStackType,
+		Old: stackState, New: stackState, Res: stackState}
+
+	oldA := &engine.StepEventStateMetadata{Type: urnA.Type(), URN: urnA, Custom: true,
+		Inputs: resource.PropertyMap{"name": resource.NewStringProperty("old")}}
+	newA := &engine.StepEventStateMetadata{Type: urnA.Type(), URN: urnA, Custom: true,
+		Inputs: resource.PropertyMap{"name": resource.NewStringProperty("new")}}
+	a := engine.StepEventMetadata{Op: deploy.OpUpdate, URN: urnA, Type: urnA.Type(), Old: oldA, New: newA, Res: newA,
+		Diffs:        []resource.PropertyKey{"name"},
+		DetailedDiff: map[string]plugin.PropertyDiff{"name": {Kind: plugin.DiffUpdate, InputDiff: true}},
+	}
+
+	newB := &engine.StepEventStateMetadata{Type: urnB.Type(), URN: urnB, Custom: true,
+		Inputs: resource.PropertyMap{"size": resource.NewNumberProperty(3)}}
+	b := engine.StepEventMetadata{Op: deploy.OpCreate, URN: urnB, Type: urnB.Type(), New: newB, Res: newB}
+
+	return []engine.Event{
+		{Type: engine.PreludeEvent, Payload: engine.PreludeEventPayload{IsPreview: planning}},
+		{Type: engine.ResourcePreEvent, Payload: engine.ResourcePreEventPayload{Metadata: stack, Planning: planning}},
+		{Type: engine.ResourcePreEvent, Payload: engine.ResourcePreEventPayload{Metadata: a, Planning: planning}},
+		{Type: engine.ResourceOutputsEvent, Payload: engine.ResourceOutputsEventPayload{Metadata: a, Planning: planning}},
+		{Type: engine.ResourcePreEvent, Payload: engine.ResourcePreEventPayload{Metadata: b, Planning: planning}},
+		{Type: engine.PolicyViolationEvent, Payload: engine.PolicyViolationEventPayload{
+			ResourceURN: urnB, Message: "size must be\nat most 2 | 1", Color: colors.Never, PolicyName: "max-size",
+			PolicyPackName: "sizes", PolicyPackVersion: "1", EnforcementLevel: apitype.Mandatory,
+		}},
+		{Type: engine.DiagEvent, Payload: engine.DiagEventPayload{
+			URN: urnB, Message: "creation failed\n", Color: colors.Never, Severity: diag.Error,
+		}},
+		{Type: engine.ResourceOperationFailed, Payload: engine.ResourceOperationFailedPayload{Metadata: b}},
+		{Type: engine.DiagEvent, Payload: engine.DiagEventPayload{
+			Message: "update failed\n", Color: colors.Never, Severity: diag.Error,
+		}},
+		{Type: engine.DiagEvent, Payload: engine.DiagEventPayload{
+			Message: "debugging\n", Color: colors.Never, Severity: diag.Debug,
+		}},
+		{Type: engine.ResourceOutputsEvent, Payload: engine.ResourceOutputsEventPayload{
+			Metadata: stack, Planning: planning,
+		}},
+		{Type: engine.SummaryEvent, Payload: engine.SummaryEventPayload{
+			IsPreview:       planning,
+			ResourceChanges: engine.ResourceChanges{deploy.OpUpdate: 1, deploy.OpSame: 1},
+		}},
+	}
+}
+
+func TestMarkdownSummary(t *testing.T) {
+	summary := newMarkdownSummary("update", "dev", false, Options{Color: colors.Never})
+	for _, e := range testUpdateEvents(false) {
+		summary.process(e)
+	}
+	out := summary.render()
+
+	assert.Contains(t, out, "### Update (dev)\n\n**Resources:** 1 updated, 1 unchanged\n\n**Duration:** 0s\n\n")
+	assert.Contains(t, out, "| **mandatory** | `sizes@1`: `max-size` | `resB` | size must be<br>at most 2 \\| 1 |\n")
+	assert.Contains(t, out, "#### Errors\n\n`pkgA:m:typB` **resB**\n\n```\ncreation failed\n```\n\n"+
+		"```\nupdate failed\n```\n\n")
+	assert.NotContains(t, out, "debugging")
+
+	assert.Contains(t, out, "#### Unchanged (1)\n\n- `pulumi:pulumi:Stack` **proj-dev**\n")
+	assert.Contains(t, out, "#### Created (1)\n\n- `pkgA:m:typB` **resB** (failed)\n")
+	assert.Contains(t, out, "#### Updated (1)\n\n- `pkgA:m:typA` **resA**\n  <details><summary>Details</summary>\n")
+	assert.Contains(t, out, `name: "old" => "new"`)
+}
+
+func TestMarkdownPreviewSummary(t *testing.T) {
+	summary := newMarkdownSummary("preview", "", true, Options{Color: colors.Never})
+	summary.process(engine.Event{Type: engine.SummaryEvent, Payload: engine.SummaryEventPayload{IsPreview: true}})
+	out := summary.render()
+
+	assert.Equal(t, "### Preview\n\n**Resources:** no changes\n\n", out)
+}
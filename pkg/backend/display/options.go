@@ -14,7 +14,10 @@
 
 package display
 
-import "github.com/pulumi/pulumi/pkg/diag/colors"
+import (
+	"github.com/pulumi/pulumi/pkg/diag/colors"
+	"github.com/pulumi/pulumi/pkg/engine"
+)
 
 // Type of output to display.
 type Type int
@@ -28,6 +31,12 @@ const (
 	DisplayQuery
 	// DisplayQuery displays query output.
 	DisplayWatch
+	// DisplayNone displays nothing. This is useful when events are consumed programmatically via Options.Events.
+	DisplayNone
+	// DisplayMarkdown displays a Markdown summary of an update once it completes.
+	DisplayMarkdown
+	// DisplayJUnit displays a JUnit XML report of an update once it completes, with a test case per resource step.
+	DisplayJUnit
 )
 
 // Options controls how the output of events are rendered
@@ -43,5 +52,7 @@ type Options struct {
 	Type                 Type                // type of display (rich diff, progress, or query).
 	JSONDisplay          bool                // true if we should emit the entire diff as JSON.
 	EventLogPath         string              // the path to the file to use for logging events, if any.
+	DriftReport          *DriftReport        // the report in which to record drift observed by a refresh, if any.
+	Events               chan<- engine.Event // the channel to which to forward events as they are displayed, if any.
 	Debug                bool                // true to enable debug output.
 }
@@ -0,0 +1,133 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package display
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/apitype"
+	"github.com/pulumi/pulumi/pkg/engine"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/tokens"
+	"github.com/pulumi/pulumi/pkg/util/logging"
+)
+
+// EventLog holds the events recorded in the file named by Options.EventLogPath.
+type EventLog struct {
+	Events     []engine.Event     // the logged events, ending with a CancelEvent.
+	Timestamps []time.Time        // the time at which each event was logged.
+	IsPreview  bool               // true if the events were logged by a preview.
+	Stack      tokens.QName       // the name of the stack the events were logged for, if known.
+	Project    tokens.PackageName // the name of the project the events were logged for, if known.
+}
+
+// ReadEventLog reads an event log written by a previous update. A log that was cut short, e.g. because the update
+// was interrupted, is read up to its last complete event.
+func ReadEventLog(r io.Reader) (*EventLog, error) {
+	log := &EventLog{}
+	var urn resource.URN
+
+	decoder := json.NewDecoder(r)
+	for {
+		var apiEvent apitype.EngineEvent
+		if err := decoder.Decode(&apiEvent); err == io.EOF {
+			break
+		} else if err == io.ErrUnexpectedEOF {
+			logging.V(7).Infof("event log truncated after %d events", len(log.Events))
+			break
+		} else if err != nil {
+			return nil, errors.Wrapf(err, "reading event %d", len(log.Events))
+		}
+
+		e, err := ConvertJSONEvent(apiEvent)
+		if err != nil {
+			return nil, errors.Wrapf(err, "converting event %d", len(log.Events))
+		}
+		log.Events = append(log.Events, e)
+		log.Timestamps = append(log.Timestamps, time.Unix(int64(apiEvent.Timestamp), 0))
+
+		if e.Type == engine.CancelEvent {
+			break
+		}
+
+		// The log does not record the kind of the operation or the stack it targeted, so we infer them from the
+		// resource events: these are only marked as planning during previews.
+		var md engine.StepEventMetadata
+		switch p := e.Payload.(type) {
+		case engine.ResourcePreEventPayload:
+			md, log.IsPreview = p.Metadata, log.IsPreview || p.Planning
+		case engine.ResourceOutputsEventPayload:
+			md, log.IsPreview = p.Metadata, log.IsPreview || p.Planning
+		case engine.ResourceOperationFailedPayload:
+			md = p.Metadata
+		}
+		if urn == "" && md.URN.IsValid() {
+			urn = md.URN
+		}
+	}
+
+	// The renderers expect the event stream to end with a CancelEvent.
+	if n := len(log.Events); n == 0 || log.Events[n-1].Type != engine.CancelEvent {
+		last := time.Unix(0, 0)
+		if n > 0 {
+			last = log.Timestamps[n-1]
+		}
+		log.Events = append(log.Events, engine.Event{Type: engine.CancelEvent})
+		log.Timestamps = append(log.Timestamps, last)
+	}
+
+	// Finally, mark the prelude and summary with the kind of the operation.
+	for i, e := range log.Events {
+		switch p := e.Payload.(type) {
+		case engine.PreludeEventPayload:
+			p.IsPreview = log.IsPreview
+			log.Events[i].Payload = p
+		case engine.SummaryEventPayload:
+			p.IsPreview = log.IsPreview
+			log.Events[i].Payload = p
+		}
+	}
+
+	if urn != "" {
+		log.Stack, log.Project = urn.Stack(), urn.Project()
+	}
+	return log, nil
+}
+
+// ReplayEvents displays the events in an event log using the display selected by the given options. If speed is
+// positive, the delay between each pair of events is the delay between their original occurrence divided by speed;
+// otherwise, the events are displayed without delay. Events are logged with a resolution of one second.
+func ReplayEvents(op string, action apitype.UpdateKind, log *EventLog, opts Options, speed float64) {
+	events, done := make(chan engine.Event), make(chan bool)
+	if opts.JSONDisplay {
+		go ShowJSONEvents(op, action, events, done, opts)
+	} else {
+		go ShowEvents(op, action, log.Stack, log.Project, events, done, opts, log.IsPreview)
+	}
+
+	for i, e := range log.Events {
+		if speed > 0 && i > 0 {
+			if delay := log.Timestamps[i].Sub(log.Timestamps[i-1]); delay > 0 {
+				time.Sleep(time.Duration(float64(delay) / speed))
+			}
+		}
+		events <- e
+	}
+	<-done
+}
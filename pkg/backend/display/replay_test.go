@@ -0,0 +1,184 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package display
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/apitype"
+	"github.com/pulumi/pulumi/pkg/diag"
+	"github.com/pulumi/pulumi/pkg/diag/colors"
+	"github.com/pulumi/pulumi/pkg/engine"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/resource/plugin"
+)
+
+// logEvents writes the given events to an event log in a temporary directory and returns the log's contents.
+func logEvents(t *testing.T, events []engine.Event) []byte {
+	dir, err := ioutil.TempDir("", "event-log")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "events.json")
+
+	in, done := make(chan engine.Event), make(chan bool)
+	out, outDone := startEventLogger(in, done, path)
+	go func() {
+		for e := range out {
+			if e.Type == engine.CancelEvent {
+				break
+			}
+		}
+		close(outDone)
+	}()
+	for _, e := range events {
+		in <- e
+	}
+	<-done
+
+	b, err := ioutil.ReadFile(path)
+	assert.NoError(t, err)
+	return b
+}
+
+func TestEventLogRoundTrip(t *testing.T) {
+	urn := resource.NewURN("stack", "proj", "", "pkgA:m:typA", "resA")
+	inputs := resource.PropertyMap{
+		"name":    resource.NewStringProperty("a"),
+		"size":    resource.NewNumberProperty(1),
+		"enabled": resource.NewBoolProperty(true),
+		"tags": resource.NewObjectProperty(resource.PropertyMap{
+			"owner": resource.NewStringProperty("me"),
+		}),
+		"ports": resource.NewArrayProperty([]resource.PropertyValue{resource.NewNumberProperty(80)}),
+		"id":    resource.MakeComputed(resource.NewStringProperty("")),
+		"code":  resource.NewAssetProperty(&resource.Asset{Sig: resource.AssetSig, Hash: "abc", Path: "index.js"}),
+		"none":  resource.NewNullProperty(),
+	}
+	stateMetadata := &engine.StepEventStateMetadata{
+		Type:   urn.Type(),
+		URN:    urn,
+		Custom: true,
+		Inputs: inputs,
+	}
+	metadata := engine.StepEventMetadata{
+		Op:    deploy.OpUpdate,
+		URN:   urn,
+		Type:  urn.Type(),
+		Old:   stateMetadata,
+		New:   stateMetadata,
+		Diffs: []resource.PropertyKey{"name"},
+		DetailedDiff: map[string]plugin.PropertyDiff{
+			"name": {Kind: plugin.DiffUpdateReplace, InputDiff: true},
+		},
+		Provider: "urn:pulumi:stack::proj::pulumi:providers:pkgA::default::id",
+	}
+
+	events := []engine.Event{
+		{Type: engine.PreludeEvent, Payload: engine.PreludeEventPayload{Config: map[string]string{"a": "b"}}},
+		{Type: engine.ResourcePreEvent, Payload: engine.ResourcePreEventPayload{Metadata: metadata, Planning: true}},
+		{Type: engine.DiagEvent, Payload: engine.DiagEventPayload{
+			URN: urn, Message: "hello", Color: colors.Never, Severity: diag.Warning,
+		}},
+		{Type: engine.ResourceOutputsEvent, Payload: engine.ResourceOutputsEventPayload{
+			Metadata: metadata, Planning: true,
+		}},
+		{Type: engine.SummaryEvent, Payload: engine.SummaryEventPayload{
+			ResourceChanges: engine.ResourceChanges{deploy.OpUpdate: 1},
+		}},
+		{Type: engine.CancelEvent},
+	}
+
+	log, err := ReadEventLog(bytes.NewReader(logEvents(t, events)))
+	assert.NoError(t, err)
+	assert.True(t, log.IsPreview)
+	assert.Equal(t, urn.Stack(), log.Stack)
+	assert.Equal(t, urn.Project(), log.Project)
+	assert.Len(t, log.Timestamps, len(events))
+	if !assert.Len(t, log.Events, len(events)) {
+		return
+	}
+
+	for i, e := range log.Events {
+		assert.Equal(t, events[i].Type, e.Type)
+	}
+	assert.Equal(t, engine.PreludeEventPayload{IsPreview: true, Config: map[string]string{"a": "b"}},
+		log.Events[0].Payload)
+	assert.Equal(t, events[2].Payload, log.Events[2].Payload)
+	assert.Equal(t, engine.SummaryEventPayload{
+		IsPreview:       true,
+		ResourceChanges: engine.ResourceChanges{deploy.OpUpdate: 1},
+	}, log.Events[4].Payload)
+
+	md := log.Events[1].Payload.(engine.ResourcePreEventPayload).Metadata
+	assert.Equal(t, metadata.Op, md.Op)
+	assert.Equal(t, metadata.URN, md.URN)
+	assert.Equal(t, metadata.Diffs, md.Diffs)
+	assert.Equal(t, metadata.DetailedDiff, md.DetailedDiff)
+	assert.Equal(t, metadata.Provider, md.Provider)
+	assert.True(t, md.New == md.Res)
+	if assert.NotNil(t, md.New) && assert.NotNil(t, md.New.State) {
+		assert.True(t, md.New.Custom)
+		assert.Equal(t, md.New.Inputs, md.New.State.Inputs)
+
+		// Assets lose their signatures when they are read back.
+		expected := inputs.Copy()
+		expected["code"] = resource.NewAssetProperty(&resource.Asset{Hash: "abc", Path: "index.js"})
+		assert.Equal(t, expected, md.New.Inputs)
+	}
+}
+
+func TestReadTruncatedEventLog(t *testing.T) {
+	events := []engine.Event{
+		{Type: engine.StdoutColorEvent, Payload: engine.StdoutEventPayload{Message: "one", Color: colors.Never}},
+		{Type: engine.StdoutColorEvent, Payload: engine.StdoutEventPayload{Message: "two", Color: colors.Never}},
+		{Type: engine.CancelEvent},
+	}
+	b := logEvents(t, events)
+
+	// Cut the log off in the middle of the second event.
+	first := bytes.IndexByte(b, '\n') + 1
+	log, err := ReadEventLog(bytes.NewReader(b[:first+10]))
+	assert.NoError(t, err)
+	assert.False(t, log.IsPreview)
+	assert.Equal(t, []engine.Event{events[0], {Type: engine.CancelEvent}}, log.Events)
+	assert.Len(t, log.Timestamps, 2)
+
+	// Malformed events are errors.
+	_, err = ReadEventLog(bytes.NewReader([]byte("{}\n")))
+	assert.Error(t, err)
+}
+
+func TestReplayEvents(t *testing.T) {
+	event := engine.Event{
+		Type:    engine.StdoutColorEvent,
+		Payload: engine.StdoutEventPayload{Message: "one", Color: colors.Never},
+	}
+	log := &EventLog{
+		Events:     []engine.Event{event, {Type: engine.CancelEvent}},
+		Timestamps: []time.Time{time.Unix(0, 0), time.Unix(0, 0)},
+	}
+
+	forwarded := make(chan engine.Event, 1)
+	ReplayEvents("update", apitype.UpdateUpdate, log, Options{Type: DisplayNone, Events: forwarded}, 1)
+	assert.Equal(t, event, <-forwarded)
+}
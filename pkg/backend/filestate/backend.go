@@ -27,6 +27,7 @@ import (
 	"time"
 
 	"github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
 	"gocloud.dev/blob"
 	_ "gocloud.dev/blob/azureblob" // driver for azblob://
 	_ "gocloud.dev/blob/fileblob"  // driver for file://
@@ -57,7 +58,10 @@ import (
 // Backend extends the base backend interface with specific information about local backends.
 type Backend interface {
 	backend.Backend
-	local() // at the moment, no local specific info, so just use a marker function.
+	local() // a marker function, distinguishing local backends from other backends.
+
+	// CancelCurrentUpdate forcibly removes any locks held on the given stack.
+	CancelCurrentUpdate(ctx context.Context, stackRef backend.StackReference) error
 }
 
 type localBackend struct {
@@ -70,6 +74,10 @@ type localBackend struct {
 	url         string
 
 	bucket Bucket
+
+	// lockID uniquely identifies the locks taken by this backend, so that it can tell them apart from those taken by
+	// other processes.
+	lockID string
 }
 
 type localBackendReference struct {
@@ -131,6 +139,7 @@ func New(d diag.Sink, originalURL string) (Backend, error) {
 		originalURL: originalURL,
 		url:         u,
 		bucket:      &wrappedBucket{bucket: bucket},
+		lockID:      uuid.NewV4().String(),
 	}, nil
 }
 
@@ -297,6 +306,11 @@ func (b *localBackend) ListStacks(
 
 func (b *localBackend) RemoveStack(ctx context.Context, stack backend.Stack, force bool) (bool, error) {
 	stackName := stack.Ref().Name()
+	if err := b.Lock(ctx, stackName, "remove"); err != nil {
+		return false, err
+	}
+	defer b.Unlock(ctx, stackName)
+
 	snapshot, _, err := b.getStack(stackName)
 	if err != nil {
 		return false, err
@@ -312,6 +326,11 @@ func (b *localBackend) RemoveStack(ctx context.Context, stack backend.Stack, for
 
 func (b *localBackend) RenameStack(ctx context.Context, stack backend.Stack, newName tokens.QName) error {
 	stackName := stack.Ref().Name()
+	if err := b.Lock(ctx, stackName, "rename"); err != nil {
+		return err
+	}
+	defer b.Unlock(ctx, stackName)
+
 	snap, _, err := b.getStack(stackName)
 	if err != nil {
 		return err
@@ -393,6 +412,11 @@ func (b *localBackend) Destroy(ctx context.Context, stack backend.Stack,
 	return backend.PreviewThenPromptThenExecute(ctx, apitype.DestroyUpdate, stack, op, b.apply)
 }
 
+func (b *localBackend) Import(ctx context.Context, stack backend.Stack,
+	op backend.UpdateOperation) (engine.ResourceChanges, result.Result) {
+	return backend.PreviewThenPromptThenExecute(ctx, apitype.ResourceImportUpdate, stack, op, b.apply)
+}
+
 func (b *localBackend) Query(ctx context.Context, op backend.QueryOperation) result.Result {
 
 	return b.query(ctx, op, nil /*events*/)
@@ -413,12 +437,23 @@ func (b *localBackend) apply(
 	stackName := stackRef.Name()
 	actionLabel := backend.ActionLabel(kind, opts.DryRun)
 
-	if !(op.Opts.Display.JSONDisplay || op.Opts.Display.Type == display.DisplayWatch) {
+	if !(op.Opts.Display.JSONDisplay || op.Opts.Display.Type == display.DisplayWatch ||
+		op.Opts.Display.Type == display.DisplayNone || op.Opts.Display.Type == display.DisplayMarkdown ||
+		op.Opts.Display.Type == display.DisplayJUnit) {
 		// Print a banner so it's clear this is a local deployment.
 		fmt.Printf(op.Opts.Display.Color.Colorize(
 			colors.SpecHeadline+"%s (%s):"+colors.Reset+"\n"), actionLabel, stackRef)
 	}
 
+	// Lock the stack for the duration of any operation that may modify it. Previews only read the stack, and so do
+	// not need to hold the lock.
+	if !opts.DryRun {
+		if err := b.Lock(ctx, stackName, string(kind)); err != nil {
+			return nil, result.FromError(err)
+		}
+		defer b.Unlock(ctx, stackName)
+	}
+
 	// Start the update.
 	update, err := b.newUpdate(stackName, op)
 	if err != nil {
@@ -474,6 +509,8 @@ func (b *localBackend) apply(
 		changes, updateRes = engine.Refresh(update, engineCtx, op.Opts.Engine, opts.DryRun)
 	case apitype.DestroyUpdate:
 		changes, updateRes = engine.Destroy(update, engineCtx, op.Opts.Engine, opts.DryRun)
+	case apitype.ResourceImportUpdate:
+		changes, updateRes = engine.Import(update, engineCtx, op.Opts.Engine, op.Imports, opts.DryRun)
 	default:
 		contract.Failf("Unrecognized update kind: %s", kind)
 	}
@@ -530,7 +567,8 @@ func (b *localBackend) apply(
 	}
 
 	// Make sure to print a link to the stack's checkpoint before exiting.
-	if opts.ShowLink && !op.Opts.Display.JSONDisplay {
+	if opts.ShowLink && !op.Opts.Display.JSONDisplay && op.Opts.Display.Type != display.DisplayNone &&
+		op.Opts.Display.Type != display.DisplayMarkdown && op.Opts.Display.Type != display.DisplayJUnit {
 		// Note we get a real signed link for aws/azure/gcp links.  But no such option exists for
 		// file:// links so we manually create the link ourselves.
 		var link string
@@ -633,6 +671,11 @@ func (b *localBackend) ImportDeployment(ctx context.Context, stk backend.Stack,
 	deployment *apitype.UntypedDeployment) error {
 
 	stackName := stk.Ref().Name()
+	if err := b.Lock(ctx, stackName, "import"); err != nil {
+		return err
+	}
+	defer b.Unlock(ctx, stackName)
+
 	_, _, err := b.getStack(stackName)
 	if err != nil {
 		return err
@@ -647,6 +690,92 @@ func (b *localBackend) ImportDeployment(ctx context.Context, stk backend.Stack,
 	return err
 }
 
+func (b *localBackend) ExportDeploymentForVersion(ctx context.Context, stk backend.Stack,
+	version int) (*apitype.UntypedDeployment, error) {
+
+	update, err := backend.GetHistoryVersion(ctx, stk, version)
+	if err != nil {
+		return nil, err
+	}
+	return b.exportUpdateCheckpoint(ctx, update)
+}
+
+// exportUpdateCheckpoint exports the deployment in the checkpoint written by the given update.
+func (b *localBackend) exportUpdateCheckpoint(ctx context.Context,
+	update backend.UpdateInfo) (*apitype.UntypedDeployment, error) {
+
+	if update.Checkpoint == "" {
+		return nil, backend.ErrNoCheckpoint
+	}
+
+	bytes, err := b.bucket.ReadAll(ctx, update.Checkpoint)
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading checkpoint file %s", update.Checkpoint)
+	}
+	chk, err := stack.UnmarshalVersionedCheckpointToLatestCheckpoint(bytes)
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading checkpoint file %s", update.Checkpoint)
+	}
+
+	var deployment apitype.DeploymentV3
+	if chk.Latest != nil {
+		deployment = *chk.Latest
+	}
+	data, err := json.Marshal(deployment)
+	if err != nil {
+		return nil, err
+	}
+
+	return &apitype.UntypedDeployment{
+		Version:    3,
+		Deployment: json.RawMessage(data),
+	}, nil
+}
+
+func (b *localBackend) RollbackDeployment(ctx context.Context, stk backend.Stack, version int) error {
+	stackName := stk.Ref().Name()
+	if err := b.Lock(ctx, stackName, string(apitype.RollbackUpdate)); err != nil {
+		return err
+	}
+	defer b.Unlock(ctx, stackName)
+
+	update, err := backend.GetHistoryVersion(ctx, stk, version)
+	if err != nil {
+		return err
+	}
+	deployment, err := b.exportUpdateCheckpoint(ctx, update)
+	if err != nil {
+		return err
+	}
+	snap, err := backend.DeserializeRollbackTarget(deployment)
+	if err != nil {
+		return err
+	}
+
+	start := time.Now().Unix()
+	if _, err = b.saveStack(stackName, snap, snap.SecretsManager); err != nil {
+		return err
+	}
+
+	// Record the rollback in the stack's history, along with the configuration used by the restored update.
+	info := backend.UpdateInfo{
+		Kind:        apitype.RollbackUpdate,
+		StartTime:   start,
+		Message:     fmt.Sprintf("Rolled back to version %d", version),
+		Environment: make(map[string]string),
+		Config:      update.Config,
+		Result:      backend.SucceededResult,
+		EndTime:     time.Now().Unix(),
+	}
+	if err = b.addToHistory(stackName, info); err != nil {
+		return errors.Wrap(err, "saving update info")
+	}
+	if err = b.backupStack(stackName); err != nil {
+		return errors.Wrap(err, "saving backup")
+	}
+	return nil
+}
+
 func (b *localBackend) Logout() error {
 	return workspace.DeleteAccount(b.originalURL)
 }
@@ -0,0 +1,176 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filestate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/backend"
+	"github.com/pulumi/pulumi/pkg/diag"
+	"github.com/pulumi/pulumi/pkg/tokens"
+	"github.com/pulumi/pulumi/pkg/util/contract"
+	"github.com/pulumi/pulumi/pkg/util/fsutil"
+	"github.com/pulumi/pulumi/pkg/util/logging"
+	"github.com/pulumi/pulumi/pkg/workspace"
+)
+
+// lockContent is the information recorded in a stack's lock file. It describes the process that holds the lock so
+// that users can tell who is operating on a stack, and so that locks left behind by dead processes can be detected.
+type lockContent struct {
+	Pid       int       `json:"pid"`
+	Username  string    `json:"username"`
+	Hostname  string    `json:"hostname"`
+	Timestamp time.Time `json:"timestamp"`
+	Operation string    `json:"operation"`
+}
+
+func newLockContent(operation string) (*lockContent, error) {
+	u, err := user.Current()
+	if err != nil {
+		return nil, err
+	}
+	hostname, err := os.Hostname()
+	if err != nil {
+		return nil, err
+	}
+	return &lockContent{
+		Pid:       os.Getpid(),
+		Username:  u.Username,
+		Hostname:  hostname,
+		Timestamp: time.Now(),
+		Operation: operation,
+	}, nil
+}
+
+// isStale returns true if the lock is known to have been abandoned, i.e. it was taken by a process on this machine
+// that is no longer running. Locks taken on other machines are never considered stale, as we have no way of telling
+// whether their owners are still alive.
+func (l *lockContent) isStale() bool {
+	hostname, err := os.Hostname()
+	if err != nil || hostname != l.Hostname {
+		return false
+	}
+	return !processExists(l.Pid)
+}
+
+func (l *lockContent) String() string {
+	return fmt.Sprintf("%s by %s@%s (pid %d) at %s", l.Operation, l.Username, l.Hostname, l.Pid,
+		l.Timestamp.Format(time.RFC3339))
+}
+
+func (b *localBackend) lockDirectory(stack tokens.QName) string {
+	contract.Require(stack != "", "stack")
+	return filepath.Join(b.StateDir(), workspace.LockDir, fsutil.QnamePath(stack))
+}
+
+func (b *localBackend) lockPath(stack tokens.QName) string {
+	return filepath.Join(b.lockDirectory(stack), b.lockID+".json")
+}
+
+// Lock acquires the lock for the given stack on behalf of this backend, failing if any other process holds it. The
+// operation describes what the lock is being taken for and is reported to anyone else that attempts to take it.
+func (b *localBackend) Lock(ctx context.Context, stackName tokens.QName, operation string) error {
+	// Check before writing our lock so that we don't disturb another process that already holds one.
+	if err := b.checkForLock(ctx, stackName); err != nil {
+		return err
+	}
+
+	content, err := newLockContent(operation)
+	if err != nil {
+		return errors.Wrap(err, "gathering lock information")
+	}
+	byts, err := json.Marshal(content)
+	if err != nil {
+		return err
+	}
+	if err = b.bucket.WriteAll(ctx, b.lockPath(stackName), byts, nil); err != nil {
+		return errors.Wrap(err, "writing lock")
+	}
+
+	// Bucket writes are not exclusive, so another process may have taken the lock at the same time that we did.
+	// Check again now that our lock is visible to others, and back off if so.
+	if err = b.checkForLock(ctx, stackName); err != nil {
+		b.Unlock(ctx, stackName)
+		return err
+	}
+	return nil
+}
+
+// Unlock releases this backend's lock on the given stack, if it holds one.
+func (b *localBackend) Unlock(ctx context.Context, stackName tokens.QName) {
+	if err := b.bucket.Delete(ctx, b.lockPath(stackName)); err != nil {
+		logging.V(5).Infof("error deleting lock %v (%v) skipping", b.lockPath(stackName), err)
+	}
+}
+
+// CancelCurrentUpdate forcibly removes every lock held on the given stack, regardless of its owner.
+func (b *localBackend) CancelCurrentUpdate(ctx context.Context, stackRef backend.StackReference) error {
+	return removeAllByPrefix(b.bucket, b.lockDirectory(stackRef.Name()))
+}
+
+// checkForLock returns an error if a process other than this one holds a lock on the given stack. Stale locks are
+// removed along the way.
+func (b *localBackend) checkForLock(ctx context.Context, stackName tokens.QName) error {
+	files, err := listBucket(b.bucket, b.lockDirectory(stackName))
+	if err != nil {
+		return err
+	}
+
+	ours := filepath.ToSlash(b.lockPath(stackName))
+
+	var holders []string
+	for _, file := range files {
+		if file.IsDir || file.Key == ours {
+			continue
+		}
+
+		byts, err := b.bucket.ReadAll(ctx, file.Key)
+		if err != nil {
+			return errors.Wrapf(err, "reading lock %v", file.Key)
+		}
+		var content lockContent
+		if err = json.Unmarshal(byts, &content); err != nil {
+			// A lock we can't read is still a lock: it may simply be mid-write.
+			holders = append(holders, fmt.Sprintf("unreadable lock %v", objectName(file)))
+			continue
+		}
+
+		if content.isStale() {
+			b.d.Warningf(diag.Message("", "removing stale lock on stack '%s' held by %s"), stackName, &content)
+			if err = b.bucket.Delete(ctx, file.Key); err != nil {
+				return errors.Wrapf(err, "removing stale lock %v", file.Key)
+			}
+			continue
+		}
+
+		holders = append(holders, content.String())
+	}
+
+	if len(holders) != 0 {
+		return errors.Errorf("the stack '%s' is currently locked (%s); wait for the operation to complete, or run "+
+			"`pulumi cancel` to forcibly remove the lock if you are sure that it is no longer in use",
+			stackName, strings.Join(holders, "; "))
+	}
+	return nil
+}
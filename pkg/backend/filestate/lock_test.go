@@ -0,0 +1,102 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filestate
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/tokens"
+	"github.com/pulumi/pulumi/pkg/util/cmdutil"
+)
+
+func newTestLocalBackend(t *testing.T, dir string) *localBackend {
+	b, err := New(cmdutil.Diag(), FilePathPrefix+filepath.ToSlash(dir))
+	assert.NoError(t, err)
+	return b.(*localBackend)
+}
+
+func TestLock(t *testing.T) {
+	dir, err := ioutil.TempDir("", "filestate-lock")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	ctx := context.Background()
+	stackName := tokens.QName("dev")
+	b1, b2 := newTestLocalBackend(t, dir), newTestLocalBackend(t, dir)
+
+	// Only one backend may hold the lock at a time.
+	assert.NoError(t, b1.Lock(ctx, stackName, "update"))
+	assert.Error(t, b2.Lock(ctx, stackName, "update"))
+
+	// Locks are per-stack.
+	assert.NoError(t, b2.Lock(ctx, "prod", "update"))
+	b2.Unlock(ctx, "prod")
+
+	// Once the lock is released, it may be taken by someone else.
+	b1.Unlock(ctx, stackName)
+	assert.NoError(t, b2.Lock(ctx, stackName, "update"))
+
+	// Cancelling forcibly removes the lock.
+	assert.NoError(t, b1.CancelCurrentUpdate(ctx, localBackendReference{name: stackName}))
+	assert.NoError(t, b1.Lock(ctx, stackName, "update"))
+	b1.Unlock(ctx, stackName)
+}
+
+func TestStaleLock(t *testing.T) {
+	dir, err := ioutil.TempDir("", "filestate-lock")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	ctx := context.Background()
+	stackName := tokens.QName("dev")
+	b1, b2 := newTestLocalBackend(t, dir), newTestLocalBackend(t, dir)
+
+	// Write a lock on behalf of a process on this machine that is not running.
+	hostname, err := os.Hostname()
+	assert.NoError(t, err)
+	byts, err := json.Marshal(&lockContent{
+		Pid:       1<<31 - 1,
+		Username:  "someone",
+		Hostname:  hostname,
+		Timestamp: time.Now(),
+		Operation: "update",
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, b1.bucket.WriteAll(ctx, b1.lockPath(stackName), byts, nil))
+
+	// The stale lock should be removed rather than blocking the new one.
+	assert.NoError(t, b2.Lock(ctx, stackName, "update"))
+	b2.Unlock(ctx, stackName)
+
+	// A lock from another machine is never considered stale.
+	byts, err = json.Marshal(&lockContent{
+		Pid:       1<<31 - 1,
+		Username:  "someone",
+		Hostname:  hostname + "-elsewhere",
+		Timestamp: time.Now(),
+		Operation: "update",
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, b1.bucket.WriteAll(ctx, b1.lockPath(stackName), byts, nil))
+	assert.Error(t, b2.Lock(ctx, stackName, "update"))
+}
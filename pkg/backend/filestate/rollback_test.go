@@ -0,0 +1,128 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filestate
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/apitype"
+	"github.com/pulumi/pulumi/pkg/backend"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/config"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/secrets/b64"
+	"github.com/pulumi/pulumi/pkg/tokens"
+)
+
+// newRollbackTestStack creates a stack with two updates: the first creates resource a with config value "one", and
+// the second creates resource b with config value "two".
+func newRollbackTestStack(t *testing.T, b *localBackend) backend.Stack {
+	ctx := context.Background()
+	ref, err := b.ParseStackReference("dev")
+	assert.NoError(t, err)
+	s, err := b.CreateStack(ctx, ref, nil)
+	assert.NoError(t, err)
+
+	sm := b64.NewBase64SecretsManager()
+	var resources []*resource.State
+	for _, name := range []string{"a", "b"} {
+		urn := resource.NewURN("dev", "proj", "", "pkgA:m:typA", tokens.QName(name))
+		resources = append(resources, &resource.State{URN: urn, Type: urn.Type(), Custom: true, ID: "id"})
+		snap := deploy.NewSnapshot(deploy.Manifest{Time: time.Now()}, sm, resources, nil)
+		_, err = b.saveStack("dev", snap, sm)
+		assert.NoError(t, err)
+
+		value := map[string]string{"a": "one", "b": "two"}[name]
+		assert.NoError(t, b.addToHistory("dev", backend.UpdateInfo{
+			Kind:   apitype.UpdateUpdate,
+			Config: config.Map{config.MustMakeKey("proj", "value"): config.NewValue(value)},
+			Result: backend.SucceededResult,
+		}))
+	}
+	return s
+}
+
+// currentResources returns the names of the resources in the stack's current state.
+func currentResources(t *testing.T, b *localBackend) []string {
+	snap, _, err := b.getStack("dev")
+	assert.NoError(t, err)
+	var names []string
+	for _, res := range snap.Resources {
+		names = append(names, string(res.URN.Name()))
+	}
+	return names
+}
+
+func TestRollbackDeployment(t *testing.T) {
+	dir, err := ioutil.TempDir("", "filestate-rollback")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	ctx := context.Background()
+	b := newTestLocalBackend(t, dir)
+	s := newRollbackTestStack(t, b)
+	assert.Equal(t, []string{"a", "b"}, currentResources(t, b))
+
+	assert.NoError(t, b.RollbackDeployment(ctx, s, 1))
+	assert.Equal(t, []string{"a"}, currentResources(t, b))
+
+	// The rollback is recorded as a new update with the configuration of the restored one.
+	updates, err := b.GetHistory(ctx, s.Ref())
+	assert.NoError(t, err)
+	if assert.Len(t, updates, 3) {
+		assert.Equal(t, 3, updates[0].Version)
+		assert.Equal(t, apitype.RollbackUpdate, updates[0].Kind)
+		assert.Equal(t, "Rolled back to version 1", updates[0].Message)
+		assert.Equal(t, config.NewValue("one"), updates[0].Config[config.MustMakeKey("proj", "value")])
+	}
+
+	// The rollback itself may be rolled back to.
+	assert.NoError(t, b.RollbackDeployment(ctx, s, 2))
+	assert.Equal(t, []string{"a", "b"}, currentResources(t, b))
+}
+
+func TestRollbackDeploymentErrors(t *testing.T) {
+	dir, err := ioutil.TempDir("", "filestate-rollback")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	ctx := context.Background()
+	b := newTestLocalBackend(t, dir)
+	s := newRollbackTestStack(t, b)
+
+	// Versions that don't exist are rejected.
+	err = b.RollbackDeployment(ctx, s, 3)
+	assert.EqualError(t, err, "stack 'dev' has no update with version 3")
+
+	// So are versions whose checkpoints were not retained.
+	updates, err := b.GetHistory(ctx, s.Ref())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, updates[1].Version)
+	assert.NoError(t, b.bucket.Delete(ctx, updates[1].Checkpoint))
+	err = b.RollbackDeployment(ctx, s, 1)
+	assert.Equal(t, backend.ErrNoCheckpoint, err)
+
+	// Neither failed rollback changes the stack's state or history.
+	assert.Equal(t, []string{"a", "b"}, currentResources(t, b))
+	updates, err = b.GetHistory(ctx, s.Ref())
+	assert.NoError(t, err)
+	assert.Len(t, updates, 2)
+}
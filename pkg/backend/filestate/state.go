@@ -295,6 +295,14 @@ func (b *localBackend) getHistory(name tokens.QName) ([]backend.UpdateInfo, erro
 		return nil, err
 	}
 
+	// Note which checkpoints were retained so that we can refer to them from their updates.
+	checkpoints := make(map[string]bool)
+	for _, file := range allFiles {
+		if strings.HasSuffix(file.Key, ".checkpoint.json") {
+			checkpoints[file.Key] = true
+		}
+	}
+
 	var updates []backend.UpdateInfo
 
 	// listBucket returns the array sorted by file name, but because of how we name files, older updates come before
@@ -318,9 +326,19 @@ func (b *localBackend) getHistory(name tokens.QName) ([]backend.UpdateInfo, erro
 			return nil, errors.Wrapf(err, "reading history file %s", filepath)
 		}
 
+		checkpoint := strings.TrimSuffix(filepath, ".history.json") + ".checkpoint.json"
+		if checkpoints[checkpoint] {
+			update.Checkpoint = checkpoint
+		}
+
 		updates = append(updates, update)
 	}
 
+	// Number the updates in the order in which they occurred.
+	for i := range updates {
+		updates[i].Version = len(updates) - i
+	}
+
 	return updates, nil
 }
 
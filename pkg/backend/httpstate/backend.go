@@ -717,6 +717,11 @@ func (b *cloudBackend) Destroy(ctx context.Context, stack backend.Stack,
 	return backend.PreviewThenPromptThenExecute(ctx, apitype.DestroyUpdate, stack, op, b.apply)
 }
 
+func (b *cloudBackend) Import(ctx context.Context, stack backend.Stack,
+	op backend.UpdateOperation) (engine.ResourceChanges, result.Result) {
+	return backend.PreviewThenPromptThenExecute(ctx, apitype.ResourceImportUpdate, stack, op, b.apply)
+}
+
 func (b *cloudBackend) Watch(ctx context.Context, stack backend.Stack,
 	op backend.UpdateOperation) result.Result {
 	return backend.Watch(ctx, b, stack, op, b.apply)
@@ -793,7 +798,9 @@ func (b *cloudBackend) apply(
 
 	actionLabel := backend.ActionLabel(kind, opts.DryRun)
 
-	if !(op.Opts.Display.JSONDisplay || op.Opts.Display.Type == display.DisplayWatch) {
+	if !(op.Opts.Display.JSONDisplay || op.Opts.Display.Type == display.DisplayWatch ||
+		op.Opts.Display.Type == display.DisplayNone || op.Opts.Display.Type == display.DisplayMarkdown ||
+		op.Opts.Display.Type == display.DisplayJUnit) {
 		// Print a banner so it's clear this is going to the cloud.
 		fmt.Printf(op.Opts.Display.Color.Colorize(
 			colors.SpecHeadline+"%s (%s):"+colors.Reset+"\n"), actionLabel, stack.Ref())
@@ -806,7 +813,8 @@ func (b *cloudBackend) apply(
 		return nil, result.FromError(err)
 	}
 
-	if opts.ShowLink && !op.Opts.Display.JSONDisplay {
+	if opts.ShowLink && !op.Opts.Display.JSONDisplay && op.Opts.Display.Type != display.DisplayNone &&
+		op.Opts.Display.Type != display.DisplayMarkdown && op.Opts.Display.Type != display.DisplayJUnit {
 		// Print a URL at the end of the update pointing to the Pulumi Service.
 		var link string
 		base := b.cloudConsoleStackPath(update.StackIdentifier)
@@ -903,6 +911,8 @@ func (b *cloudBackend) runEngineAction(
 		changes, res = engine.Refresh(u, engineCtx, op.Opts.Engine, dryRun)
 	case apitype.DestroyUpdate:
 		changes, res = engine.Destroy(u, engineCtx, op.Opts.Engine, dryRun)
+	case apitype.ResourceImportUpdate:
+		changes, res = engine.Import(u, engineCtx, op.Opts.Engine, op.Imports, dryRun)
 	default:
 		contract.Failf("Unrecognized update kind: %s", kind)
 	}
@@ -985,6 +995,8 @@ func (b *cloudBackend) GetHistory(ctx context.Context, stackRef backend.StackRef
 			StartTime:       update.StartTime,
 			EndTime:         update.EndTime,
 			ResourceChanges: convertResourceChanges(update.ResourceChanges),
+			Version:         update.Version,
+			Checkpoint:      b.client.StackDeploymentVersionURL(stack, update.Version),
 		})
 	}
 
@@ -1099,6 +1111,35 @@ func (b *cloudBackend) ImportDeployment(ctx context.Context, stack backend.Stack
 	return nil
 }
 
+func (b *cloudBackend) ExportDeploymentForVersion(ctx context.Context, stack backend.Stack,
+	version int) (*apitype.UntypedDeployment, error) {
+
+	stackID, err := b.getCloudStackIdentifier(stack.Ref())
+	if err != nil {
+		return nil, err
+	}
+
+	deployment, err := b.client.ExportStackDeploymentVersion(ctx, stackID, version)
+	if err != nil {
+		return nil, err
+	}
+
+	return &deployment, nil
+}
+
+// RollbackDeployment restores the deployment written by the given version of the stack's updates by importing it. The
+// Pulumi Service records the rollback in the stack's history as an import.
+func (b *cloudBackend) RollbackDeployment(ctx context.Context, stack backend.Stack, version int) error {
+	deployment, err := b.ExportDeploymentForVersion(ctx, stack, version)
+	if err != nil {
+		return err
+	}
+	if _, err = backend.DeserializeRollbackTarget(deployment); err != nil {
+		return err
+	}
+	return b.ImportDeployment(ctx, stack, deployment)
+}
+
 var (
 	projectNameCleanRegexp = regexp.MustCompile("[^a-zA-Z0-9-_.]")
 )
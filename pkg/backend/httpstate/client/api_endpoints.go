@@ -85,6 +85,7 @@ func init() {
 	addEndpoint("DELETE", "/api/stacks/{orgName}/{projectName}/{stackName}", "deleteStack")
 	addEndpoint("GET", "/api/stacks/{orgName}/{projectName}/{stackName}", "getStack")
 	addEndpoint("GET", "/api/stacks/{orgName}/{projectName}/{stackName}/export", "exportStack")
+	addEndpoint("GET", "/api/stacks/{orgName}/{projectName}/{stackName}/export/{version}", "exportStackVersion")
 	addEndpoint("POST", "/api/stacks/{orgName}/{projectName}/{stackName}/import", "importStack")
 	addEndpoint("POST", "/api/stacks/{orgName}/{projectName}/{stackName}/encrypt", "encryptValue")
 	addEndpoint("POST", "/api/stacks/{orgName}/{projectName}/{stackName}/decrypt", "decryptValue")
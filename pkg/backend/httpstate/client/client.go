@@ -22,6 +22,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"path"
+	"strconv"
 	"time"
 
 	"github.com/pulumi/pulumi/pkg/resource/plugin"
@@ -362,6 +363,26 @@ func (pc *Client) ExportStackDeployment(ctx context.Context,
 	return apitype.UntypedDeployment(resp), nil
 }
 
+// ExportStackDeploymentVersion exports the deployment written by the indicated update of the indicated stack as a raw
+// JSON message.
+func (pc *Client) ExportStackDeploymentVersion(ctx context.Context, stack StackIdentifier,
+	version int) (apitype.UntypedDeployment, error) {
+
+	var resp apitype.ExportStackResponse
+	exportPath := getStackPath(stack, "export", strconv.Itoa(version))
+	if err := pc.restCall(ctx, "GET", exportPath, nil, nil, &resp); err != nil {
+		return apitype.UntypedDeployment{}, err
+	}
+
+	return apitype.UntypedDeployment(resp), nil
+}
+
+// StackDeploymentVersionURL returns the URL from which the deployment written by the indicated update of the
+// indicated stack may be exported.
+func (pc *Client) StackDeploymentVersionURL(stack StackIdentifier, version int) string {
+	return pc.apiURL + getStackPath(stack, "export", strconv.Itoa(version))
+}
+
 // ImportStackDeployment imports a new deployment into the indicated stack.
 func (pc *Client) ImportStackDeployment(ctx context.Context, stack StackIdentifier,
 	deployment *apitype.UntypedDeployment) (UpdateIdentifier, error) {
@@ -425,7 +446,7 @@ func (pc *Client) CreateUpdate(
 	// Create the initial update object.
 	var endpoint string
 	switch kind {
-	case apitype.UpdateUpdate:
+	case apitype.UpdateUpdate, apitype.ResourceImportUpdate:
 		endpoint = "update"
 	case apitype.PreviewUpdate:
 		endpoint = "preview"
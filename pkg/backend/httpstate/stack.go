@@ -151,6 +151,10 @@ func (s *cloudStack) Destroy(ctx context.Context, op backend.UpdateOperation) (e
 	return backend.DestroyStack(ctx, s, op)
 }
 
+func (s *cloudStack) Import(ctx context.Context, op backend.UpdateOperation) (engine.ResourceChanges, result.Result) {
+	return backend.ImportStack(ctx, s, op)
+}
+
 func (s *cloudStack) Watch(ctx context.Context, op backend.UpdateOperation) result.Result {
 	return backend.WatchStack(ctx, s, op)
 }
@@ -32,28 +32,30 @@ import (
 //
 
 type MockBackend struct {
-	NameF                   func() string
-	URLF                    func() string
-	GetPolicyPackF          func(ctx context.Context, policyPack string, d diag.Sink) (PolicyPack, error)
-	SupportsOrganizationsF  func() bool
-	ParseStackReferenceF    func(s string) (StackReference, error)
-	DoesProjectExistF       func(context.Context, string) (bool, error)
-	GetStackF               func(context.Context, StackReference) (Stack, error)
-	CreateStackF            func(context.Context, StackReference, interface{}) (Stack, error)
-	RemoveStackF            func(context.Context, Stack, bool) (bool, error)
-	ListStacksF             func(context.Context, ListStacksFilter) ([]StackSummary, error)
-	RenameStackF            func(context.Context, Stack, tokens.QName) error
-	GetStackCrypterF        func(StackReference) (config.Crypter, error)
-	QueryF                  func(context.Context, QueryOperation) result.Result
-	GetLatestConfigurationF func(context.Context, Stack) (config.Map, error)
-	GetHistoryF             func(context.Context, StackReference) ([]UpdateInfo, error)
-	GetStackTagsF           func(context.Context, Stack) (map[apitype.StackTagName]string, error)
-	UpdateStackTagsF        func(context.Context, Stack, map[apitype.StackTagName]string) error
-	ExportDeploymentF       func(context.Context, Stack) (*apitype.UntypedDeployment, error)
-	ImportDeploymentF       func(context.Context, Stack, *apitype.UntypedDeployment) error
-	LogoutF                 func() error
-	CurrentUserF            func() (string, error)
-	PreviewF                func(context.Context, Stack,
+	NameF                       func() string
+	URLF                        func() string
+	GetPolicyPackF              func(ctx context.Context, policyPack string, d diag.Sink) (PolicyPack, error)
+	SupportsOrganizationsF      func() bool
+	ParseStackReferenceF        func(s string) (StackReference, error)
+	DoesProjectExistF           func(context.Context, string) (bool, error)
+	GetStackF                   func(context.Context, StackReference) (Stack, error)
+	CreateStackF                func(context.Context, StackReference, interface{}) (Stack, error)
+	RemoveStackF                func(context.Context, Stack, bool) (bool, error)
+	ListStacksF                 func(context.Context, ListStacksFilter) ([]StackSummary, error)
+	RenameStackF                func(context.Context, Stack, tokens.QName) error
+	GetStackCrypterF            func(StackReference) (config.Crypter, error)
+	QueryF                      func(context.Context, QueryOperation) result.Result
+	GetLatestConfigurationF     func(context.Context, Stack) (config.Map, error)
+	GetHistoryF                 func(context.Context, StackReference) ([]UpdateInfo, error)
+	GetStackTagsF               func(context.Context, Stack) (map[apitype.StackTagName]string, error)
+	UpdateStackTagsF            func(context.Context, Stack, map[apitype.StackTagName]string) error
+	ExportDeploymentF           func(context.Context, Stack) (*apitype.UntypedDeployment, error)
+	ImportDeploymentF           func(context.Context, Stack, *apitype.UntypedDeployment) error
+	ExportDeploymentForVersionF func(context.Context, Stack, int) (*apitype.UntypedDeployment, error)
+	RollbackDeploymentF         func(context.Context, Stack, int) error
+	LogoutF                     func() error
+	CurrentUserF                func() (string, error)
+	PreviewF                    func(context.Context, Stack,
 		UpdateOperation) (engine.ResourceChanges, result.Result)
 	UpdateF func(context.Context, Stack,
 		UpdateOperation) (engine.ResourceChanges, result.Result)
@@ -61,6 +63,8 @@ type MockBackend struct {
 		UpdateOperation) (engine.ResourceChanges, result.Result)
 	DestroyF func(context.Context, Stack,
 		UpdateOperation) (engine.ResourceChanges, result.Result)
+	ImportF func(context.Context, Stack,
+		UpdateOperation) (engine.ResourceChanges, result.Result)
 	WatchF func(context.Context, Stack,
 		UpdateOperation) result.Result
 	GetLogsF func(context.Context, Stack, StackConfiguration,
@@ -191,6 +195,15 @@ func (be *MockBackend) Destroy(ctx context.Context, stack Stack,
 	panic("not implemented")
 }
 
+func (be *MockBackend) Import(ctx context.Context, stack Stack,
+	op UpdateOperation) (engine.ResourceChanges, result.Result) {
+
+	if be.ImportF != nil {
+		return be.ImportF(ctx, stack, op)
+	}
+	panic("not implemented")
+}
+
 func (be *MockBackend) Watch(ctx context.Context, stack Stack,
 	op UpdateOperation) result.Result {
 
@@ -269,6 +282,22 @@ func (be *MockBackend) ImportDeployment(ctx context.Context, stack Stack,
 	panic("not implemented")
 }
 
+func (be *MockBackend) ExportDeploymentForVersion(ctx context.Context, stack Stack,
+	version int) (*apitype.UntypedDeployment, error) {
+
+	if be.ExportDeploymentForVersionF != nil {
+		return be.ExportDeploymentForVersionF(ctx, stack, version)
+	}
+	panic("not implemented")
+}
+
+func (be *MockBackend) RollbackDeployment(ctx context.Context, stack Stack, version int) error {
+	if be.RollbackDeploymentF != nil {
+		return be.RollbackDeploymentF(ctx, stack, version)
+	}
+	panic("not implemented")
+}
+
 func (be *MockBackend) Logout() error {
 	if be.LogoutF != nil {
 		return be.LogoutF()
@@ -296,6 +325,7 @@ type MockStack struct {
 	UpdateF   func(ctx context.Context, op UpdateOperation) (engine.ResourceChanges, result.Result)
 	RefreshF  func(ctx context.Context, op UpdateOperation) (engine.ResourceChanges, result.Result)
 	DestroyF  func(ctx context.Context, op UpdateOperation) (engine.ResourceChanges, result.Result)
+	ImportF   func(ctx context.Context, op UpdateOperation) (engine.ResourceChanges, result.Result)
 	WatchF    func(ctx context.Context, op UpdateOperation) result.Result
 	QueryF    func(ctx context.Context, op UpdateOperation) result.Result
 	RemoveF   func(ctx context.Context, force bool) (bool, error)
@@ -364,6 +394,13 @@ func (ms *MockStack) Destroy(ctx context.Context, op UpdateOperation) (engine.Re
 	panic("not implemented")
 }
 
+func (ms *MockStack) Import(ctx context.Context, op UpdateOperation) (engine.ResourceChanges, result.Result) {
+	if ms.ImportF != nil {
+		return ms.ImportF(ctx, op)
+	}
+	panic("not implemented")
+}
+
 func (ms *MockStack) Watch(ctx context.Context, op UpdateOperation) result.Result {
 	if ms.WatchF != nil {
 		return ms.WatchF(ctx, op)
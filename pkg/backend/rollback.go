@@ -0,0 +1,56 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backend
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/apitype"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/resource/stack"
+)
+
+// ErrNoCheckpoint is returned when the checkpoint written by an update was not retained.
+var ErrNoCheckpoint = errors.New("the checkpoint written by this update was not retained")
+
+// GetHistoryVersion returns the entry in the given stack's history with the given version.
+func GetHistoryVersion(ctx context.Context, s Stack, version int) (UpdateInfo, error) {
+	updates, err := s.Backend().GetHistory(ctx, s.Ref())
+	if err != nil {
+		return UpdateInfo{}, errors.Wrap(err, "getting history")
+	}
+	for _, update := range updates {
+		if update.Version == version {
+			return update, nil
+		}
+	}
+	return UpdateInfo{}, errors.Errorf("stack '%s' has no update with version %d", s.Ref(), version)
+}
+
+// DeserializeRollbackTarget deserializes a deployment that is the target of a rollback. An error is returned if the
+// deployment contains pending operations, as the state of the resources they affect is unknown.
+func DeserializeRollbackTarget(deployment *apitype.UntypedDeployment) (*deploy.Snapshot, error) {
+	snap, err := stack.DeserializeUntypedDeployment(deployment, stack.DefaultSecretsProvider)
+	if err != nil {
+		return nil, errors.Wrap(err, "deserializing deployment")
+	}
+	if len(snap.PendingOperations) != 0 {
+		return nil, errors.Errorf("the deployment contains %d pending operation(s); refusing to roll back to it",
+			len(snap.PendingOperations))
+	}
+	return snap, nil
+}
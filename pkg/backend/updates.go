@@ -103,4 +103,9 @@ type UpdateInfo struct {
 	Result          UpdateResult           `json:"result"`
 	EndTime         int64                  `json:"endTime"`
 	ResourceChanges engine.ResourceChanges `json:"resourceChanges,omitempty"`
+
+	// Version identifies the update within the stack's history. The first update of a stack has version 1.
+	Version int `json:"version,omitempty"`
+	// Checkpoint is the location of the checkpoint written by the update, if it was retained.
+	Checkpoint string `json:"checkpoint,omitempty"`
 }
@@ -0,0 +1,469 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package importer generates skeleton program code for resources that have been imported into a stack. The generated
+// code declares each resource with the inputs that were read from its provider so that the resource can be adopted by
+// the stack's program.
+package importer
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/codegen/python"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/deploy/providers"
+	"github.com/pulumi/pulumi/pkg/tokens"
+)
+
+// language describes how to render resource declarations in a particular language.
+type language interface {
+	// variableName returns a variable name for the resource with the given name.
+	variableName(name string) string
+	// prologue writes any code that must precede the resource declarations. usesAssets is true if any of the
+	// resources' inputs contain assets or archives.
+	prologue(w io.Writer, packages []string, usesAssets bool)
+	// resource writes the declaration of a single resource.
+	resource(w io.Writer, r *declaration)
+	// epilogue writes any code that must follow the resource declarations.
+	epilogue(w io.Writer)
+}
+
+// declaration is a single resource declaration.
+type declaration struct {
+	variable string
+	state    *resource.State
+	parent   string // the variable that refers to the resource's parent, if any.
+	provider string // the variable that refers to the resource's provider, if any.
+}
+
+var languages = map[string]language{
+	"nodejs": nodejs{},
+	"python": pythonLanguage{},
+	"go":     golang{},
+}
+
+// IsSupportedLanguage returns true if skeleton code can be generated for the given runtime.
+func IsSupportedLanguage(runtime string) bool {
+	_, ok := languages[runtime]
+	return ok
+}
+
+// GenerateSkeleton writes code in the language of the given runtime that declares each of the given resources. The
+// resources must be ordered such that each resource's parent and provider precede it. Parents and explicit providers
+// that are not part of the list are not referenced by the generated code, and default providers are omitted.
+func GenerateSkeleton(w io.Writer, runtime string, resources []*resource.State) error {
+	lang, ok := languages[runtime]
+	if !ok {
+		return errors.Errorf("cannot generate code for runtime '%v'", runtime)
+	}
+
+	var packages []string
+	usesAssets := false
+	seenPackages := make(map[tokens.Package]bool)
+	variables := make(map[resource.URN]string)
+	usedVariables := make(map[string]bool)
+	var decls []*declaration
+	for _, r := range resources {
+		pkg := r.Type.Package()
+		if providers.IsProviderType(r.Type) {
+			pkg = providers.GetProviderPackage(r.Type)
+		}
+		if !seenPackages[pkg] {
+			seenPackages[pkg] = true
+			packages = append(packages, string(pkg))
+		}
+
+		variable := lang.variableName(string(r.URN.Name()))
+		for suffix := 2; usedVariables[variable]; suffix++ {
+			variable = fmt.Sprintf("%s%d", lang.variableName(string(r.URN.Name())), suffix)
+		}
+		usedVariables[variable] = true
+		usesAssets = usesAssets || containsAssets(resource.NewObjectProperty(r.Inputs))
+		variables[r.URN] = variable
+
+		decl := &declaration{variable: variable, state: r, parent: variables[r.Parent]}
+		if r.Provider != "" {
+			ref, err := providers.ParseReference(r.Provider)
+			if err != nil {
+				return errors.Wrapf(err, "parsing provider reference for '%v'", r.URN)
+			}
+			decl.provider = variables[ref.URN()]
+		}
+		decls = append(decls, decl)
+	}
+
+	lang.prologue(w, packages, usesAssets)
+	for _, d := range decls {
+		lang.resource(w, d)
+	}
+	lang.epilogue(w)
+	return nil
+}
+
+// containsAssets returns true if the given value contains any assets or archives.
+func containsAssets(v resource.PropertyValue) bool {
+	switch {
+	case v.IsAsset() || v.IsArchive():
+		return true
+	case v.IsSecret():
+		return containsAssets(v.SecretValue().Element)
+	case v.IsArray():
+		for _, e := range v.ArrayValue() {
+			if containsAssets(e) {
+				return true
+			}
+		}
+	case v.IsObject():
+		for _, e := range v.ObjectValue() {
+			if containsAssets(e) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// camelCase turns an arbitrary resource name into a camelCase identifier.
+func camelCase(name string) string {
+	var b strings.Builder
+	upper := false
+	for _, c := range name {
+		switch {
+		case unicode.IsLetter(c) || unicode.IsDigit(c) || c == '_':
+			if b.Len() == 0 && unicode.IsDigit(c) {
+				b.WriteRune('_')
+			}
+			if upper && b.Len() > 0 {
+				c = unicode.ToUpper(c)
+			} else if b.Len() == 0 {
+				c = unicode.ToLower(c)
+			}
+			b.WriteRune(c)
+			upper = false
+		default:
+			upper = true
+		}
+	}
+	if b.Len() == 0 {
+		return "resource"
+	}
+	return b.String()
+}
+
+// typeMember returns the module and member components of a resource type token. The "index" module is elided.
+func typeMember(t tokens.Type) (string, string) {
+	if providers.IsProviderType(t) {
+		return "", "Provider"
+	}
+
+	module := string(t.Module().Name())
+	if slash := strings.Index(module, "/"); slash != -1 {
+		module = module[:slash]
+	}
+	if module == "index" {
+		module = ""
+	}
+	return module, string(t.Name())
+}
+
+// valueWriter renders property values using language-specific syntax.
+type valueWriter struct {
+	indent                  string
+	null, true, false       string
+	arrayOpen, arrayClose   string
+	objectOpen, objectClose string
+	objectKey               func(key string) string
+	secret                  func(v string) string
+	asset                   func(kind, arg string) string
+	archive                 func(kind, arg string) string
+}
+
+func (vw *valueWriter) value(v resource.PropertyValue, indent string) string {
+	switch {
+	case v.IsNull() || v.IsComputed() || v.IsOutput():
+		return vw.null
+	case v.IsBool():
+		if v.BoolValue() {
+			return vw.true
+		}
+		return vw.false
+	case v.IsNumber():
+		return strconv.FormatFloat(v.NumberValue(), 'f', -1, 64)
+	case v.IsString():
+		return strconv.Quote(v.StringValue())
+	case v.IsArray():
+		arr := v.ArrayValue()
+		if len(arr) == 0 {
+			return vw.arrayOpen + vw.arrayClose
+		}
+		var b strings.Builder
+		b.WriteString(vw.arrayOpen + "\n")
+		for _, e := range arr {
+			fmt.Fprintf(&b, "%s%s%s,\n", indent, vw.indent, vw.value(e, indent+vw.indent))
+		}
+		b.WriteString(indent + vw.arrayClose)
+		return b.String()
+	case v.IsObject():
+		return vw.object(v.ObjectValue(), indent)
+	case v.IsSecret():
+		return vw.secret(vw.value(v.SecretValue().Element, indent))
+	case v.IsAsset():
+		a := v.AssetValue()
+		switch {
+		case a.IsPath():
+			return vw.asset("File", strconv.Quote(a.Path))
+		case a.IsURI():
+			return vw.asset("Remote", strconv.Quote(a.URI))
+		default:
+			return vw.asset("String", strconv.Quote(a.Text))
+		}
+	case v.IsArchive():
+		a := v.ArchiveValue()
+		switch {
+		case a.IsPath():
+			return vw.archive("File", strconv.Quote(a.Path))
+		case a.IsURI():
+			return vw.archive("Remote", strconv.Quote(a.URI))
+		default:
+			assets := make(resource.PropertyMap)
+			for k, v := range a.Assets {
+				switch v := v.(type) {
+				case *resource.Asset:
+					assets[resource.PropertyKey(k)] = resource.NewAssetProperty(v)
+				case *resource.Archive:
+					assets[resource.PropertyKey(k)] = resource.NewArchiveProperty(v)
+				}
+			}
+			return vw.archive("Asset", vw.object(assets, indent))
+		}
+	default:
+		return vw.null
+	}
+}
+
+func (vw *valueWriter) object(m resource.PropertyMap, indent string) string {
+	if len(m) == 0 {
+		return vw.objectOpen + vw.objectClose
+	}
+	var b strings.Builder
+	b.WriteString(vw.objectOpen + "\n")
+	for _, k := range m.StableKeys() {
+		fmt.Fprintf(&b, "%s%s%s: %s,\n", indent, vw.indent, vw.objectKey(string(k)), vw.value(m[k], indent+vw.indent))
+	}
+	b.WriteString(indent + vw.objectClose)
+	return b.String()
+}
+
+// nodejs generates TypeScript.
+type nodejs struct{}
+
+var nodejsValues = &valueWriter{
+	indent: "    ",
+	null:   "undefined", true: "true", false: "false",
+	arrayOpen: "[", arrayClose: "]",
+	objectOpen: "{", objectClose: "}",
+	objectKey: func(key string) string {
+		if camelCase(key) == key {
+			return key
+		}
+		return strconv.Quote(key)
+	},
+	secret:  func(v string) string { return "pulumi.secret(" + v + ")" },
+	asset:   func(kind, arg string) string { return "new pulumi.asset." + kind + "Asset(" + arg + ")" },
+	archive: func(kind, arg string) string { return "new pulumi.asset." + kind + "Archive(" + arg + ")" },
+}
+
+func (nodejs) variableName(name string) string {
+	return camelCase(name)
+}
+
+func (nodejs) prologue(w io.Writer, packages []string, usesAssets bool) {
+	fmt.Fprintf(w, "import * as pulumi from \"@pulumi/pulumi\";\n")
+	for _, pkg := range packages {
+		fmt.Fprintf(w, "import * as %s from \"@pulumi/%s\";\n", camelCase(pkg), pkg)
+	}
+}
+
+func (nodejs) resource(w io.Writer, r *declaration) {
+	module, member := typeMember(r.state.Type)
+	ctor := camelCase(string(r.state.Type.Package()))
+	if providers.IsProviderType(r.state.Type) {
+		ctor = camelCase(string(providers.GetProviderPackage(r.state.Type)))
+	}
+	if module != "" {
+		ctor += "." + camelCase(module)
+	}
+	ctor += "." + member
+
+	var opts []string
+	if r.parent != "" {
+		opts = append(opts, "parent: "+r.parent)
+	}
+	if r.provider != "" {
+		opts = append(opts, "provider: "+r.provider)
+	}
+	if r.state.Protect {
+		opts = append(opts, "protect: true")
+	}
+
+	fmt.Fprintf(w, "\nconst %s = new %s(%s, %s", r.variable, ctor, strconv.Quote(string(r.state.URN.Name())),
+		nodejsValues.object(r.state.Inputs, ""))
+	if len(opts) != 0 {
+		fmt.Fprintf(w, ", { %s }", strings.Join(opts, ", "))
+	}
+	fmt.Fprintf(w, ");\n")
+}
+
+func (nodejs) epilogue(w io.Writer) {}
+
+// pythonLanguage generates Python.
+type pythonLanguage struct{}
+
+var pythonValues = &valueWriter{
+	indent: "    ",
+	null:   "None", true: "True", false: "False",
+	arrayOpen: "[", arrayClose: "]",
+	objectOpen: "{", objectClose: "}",
+	objectKey: strconv.Quote,
+	secret:    func(v string) string { return "pulumi.Output.secret(" + v + ")" },
+	asset:     func(kind, arg string) string { return "pulumi." + kind + "Asset(" + arg + ")" },
+	archive:   func(kind, arg string) string { return "pulumi." + kind + "Archive(" + arg + ")" },
+}
+
+func (pythonLanguage) variableName(name string) string {
+	return python.PyName(camelCase(name))
+}
+
+func (pythonLanguage) prologue(w io.Writer, packages []string, usesAssets bool) {
+	fmt.Fprintf(w, "import pulumi\n")
+	for _, pkg := range packages {
+		fmt.Fprintf(w, "import pulumi_%s as %s\n", python.PyName(camelCase(pkg)), python.PyName(camelCase(pkg)))
+	}
+}
+
+func (pythonLanguage) resource(w io.Writer, r *declaration) {
+	module, member := typeMember(r.state.Type)
+	pkg := r.state.Type.Package()
+	if providers.IsProviderType(r.state.Type) {
+		pkg = providers.GetProviderPackage(r.state.Type)
+	}
+	ctor := python.PyName(camelCase(string(pkg)))
+	if module != "" {
+		ctor += "." + python.PyName(camelCase(module))
+	}
+	ctor += "." + member
+
+	fmt.Fprintf(w, "\n%s = %s(%s", r.variable, ctor, strconv.Quote(string(r.state.URN.Name())))
+	for _, k := range r.state.Inputs.StableKeys() {
+		fmt.Fprintf(w, ",\n    %s=%s", python.PyName(string(k)), pythonValues.value(r.state.Inputs[k], "    "))
+	}
+
+	var opts []string
+	if r.parent != "" {
+		opts = append(opts, "parent="+r.parent)
+	}
+	if r.provider != "" {
+		opts = append(opts, "provider="+r.provider)
+	}
+	if r.state.Protect {
+		opts = append(opts, "protect=True")
+	}
+	if len(opts) != 0 {
+		fmt.Fprintf(w, ",\n    opts=pulumi.ResourceOptions(%s)", strings.Join(opts, ", "))
+	}
+	fmt.Fprintf(w, ")\n")
+}
+
+func (pythonLanguage) epilogue(w io.Writer) {}
+
+// golang generates Go.
+type golang struct{}
+
+var golangValues = &valueWriter{
+	indent: "\t",
+	null:   "nil", true: "true", false: "false",
+	arrayOpen: "[]interface{}{", arrayClose: "}",
+	objectOpen: "map[string]interface{}{", objectClose: "}",
+	objectKey: strconv.Quote,
+	// The Go SDK does not yet support secret inputs, so secrets are rendered as their plaintext values.
+	secret:  func(v string) string { return v },
+	asset:   func(kind, arg string) string { return "asset.New" + kind + "Asset(" + arg + ")" },
+	archive: func(kind, arg string) string { return "asset.New" + kind + "Archive(" + arg + ")" },
+}
+
+var golangKeywords = map[string]bool{
+	"break": true, "case": true, "chan": true, "const": true, "continue": true, "default": true, "defer": true,
+	"else": true, "fallthrough": true, "for": true, "func": true, "go": true, "goto": true, "if": true,
+	"import": true, "interface": true, "map": true, "package": true, "range": true, "return": true,
+	"select": true, "struct": true, "switch": true, "type": true, "var": true,
+	// These are not keywords, but are already in scope in the generated code.
+	"ctx": true, "err": true, "pulumi": true, "asset": true,
+}
+
+func (golang) variableName(name string) string {
+	v := camelCase(name)
+	if golangKeywords[v] {
+		return v + "_"
+	}
+	return v
+}
+
+func (golang) prologue(w io.Writer, packages []string, usesAssets bool) {
+	fmt.Fprintf(w, "package main\n\n")
+	fmt.Fprintf(w, "import (\n")
+	fmt.Fprintf(w, "\t\"github.com/pulumi/pulumi/sdk/go/pulumi\"\n")
+	if usesAssets {
+		fmt.Fprintf(w, "\t\"github.com/pulumi/pulumi/sdk/go/pulumi/asset\"\n")
+	}
+	fmt.Fprintf(w, ")\n\n")
+	fmt.Fprintf(w, "func main() {\n")
+	fmt.Fprintf(w, "\tpulumi.Run(func(ctx *pulumi.Context) error {\n")
+}
+
+func (golang) resource(w io.Writer, r *declaration) {
+	indent := "\t\t"
+
+	var opts []string
+	if r.parent != "" {
+		opts = append(opts, "Parent: "+r.parent)
+	}
+	if r.provider != "" {
+		opts = append(opts, "Provider: "+r.provider)
+	}
+	if r.state.Protect {
+		opts = append(opts, "Protect: true")
+	}
+
+	fmt.Fprintf(w, "\n%s%s, err := ctx.RegisterResource(%s, %s, true, %s", indent, r.variable,
+		strconv.Quote(string(r.state.Type)), strconv.Quote(string(r.state.URN.Name())),
+		golangValues.object(r.state.Inputs, indent))
+	if len(opts) != 0 {
+		fmt.Fprintf(w, ", pulumi.ResourceOpt{%s}", strings.Join(opts, ", "))
+	}
+	fmt.Fprintf(w, ")\n")
+	fmt.Fprintf(w, "%sif err != nil {\n%s\treturn err\n%s}\n", indent, indent, indent)
+	fmt.Fprintf(w, "%s_ = %s\n", indent, r.variable)
+}
+
+func (golang) epilogue(w io.Writer) {
+	fmt.Fprintf(w, "\n\t\treturn nil\n")
+	fmt.Fprintf(w, "\t})\n")
+	fmt.Fprintf(w, "}\n")
+}
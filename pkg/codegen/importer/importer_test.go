@@ -0,0 +1,116 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package importer
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/deploy/providers"
+	"github.com/pulumi/pulumi/pkg/tokens"
+)
+
+func testResources(t *testing.T) []*resource.State {
+	newURN := func(t tokens.Type, name string, parent resource.URN) resource.URN {
+		var pt tokens.Type
+		if parent != "" {
+			pt = parent.Type()
+		}
+		return resource.NewURN("stack", "proj", pt, t, tokens.QName(name))
+	}
+
+	defaultProvider := newURN(providers.MakeProviderType("aws"), "default", "")
+	defaultRef, err := providers.NewReference(defaultProvider, "provider-id")
+	assert.NoError(t, err)
+
+	bucket := newURN("aws:s3/bucket:Bucket", "my-bucket", "")
+	object := newURN("aws:s3/bucketObject:BucketObject", "index.html", bucket)
+	return []*resource.State{
+		{
+			Type:     "aws:s3/bucket:Bucket",
+			URN:      bucket,
+			Custom:   true,
+			ID:       "my-bucket-1234",
+			Provider: defaultRef.String(),
+			Inputs: resource.PropertyMap{
+				"bucket": resource.NewStringProperty("my-bucket-1234"),
+				"tags": resource.NewObjectProperty(resource.PropertyMap{
+					"owner": resource.NewStringProperty("me"),
+				}),
+			},
+			Protect: true,
+		},
+		{
+			Type:     "aws:s3/bucketObject:BucketObject",
+			URN:      object,
+			Custom:   true,
+			ID:       "index.html",
+			Parent:   bucket,
+			Provider: defaultRef.String(),
+			Inputs: resource.PropertyMap{
+				"contentType":  resource.NewStringProperty("text/html"),
+				"cacheControl": resource.MakeSecret(resource.NewStringProperty("no-cache")),
+			},
+		},
+	}
+}
+
+func TestGenerateNodeJS(t *testing.T) {
+	var buf bytes.Buffer
+	assert.NoError(t, GenerateSkeleton(&buf, "nodejs", testResources(t)))
+	assert.Equal(t, `import * as pulumi from "@pulumi/pulumi";
+import * as aws from "@pulumi/aws";
+
+const myBucket = new aws.s3.Bucket("my-bucket", {
+    bucket: "my-bucket-1234",
+    tags: {
+        owner: "me",
+    },
+}, { protect: true });
+
+const indexHtml = new aws.s3.BucketObject("index.html", {
+    cacheControl: pulumi.secret("no-cache"),
+    contentType: "text/html",
+}, { parent: myBucket });
+`, buf.String())
+}
+
+func TestGeneratePython(t *testing.T) {
+	var buf bytes.Buffer
+	assert.NoError(t, GenerateSkeleton(&buf, "python", testResources(t)))
+	assert.Equal(t, `import pulumi
+import pulumi_aws as aws
+
+my_bucket = aws.s3.Bucket("my-bucket",
+    bucket="my-bucket-1234",
+    tags={
+        "owner": "me",
+    },
+    opts=pulumi.ResourceOptions(protect=True))
+
+index_html = aws.s3.BucketObject("index.html",
+    cache_control=pulumi.Output.secret("no-cache"),
+    content_type="text/html",
+    opts=pulumi.ResourceOptions(parent=my_bucket))
+`, buf.String())
+}
+
+func TestGenerateUnsupported(t *testing.T) {
+	assert.False(t, IsSupportedLanguage("dotnet"))
+	assert.Error(t, GenerateSkeleton(&bytes.Buffer{}, "dotnet", testResources(t)))
+}
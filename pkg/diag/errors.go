@@ -80,3 +80,11 @@ func GetResourceWillBeDestroyedButWasNotSpecifiedInTargetList(urn resource.URN)
 	return newError(urn, 2014, `Resource '%v' will be destroyed but was not specified in --target list.
 Either include resource in --target list or pass --target-dependents to proceed.`)
 }
+
+func GetResourceViolatesPlanError(urn resource.URN) *Diag {
+	return newError(urn, 2015, "Resource '%v' violates the plan: %v")
+}
+
+func GetImportError(urn resource.URN) *Diag {
+	return newError(urn, 2016, "Resource '%v' cannot be imported: %v")
+}
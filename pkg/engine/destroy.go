@@ -18,7 +18,6 @@ import (
 	"github.com/pulumi/pulumi/pkg/resource/deploy"
 	"github.com/pulumi/pulumi/pkg/resource/plugin"
 	"github.com/pulumi/pulumi/pkg/util/contract"
-	"github.com/pulumi/pulumi/pkg/util/logging"
 	"github.com/pulumi/pulumi/pkg/util/result"
 	"github.com/pulumi/pulumi/pkg/workspace"
 )
@@ -62,9 +61,10 @@ func newDestroySource(
 		return nil, err
 	}
 
-	// Like Update, if we're missing plugins, attempt to download the missing plugins.
-	if err := ensurePluginsAreInstalled(plugins); err != nil {
-		logging.V(7).Infof("newDestroySource(): failed to install missing plugins: %v", err)
+	// Like Update, if we're missing plugins, attempt to download the missing plugins, verifying them against the
+	// project's plugin lockfile, if any.
+	if err := installLockedPlugins(proj, pwd, newPluginSet(), plugins); err != nil {
+		return nil, err
 	}
 
 	// We don't need the language plugin, since destroy doesn't run code, so we will leave that out.
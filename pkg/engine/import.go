@@ -0,0 +1,87 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package engine
+
+import (
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/resource/plugin"
+	"github.com/pulumi/pulumi/pkg/util/contract"
+	"github.com/pulumi/pulumi/pkg/util/result"
+	"github.com/pulumi/pulumi/pkg/workspace"
+)
+
+// Import imports the given existing resources into a stack. The stack's program is not run.
+func Import(u UpdateInfo, ctx *Context, opts UpdateOptions, imports []deploy.Import,
+	dryRun bool) (ResourceChanges, result.Result) {
+
+	contract.Require(u != nil, "u")
+	contract.Require(ctx != nil, "ctx")
+
+	defer func() { ctx.Events <- cancelEvent() }()
+
+	if len(imports) == 0 {
+		return nil, result.Error("no resources to import")
+	}
+
+	info, err := newPlanContext(u, "import", ctx.ParentSpan)
+	if err != nil {
+		return nil, result.FromError(err)
+	}
+	defer info.Close()
+
+	emitter, err := makeEventEmitter(ctx.Events, u)
+	if err != nil {
+		return nil, result.FromError(err)
+	}
+	defer emitter.Close()
+
+	return update(ctx, info, planOptions{
+		UpdateOptions: opts,
+		SourceFunc:    newImportSource,
+		Events:        emitter,
+		Diag:          newEventSink(emitter, false),
+		StatusDiag:    newEventSink(emitter, true),
+		imports:       imports,
+	}, dryRun)
+}
+
+func newImportSource(client deploy.BackendClient, opts planOptions, proj *workspace.Project, pwd, main string,
+	target *deploy.Target, plugctx *plugin.Context, dryRun bool) (deploy.Source, error) {
+
+	// Like Refresh, we don't run the user's program, so we only need the plugins described in the snapshot plus
+	// those for the packages of the resources we are importing.
+	plugins, err := gatherPluginsFromSnapshot(plugctx, target)
+	if err != nil {
+		return nil, err
+	}
+	importPlugins := newPluginSet()
+	for _, imp := range opts.imports {
+		importPlugins.Add(workspace.PluginInfo{
+			Name:    string(imp.Type.Package()),
+			Kind:    workspace.ResourcePlugin,
+			Version: imp.Version,
+		})
+	}
+	plugins = plugins.Union(importPlugins)
+
+	// If we're missing plugins, attempt to download the missing plugins, verifying them against the project's plugin
+	// lockfile, if any. The plugins for the imported resources must match their locked versions.
+	if err := installLockedPlugins(proj, pwd, importPlugins, plugins); err != nil {
+		return nil, err
+	}
+
+	// Just return an error source. Import doesn't use its source.
+	return deploy.NewErrorSource(proj.Name), nil
+}
@@ -571,7 +571,7 @@ func TestEmptyProgramLifecycle(t *testing.T) {
 	host := deploytest.NewPluginHost(nil, nil, program)
 
 	p := &TestPlan{
-		Options: UpdateOptions{host: host},
+		Options: UpdateOptions{Host: host},
 		Steps:   MakeBasicLifecycleSteps(t, 0),
 	}
 	p.Run(t, nil)
@@ -592,7 +592,7 @@ func TestSingleResourceDefaultProviderLifecycle(t *testing.T) {
 	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
 
 	p := &TestPlan{
-		Options: UpdateOptions{host: host},
+		Options: UpdateOptions{Host: host},
 		Steps:   MakeBasicLifecycleSteps(t, 2),
 	}
 	p.Run(t, nil)
@@ -626,7 +626,7 @@ func TestSingleResourceExplicitProviderLifecycle(t *testing.T) {
 	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
 
 	p := &TestPlan{
-		Options: UpdateOptions{host: host},
+		Options: UpdateOptions{Host: host},
 		Steps:   MakeBasicLifecycleSteps(t, 2),
 	}
 	p.Run(t, nil)
@@ -647,7 +647,7 @@ func TestSingleResourceDefaultProviderUpgrade(t *testing.T) {
 	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
 
 	p := &TestPlan{
-		Options: UpdateOptions{host: host},
+		Options: UpdateOptions{Host: host},
 	}
 
 	provURN := p.NewProviderURN("pkgA", "default", "")
@@ -753,7 +753,7 @@ func TestSingleResourceDefaultProviderReplace(t *testing.T) {
 	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
 
 	p := &TestPlan{
-		Options: UpdateOptions{host: host},
+		Options: UpdateOptions{Host: host},
 		Config: config.Map{
 			config.MustMakeKey("pkgA", "foo"): config.NewValue("bar"),
 		},
@@ -848,7 +848,7 @@ func TestSingleResourceExplicitProviderReplace(t *testing.T) {
 	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
 
 	p := &TestPlan{
-		Options: UpdateOptions{host: host},
+		Options: UpdateOptions{Host: host},
 	}
 
 	// Build a basic lifecycle.
@@ -939,7 +939,7 @@ func TestSingleResourceExplicitProviderDeleteBeforeReplace(t *testing.T) {
 	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
 
 	p := &TestPlan{
-		Options: UpdateOptions{host: host},
+		Options: UpdateOptions{Host: host},
 	}
 
 	// Build a basic lifecycle.
@@ -1032,7 +1032,7 @@ func TestSingleResourceDiffUnavailable(t *testing.T) {
 	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
 
 	p := &TestPlan{
-		Options: UpdateOptions{host: host},
+		Options: UpdateOptions{Host: host},
 	}
 	resURN := p.NewURN("pkgA:m:typA", "resA", "")
 
@@ -1075,7 +1075,7 @@ func TestDestroyWithPendingDelete(t *testing.T) {
 	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
 
 	p := &TestPlan{
-		Options: UpdateOptions{host: host},
+		Options: UpdateOptions{Host: host},
 	}
 
 	resURN := p.NewURN("pkgA:m:typA", "resA", "")
@@ -1149,7 +1149,7 @@ func TestUpdateWithPendingDelete(t *testing.T) {
 	host := deploytest.NewPluginHost(nil, nil, nil, loaders...)
 
 	p := &TestPlan{
-		Options: UpdateOptions{host: host},
+		Options: UpdateOptions{Host: host},
 	}
 
 	resURN := p.NewURN("pkgA:m:typA", "resA", "")
@@ -1246,7 +1246,7 @@ func TestParallelRefresh(t *testing.T) {
 	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
 
 	p := &TestPlan{
-		Options: UpdateOptions{Parallel: 4, host: host},
+		Options: UpdateOptions{Parallel: 4, Host: host},
 	}
 
 	p.Steps = []TestStep{{Op: Update}}
@@ -1288,7 +1288,7 @@ func TestExternalRefresh(t *testing.T) {
 	})
 	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
 	p := &TestPlan{
-		Options: UpdateOptions{host: host},
+		Options: UpdateOptions{Host: host},
 		Steps:   []TestStep{{Op: Update}},
 	}
 
@@ -1300,7 +1300,7 @@ func TestExternalRefresh(t *testing.T) {
 	assert.True(t, snap.Resources[1].External)
 
 	p = &TestPlan{
-		Options: UpdateOptions{host: host},
+		Options: UpdateOptions{Host: host},
 		Steps:   []TestStep{{Op: Refresh}},
 	}
 
@@ -1357,7 +1357,7 @@ func TestRefreshInitFailure(t *testing.T) {
 	})
 	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
 
-	p.Options.host = host
+	p.Options.Host = host
 
 	//
 	// Create an old snapshot with a single initialization failure.
@@ -1446,7 +1446,7 @@ func TestCheckFailureRecord(t *testing.T) {
 
 	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
 	p := &TestPlan{
-		Options: UpdateOptions{host: host},
+		Options: UpdateOptions{Host: host},
 		Steps: []TestStep{{
 			Op:            Update,
 			ExpectFailure: true,
@@ -1496,7 +1496,7 @@ func TestCheckFailureInvalidPropertyRecord(t *testing.T) {
 
 	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
 	p := &TestPlan{
-		Options: UpdateOptions{host: host},
+		Options: UpdateOptions{Host: host},
 		Steps: []TestStep{{
 			Op:            Update,
 			ExpectFailure: true,
@@ -1552,7 +1552,7 @@ func TestRefreshWithDelete(t *testing.T) {
 			})
 
 			host := deploytest.NewPluginHost(nil, nil, program, loaders...)
-			p := &TestPlan{Options: UpdateOptions{host: host, Parallel: parallelFactor}}
+			p := &TestPlan{Options: UpdateOptions{Host: host, Parallel: parallelFactor}}
 
 			p.Steps = []TestStep{{Op: Update}}
 			snap := p.Run(t, nil)
@@ -1662,7 +1662,7 @@ func validateRefreshDeleteCombination(t *testing.T, names []string, targets []st
 		}),
 	}
 
-	p.Options.host = deploytest.NewPluginHost(nil, nil, nil, loaders...)
+	p.Options.Host = deploytest.NewPluginHost(nil, nil, nil, loaders...)
 
 	p.Steps = []TestStep{
 		{
@@ -1832,7 +1832,7 @@ func validateRefreshBasicsCombination(t *testing.T, names []string, targets []st
 		}),
 	}
 
-	p.Options.host = deploytest.NewPluginHost(nil, nil, nil, loaders...)
+	p.Options.Host = deploytest.NewPluginHost(nil, nil, nil, loaders...)
 
 	p.Steps = []TestStep{{
 		Op: Refresh,
@@ -1984,7 +1984,7 @@ func TestCanceledRefresh(t *testing.T) {
 	op := TestOp(Refresh)
 	options := UpdateOptions{
 		Parallel: 1,
-		host:     deploytest.NewPluginHost(nil, nil, nil, loaders...),
+		Host:     deploytest.NewPluginHost(nil, nil, nil, loaders...),
 	}
 	project, target := p.GetProject(), p.GetTarget(old)
 	validate := func(project workspace.Project, target deploy.Target, j *Journal,
@@ -2078,7 +2078,7 @@ func TestLanguageHostDiagnostics(t *testing.T) {
 
 	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
 	p := &TestPlan{
-		Options: UpdateOptions{host: host},
+		Options: UpdateOptions{Host: host},
 		Steps: []TestStep{{
 			Op:            Update,
 			ExpectFailure: true,
@@ -2133,7 +2133,7 @@ func TestBrokenDecrypter(t *testing.T) {
 	configMap := make(config.Map)
 	configMap[key] = config.NewSecureValue("hunter2")
 	p := &TestPlan{
-		Options:   UpdateOptions{host: host},
+		Options:   UpdateOptions{Host: host},
 		Decrypter: brokenDecrypter{ErrorMessage: msg},
 		Config:    configMap,
 		Steps: []TestStep{{
@@ -2189,7 +2189,7 @@ func TestBadResourceType(t *testing.T) {
 
 	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
 	p := &TestPlan{
-		Options: UpdateOptions{host: host},
+		Options: UpdateOptions{Host: host},
 		Steps: []TestStep{{
 			Op:            Update,
 			ExpectFailure: true,
@@ -2261,7 +2261,7 @@ func TestProviderCancellation(t *testing.T) {
 	op := TestOp(Update)
 	options := UpdateOptions{
 		Parallel: resourceCount,
-		host:     deploytest.NewPluginHost(nil, nil, program, loaders...),
+		Host:     deploytest.NewPluginHost(nil, nil, program, loaders...),
 	}
 	project, target := p.GetProject(), p.GetTarget(nil)
 
@@ -2315,7 +2315,7 @@ func TestPreviewWithPendingOperations(t *testing.T) {
 	})
 
 	op := TestOp(Update)
-	options := UpdateOptions{host: deploytest.NewPluginHost(nil, nil, program, loaders...)}
+	options := UpdateOptions{Host: deploytest.NewPluginHost(nil, nil, program, loaders...)}
 	project, target := p.GetProject(), p.GetTarget(old)
 
 	// A preview should succeed despite the pending operations.
@@ -2363,7 +2363,7 @@ func TestUpdatePartialFailure(t *testing.T) {
 	})
 
 	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
-	p := &TestPlan{Options: UpdateOptions{host: host}}
+	p := &TestPlan{Options: UpdateOptions{Host: host}}
 
 	resURN := p.NewURN("pkgA:m:typA", "resA", "")
 	p.Steps = []TestStep{{
@@ -2450,7 +2450,7 @@ func TestStackReference(t *testing.T) {
 				}
 			},
 		},
-		Options: UpdateOptions{host: deploytest.NewPluginHost(nil, nil, program, loaders...)},
+		Options: UpdateOptions{Host: deploytest.NewPluginHost(nil, nil, program, loaders...)},
 		Steps:   MakeBasicLifecycleSteps(t, 2),
 	}
 	p.Run(t, nil)
@@ -2508,7 +2508,7 @@ func TestStackReference(t *testing.T) {
 		assert.Error(t, err)
 		return err
 	})
-	p.Options = UpdateOptions{host: deploytest.NewPluginHost(nil, nil, program, loaders...)}
+	p.Options = UpdateOptions{Host: deploytest.NewPluginHost(nil, nil, program, loaders...)}
 	p.Steps = []TestStep{{
 		Op:            Update,
 		ExpectFailure: true,
@@ -2527,7 +2527,7 @@ func TestStackReference(t *testing.T) {
 		assert.Error(t, err)
 		return err
 	})
-	p.Options = UpdateOptions{host: deploytest.NewPluginHost(nil, nil, program, loaders...)}
+	p.Options = UpdateOptions{Host: deploytest.NewPluginHost(nil, nil, program, loaders...)}
 	p.Run(t, nil)
 }
 
@@ -2624,7 +2624,7 @@ func TestLoadFailureShutdown(t *testing.T) {
 
 	op := TestOp(Update)
 	sink := diag.DefaultSink(sinkWriter, sinkWriter, diag.FormatOptions{Color: colors.Raw})
-	options := UpdateOptions{host: deploytest.NewPluginHost(sink, sink, program, loaders...)}
+	options := UpdateOptions{Host: deploytest.NewPluginHost(sink, sink, program, loaders...)}
 	project, target := p.GetProject(), p.GetTarget(old)
 
 	_, res := op.Run(project, target, options, true, nil, nil)
@@ -2797,7 +2797,7 @@ func TestDeleteBeforeReplace(t *testing.T) {
 		}),
 	}
 
-	p.Options.host = deploytest.NewPluginHost(nil, nil, program, loaders...)
+	p.Options.Host = deploytest.NewPluginHost(nil, nil, program, loaders...)
 
 	p.Steps = []TestStep{{
 		Op:            Update,
@@ -2879,7 +2879,7 @@ func TestPropertyDependenciesAdapter(t *testing.T) {
 
 	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
 	p := &TestPlan{
-		Options: UpdateOptions{host: host},
+		Options: UpdateOptions{Host: host},
 		Steps:   []TestStep{{Op: Update}},
 	}
 	snap := p.Run(t, nil)
@@ -2965,7 +2965,7 @@ func TestExplicitDeleteBeforeReplace(t *testing.T) {
 		return nil
 	})
 
-	p.Options.host = deploytest.NewPluginHost(nil, nil, program, loaders...)
+	p.Options.Host = deploytest.NewPluginHost(nil, nil, program, loaders...)
 	p.Steps = []TestStep{{Op: Update}}
 	snap := p.Run(t, nil)
 
@@ -3151,7 +3151,7 @@ func TestSingleResourceIgnoreChanges(t *testing.T) {
 		})
 		host := deploytest.NewPluginHost(nil, nil, program, loaders...)
 		p := &TestPlan{
-			Options: UpdateOptions{host: host},
+			Options: UpdateOptions{Host: host},
 			Steps: []TestStep{
 				{
 					Op: Update,
@@ -3244,7 +3244,7 @@ func TestDefaultProviderDiff(t *testing.T) {
 		})
 		host := deploytest.NewPluginHost(nil, nil, program, loaders...)
 		p := &TestPlan{
-			Options: UpdateOptions{host: host},
+			Options: UpdateOptions{Host: host},
 			Steps: []TestStep{
 				{
 					Op: Update,
@@ -3365,7 +3365,7 @@ func TestDefaultProviderDiffReplacement(t *testing.T) {
 		})
 		host := deploytest.NewPluginHost(nil, nil, program, loaders...)
 		p := &TestPlan{
-			Options: UpdateOptions{host: host},
+			Options: UpdateOptions{Host: host},
 			Steps: []TestStep{
 				{
 					Op: Update,
@@ -3486,7 +3486,7 @@ func TestAliases(t *testing.T) {
 		})
 		host := deploytest.NewPluginHost(nil, nil, program, loaders...)
 		p := &TestPlan{
-			Options: UpdateOptions{host: host},
+			Options: UpdateOptions{Host: host},
 			Steps: []TestStep{
 				{
 					Op: Update,
@@ -3785,7 +3785,7 @@ func TestPersistentDiff(t *testing.T) {
 	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
 
 	p := &TestPlan{
-		Options: UpdateOptions{host: host},
+		Options: UpdateOptions{Host: host},
 	}
 	resURN := p.NewURN("pkgA:m:typA", "resA", "")
 
@@ -3866,7 +3866,7 @@ func TestDetailedDiffReplace(t *testing.T) {
 	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
 
 	p := &TestPlan{
-		Options: UpdateOptions{host: host},
+		Options: UpdateOptions{Host: host},
 	}
 	resURN := p.NewURN("pkgA:m:typA", "resA", "")
 
@@ -3957,7 +3957,7 @@ func TestImport(t *testing.T) {
 	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
 
 	p := &TestPlan{
-		Options: UpdateOptions{host: host},
+		Options: UpdateOptions{Host: host},
 	}
 	provURN := p.NewProviderURN("pkgA", "default", "")
 	resURN := p.NewURN("pkgA:m:typA", "resA", "")
@@ -4163,7 +4163,7 @@ func TestCustomTimeouts(t *testing.T) {
 	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
 
 	p := &TestPlan{
-		Options: UpdateOptions{host: host},
+		Options: UpdateOptions{Host: host},
 	}
 
 	p.Steps = []TestStep{{Op: Update}}
@@ -4206,7 +4206,7 @@ func TestProviderDiffMissingOldOutputs(t *testing.T) {
 	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
 
 	p := &TestPlan{
-		Options: UpdateOptions{host: host},
+		Options: UpdateOptions{Host: host},
 		Config: config.Map{
 			config.MustMakeKey("pkgA", "foo"): config.NewValue("bar"),
 		},
@@ -4298,7 +4298,7 @@ func TestRefreshStepWillPersistUpdatedIDs(t *testing.T) {
 	})
 	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
 
-	p.Options.host = host
+	p.Options.Host = host
 
 	old := &deploy.Snapshot{
 		Resources: []*resource.State{
@@ -4349,7 +4349,7 @@ func TestMissingRead(t *testing.T) {
 	})
 	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
 	p := &TestPlan{
-		Options: UpdateOptions{host: host},
+		Options: UpdateOptions{Host: host},
 		Steps:   []TestStep{{Op: Update, ExpectFailure: true}},
 	}
 	p.Run(t, nil)
@@ -4387,7 +4387,7 @@ func TestImportUpdatedID(t *testing.T) {
 		assert.Equal(t, actualID, id)
 		return nil
 	})
-	p.Options.host = deploytest.NewPluginHost(nil, nil, program, loaders...)
+	p.Options.Host = deploytest.NewPluginHost(nil, nil, program, loaders...)
 
 	p.Steps = []TestStep{{Op: Refresh, SkipPreview: true}}
 	snap := p.Run(t, nil)
@@ -4478,7 +4478,7 @@ func destroySpecificTargets(
 		}),
 	}
 
-	p.Options.host = deploytest.NewPluginHost(nil, nil, program, loaders...)
+	p.Options.Host = deploytest.NewPluginHost(nil, nil, program, loaders...)
 	p.Options.TargetDependents = targetDependents
 
 	destroyTargets := []resource.URN{}
@@ -4572,7 +4572,7 @@ func updateSpecificTargets(t *testing.T, targets []string) {
 		}),
 	}
 
-	p.Options.host = deploytest.NewPluginHost(nil, nil, program, loaders...)
+	p.Options.Host = deploytest.NewPluginHost(nil, nil, program, loaders...)
 
 	updateTargets := []resource.URN{}
 	for _, target := range targets {
@@ -4646,7 +4646,7 @@ func updateInvalidTarget(t *testing.T) {
 		}),
 	}
 
-	p.Options.host = deploytest.NewPluginHost(nil, nil, program, loaders...)
+	p.Options.Host = deploytest.NewPluginHost(nil, nil, program, loaders...)
 
 	p.Options.UpdateTargets = []resource.URN{"foo"}
 	t.Logf("Updating invalid targets: %v", p.Options.UpdateTargets)
@@ -4674,7 +4674,7 @@ func TestCreateDuringTargetedUpdate_CreateMentionedAsTarget(t *testing.T) {
 	host1 := deploytest.NewPluginHost(nil, nil, program1, loaders...)
 
 	p := &TestPlan{
-		Options: UpdateOptions{host: host1},
+		Options: UpdateOptions{Host: host1},
 	}
 
 	p.Steps = []TestStep{{Op: Update}}
@@ -4694,7 +4694,7 @@ func TestCreateDuringTargetedUpdate_CreateMentionedAsTarget(t *testing.T) {
 
 	resA := p.NewURN("pkgA:m:typA", "resA", "")
 	resB := p.NewURN("pkgA:m:typA", "resB", "")
-	p.Options.host = host2
+	p.Options.Host = host2
 	p.Options.UpdateTargets = []resource.URN{resA, resB}
 	p.Steps = []TestStep{{
 		Op:            Update,
@@ -4734,7 +4734,7 @@ func TestCreateDuringTargetedUpdate_UntargetedCreateNotReferenced(t *testing.T)
 	host1 := deploytest.NewPluginHost(nil, nil, program1, loaders...)
 
 	p := &TestPlan{
-		Options: UpdateOptions{host: host1},
+		Options: UpdateOptions{Host: host1},
 	}
 
 	p.Steps = []TestStep{{Op: Update}}
@@ -4754,7 +4754,7 @@ func TestCreateDuringTargetedUpdate_UntargetedCreateNotReferenced(t *testing.T)
 
 	resA := p.NewURN("pkgA:m:typA", "resA", "")
 
-	p.Options.host = host2
+	p.Options.Host = host2
 	p.Options.UpdateTargets = []resource.URN{resA}
 	p.Steps = []TestStep{{
 		Op:            Update,
@@ -4791,7 +4791,7 @@ func TestCreateDuringTargetedUpdate_UntargetedCreateReferencedByTarget(t *testin
 	host1 := deploytest.NewPluginHost(nil, nil, program1, loaders...)
 
 	p := &TestPlan{
-		Options: UpdateOptions{host: host1},
+		Options: UpdateOptions{Host: host1},
 	}
 
 	p.Steps = []TestStep{{Op: Update}}
@@ -4816,7 +4816,7 @@ func TestCreateDuringTargetedUpdate_UntargetedCreateReferencedByTarget(t *testin
 	})
 	host2 := deploytest.NewPluginHost(nil, nil, program2, loaders...)
 
-	p.Options.host = host2
+	p.Options.Host = host2
 	p.Options.UpdateTargets = []resource.URN{resA}
 	p.Steps = []TestStep{{
 		Op:            Update,
@@ -4840,7 +4840,7 @@ func TestCreateDuringTargetedUpdate_UntargetedCreateReferencedByUntargetedCreate
 	host1 := deploytest.NewPluginHost(nil, nil, program1, loaders...)
 
 	p := &TestPlan{
-		Options: UpdateOptions{host: host1},
+		Options: UpdateOptions{Host: host1},
 	}
 
 	p.Steps = []TestStep{{Op: Update}}
@@ -4868,7 +4868,7 @@ func TestCreateDuringTargetedUpdate_UntargetedCreateReferencedByUntargetedCreate
 	})
 	host2 := deploytest.NewPluginHost(nil, nil, program2, loaders...)
 
-	p.Options.host = host2
+	p.Options.Host = host2
 	p.Options.UpdateTargets = []resource.URN{resA}
 	p.Steps = []TestStep{{
 		Op:            Update,
@@ -4944,7 +4944,7 @@ func TestDependencyChangeDBR(t *testing.T) {
 		return nil
 	})
 
-	p.Options.host = deploytest.NewPluginHost(nil, nil, program, loaders...)
+	p.Options.Host = deploytest.NewPluginHost(nil, nil, program, loaders...)
 	p.Steps = []TestStep{{Op: Update}}
 	snap := p.Run(t, nil)
 
@@ -4963,7 +4963,7 @@ func TestDependencyChangeDBR(t *testing.T) {
 		return nil
 	})
 
-	p.Options.host = deploytest.NewPluginHost(nil, nil, program, loaders...)
+	p.Options.Host = deploytest.NewPluginHost(nil, nil, program, loaders...)
 	p.Steps = []TestStep{
 		{
 			Op: Update,
@@ -5026,7 +5026,7 @@ func TestReplaceSpecificTargets(t *testing.T) {
 		}),
 	}
 
-	p.Options.host = deploytest.NewPluginHost(nil, nil, program, loaders...)
+	p.Options.Host = deploytest.NewPluginHost(nil, nil, program, loaders...)
 
 	getURN := func(name string) resource.URN {
 		return pickURN(t, urns, complexTestDependencyGraphNames, name)
@@ -5117,7 +5117,7 @@ func TestPreviewInputPropagation(t *testing.T) {
 	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
 
 	p := &TestPlan{
-		Options: UpdateOptions{host: host},
+		Options: UpdateOptions{Host: host},
 	}
 
 	project := p.GetProject()
@@ -5138,6 +5138,173 @@ func TestPreviewInputPropagation(t *testing.T) {
 	assert.Nil(t, res)
 }
 
+func TestUpdatePlan(t *testing.T) {
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{
+				CreateF: func(urn resource.URN,
+					news resource.PropertyMap, timeout float64) (resource.ID, resource.PropertyMap, resource.Status, error) {
+
+					return "created-id", news, resource.StatusOK, nil
+				},
+			}, nil
+		}),
+	}
+
+	preview, foo, createB := true, "bar", false
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		computed := interface{}(resource.Computed{Element: resource.NewStringProperty("")})
+		if !preview {
+			computed = "alpha"
+		}
+
+		ins := resource.NewPropertyMapFromMap(map[string]interface{}{
+			"foo": foo,
+			"zed": computed,
+		})
+		_, _, _, err := monitor.RegisterResource("pkgA:m:typA", "resA", true, deploytest.ResourceOptions{
+			Inputs: ins,
+		})
+		assert.NoError(t, err)
+
+		if createB {
+			_, _, _, err = monitor.RegisterResource("pkgA:m:typA", "resB", true)
+			assert.NoError(t, err)
+		}
+
+		return nil
+	})
+	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
+
+	p := &TestPlan{
+		Options: UpdateOptions{Host: host},
+	}
+
+	project := p.GetProject()
+
+	// Run a preview and record its plan. The plan should include the creation of resA.
+	plan := deploy.NewUpdatePlan(deploy.Manifest{})
+	p.Options.GeneratedPlan = plan
+	_, res := TestOp(Update).Run(project, p.GetTarget(nil), p.Options, true, p.BackendClient, nil)
+	assert.Nil(t, res)
+	resA := p.NewURN("pkgA:m:typA", "resA", "")
+	if assert.Contains(t, plan.ResourcePlans, resA) {
+		assert.Equal(t, []deploy.StepOp{deploy.OpCreate}, plan.ResourcePlans[resA].Ops)
+	}
+
+	// An update whose inputs differ from the plan should fail.
+	p.Options.GeneratedPlan, p.Options.Plan = nil, plan
+	preview, foo = false, "baz"
+	_, res = TestOp(Update).Run(project, p.GetTarget(nil), p.Options, false, p.BackendClient, nil)
+	assert.NotNil(t, res)
+
+	// As should an update that creates a resource that is not in the plan.
+	foo, createB = "bar", true
+	_, res = TestOp(Update).Run(project, p.GetTarget(nil), p.Options, false, p.BackendClient, nil)
+	assert.NotNil(t, res)
+
+	// An update that matches the plan should succeed, even though a value that was unknown during the preview is now
+	// known.
+	createB = false
+	snap, res := TestOp(Update).Run(project, p.GetTarget(nil), p.Options, false, p.BackendClient, nil)
+	assert.Nil(t, res)
+	assert.Len(t, snap.Resources, 2)
+}
+
+func TestImportResources(t *testing.T) {
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{
+				CreateF: func(urn resource.URN,
+					news resource.PropertyMap, timeout float64) (resource.ID, resource.PropertyMap, resource.Status, error) {
+
+					return "created-id", news, resource.StatusOK, nil
+				},
+				ReadF: func(urn resource.URN, id resource.ID,
+					inputs, state resource.PropertyMap) (plugin.ReadResult, resource.Status, error) {
+
+					if id == "missing-id" {
+						return plugin.ReadResult{}, resource.StatusOK, nil
+					}
+					return plugin.ReadResult{
+						Inputs: resource.PropertyMap{"foo": resource.NewStringProperty(string(id))},
+						Outputs: resource.PropertyMap{
+							"foo": resource.NewStringProperty(string(id)),
+							"bar": resource.NewNumberProperty(42),
+						},
+					}, resource.StatusOK, nil
+				},
+			}, nil
+		}),
+	}
+
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		_, _, _, err := monitor.RegisterResource("pkgA:m:typA", "resA", true)
+		assert.NoError(t, err)
+		return nil
+	})
+	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
+
+	p := &TestPlan{
+		Options: UpdateOptions{Host: host},
+	}
+	project := p.GetProject()
+
+	importOp := func(imports []deploy.Import) TestOp {
+		return func(info UpdateInfo, ctx *Context, opts UpdateOptions, dryRun bool) (ResourceChanges, result.Result) {
+			return Import(info, ctx, opts, imports, dryRun)
+		}
+	}
+
+	// Create a resource using the program.
+	snap, res := TestOp(Update).Run(project, p.GetTarget(nil), p.Options, false, p.BackendClient, nil)
+	assert.Nil(t, res)
+	assert.Len(t, snap.Resources, 2)
+
+	// Import a resource and a child of that resource. A root stack resource should be created to parent the first.
+	resA := p.NewURN("pkgA:m:typA", "resA", "")
+	resB := p.NewURN("pkgA:m:typA", "resB", "")
+	resC := p.NewURN("pkgA:m:typB", "resC", resB)
+	imports := []deploy.Import{
+		{Type: "pkgA:m:typA", Name: "resB", ID: "b-id"},
+		{Type: "pkgA:m:typB", Name: "resC", ID: "c-id", Parent: resB, Protect: true},
+	}
+	_, res = importOp(imports).Run(project, p.GetTarget(snap), p.Options, true, p.BackendClient, nil)
+	assert.Nil(t, res)
+	snap, res = importOp(imports).Run(project, p.GetTarget(snap), p.Options, false, p.BackendClient, nil)
+	assert.Nil(t, res)
+	assert.Len(t, snap.Resources, 5)
+
+	byURN := make(map[resource.URN]*resource.State)
+	for _, r := range snap.Resources {
+		byURN[r.URN] = r
+	}
+	assert.Contains(t, byURN, resA)
+	if assert.Contains(t, byURN, resB) {
+		assert.Equal(t, resource.ID("b-id"), byURN[resB].ID)
+		assert.Equal(t, resource.RootStackType, byURN[resB].Parent.Type())
+		assert.Equal(t, resource.NewStringProperty("b-id"), byURN[resB].Inputs["foo"])
+		assert.Equal(t, resource.NewNumberProperty(42), byURN[resB].Outputs["bar"])
+		assert.Equal(t, byURN[resA].Provider, byURN[resB].Provider)
+	}
+	if assert.Contains(t, byURN, resC) {
+		assert.Equal(t, resB, byURN[resC].Parent)
+		assert.True(t, byURN[resC].Protect)
+	}
+
+	// Importing a resource that already exists should fail, as should importing a resource with an unknown parent or
+	// a resource that does not exist.
+	for _, imp := range []deploy.Import{
+		{Type: "pkgA:m:typA", Name: "resB", ID: "b-id"},
+		{Type: "pkgA:m:typA", Name: "resD", ID: "d-id", Parent: p.NewURN("pkgA:m:typA", "resE", "")},
+		{Type: "pkgA:m:typA", Name: "resD", ID: "missing-id"},
+	} {
+		imports = []deploy.Import{imp}
+		_, res = importOp(imports).Run(project, p.GetTarget(snap), p.Options, false, p.BackendClient, nil)
+		assert.NotNil(t, res)
+	}
+}
+
 func TestSingleResourceDefaultProviderGolangLifecycle(t *testing.T) {
 	loaders := []*deploytest.ProviderLoader{
 		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
@@ -5182,12 +5349,259 @@ func TestSingleResourceDefaultProviderGolangLifecycle(t *testing.T) {
 	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
 
 	p := &TestPlan{
-		Options: UpdateOptions{host: host},
+		Options: UpdateOptions{Host: host},
 		Steps:   MakeBasicLifecycleSteps(t, 4),
 	}
 	p.Run(t, nil)
 }
 
+type testTypedResource struct {
+	pulumi.ResourceState
+
+	Foo pulumi.StringOutput      `pulumi:"foo"`
+	Bar pulumi.StringArrayOutput `pulumi:"bar"`
+}
+
+type testTypedResourceArgs struct {
+	Foo pulumi.StringInput `pulumi:"foo"`
+	Baz pulumi.StringInput `pulumi:"baz"`
+}
+
+func TestTypedResourceGolangLifecycle(t *testing.T) {
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{
+				CreateF: func(urn resource.URN,
+					news resource.PropertyMap, timeout float64) (resource.ID, resource.PropertyMap, resource.Status, error) {
+
+					outs := news.Copy()
+					outs["bar"] = resource.NewArrayProperty([]resource.PropertyValue{
+						resource.NewStringProperty("a"),
+						resource.NewStringProperty("b"),
+					})
+					return "created-id", outs, resource.StatusOK, nil
+				},
+			}, nil
+		}),
+	}
+
+	program := deploytest.NewLanguageRuntime(func(info plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		ctx, err := pulumi.NewContext(context.Background(), pulumi.RunInfo{
+			Project:     info.Project,
+			Stack:       info.Stack,
+			Parallel:    info.Parallel,
+			DryRun:      info.DryRun,
+			MonitorAddr: info.MonitorAddress,
+		})
+		assert.NoError(t, err)
+
+		return pulumi.RunWithContext(ctx, func(ctx *pulumi.Context) error {
+			var resA testTypedResource
+			err := ctx.RegisterCustomResource("pkgA:m:typA", "resA", &testTypedResourceArgs{
+				Foo: pulumi.String("foo"),
+			}, &resA)
+			assert.NoError(t, err)
+
+			// Outputs that are not inputs should be available, and typed outputs should flow into other resources.
+			var resB testTypedResource
+			err = ctx.RegisterCustomResource("pkgA:m:typA", "resB", &testTypedResourceArgs{
+				Foo: resA.Foo,
+				Baz: pulumi.StringOutput(resA.Bar.ApplyT(func(v []string) string {
+					return strings.Join(v, ",")
+				})),
+			}, &resB)
+			assert.NoError(t, err)
+
+			return nil
+		})
+	})
+	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
+
+	p := &TestPlan{
+		Options: UpdateOptions{Host: host},
+	}
+	snap, res := TestOp(Update).Run(p.GetProject(), p.GetTarget(nil), p.Options, false, p.BackendClient, nil)
+	assert.Nil(t, res)
+
+	resA, resB := p.NewURN("pkgA:m:typA", "resA", ""), p.NewURN("pkgA:m:typA", "resB", "")
+	var found bool
+	for _, r := range snap.Resources {
+		if r.URN == resB {
+			found = true
+			assert.Equal(t, resource.NewStringProperty("foo"), r.Inputs["foo"])
+			assert.Equal(t, resource.NewStringProperty("a,b"), r.Inputs["baz"])
+			assert.Equal(t, []resource.URN{resA}, r.Dependencies)
+		}
+	}
+	assert.True(t, found)
+}
+
+type testComponentResource struct {
+	pulumi.ResourceState
+}
+
+func TestComponentResourceGolangLifecycle(t *testing.T) {
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{}, nil
+		}),
+	}
+
+	newProgram := func(name string, aliases []pulumi.Alias) plugin.LanguageRuntime {
+		return deploytest.NewLanguageRuntime(func(info plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+			ctx, err := pulumi.NewContext(context.Background(), pulumi.RunInfo{
+				Project:     info.Project,
+				Stack:       info.Stack,
+				Parallel:    info.Parallel,
+				DryRun:      info.DryRun,
+				MonitorAddr: info.MonitorAddress,
+			})
+			assert.NoError(t, err)
+
+			return pulumi.RunWithContext(ctx, func(ctx *pulumi.Context) error {
+				// Tag every resource in the stack.
+				err := ctx.RegisterStackTransformation(func(
+					args *pulumi.ResourceTransformationArgs) *pulumi.ResourceTransformationResult {
+
+					if !strings.HasPrefix(args.Type, "pkgA:") {
+						return nil
+					}
+					props := map[string]interface{}{"tag": "tagged"}
+					for k, v := range args.Props {
+						props[k] = v
+					}
+					return &pulumi.ResourceTransformationResult{Props: props, Opts: args.Opts}
+				})
+				assert.NoError(t, err)
+
+				var comp testComponentResource
+				err = ctx.RegisterComponentResource("my:comp:C", name, &comp, pulumi.ResourceOpt{
+					Protect: true,
+					Aliases: aliases,
+				})
+				assert.NoError(t, err)
+
+				var child testTypedResource
+				err = ctx.RegisterCustomResource("pkgA:m:typA", name+"-child", &testTypedResourceArgs{
+					Foo: pulumi.String("foo"),
+				}, &child, pulumi.ResourceOpt{Parent: &comp})
+				assert.NoError(t, err)
+
+				return ctx.RegisterComponentResourceOutputs(&comp, map[string]interface{}{"foo": child.Foo})
+			})
+		})
+	}
+
+	p := &TestPlan{
+		Options: UpdateOptions{Host: deploytest.NewPluginHost(nil, nil, newProgram("comp", nil), loaders...)},
+	}
+	snap, res := TestOp(Update).Run(p.GetProject(), p.GetTarget(nil), p.Options, false, p.BackendClient, nil)
+	assert.Nil(t, res)
+
+	// The child should be parented to the component, and should inherit its protection and the stack's
+	// transformation.
+	comp := p.NewURN("my:comp:C", "comp", "")
+	child := p.NewURN("pkgA:m:typA", "comp-child", comp)
+	var found bool
+	for _, r := range snap.Resources {
+		switch r.URN {
+		case comp:
+			assert.True(t, r.Protect)
+			assert.Equal(t, resource.NewStringProperty("foo"), r.Outputs["foo"])
+		case child:
+			found = true
+			assert.Equal(t, comp, r.Parent)
+			assert.True(t, r.Protect)
+			assert.Equal(t, resource.NewStringProperty("tagged"), r.Inputs["tag"])
+		}
+	}
+	assert.True(t, found)
+
+	// Renaming the component with an alias should also alias its child, so nothing should be created or deleted.
+	p.Options.Host = deploytest.NewPluginHost(nil, nil, newProgram("renamed", []pulumi.Alias{{Name: "comp"}}), loaders...)
+	p.Steps = []TestStep{{
+		Op: Update,
+		Validate: func(project workspace.Project, target deploy.Target, j *Journal,
+			events []Event, res result.Result) result.Result {
+			for _, entry := range j.Entries {
+				assert.Equal(t, deploy.OpSame, entry.Step.Op(), "%v", entry.Step.URN())
+			}
+			return res
+		},
+	}}
+	snap = p.Run(t, snap)
+	assert.Len(t, snap.Resources, 4)
+}
+
+func TestStackReferenceGolangLifecycle(t *testing.T) {
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{
+				CreateF: func(urn resource.URN,
+					news resource.PropertyMap, timeout float64) (resource.ID, resource.PropertyMap, resource.Status, error) {
+
+					return "created-id", news, resource.StatusOK, nil
+				},
+			}, nil
+		}),
+	}
+
+	program := deploytest.NewLanguageRuntime(func(info plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		ctx, err := pulumi.NewContext(context.Background(), pulumi.RunInfo{
+			Project:     info.Project,
+			Stack:       info.Stack,
+			Parallel:    info.Parallel,
+			DryRun:      info.DryRun,
+			MonitorAddr: info.MonitorAddress,
+		})
+		assert.NoError(t, err)
+
+		return pulumi.RunWithContext(ctx, func(ctx *pulumi.Context) error {
+			ref, err := pulumi.NewStackReference(ctx, "ref", &pulumi.StackReferenceArgs{Name: "other"})
+			assert.NoError(t, err)
+
+			var res testTypedResource
+			err = ctx.RegisterCustomResource("pkgA:m:typA", "resA", map[string]interface{}{
+				"plain":  ref.GetStringOutput(pulumi.String("plain")),
+				"secret": ref.GetOutput(pulumi.String("secret")),
+				"forced": ref.GetSecretOutput(pulumi.String("plain")),
+			}, &res)
+			assert.NoError(t, err)
+			return nil
+		})
+	})
+
+	p := &TestPlan{
+		BackendClient: &deploytest.BackendClient{
+			GetStackOutputsF: func(ctx context.Context, name string) (resource.PropertyMap, error) {
+				if name != "other" {
+					return nil, errors.Errorf("unknown stack \"%s\"", name)
+				}
+				return resource.PropertyMap{
+					"plain":  resource.NewStringProperty("foo"),
+					"secret": resource.MakeSecret(resource.NewStringProperty("bar")),
+				}, nil
+			},
+		},
+		Options: UpdateOptions{Host: deploytest.NewPluginHost(nil, nil, program, loaders...)},
+	}
+	snap, res := TestOp(Update).Run(p.GetProject(), p.GetTarget(nil), p.Options, false, p.BackendClient, nil)
+	assert.Nil(t, res)
+
+	// Only the secret stack output and the output fetched with GetSecretOutput should be secret.
+	resA := p.NewURN("pkgA:m:typA", "resA", "")
+	var found bool
+	for _, r := range snap.Resources {
+		if r.URN == resA {
+			found = true
+			assert.Equal(t, resource.NewStringProperty("foo"), r.Inputs["plain"])
+			assert.Equal(t, resource.MakeSecret(resource.NewStringProperty("bar")), r.Inputs["secret"])
+			assert.Equal(t, resource.MakeSecret(resource.NewStringProperty("foo")), r.Inputs["forced"])
+		}
+	}
+	assert.True(t, found)
+}
+
 // This test validates the wiring of the IgnoreChanges prop in the go SDK.
 // It doesn't attempt to validate underlying behavior.
 func TestIgnoreChangesGolangLifecycle(t *testing.T) {
@@ -5239,7 +5653,7 @@ func TestIgnoreChangesGolangLifecycle(t *testing.T) {
 
 		host := deploytest.NewPluginHost(nil, nil, program, loaders...)
 		p := &TestPlan{
-			Options: UpdateOptions{host: host},
+			Options: UpdateOptions{Host: host},
 			Steps: []TestStep{
 				{
 					Op: Update,
@@ -5335,7 +5749,7 @@ func TestExplicitDeleteBeforeReplaceGoSDK(t *testing.T) {
 
 	})
 
-	p.Options.host = deploytest.NewPluginHost(nil, nil, program, loaders...)
+	p.Options.Host = deploytest.NewPluginHost(nil, nil, program, loaders...)
 	p.Steps = []TestStep{{Op: Update}}
 	snap := p.Run(t, nil)
 
@@ -5424,7 +5838,7 @@ func TestReadResourceGolangLifecycle(t *testing.T) {
 
 		host := deploytest.NewPluginHost(nil, nil, program, loaders...)
 		p := &TestPlan{
-			Options: UpdateOptions{host: host},
+			Options: UpdateOptions{Host: host},
 			Steps: []TestStep{
 				{
 					Op: Update,
@@ -5618,7 +6032,7 @@ func TestProviderInheritanceGolangLifecycle(t *testing.T) {
 	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
 
 	p := &TestPlan{
-		Options: UpdateOptions{host: host},
+		Options: UpdateOptions{Host: host},
 		Steps:   []TestStep{{Op: Update}},
 	}
 	p.Run(t, nil)
@@ -0,0 +1,256 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package engine
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/resource/deploy/providers"
+	"github.com/pulumi/pulumi/pkg/resource/graph"
+	"github.com/pulumi/pulumi/pkg/tokens"
+	"github.com/pulumi/pulumi/pkg/util/logging"
+)
+
+// PerformanceReport records where the time in an update was spent. The engine fills in the report passed via
+// UpdateOptions.PerformanceReport once the update's steps have finished executing. Previews are not recorded.
+//
+// All times are reported in seconds. Start times are relative to the start of the first step.
+type PerformanceReport struct {
+	// Parallel is the degree of parallelism with which the update was configured.
+	Parallel int `json:"parallel"`
+	// EffectiveParallelism is the average number of steps that were executing at once.
+	EffectiveParallelism float64 `json:"effectiveParallelism"`
+	// DurationSeconds is the time from the start of the first step to the end of the last step.
+	DurationSeconds float64 `json:"durationSeconds"`
+	// ExecutingSeconds is the total time spent executing steps.
+	ExecutingSeconds float64 `json:"executingSeconds"`
+	// Resources holds the timing of each step, ordered by start time.
+	Resources []ResourceTiming `json:"resources"`
+	// Providers holds the time spent executing the steps performed by each provider package, slowest first.
+	Providers []ProviderTiming `json:"providers"`
+	// CriticalPath is the chain of steps that determined the duration of the update: each step in the path is the
+	// dependency of the next that finished last, and the final step is the one that finished last.
+	CriticalPath []ResourceTiming `json:"criticalPath"`
+	// CriticalPathSeconds is the time spent executing the steps in the critical path.
+	CriticalPathSeconds float64 `json:"criticalPathSeconds"`
+
+	lock    sync.Mutex
+	pending map[deploy.Step]time.Time
+	steps   []stepTiming
+}
+
+// ResourceTiming records the timing of a single step.
+//
+// A step becomes ready when the last of its dependencies finishes, or at the start of the first step if it has no
+// dependencies that ran in the update. The time that it then waits before starting is spent waiting for the program to
+// register the resource or for a free slot under the update's degree of parallelism.
+type ResourceTiming struct {
+	URN              resource.URN  `json:"urn"`
+	Type             tokens.Type   `json:"type"`
+	Op               deploy.StepOp `json:"op"`
+	Provider         string        `json:"provider,omitempty"` // the package of the provider that performed the step.
+	StartSeconds     float64       `json:"startSeconds"`       // the start of the step.
+	WaitingSeconds   float64       `json:"waitingSeconds"`     // the time from the step becoming ready to its start.
+	ExecutingSeconds float64       `json:"executingSeconds"`   // the time spent executing the step.
+	Failed           bool          `json:"failed,omitempty"`   // true if the step failed.
+}
+
+// ProviderTiming records the time spent executing the steps performed by a single provider package.
+type ProviderTiming struct {
+	Provider         string  `json:"provider"`
+	Steps            int     `json:"steps"`
+	ExecutingSeconds float64 `json:"executingSeconds"`
+	MaxSeconds       float64 `json:"maxSeconds"` // the time spent executing the provider's slowest step.
+}
+
+// stepTiming is the raw timing of a single step.
+type stepTiming struct {
+	state  *resource.State
+	op     deploy.StepOp
+	start  time.Time
+	end    time.Time
+	failed bool
+}
+
+// reset prepares the report to record an update that is executed with the given degree of parallelism.
+func (r *PerformanceReport) reset(parallel int) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.Parallel, r.EffectiveParallelism, r.DurationSeconds, r.ExecutingSeconds = parallel, 0, 0, 0
+	r.Resources, r.Providers, r.CriticalPath, r.CriticalPathSeconds = nil, nil, nil, 0
+	r.pending, r.steps = make(map[deploy.Step]time.Time), nil
+}
+
+// stepStarted records that the given step has started executing.
+func (r *PerformanceReport) stepStarted(step deploy.Step) {
+	now := time.Now()
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.pending[step] = now
+}
+
+// stepFinished records that the given step has finished executing.
+func (r *PerformanceReport) stepFinished(step deploy.Step, failed bool) {
+	now := time.Now()
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	start, ok := r.pending[step]
+	if !ok {
+		logging.V(7).Infof("PerformanceReport: step %v on %v finished without starting", step.Op(), step.URN())
+		return
+	}
+	delete(r.pending, step)
+
+	r.steps = append(r.steps, stepTiming{state: step.Res(), op: step.Op(), start: start, end: now, failed: failed})
+}
+
+// finish computes the report from the timings of the steps that have finished executing.
+func (r *PerformanceReport) finish() {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.Resources, r.Providers, r.CriticalPath = []ResourceTiming{}, []ProviderTiming{}, []ResourceTiming{}
+	if len(r.steps) == 0 {
+		return
+	}
+
+	steps := r.steps
+	sort.SliceStable(steps, func(i, j int) bool { return steps[i].start.Before(steps[j].start) })
+
+	// Steps may only start once their dependencies have finished, so ordering the steps' states by start time
+	// orders them topologically.
+	var states []*resource.State
+	seen := make(map[*resource.State]bool)
+	byURN := make(map[resource.URN][]int)
+	for i, s := range steps {
+		if !seen[s.state] {
+			states = append(states, s.state)
+			seen[s.state] = true
+		}
+		byURN[s.state.URN] = append(byURN[s.state.URN], i)
+	}
+	dg := graph.NewDependencyGraph(states)
+
+	// For each step, find the dependency that gated it: of the steps for the resources on which the step's resource
+	// depends, the one that finished last before the step started, or the earliest such step in the case of a tie.
+	// Steps that delete resources are gated by their dependents rather than by their dependencies, so they have no
+	// gating step.
+	begin, end := steps[0].start, steps[0].end
+	gates := make([]int, len(steps))
+	providerTimings := make(map[string]*ProviderTiming)
+	for i, s := range steps {
+		gates[i] = -1
+		for dep := range dg.DependenciesOf(s.state) {
+			for _, j := range byURN[dep.URN] {
+				d := steps[j]
+				if d.end.After(s.start) {
+					continue
+				}
+				if g := gates[i]; g == -1 || d.end.After(steps[g].end) || d.end.Equal(steps[g].end) && j < g {
+					gates[i] = j
+				}
+			}
+		}
+
+		ready := begin
+		if gates[i] != -1 {
+			ready = steps[gates[i]].end
+		}
+		timing := ResourceTiming{
+			URN:              s.state.URN,
+			Type:             s.state.Type,
+			Op:               s.op,
+			Provider:         stepProviderPackage(s.state),
+			StartSeconds:     s.start.Sub(begin).Seconds(),
+			WaitingSeconds:   s.start.Sub(ready).Seconds(),
+			ExecutingSeconds: s.end.Sub(s.start).Seconds(),
+			Failed:           s.failed,
+		}
+		r.Resources = append(r.Resources, timing)
+		r.ExecutingSeconds += timing.ExecutingSeconds
+
+		if s.end.After(end) {
+			end = s.end
+		}
+
+		if timing.Provider != "" {
+			p, ok := providerTimings[timing.Provider]
+			if !ok {
+				p = &ProviderTiming{Provider: timing.Provider}
+				providerTimings[timing.Provider] = p
+			}
+			p.Steps++
+			p.ExecutingSeconds += timing.ExecutingSeconds
+			if timing.ExecutingSeconds > p.MaxSeconds {
+				p.MaxSeconds = timing.ExecutingSeconds
+			}
+		}
+	}
+
+	r.DurationSeconds = end.Sub(begin).Seconds()
+	if r.DurationSeconds > 0 {
+		r.EffectiveParallelism = r.ExecutingSeconds / r.DurationSeconds
+	}
+
+	for _, p := range providerTimings {
+		r.Providers = append(r.Providers, *p)
+	}
+	sort.Slice(r.Providers, func(i, j int) bool {
+		if r.Providers[i].ExecutingSeconds != r.Providers[j].ExecutingSeconds {
+			return r.Providers[i].ExecutingSeconds > r.Providers[j].ExecutingSeconds
+		}
+		return r.Providers[i].Provider < r.Providers[j].Provider
+	})
+
+	// The critical path ends with the step that finished last and follows each step's gate back to the start.
+	last := 0
+	for i, s := range steps {
+		if s.end.After(steps[last].end) {
+			last = i
+		}
+	}
+	var path []ResourceTiming
+	for i := last; i != -1; i = gates[i] {
+		path = append(path, r.Resources[i])
+		r.CriticalPathSeconds += r.Resources[i].ExecutingSeconds
+	}
+	for i := len(path) - 1; i >= 0; i-- {
+		r.CriticalPath = append(r.CriticalPath, path[i])
+	}
+}
+
+// stepProviderPackage returns the package of the provider that manages the given resource, or the empty string if the
+// resource is not managed by a provider.
+func stepProviderPackage(state *resource.State) string {
+	if providers.IsProviderType(state.Type) {
+		return string(providers.GetProviderPackage(state.Type))
+	}
+	if state.Provider == "" {
+		return ""
+	}
+	ref, err := providers.ParseReference(state.Provider)
+	if err != nil {
+		return ""
+	}
+	return string(providers.GetProviderPackage(ref.URN().Type()))
+}
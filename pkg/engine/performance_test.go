@@ -0,0 +1,101 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/tokens"
+)
+
+func TestPerformanceReport(t *testing.T) {
+	urn := func(typ tokens.Type, name string) resource.URN {
+		return resource.NewURN("test", "test", "", typ, tokens.QName(name))
+	}
+	provURN := urn("pulumi:providers:pkgA", "default")
+	prov := &resource.State{URN: provURN, Type: "pulumi:providers:pkgA", Custom: true, ID: "id"}
+	provRef := string(provURN) + "::id"
+
+	comp := &resource.State{URN: urn("my:index:Component", "comp"), Type: "my:index:Component"}
+	a := &resource.State{URN: urn("pkgA:m:typA", "a"), Type: "pkgA:m:typA", Custom: true, Provider: provRef,
+		Parent: comp.URN}
+	b := &resource.State{URN: urn("pkgA:m:typA", "b"), Type: "pkgA:m:typA", Custom: true, Provider: provRef}
+	c := &resource.State{URN: urn("pkgA:m:typA", "c"), Type: "pkgA:m:typA", Custom: true, Provider: provRef,
+		Dependencies: []resource.URN{a.URN, b.URN}}
+
+	// prov [0s, 1s], comp [1s, 1s], a [1s, 4s], b [1s, 2s], c [5s, 6s]. c waits on a, which waits on both prov and
+	// comp; prov started first, so it is on the critical path. comp has no dependencies, so it was ready at 0s and
+	// waited 1s to start; c was ready when a finished at 4s, and waited 1s to start.
+	base := time.Unix(0, 0)
+	at := func(s int) time.Time { return base.Add(time.Duration(s) * time.Second) }
+	report := &PerformanceReport{}
+	report.reset(4)
+	report.steps = []stepTiming{
+		{state: c, op: deploy.OpCreate, start: at(5), end: at(6)},
+		{state: prov, op: deploy.OpCreate, start: at(0), end: at(1)},
+		{state: comp, op: deploy.OpCreate, start: at(1), end: at(1)},
+		{state: a, op: deploy.OpUpdate, start: at(1), end: at(4)},
+		{state: b, op: deploy.OpCreate, start: at(1), end: at(2), failed: true},
+	}
+	report.finish()
+
+	assert.Equal(t, 4, report.Parallel)
+	assert.Equal(t, 6.0, report.DurationSeconds)
+	assert.Equal(t, 6.0, report.ExecutingSeconds)
+	assert.Equal(t, 1.0, report.EffectiveParallelism)
+
+	if assert.Len(t, report.Resources, 5) {
+		assert.Equal(t, ResourceTiming{
+			URN: prov.URN, Type: prov.Type, Op: deploy.OpCreate, Provider: "pkgA", ExecutingSeconds: 1,
+		}, report.Resources[0])
+		assert.Equal(t, ResourceTiming{
+			URN: comp.URN, Type: comp.Type, Op: deploy.OpCreate, StartSeconds: 1, WaitingSeconds: 1,
+		}, report.Resources[1])
+		assert.Equal(t, ResourceTiming{
+			URN: a.URN, Type: a.Type, Op: deploy.OpUpdate, Provider: "pkgA", StartSeconds: 1, ExecutingSeconds: 3,
+		}, report.Resources[2])
+		assert.True(t, report.Resources[3].Failed)
+		assert.Equal(t, ResourceTiming{
+			URN: c.URN, Type: c.Type, Op: deploy.OpCreate, Provider: "pkgA", StartSeconds: 5, WaitingSeconds: 1,
+			ExecutingSeconds: 1,
+		}, report.Resources[4])
+	}
+
+	assert.Equal(t, []ProviderTiming{{Provider: "pkgA", Steps: 4, ExecutingSeconds: 6, MaxSeconds: 3}},
+		report.Providers)
+
+	var path []resource.URN
+	for _, r := range report.CriticalPath {
+		path = append(path, r.URN)
+	}
+	assert.Equal(t, []resource.URN{prov.URN, a.URN, c.URN}, path)
+	assert.Equal(t, 5.0, report.CriticalPathSeconds)
+}
+
+func TestEmptyPerformanceReport(t *testing.T) {
+	report := &PerformanceReport{}
+	report.reset(1)
+	report.finish()
+
+	assert.Empty(t, report.Resources)
+	assert.NotNil(t, report.Resources)
+	assert.Empty(t, report.CriticalPath)
+	assert.Equal(t, 0.0, report.EffectiveParallelism)
+}
@@ -97,6 +97,9 @@ type planOptions struct {
 	// true if we're planning a refresh.
 	isRefresh bool
 
+	// the resources to import, if we're planning an import.
+	imports []deploy.Import
+
 	// true if we should trust the dependency graph reported by the language host. Not all Pulumi-supported languages
 	// correctly report their dependencies, in which case this will be false.
 	trustDependencies bool
@@ -119,7 +122,7 @@ func plan(ctx *Context, info *planContext, opts planOptions, dryRun bool) (*plan
 	contract.Assert(proj != nil)
 	contract.Assert(target != nil)
 	projinfo := &Projinfo{Proj: proj, Root: info.Update.GetRoot()}
-	pwd, main, plugctx, err := ProjectInfoContext(projinfo, opts.host, target,
+	pwd, main, plugctx, err := ProjectInfoContext(projinfo, opts.Host, target,
 		opts.Diag, opts.StatusDiag, info.TracingSpan)
 	if err != nil {
 		return nil, err
@@ -176,6 +179,11 @@ func (planResult *planResult) Walk(cancelCtx *Context, events deploy.Events, pre
 	done := make(chan bool)
 	var walkResult result.Result
 	go func() {
+		var generatedPlan *deploy.UpdatePlan
+		if preview {
+			generatedPlan = planResult.Options.GeneratedPlan
+		}
+
 		opts := deploy.Options{
 			Events:            events,
 			Parallel:          planResult.Options.Parallel,
@@ -188,6 +196,9 @@ func (planResult *planResult) Walk(cancelCtx *Context, events deploy.Events, pre
 			TargetDependents:  planResult.Options.TargetDependents,
 			TrustDependencies: planResult.Options.trustDependencies,
 			UseLegacyDiff:     planResult.Options.UseLegacyDiff,
+			Plan:              planResult.Options.Plan,
+			GeneratedPlan:     generatedPlan,
+			Imports:           planResult.Options.imports,
 		}
 		walkResult = planResult.Plan.Execute(ctx, opts, preview)
 		close(done)
@@ -19,6 +19,7 @@ import (
 	"sort"
 
 	"github.com/blang/semver"
+	"github.com/pkg/errors"
 	"golang.org/x/sync/errgroup"
 
 	"github.com/pulumi/pulumi/pkg/resource/deploy"
@@ -120,10 +121,60 @@ func gatherPluginsFromSnapshot(plugctx *plugin.Context, target *deploy.Target) (
 	return set, nil
 }
 
+// applyPluginLock checks the plugins required by the program against the given lockfile and sets the checksum of each
+// plugin in the full set of plugins that is locked for the current platform. It is an error for the program to require
+// a version of a plugin other than the locked version. Plugins that are only required by the snapshot may be older
+// than the locked versions, so they are not checked.
+func applyPluginLock(lock *workspace.PluginLock, programPlugins, allPlugins pluginSet) error {
+	platform, err := workspace.CurrentPluginPlatform()
+	if err != nil {
+		logging.V(preparePluginLog).Infof("applyPluginLock(): %v; skipping checksums", err)
+	}
+
+	for key, plug := range allPlugins {
+		if err := lock.Verify(&plug, platform); err != nil {
+			if _, required := programPlugins[key]; required {
+				return err
+			}
+			logging.V(preparePluginLog).Infof("applyPluginLock(): ignoring snapshot plugin %s: %v", key, err)
+			continue
+		}
+		allPlugins[key] = plug
+	}
+	return nil
+}
+
+// installLockedPlugins checks the given plugins against the plugin lockfile of the project in pwd, if it has one, and
+// then attempts to install any of them that are missing. programPlugins are the plugins that the operation itself
+// requires, which must match their locked versions; the rest are only required by the snapshot and may be older.
+//
+// Note that installation is purely a best-effort thing. If we can't install missing plugins, the operation proceeds,
+// and fails later with an error message indicating exactly what plugins are missing. The exception is a plugin that
+// fails verification, which may have been tampered with.
+func installLockedPlugins(proj *workspace.Project, pwd string, programPlugins, allPlugins pluginSet) error {
+	lock, err := workspace.DetectPluginLock(pwd)
+	if err != nil {
+		return err
+	}
+	if lock != nil {
+		if err := applyPluginLock(lock, programPlugins, allPlugins); err != nil {
+			return err
+		}
+	}
+
+	if err := ensurePluginsAreInstalled(allPlugins, workspace.GetPluginSources(proj)); err != nil {
+		if _, ok := errors.Cause(err).(*workspace.ChecksumError); ok {
+			return err
+		}
+		logging.V(7).Infof("installLockedPlugins(): failed to install missing plugins: %v", err)
+	}
+	return nil
+}
+
 // ensurePluginsAreInstalled inspects all plugins in the plugin set and, if any plugins are not currently installed,
-// uses the given backend client to install them. Installations are processed in parallel, though
+// downloads them from the given sources and installs them. Installations are processed in parallel, though
 // ensurePluginsAreInstalled does not return until all installations are completed.
-func ensurePluginsAreInstalled(plugins pluginSet) error {
+func ensurePluginsAreInstalled(plugins pluginSet, sources workspace.PluginSources) error {
 	logging.V(preparePluginLog).Infof("ensurePluginsAreInstalled(): beginning")
 	var installTasks errgroup.Group
 	for _, plug := range plugins.Values() {
@@ -139,7 +190,7 @@ func ensurePluginsAreInstalled(plugins pluginSet) error {
 		installTasks.Go(func() error {
 			logging.V(preparePluginLog).Infof(
 				"ensurePluginsAreInstalled(): plugin %s %s not installed, doing install", info.Name, info.Version)
-			return installPlugin(info)
+			return installPlugin(info, sources)
 		})
 	}
 
@@ -154,8 +205,8 @@ func ensurePluginsAreLoaded(plugctx *plugin.Context, plugins pluginSet, kinds pl
 	return plugctx.Host.EnsurePlugins(plugins.Values(), kinds)
 }
 
-// installPlugin installs a plugin from the given backend client.
-func installPlugin(plugin workspace.PluginInfo) error {
+// installPlugin downloads a plugin from the given sources and installs it.
+func installPlugin(plugin workspace.PluginInfo, sources workspace.PluginSources) error {
 	logging.V(preparePluginLog).Infof("installPlugin(%s, %s): beginning install", plugin.Name, plugin.Version)
 	if plugin.Kind == workspace.LanguagePlugin {
 		logging.V(preparePluginLog).Infof(
@@ -163,13 +214,29 @@ func installPlugin(plugin workspace.PluginInfo) error {
 		return nil
 	}
 
+	platform, err := workspace.CurrentPluginPlatform()
+	if err != nil {
+		return err
+	}
+
 	logging.V(preparePluginVerboseLog).Infof(
 		"installPlugin(%s, %s): initiating download", plugin.Name, plugin.Version)
-	stream, size, err := plugin.Download()
+	stream, size, err := sources.Download(plugin, platform)
 	if err != nil {
 		return err
 	}
 
+	// If the plugin's checksum isn't locked, fall back to the checksum published by the plugin's sources, if any. We
+	// only look for it once the download has started, so that an unreachable server doesn't delay us twice.
+	if plugin.Checksum == "" {
+		checksum, err := sources.PublishedChecksum(plugin, platform)
+		if err != nil {
+			logging.V(preparePluginLog).Infof(
+				"installPlugin(%s, %s): failed to fetch checksum: %v", plugin.Name, plugin.Version, err)
+		}
+		plugin.Checksum = checksum
+	}
+
 	fmt.Printf("[%s plugin %s-%s] installing\n", plugin.Kind, plugin.Name, plugin.Version)
 	stream = workspace.ReadCloserProgressBar(stream, size, "Downloading plugin", cmdutil.GetGlobalColorization())
 
@@ -15,11 +15,18 @@
 package engine
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/blang/semver"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/resource/deploy/providers"
 	"github.com/pulumi/pulumi/pkg/tokens"
 	"github.com/pulumi/pulumi/pkg/util/logging"
 	"github.com/pulumi/pulumi/pkg/workspace"
@@ -127,3 +134,56 @@ func TestDefaultProvidersSnapshotOverrides(t *testing.T) {
 	assert.NotNil(t, awsVer)
 	assert.Equal(t, "0.17.0", awsVer.String())
 }
+
+func TestRefreshVerifiesLockedPlugins(t *testing.T) {
+	dir, err := ioutil.TempDir("", "pluginlock")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	// Use an empty plugin cache, and download plugins from a local directory.
+	home, sources, pwd := filepath.Join(dir, "home"), filepath.Join(dir, "sources"), filepath.Join(dir, "proj")
+	for _, d := range []string{home, sources, pwd} {
+		assert.NoError(t, os.MkdirAll(d, 0700))
+	}
+	oldHome, oldSources := os.Getenv(workspace.PulumiHomeEnvVar), os.Getenv(workspace.PluginSourcesEnvVar)
+	defer func() {
+		assert.NoError(t, os.Setenv(workspace.PulumiHomeEnvVar, oldHome))
+		assert.NoError(t, os.Setenv(workspace.PluginSourcesEnvVar, oldSources))
+	}()
+	assert.NoError(t, os.Setenv(workspace.PulumiHomeEnvVar, home))
+	assert.NoError(t, os.Setenv(workspace.PluginSourcesEnvVar, sources))
+
+	platform, err := workspace.CurrentPluginPlatform()
+	assert.NoError(t, err)
+	info := workspace.PluginInfo{Name: "pkgA", Kind: workspace.ResourcePlugin, Version: mustMakeVersion("1.0.0")}
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(sources, info.TarballName(platform)), []byte("tampered"), 0600))
+
+	// Lock the plugin to a checksum that the downloaded tarball doesn't match.
+	proj := &workspace.Project{Name: "proj", Runtime: workspace.NewProjectRuntimeInfo("go", nil)}
+	assert.NoError(t, proj.Save(filepath.Join(pwd, "Pulumi.yaml")))
+	lock := &workspace.PluginLock{Plugins: []workspace.LockedPlugin{{
+		Kind:      workspace.ResourcePlugin,
+		Name:      "pkgA",
+		Version:   "1.0.0",
+		Checksums: map[workspace.PluginPlatform]string{platform: workspace.TarballChecksum([]byte("original"))},
+	}}}
+	assert.NoError(t, lock.Save(filepath.Join(pwd, workspace.PluginLockFile)))
+
+	// The snapshot has a provider for the locked plugin, which refresh must download.
+	provURN := resource.NewURN("test", "proj", "", providers.MakeProviderType("pkgA"), "default")
+	prov := resource.NewState(provURN.Type(), provURN, true, false, "id", resource.PropertyMap{
+		"version": resource.NewStringProperty("1.0.0"),
+	}, nil, "", false, false, nil, nil, "", nil, false, nil, nil, nil)
+	target := &deploy.Target{
+		Name:     "test",
+		Snapshot: deploy.NewSnapshot(deploy.Manifest{}, nil, []*resource.State{prov}, nil),
+	}
+
+	_, err = newRefreshSource(nil, planOptions{}, proj, pwd, "", target, nil, true)
+	assert.Error(t, err)
+	_, isChecksumErr := errors.Cause(err).(*workspace.ChecksumError)
+	assert.True(t, isChecksumErr, "unexpected error: %v", err)
+
+	_, _, err = workspace.GetPluginPath(info.Kind, info.Name, info.Version)
+	assert.Error(t, err)
+}
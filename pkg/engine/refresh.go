@@ -18,7 +18,6 @@ import (
 	"github.com/pulumi/pulumi/pkg/resource/deploy"
 	"github.com/pulumi/pulumi/pkg/resource/plugin"
 	"github.com/pulumi/pulumi/pkg/util/contract"
-	"github.com/pulumi/pulumi/pkg/util/logging"
 	"github.com/pulumi/pulumi/pkg/util/result"
 	"github.com/pulumi/pulumi/pkg/workspace"
 )
@@ -65,9 +64,10 @@ func newRefreshSource(client deploy.BackendClient, opts planOptions, proj *works
 		return nil, err
 	}
 
-	// Like Update, if we're missing plugins, attempt to download the missing plugins.
-	if err := ensurePluginsAreInstalled(plugins); err != nil {
-		logging.V(7).Infof("newRefreshSource(): failed to install missing plugins: %v", err)
+	// Like Update, if we're missing plugins, attempt to download the missing plugins, verifying them against the
+	// project's plugin lockfile, if any.
+	if err := installLockedPlugins(proj, pwd, newPluginSet(), plugins); err != nil {
+		return nil, err
 	}
 
 	// Just return an error source. Refresh doesn't use its source.
@@ -20,6 +20,7 @@ import (
 	"time"
 
 	"github.com/blang/semver"
+
 	"github.com/pulumi/pulumi/pkg/diag"
 	"github.com/pulumi/pulumi/pkg/resource"
 	"github.com/pulumi/pulumi/pkg/resource/deploy"
@@ -81,11 +82,22 @@ type UpdateOptions struct {
 	// true if the engine should use legacy diffing behavior during an update.
 	UseLegacyDiff bool
 
+	// an optional plan recorded by an earlier preview. If set, the update fails if it attempts any step that the
+	// plan does not allow.
+	Plan *deploy.UpdatePlan
+
+	// an optional plan into which a preview records the steps it generates. Ignored for updates that are not
+	// previews.
+	GeneratedPlan *deploy.UpdatePlan
+
+	// the plugin host to use for this update. If nil, a default host that loads plugins from the environment is used.
+	Host plugin.Host
+
+	// an optional report into which an update records the timing of its steps. Ignored for previews.
+	PerformanceReport *PerformanceReport
+
 	// true if we should report events for steps that involve default providers.
 	reportDefaultProviderSteps bool
-
-	// the plugin host to use for this update
-	host plugin.Host
 }
 
 // ResourceChanges contains the aggregate resource changes by operation type.
@@ -170,12 +182,10 @@ func installPlugins(
 
 	allPlugins := languagePlugins.Union(snapshotPlugins)
 
-	// If there are any plugins that are not available, we can attempt to install them here.
-	//
-	// Note that this is purely a best-effort thing. If we can't install missing plugins, just proceed; we'll fail later
-	// with an error message indicating exactly what plugins are missing.
-	if err := ensurePluginsAreInstalled(allPlugins); err != nil {
-		logging.V(7).Infof("newUpdateSource(): failed to install missing plugins: %v", err)
+	// If the project has a plugin lockfile, check the program's plugins against it, and then install any plugins
+	// that are missing, verifying their downloads against the locked checksums.
+	if err := installLockedPlugins(proj, pwd, languagePlugins, allPlugins); err != nil {
+		return nil, nil, err
 	}
 
 	// Collect the version information for default providers.
@@ -276,9 +286,15 @@ func update(ctx *Context, info *planContext, opts planOptions, dryRun bool) (Res
 			// Walk the plan, reporting progress and executing the actual operations as we go.
 			start := time.Now()
 			actions := newUpdateActions(ctx, info.Update, opts)
+			if opts.PerformanceReport != nil {
+				opts.PerformanceReport.reset(opts.Parallel)
+			}
 
 			res = planResult.Walk(ctx, actions, false)
 			resourceChanges = ResourceChanges(actions.Ops)
+			if opts.PerformanceReport != nil {
+				opts.PerformanceReport.finish()
+			}
 
 			if len(resourceChanges) != 0 {
 
@@ -314,6 +330,10 @@ func newUpdateActions(context *Context, u UpdateInfo, opts planOptions) *updateA
 }
 
 func (acts *updateActions) OnResourceStepPre(step deploy.Step) (interface{}, error) {
+	if acts.Opts.PerformanceReport != nil {
+		acts.Opts.PerformanceReport.stepStarted(step)
+	}
+
 	// Ensure we've marked this step as observed.
 	acts.MapLock.Lock()
 	acts.Seen[step.URN()] = step
@@ -332,6 +352,10 @@ func (acts *updateActions) OnResourceStepPost(
 	ctx interface{}, step deploy.Step,
 	status resource.Status, err error) error {
 
+	if acts.Opts.PerformanceReport != nil {
+		acts.Opts.PerformanceReport.stepFinished(step, err != nil)
+	}
+
 	acts.MapLock.Lock()
 	assertSeen(acts.Seen, step)
 	acts.MapLock.Unlock()
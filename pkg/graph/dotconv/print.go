@@ -65,12 +65,14 @@ func Print(g graph.Graph, w io.Writer) error {
 	// Now, until the frontier is empty, emit entries into the stream.
 	indent := "    "
 	emitted := make(map[graph.Vertex]bool)
+	var order []graph.Vertex
 	for len(frontier) > 0 {
 		// Dequeue the head of the frontier.
 		v := frontier[0]
 		frontier = frontier[1:]
 		contract.Assert(!emitted[v])
 		emitted[v] = true
+		order = append(order, v)
 
 		// Get and lazily allocate the ID for this vertex.
 		id := getID(v)
@@ -125,6 +127,29 @@ func Print(g graph.Graph, w io.Writer) error {
 		}
 	}
 
+	// Group clustered vertices into subgraphs, which Graphviz draws as boxes, now that every vertex has an ID.
+	members, top := graph.Clusters(order)
+	var printCluster func(v graph.Vertex, indent string)
+	printCluster = func(v graph.Vertex, indent string) {
+		id := getID(v)
+		fmt.Fprintf(b, "%ssubgraph cluster_%s {\n", indent, id)
+		fmt.Fprintf(b, "%s    label = \"%s\";\n", indent, v.Label())
+		fmt.Fprintf(b, "%s    %s;\n", indent, id)
+		for _, m := range members[v] {
+			if len(members[m]) > 0 {
+				printCluster(m, indent+"    ")
+			} else {
+				fmt.Fprintf(b, "%s    %s;\n", indent, getID(m))
+			}
+		}
+		fmt.Fprintf(b, "%s}\n", indent)
+	}
+	for _, v := range top {
+		if len(members[v]) > 0 {
+			printCluster(v, indent)
+		}
+	}
+
 	// Finish the graph.
 	if _, err := b.WriteString("}\n"); err != nil {
 		return err
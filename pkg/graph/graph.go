@@ -46,3 +46,11 @@ type Edge interface {
 	From() Vertex      // the vertex this edge connects from.
 	Color() string     // an optional color for this edge, for when this graph is displayed.
 }
+
+// ClusteredVertex is a vertex that belongs to the cluster of another vertex, such as a child resource that belongs to
+// the cluster of its parent component.  Converters that support clusters display each vertex that has members
+// together with its members, nested within the cluster of the vertex that it belongs to in turn, if any.
+type ClusteredVertex interface {
+	Vertex
+	Cluster() Vertex // the vertex whose cluster this vertex belongs to, or nil if it belongs to none.
+}
@@ -0,0 +1,141 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package graphmlconv converts a resource graph into its GraphML equivalent.  GraphML is an XML format understood by
+// many graph editors and analysis tools, like yEd, Gephi, and NetworkX.  Please see http://graphml.graphdrawing.org/
+// for a specification of the format.
+package graphmlconv
+
+import (
+	"encoding/xml"
+	"io"
+	"strconv"
+
+	"github.com/pulumi/pulumi/pkg/graph"
+)
+
+// The IDs of the GraphML attributes that hold vertex and edge labels and edge colors.
+const (
+	labelKey     = "label"
+	edgeLabelKey = "edgeLabel"
+	colorKey     = "color"
+)
+
+type graphML struct {
+	XMLName xml.Name     `xml:"graphml"`
+	XMLNS   string       `xml:"xmlns,attr"`
+	Keys    []graphMLKey `xml:"key"`
+	Graph   graphMLGraph `xml:"graph"`
+}
+
+type graphMLKey struct {
+	ID   string `xml:"id,attr"`
+	For  string `xml:"for,attr"`
+	Name string `xml:"attr.name,attr"`
+	Type string `xml:"attr.type,attr"`
+}
+
+type graphMLGraph struct {
+	ID          string        `xml:"id,attr"`
+	EdgeDefault string        `xml:"edgedefault,attr"`
+	Nodes       []graphMLNode `xml:"node"`
+	Edges       []graphMLEdge `xml:"edge"`
+}
+
+type graphMLNode struct {
+	ID    string        `xml:"id,attr"`
+	Data  []graphMLData `xml:"data"`
+	Graph *graphMLGraph `xml:"graph,omitempty"`
+}
+
+type graphMLEdge struct {
+	ID     string        `xml:"id,attr"`
+	Source string        `xml:"source,attr"`
+	Target string        `xml:"target,attr"`
+	Data   []graphMLData `xml:"data"`
+}
+
+type graphMLData struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+// Print prints a resource graph.  The members of each cluster are nested within a subgraph of the vertex whose
+// cluster they belong to.
+func Print(g graph.Graph, w io.Writer) error {
+	vertices := graph.Vertices(g)
+	ids := make(map[graph.Vertex]string)
+	for i, v := range vertices {
+		ids[v] = "n" + strconv.Itoa(i)
+	}
+
+	members, top := graph.Clusters(vertices)
+	var makeNode func(v graph.Vertex) graphMLNode
+	makeNode = func(v graph.Vertex) graphMLNode {
+		node := graphMLNode{
+			ID:   ids[v],
+			Data: []graphMLData{{Key: labelKey, Value: v.Label()}},
+		}
+		if len(members[v]) > 0 {
+			// By convention, the ID of a node's subgraph is the node's ID followed by a colon.
+			node.Graph = &graphMLGraph{ID: ids[v] + ":", EdgeDefault: "directed"}
+			for _, m := range members[v] {
+				node.Graph.Nodes = append(node.Graph.Nodes, makeNode(m))
+			}
+		}
+		return node
+	}
+
+	doc := graphML{
+		XMLNS: "http://graphml.graphdrawing.org/xmlns",
+		Keys: []graphMLKey{
+			{ID: labelKey, For: "node", Name: "label", Type: "string"},
+			{ID: edgeLabelKey, For: "edge", Name: "label", Type: "string"},
+			{ID: colorKey, For: "edge", Name: "color", Type: "string"},
+		},
+		Graph: graphMLGraph{ID: "G", EdgeDefault: "directed"},
+	}
+	for _, v := range top {
+		doc.Graph.Nodes = append(doc.Graph.Nodes, makeNode(v))
+	}
+
+	// Edges may connect nodes in different subgraphs, so they all belong to the top-level graph.
+	for _, v := range vertices {
+		for _, out := range v.Outs() {
+			edge := graphMLEdge{
+				ID:     "e" + strconv.Itoa(len(doc.Graph.Edges)),
+				Source: ids[v],
+				Target: ids[out.To()],
+			}
+			if label := out.Label(); label != "" {
+				edge.Data = append(edge.Data, graphMLData{Key: edgeLabelKey, Value: label})
+			}
+			if color := out.Color(); color != "" {
+				edge.Data = append(edge.Data, graphMLData{Key: colorKey, Value: color})
+			}
+			doc.Graph.Edges = append(doc.Graph.Edges, edge)
+		}
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "    ")
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
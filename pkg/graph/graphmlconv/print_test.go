@@ -0,0 +1,61 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graphmlconv
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/graph/graphtest"
+)
+
+func TestPrint(t *testing.T) {
+	// A component whose name contains quotes and brackets, with a child that depends on a resource outside it.
+	comp := graphtest.NewVertex(`comp "quoted" [bracketed]`, nil)
+	child := graphtest.NewVertex("child[0]", comp)
+	other := graphtest.NewVertex("other", nil)
+	comp.Connect(child, "", "")
+	child.Connect(other, `"dep"`, "#AA6639")
+
+	var buf bytes.Buffer
+	assert.NoError(t, Print(graphtest.NewGraph(comp, other), &buf))
+	assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>
+<graphml xmlns="http://graphml.graphdrawing.org/xmlns">
+    <key id="label" for="node" attr.name="label" attr.type="string"></key>
+    <key id="edgeLabel" for="edge" attr.name="label" attr.type="string"></key>
+    <key id="color" for="edge" attr.name="color" attr.type="string"></key>
+    <graph id="G" edgedefault="directed">
+        <node id="n0">
+            <data key="label">comp &#34;quoted&#34; [bracketed]</data>
+            <graph id="n0:" edgedefault="directed">
+                <node id="n2">
+                    <data key="label">child[0]</data>
+                </node>
+            </graph>
+        </node>
+        <node id="n1">
+            <data key="label">other</data>
+        </node>
+        <edge id="e0" source="n0" target="n2"></edge>
+        <edge id="e1" source="n2" target="n1">
+            <data key="edgeLabel">&#34;dep&#34;</data>
+            <data key="color">#AA6639</data>
+        </edge>
+    </graph>
+</graphml>
+`, buf.String())
+}
@@ -0,0 +1,90 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package graphtest provides a simple in-memory graph for testing the graph converters.
+package graphtest
+
+import (
+	"github.com/pulumi/pulumi/pkg/graph"
+)
+
+// Graph is a graph whose roots are edges to a fixed set of vertices.
+type Graph struct {
+	roots []graph.Edge
+}
+
+// NewGraph creates a graph with the given root vertices.
+func NewGraph(roots ...*Vertex) *Graph {
+	g := &Graph{}
+	for _, root := range roots {
+		g.roots = append(g.roots, &Edge{to: root})
+	}
+	return g
+}
+
+func (g *Graph) Roots() []graph.Edge { return g.roots }
+
+// Vertex is a labeled vertex that optionally belongs to the cluster of another vertex.
+type Vertex struct {
+	label   string
+	cluster *Vertex
+	ins     []graph.Edge
+	outs    []graph.Edge
+}
+
+var _ graph.ClusteredVertex = (*Vertex)(nil)
+
+// NewVertex creates a vertex with the given label that belongs to the cluster of the given vertex, if it is non-nil.
+func NewVertex(label string, cluster *Vertex) *Vertex {
+	return &Vertex{label: label, cluster: cluster}
+}
+
+// Connect adds an edge with the given label and color from this vertex to another.
+func (v *Vertex) Connect(to *Vertex, label, color string) {
+	e := &Edge{from: v, to: to, label: label, color: color}
+	v.outs = append(v.outs, e)
+	to.ins = append(to.ins, e)
+}
+
+func (v *Vertex) Data() interface{}  { return nil }
+func (v *Vertex) Label() string      { return v.label }
+func (v *Vertex) Ins() []graph.Edge  { return v.ins }
+func (v *Vertex) Outs() []graph.Edge { return v.outs }
+
+func (v *Vertex) Cluster() graph.Vertex {
+	if v.cluster == nil {
+		return nil
+	}
+	return v.cluster
+}
+
+// Edge is a labeled, colored edge between two vertices.  The edges of a graph's roots have no source vertex.
+type Edge struct {
+	from  *Vertex
+	to    *Vertex
+	label string
+	color string
+}
+
+func (e *Edge) Data() interface{} { return nil }
+func (e *Edge) Label() string     { return e.label }
+func (e *Edge) To() graph.Vertex  { return e.to }
+func (e *Edge) Color() string     { return e.color }
+
+func (e *Edge) From() graph.Vertex {
+	if e.from == nil {
+		return nil
+	}
+	return e.from
+}
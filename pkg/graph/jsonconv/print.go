@@ -0,0 +1,86 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package jsonconv converts a resource graph into a JSON adjacency list, which lists each vertex along with the edges
+// leading out of it.  This is useful for feeding graphs into other tools.
+package jsonconv
+
+import (
+	"encoding/json"
+	"io"
+	"strconv"
+
+	"github.com/pulumi/pulumi/pkg/graph"
+)
+
+// Graph is the JSON representation of a graph.
+type Graph struct {
+	// Vertices lists the graph's vertices in breadth-first order, starting with its roots.
+	Vertices []Vertex `json:"vertices"`
+}
+
+// Vertex is the JSON representation of a vertex and the edges leading out of it.
+type Vertex struct {
+	// ID identifies the vertex within the graph.
+	ID string `json:"id"`
+	// Label is the vertex's label.
+	Label string `json:"label"`
+	// Cluster is the ID of the vertex whose cluster this vertex belongs to, if any.
+	Cluster string `json:"cluster,omitempty"`
+	// Edges lists the edges leading out of this vertex.
+	Edges []Edge `json:"edges"`
+}
+
+// Edge is the JSON representation of an edge.
+type Edge struct {
+	// To is the ID of the vertex this edge connects to.
+	To string `json:"to"`
+	// Label is the edge's label, if any.
+	Label string `json:"label,omitempty"`
+	// Color is the edge's color, if any.
+	Color string `json:"color,omitempty"`
+}
+
+// Print prints a resource graph.
+func Print(g graph.Graph, w io.Writer) error {
+	vertices := graph.Vertices(g)
+	ids := make(map[graph.Vertex]string)
+	for i, v := range vertices {
+		ids[v] = "Resource" + strconv.Itoa(i)
+	}
+
+	members, _ := graph.Clusters(vertices)
+	clusters := make(map[graph.Vertex]graph.Vertex)
+	for c, ms := range members {
+		for _, m := range ms {
+			clusters[m] = c
+		}
+	}
+
+	result := Graph{Vertices: []Vertex{}}
+	for _, v := range vertices {
+		vertex := Vertex{ID: ids[v], Label: v.Label(), Edges: []Edge{}}
+		if c, ok := clusters[v]; ok {
+			vertex.Cluster = ids[c]
+		}
+		for _, out := range v.Outs() {
+			vertex.Edges = append(vertex.Edges, Edge{To: ids[out.To()], Label: out.Label(), Color: out.Color()})
+		}
+		result.Vertices = append(result.Vertices, vertex)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "    ")
+	return enc.Encode(result)
+}
@@ -0,0 +1,89 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package mermaidconv converts a resource graph into its Mermaid flowchart equivalent.  Mermaid diagrams can be
+// embedded in Markdown documents and are rendered by many documentation tools.  Please see
+// https://mermaid-js.github.io/mermaid/ for a specification of the flowchart syntax.
+package mermaidconv
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/pulumi/pulumi/pkg/graph"
+)
+
+// Print prints a resource graph.
+func Print(g graph.Graph, w io.Writer) error {
+	// Allocate a new writer.  We ignore write errors throughout this function, for simplicity, opting instead to return
+	// the result of flushing the buffer at the end, which is latching.
+	b := bufio.NewWriter(w)
+
+	vertices := graph.Vertices(g)
+	ids := make(map[graph.Vertex]string)
+	for i, v := range vertices {
+		ids[v] = "Resource" + strconv.Itoa(i)
+	}
+
+	fmt.Fprintln(b, "graph TD")
+
+	// Print the vertices, nesting the members of each cluster within a subgraph.
+	members, top := graph.Clusters(vertices)
+	var printVertex func(v graph.Vertex, indent string)
+	printVertex = func(v graph.Vertex, indent string) {
+		if len(members[v]) == 0 {
+			fmt.Fprintf(b, "%s%s[\"%s\"]\n", indent, ids[v], escape(v.Label()))
+			return
+		}
+		fmt.Fprintf(b, "%ssubgraph cluster_%s [\"%s\"]\n", indent, ids[v], escape(v.Label()))
+		fmt.Fprintf(b, "%s    %s[\"%s\"]\n", indent, ids[v], escape(v.Label()))
+		for _, m := range members[v] {
+			printVertex(m, indent+"    ")
+		}
+		fmt.Fprintf(b, "%send\n", indent)
+	}
+	for _, v := range top {
+		printVertex(v, "    ")
+	}
+
+	// Now print the edges.  Mermaid styles edges by their index, so collect the styles of colored edges as we go.
+	var styles []string
+	index := 0
+	for _, v := range vertices {
+		for _, out := range v.Outs() {
+			arrow := "-->"
+			if label := out.Label(); label != "" {
+				arrow = fmt.Sprintf("-->|\"%s\"|", escape(label))
+			}
+			fmt.Fprintf(b, "    %s %s %s\n", ids[v], arrow, ids[out.To()])
+			if color := out.Color(); color != "" {
+				styles = append(styles, fmt.Sprintf("    linkStyle %d stroke:%s", index, color))
+			}
+			index++
+		}
+	}
+	for _, style := range styles {
+		fmt.Fprintln(b, style)
+	}
+
+	return b.Flush()
+}
+
+// escape escapes the double quotes in a label, which would otherwise terminate it.
+func escape(label string) string {
+	return strings.Replace(label, "\"", "#quot;", -1)
+}
@@ -0,0 +1,46 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mermaidconv
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/graph/graphtest"
+)
+
+func TestPrint(t *testing.T) {
+	// A component whose name contains quotes and brackets, with a child that depends on a resource outside it.
+	comp := graphtest.NewVertex(`comp "quoted" [bracketed]`, nil)
+	child := graphtest.NewVertex("child[0]", comp)
+	other := graphtest.NewVertex("other", nil)
+	comp.Connect(child, "", "")
+	child.Connect(other, `"dep"`, "#AA6639")
+
+	var buf bytes.Buffer
+	assert.NoError(t, Print(graphtest.NewGraph(comp, other), &buf))
+	assert.Equal(t, `graph TD
+    subgraph cluster_Resource0 ["comp #quot;quoted#quot; [bracketed]"]
+        Resource0["comp #quot;quoted#quot; [bracketed]"]
+        Resource2["child[0]"]
+    end
+    Resource1["other"]
+    Resource0 --> Resource2
+    Resource2 -->|"#quot;dep#quot;"| Resource1
+    linkStyle 1 stroke:#AA6639
+`, buf.String())
+}
@@ -0,0 +1,58 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graph
+
+// Vertices returns every vertex that is reachable from the graph's roots by following outgoing edges, in breadth-first
+// order starting with the roots themselves.
+func Vertices(g Graph) []Vertex {
+	var vertices []Vertex
+	queued := make(map[Vertex]bool)
+	enqueue := func(v Vertex) {
+		if v != nil && !queued[v] {
+			queued[v] = true
+			vertices = append(vertices, v)
+		}
+	}
+	for _, root := range g.Roots() {
+		enqueue(root.To())
+	}
+	for i := 0; i < len(vertices); i++ {
+		for _, out := range vertices[i].Outs() {
+			enqueue(out.To())
+		}
+	}
+	return vertices
+}
+
+// Clusters groups the given vertices into clusters.  It returns the members of each vertex's cluster, in the order in
+// which they appear in vertices, along with the vertices that belong to no cluster.  Only vertices that implement
+// ClusteredVertex belong to clusters, and only to the clusters of other vertices in vertices.
+func Clusters(vertices []Vertex) (map[Vertex][]Vertex, []Vertex) {
+	present := make(map[Vertex]bool)
+	for _, v := range vertices {
+		present[v] = true
+	}
+
+	members := make(map[Vertex][]Vertex)
+	var top []Vertex
+	for _, v := range vertices {
+		if cv, ok := v.(ClusteredVertex); ok && present[cv.Cluster()] {
+			members[cv.Cluster()] = append(members[cv.Cluster()], v)
+		} else {
+			top = append(top, v)
+		}
+	}
+	return members, top
+}
@@ -34,7 +34,10 @@ import (
 	"strings"
 	"time"
 
+	"github.com/dsnet/compress/bzip2"
+	"github.com/klauspost/compress/zstd"
 	"github.com/pkg/errors"
+	"github.com/ulikunitz/xz"
 
 	"github.com/pulumi/pulumi/pkg/util/contract"
 	"github.com/pulumi/pulumi/pkg/util/httputil"
@@ -675,13 +678,19 @@ type ArchiveReader interface {
 
 // Open returns an ArchiveReader that can be used to iterate over the named blobs that comprise the archive.
 func (a *Archive) Open() (ArchiveReader, error) {
+	return a.open(false)
+}
+
+// open returns an ArchiveReader for the archive. If sorted is true, the members of each directory and archive file
+// are returned in order of name, rather than in the order in which they are stored.
+func (a *Archive) open(sorted bool) (ArchiveReader, error) {
 	contract.Assertf(a.HasContents(), "cannot read an archive that has no contents")
 	if a.IsAssets() {
-		return a.readAssets()
+		return a.readAssets(sorted)
 	} else if a.IsPath() {
-		return a.readPath()
+		return a.readPath(sorted)
 	} else if a.IsURI() {
-		return a.readURI()
+		return a.readURI(sorted)
 	}
 	return nil, errors.New("unrecognized archive type")
 }
@@ -692,6 +701,7 @@ type assetsArchiveReader struct {
 	keys        []string
 	archive     ArchiveReader
 	archiveRoot string
+	sorted      bool
 }
 
 func (r *assetsArchiveReader) Next() (string, *Blob, error) {
@@ -733,7 +743,7 @@ func (r *assetsArchiveReader) Next() (string, *Blob, error) {
 			return name, blob, nil
 		case *Archive:
 			// An archive must be flattened into its constituent blobs. Open the archive for reading and loop.
-			archive, err := t.Open()
+			archive, err := t.open(r.sorted)
 			if err != nil {
 				return "", nil, errors.Wrapf(err, "failed to expand sub-archive '%v'", name)
 			}
@@ -750,7 +760,7 @@ func (r *assetsArchiveReader) Close() error {
 	return nil
 }
 
-func (a *Archive) readAssets() (ArchiveReader, error) {
+func (a *Archive) readAssets(sorted bool) (ArchiveReader, error) {
 	// To read a map-based archive, just produce a map from each asset to its associated reader.
 	m, isassets := a.GetAssets()
 	contract.Assertf(isassets, "Expected an asset map-based archive")
@@ -765,6 +775,7 @@ func (a *Archive) readAssets() (ArchiveReader, error) {
 	r := &assetsArchiveReader{
 		assets: m,
 		keys:   keys,
+		sorted: sorted,
 	}
 	return r, nil
 }
@@ -807,7 +818,7 @@ func (r *directoryArchiveReader) Close() error {
 	return nil
 }
 
-func (a *Archive) readPath() (ArchiveReader, error) {
+func (a *Archive) readPath(sorted bool) (ArchiveReader, error) {
 	// To read a path-based archive, read that file and use its extension to ascertain what format to use.
 	path, ispath := a.GetPath()
 	contract.Assertf(ispath, "Expected a path-based asset")
@@ -865,6 +876,11 @@ func (a *Archive) readPath() (ArchiveReader, error) {
 		}); walkerr != nil {
 			return nil, walkerr
 		}
+		if sorted {
+			sort.Slice(assetPaths, func(i, j int) bool {
+				return filepath.ToSlash(assetPaths[i]) < filepath.ToSlash(assetPaths[j])
+			})
+		}
 
 		r := &directoryArchiveReader{
 			directoryPath: path,
@@ -878,10 +894,10 @@ func (a *Archive) readPath() (ArchiveReader, error) {
 	if err != nil {
 		return nil, err
 	}
-	return readArchive(file, format)
+	return readArchive(file, format, sorted)
 }
 
-func (a *Archive) readURI() (ArchiveReader, error) {
+func (a *Archive) readURI(sorted bool) (ArchiveReader, error) {
 	// To read a URI-based archive, fetch the contents remotely and use the extension to pick the format to use.
 	url, isurl, err := a.GetURIURL()
 	if err != nil {
@@ -899,7 +915,7 @@ func (a *Archive) readURI() (ArchiveReader, error) {
 	if err != nil {
 		return nil, err
 	}
-	return readArchive(ar, format)
+	return readArchive(ar, format, sorted)
 }
 
 func (a *Archive) openURLStream(url *url.URL) (io.ReadCloser, error) {
@@ -932,34 +948,80 @@ func (a *Archive) Bytes(format ArchiveFormat) ([]byte, error) {
 	return data.Bytes(), nil
 }
 
+// DeterministicArchivesEnvVar is the environment variable that, if set to a truthy value, makes Archive, Bytes and
+// EnsureHash operate in deterministic mode (see ArchiveOptions). Deterministic mode changes the hashes of archives, so
+// enabling it for an existing stack updates every resource that uses an archive once.
+const DeterministicArchivesEnvVar = "PULUMI_DETERMINISTIC_ARCHIVES"
+
+// ArchiveOptions controls how an archive stream is produced.
+type ArchiveOptions struct {
+	// Deterministic, if true, produces output that depends only on the names and contents of the archive's members,
+	// so that archiving the same files yields the same bytes, and the same hash, on every machine. Source archive
+	// files are always re-encoded rather than copied, the members of each directory and archive file are written in
+	// order of name, and every member is given the same timestamp and permissions. Because archive files such as
+	// tarballs can only be read in order, their members are buffered in memory in order to sort them.
+	Deterministic bool
+}
+
+// DefaultArchiveOptions returns the options used by Archive, Bytes and EnsureHash, which enable deterministic mode if
+// the PULUMI_DETERMINISTIC_ARCHIVES environment variable is set to a truthy value.
+func DefaultArchiveOptions() ArchiveOptions {
+	v := os.Getenv(DeterministicArchivesEnvVar)
+	return ArchiveOptions{Deterministic: v == "1" || strings.EqualFold(v, "true")}
+}
+
 // Archive produces a single archive stream in the desired format.  It prefers to return the archive with as little
 // copying as is feasible, however if the desired format is different from the source, it will need to translate.
 func (a *Archive) Archive(format ArchiveFormat, w io.Writer) error {
-	// If the source format is the same, just return that.
-	if sf, ss, err := a.ReadSourceArchive(); sf != NotArchive && sf == format {
-		if err != nil {
+	return a.ArchiveWithOptions(format, w, DefaultArchiveOptions())
+}
+
+// ArchiveWithOptions produces a single archive stream in the desired format using the given options.
+func (a *Archive) ArchiveWithOptions(format ArchiveFormat, w io.Writer, opts ArchiveOptions) error {
+	// If the source format is the same, just return that, unless the output must be deterministic.
+	if !opts.Deterministic {
+		sf, ss, err := a.ReadSourceArchive()
+		if ss != nil {
+			defer contract.IgnoreClose(ss)
+		}
+		if sf != NotArchive && sf == format {
+			if err != nil {
+				return err
+			}
+			_, err := io.Copy(w, ss)
 			return err
 		}
-		_, err := io.Copy(w, ss)
-		return err
 	}
 
 	switch format {
 	case TarArchive:
-		return a.archiveTar(w)
+		return a.archiveTar(w, opts)
 	case TarGZIPArchive:
-		return a.archiveTarGZIP(w)
+		return a.archiveCompressedTar(w, opts, newGZIPWriter)
+	case TarBZIP2Archive:
+		return a.archiveCompressedTar(w, opts, newBZIP2Writer)
+	case TarXZArchive:
+		return a.archiveCompressedTar(w, opts, newXZWriter)
+	case TarZstdArchive:
+		return a.archiveCompressedTar(w, opts, newZstdWriter)
 	case ZIPArchive:
-		return a.archiveZIP(w)
+		return a.archiveZIP(w, opts)
 	default:
 		contract.Failf("Illegal archive type: %v", format)
 		return nil
 	}
 }
 
+// deterministicModTime is the modification time given to every member of an archive in deterministic mode, and to
+// every member of a ZIP archive. It is comfortably after 1980, where the ZIP format's date representation starts.
+var deterministicModTime = time.Date(1990, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// deterministicMode is the permissions given to every member of an archive in deterministic mode.
+const deterministicMode = 0644
+
 // addNextFileToTar adds the next file in the given archive to the given tar file. Returns io.EOF if the archive
 // contains no more files.
-func addNextFileToTar(r ArchiveReader, tw *tar.Writer, seenFiles map[string]bool) error {
+func addNextFileToTar(r ArchiveReader, tw *tar.Writer, seenFiles map[string]bool, opts ArchiveOptions) error {
 	file, data, err := r.Next()
 	if err != nil {
 		return err
@@ -975,11 +1037,16 @@ func addNextFileToTar(r ArchiveReader, tw *tar.Writer, seenFiles map[string]bool
 	seenFiles[file] = true
 
 	sz := data.Size()
-	if err = tw.WriteHeader(&tar.Header{
+	hdr := &tar.Header{
 		Name: file,
 		Mode: 0600,
 		Size: sz,
-	}); err != nil {
+	}
+	if opts.Deterministic {
+		hdr.Mode = deterministicMode
+		hdr.ModTime = deterministicModTime
+	}
+	if err = tw.WriteHeader(hdr); err != nil {
 		return err
 	}
 	n, err := io.Copy(tw, data)
@@ -989,9 +1056,9 @@ func addNextFileToTar(r ArchiveReader, tw *tar.Writer, seenFiles map[string]bool
 	return err
 }
 
-func (a *Archive) archiveTar(w io.Writer) error {
+func (a *Archive) archiveTar(w io.Writer, opts ArchiveOptions) error {
 	// Open the archive.
-	reader, err := a.Open()
+	reader, err := a.open(opts.Deterministic)
 	if err != nil {
 		return err
 	}
@@ -1001,7 +1068,7 @@ func (a *Archive) archiveTar(w io.Writer) error {
 	tw := tar.NewWriter(w)
 	seenFiles := make(map[string]bool)
 	for err == nil {
-		err = addNextFileToTar(reader, tw, seenFiles)
+		err = addNextFileToTar(reader, tw, seenFiles, opts)
 	}
 	if err != io.EOF {
 		return err
@@ -1010,14 +1077,42 @@ func (a *Archive) archiveTar(w io.Writer) error {
 	return tw.Close()
 }
 
-func (a *Archive) archiveTarGZIP(w io.Writer) error {
-	z := gzip.NewWriter(w)
-	return a.archiveTar(z)
+// archiveCompressedTar writes the archive as a tar stream compressed by the writer returned by newWriter.
+func (a *Archive) archiveCompressedTar(w io.Writer, opts ArchiveOptions,
+	newWriter func(w io.Writer) (io.WriteCloser, error)) error {
+
+	z, err := newWriter(w)
+	if err != nil {
+		return err
+	}
+	if err = a.archiveTar(z, opts); err != nil {
+		contract.IgnoreClose(z)
+		return err
+	}
+	// Closing the compressor flushes any buffered data and writes the stream's footer.
+	return z.Close()
+}
+
+func newGZIPWriter(w io.Writer) (io.WriteCloser, error) {
+	return gzip.NewWriter(w), nil
+}
+
+func newBZIP2Writer(w io.Writer) (io.WriteCloser, error) {
+	return bzip2.NewWriter(w, nil)
+}
+
+func newXZWriter(w io.Writer) (io.WriteCloser, error) {
+	return xz.NewWriter(w)
+}
+
+func newZstdWriter(w io.Writer) (io.WriteCloser, error) {
+	// A single encoder keeps the output independent of the number of CPUs.
+	return zstd.NewWriter(w, zstd.WithEncoderConcurrency(1))
 }
 
 // addNextFileToZIP adds the next file in the given archive to the given ZIP file. Returns io.EOF if the archive
 // contains no more files.
-func addNextFileToZIP(r ArchiveReader, zw *zip.Writer, seenFiles map[string]bool) error {
+func addNextFileToZIP(r ArchiveReader, zw *zip.Writer, seenFiles map[string]bool, opts ArchiveOptions) error {
 	file, data, err := r.Next()
 	if err != nil {
 		return err
@@ -1043,7 +1138,10 @@ func addNextFileToZIP(r ArchiveReader, zw *zip.Writer, seenFiles map[string]bool
 	// the ZIP format includes a date representation that starts at 1980. Use `SetModTime` to
 	// remain compatible with Go 1.9.
 	// nolint: megacheck
-	fh.SetModTime(time.Date(1990, time.January, 1, 0, 0, 0, 0, time.UTC))
+	fh.SetModTime(deterministicModTime)
+	if opts.Deterministic {
+		fh.SetMode(deterministicMode)
+	}
 
 	fw, err := zw.CreateHeader(fh)
 	if err != nil {
@@ -1053,9 +1151,9 @@ func addNextFileToZIP(r ArchiveReader, zw *zip.Writer, seenFiles map[string]bool
 	return err
 }
 
-func (a *Archive) archiveZIP(w io.Writer) error {
+func (a *Archive) archiveZIP(w io.Writer, opts ArchiveOptions) error {
 	// Open the archive.
-	reader, err := a.Open()
+	reader, err := a.open(opts.Deterministic)
 	if err != nil {
 		return err
 	}
@@ -1065,7 +1163,7 @@ func (a *Archive) archiveZIP(w io.Writer) error {
 	zw := zip.NewWriter(w)
 	seenFiles := make(map[string]bool)
 	for err == nil {
-		err = addNextFileToZIP(reader, zw, seenFiles)
+		err = addNextFileToZIP(reader, zw, seenFiles, opts)
 	}
 	if err != io.EOF {
 		return err
@@ -1092,14 +1190,26 @@ func (a *Archive) ReadSourceArchive() (ArchiveFormat, io.ReadCloser, error) {
 
 // EnsureHash computes the SHA256 hash of the archive's contents and stores it on the object.
 func (a *Archive) EnsureHash() error {
+	return a.EnsureHashWithOptions(DefaultArchiveOptions())
+}
+
+// EnsureHashWithOptions computes the SHA256 hash of the archive's contents using the given options and stores it on
+// the object. In deterministic mode, the hash is that of a deterministic tar archive of the contents, even if the
+// source is an archive file.
+func (a *Archive) EnsureHashWithOptions(opts ArchiveOptions) error {
 	if a.Hash == "" {
 		hash := sha256.New()
 
 		// Attempt to compute the hash in the most efficient way.  First try to open the archive directly and copy it
 		// to the hash.  This avoids traversing any of the contents and just treats it as a byte stream.
-		f, r, err := a.ReadSourceArchive()
-		if err != nil {
-			return err
+		// Deterministic hashes are always computed from the archive's contents.
+		var f ArchiveFormat = NotArchive
+		var r io.ReadCloser
+		var err error
+		if !opts.Deterministic {
+			if f, r, err = a.ReadSourceArchive(); err != nil {
+				return err
+			}
 		}
 		if f != NotArchive && r != nil {
 			defer contract.IgnoreClose(r)
@@ -1110,7 +1220,7 @@ func (a *Archive) EnsureHash() error {
 		} else {
 			// Otherwise, it's not an archive; we'll need to transform it into one.  Pick tar since it avoids
 			// any superfluous compression which doesn't actually help us in this situation.
-			err := a.Archive(TarArchive, hash)
+			err := a.ArchiveWithOptions(TarArchive, hash, opts)
 			if err != nil {
 				return err
 			}
@@ -1126,18 +1236,27 @@ func (a *Archive) EnsureHash() error {
 type ArchiveFormat int
 
 const (
-	NotArchive     = iota // not an archive.
-	TarArchive            // a POSIX tar archive.
-	TarGZIPArchive        // a POSIX tar archive that has been subsequently compressed using GZip.
-	ZIPArchive            // a multi-file ZIP archive.
+	NotArchive      = iota // not an archive.
+	TarArchive             // a POSIX tar archive.
+	TarGZIPArchive         // a POSIX tar archive that has been subsequently compressed using GZip.
+	ZIPArchive             // a multi-file ZIP archive.
+	TarBZIP2Archive        // a POSIX tar archive that has been subsequently compressed using BZip2.
+	TarXZArchive           // a POSIX tar archive that has been subsequently compressed using XZ.
+	TarZstdArchive         // a POSIX tar archive that has been subsequently compressed using Zstandard.
 )
 
 // ArchiveExts maps from a file extension and its associated archive and/or compression format.
 var ArchiveExts = map[string]ArchiveFormat{
-	".tar":    TarArchive,
-	".tgz":    TarGZIPArchive,
-	".tar.gz": TarGZIPArchive,
-	".zip":    ZIPArchive,
+	".tar":     TarArchive,
+	".tgz":     TarGZIPArchive,
+	".tar.gz":  TarGZIPArchive,
+	".zip":     ZIPArchive,
+	".tbz2":    TarBZIP2Archive,
+	".tar.bz2": TarBZIP2Archive,
+	".txz":     TarXZArchive,
+	".tar.xz":  TarXZArchive,
+	".tzst":    TarZstdArchive,
+	".tar.zst": TarZstdArchive,
 }
 
 // detectArchiveFormat takes a path and infers its archive format based on the file extension.
@@ -1152,13 +1271,22 @@ func detectArchiveFormat(path string) ArchiveFormat {
 }
 
 // readArchive takes a stream to an existing archive and returns a map of names to readers for the inner assets.
-// The routine returns an error if something goes wrong and, no matter what, closes the stream before returning.
-func readArchive(ar io.ReadCloser, format ArchiveFormat) (ArchiveReader, error) {
+// The routine returns an error if something goes wrong and, no matter what, closes the stream before returning. If
+// sorted is true, the reader returns the archive's members in order of name.
+func readArchive(ar io.ReadCloser, format ArchiveFormat, sorted bool) (ArchiveReader, error) {
+	var r ArchiveReader
+	var err error
 	switch format {
 	case TarArchive:
-		return readTarArchive(ar)
+		r, err = readTarArchive(ar)
 	case TarGZIPArchive:
-		return readTarGZIPArchive(ar)
+		r, err = readCompressedTarArchive(ar, newGZIPReader)
+	case TarBZIP2Archive:
+		r, err = readCompressedTarArchive(ar, newBZIP2Reader)
+	case TarXZArchive:
+		r, err = readCompressedTarArchive(ar, newXZReader)
+	case TarZstdArchive:
+		r, err = readCompressedTarArchive(ar, newZstdReader)
 	case ZIPArchive:
 		// Unfortunately, the ZIP archive reader requires ReaderAt functionality.  If it's a file, we can recover this
 		// with a simple stat.  Otherwise, we will need to go ahead and make a copy in memory.
@@ -1177,11 +1305,16 @@ func readArchive(ar io.ReadCloser, format ArchiveFormat) (ArchiveReader, error)
 			ra = bytes.NewReader(data)
 			sz = int64(len(data))
 		}
-		return readZIPArchive(ra, sz)
+		// ZIP archives support random access, so they can be sorted without buffering their contents.
+		return readZIPArchive(ra, sz, sorted)
 	default:
 		contract.Failf("Illegal archive type: %v", format)
 		return nil, nil
 	}
+	if err != nil || !sorted {
+		return r, err
+	}
+	return readSortedArchive(r)
 }
 
 // tarArchiveReader is used to read an archive that is stored in tar format.
@@ -1226,27 +1359,124 @@ func readTarArchive(ar io.ReadCloser) (ArchiveReader, error) {
 	return r, nil
 }
 
-func readTarGZIPArchive(ar io.ReadCloser) (ArchiveReader, error) {
-	// First decompress the GZIP stream.
-	gz, err := gzip.NewReader(ar)
+// readCompressedTarArchive reads a tar archive from the compressed stream ar, which is decompressed by the reader
+// returned by newReader.
+func readCompressedTarArchive(ar io.ReadCloser, newReader func(r io.Reader) (io.ReadCloser, error)) (ArchiveReader,
+	error) {
+
+	// First decompress the stream.
+	z, err := newReader(ar)
+	if err != nil {
+		contract.IgnoreClose(ar)
+		return nil, err
+	}
+
+	// Now read the tarfile. Closing it closes both the decompressor and the underlying stream.
+	return readTarArchive(&decompressingReadCloser{ReadCloser: z, ar: ar})
+}
+
+// decompressingReadCloser is a decompressing stream that closes its underlying stream when it is closed.
+type decompressingReadCloser struct {
+	io.ReadCloser
+	ar io.Closer
+}
+
+func (r *decompressingReadCloser) Close() error {
+	contract.IgnoreClose(r.ReadCloser)
+	return r.ar.Close()
+}
+
+func newGZIPReader(r io.Reader) (io.ReadCloser, error) {
+	return gzip.NewReader(r)
+}
+
+func newBZIP2Reader(r io.Reader) (io.ReadCloser, error) {
+	return bzip2.NewReader(r, nil)
+}
+
+func newXZReader(r io.Reader) (io.ReadCloser, error) {
+	xr, err := xz.NewReader(r)
 	if err != nil {
 		return nil, err
 	}
+	return ioutil.NopCloser(xr), nil
+}
+
+func newZstdReader(r io.Reader) (io.ReadCloser, error) {
+	zr, err := zstd.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	return &zstdReadCloser{zr}, nil
+}
+
+// zstdReadCloser adapts a Zstandard decoder, whose Close method releases its resources but returns nothing, to an
+// io.ReadCloser.
+type zstdReadCloser struct {
+	*zstd.Decoder
+}
+
+func (r *zstdReadCloser) Close() error {
+	r.Decoder.Close()
+	return nil
+}
+
+// sortedArchiveReader presents the members of another archive in order of name.
+type sortedArchiveReader struct {
+	names    []string
+	contents map[string][]byte
+}
+
+func (r *sortedArchiveReader) Next() (string, *Blob, error) {
+	if len(r.names) == 0 {
+		return "", nil, io.EOF
+	}
+	name := r.names[0]
+	r.names = r.names[1:]
+	return name, NewByteBlob(r.contents[name]), nil
+}
 
-	// Now read the tarfile.
-	return readTarArchive(gz)
+func (r *sortedArchiveReader) Close() error {
+	return nil
+}
+
+// readSortedArchive reads every member of the given archive into memory, closes the archive, and returns a reader
+// that presents the members in order of name. If a name appears more than once, its first member is kept.
+func readSortedArchive(ar ArchiveReader) (ArchiveReader, error) {
+	defer contract.IgnoreClose(ar)
+
+	r := &sortedArchiveReader{contents: make(map[string][]byte)}
+	for {
+		name, blob, err := ar.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		data, err := ioutil.ReadAll(blob)
+		contract.IgnoreClose(blob)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read archive member %v", name)
+		}
+		if _, has := r.contents[name]; !has {
+			r.names = append(r.names, name)
+			r.contents[name] = data
+		}
+	}
+	sort.Strings(r.names)
+	return r, nil
 }
 
 // zipArchiveReader is used to read an archive that is stored in ZIP format.
 type zipArchiveReader struct {
 	ar    io.ReaderAt
-	zr    *zip.Reader
+	files []*zip.File
 	index int
 }
 
 func (r *zipArchiveReader) Next() (string, *Blob, error) {
-	for r.index < len(r.zr.File) {
-		file := r.zr.File[r.index]
+	for r.index < len(r.files) {
+		file := r.files[r.index]
 		r.index++
 
 		// Skip directories, since they aren't included in TAR and other archives above.
@@ -1276,15 +1506,23 @@ func (r *zipArchiveReader) Close() error {
 	return nil
 }
 
-func readZIPArchive(ar io.ReaderAt, size int64) (ArchiveReader, error) {
+func readZIPArchive(ar io.ReaderAt, size int64, sorted bool) (ArchiveReader, error) {
 	zr, err := zip.NewReader(ar, size)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to read ZIP")
 	}
 
+	files := zr.File
+	if sorted {
+		files = append([]*zip.File(nil), files...)
+		sort.SliceStable(files, func(i, j int) bool {
+			return filepath.Clean(files[i].Name) < filepath.Clean(files[j].Name)
+		})
+	}
+
 	r := &zipArchiveReader{
-		ar: ar,
-		zr: zr,
+		ar:    ar,
+		files: files,
 	}
 	return r, nil
 }
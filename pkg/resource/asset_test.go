@@ -26,6 +26,7 @@ import (
 	"path/filepath"
 	"runtime"
 	"testing"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
@@ -470,6 +471,104 @@ func TestFileExtentionSniffing(t *testing.T) {
 	assert.Equal(t, ArchiveFormat(TarGZIPArchive), detectArchiveFormat("./some/path/my.file.tar.gz"))
 	assert.Equal(t, ArchiveFormat(TarGZIPArchive), detectArchiveFormat("./some/path/my.file.tgz"))
 	assert.Equal(t, ArchiveFormat(NotArchive), detectArchiveFormat("./some/path/who.even.knows"))
+
+	assert.Equal(t, ArchiveFormat(TarBZIP2Archive), detectArchiveFormat("./some/path/my.tar.bz2"))
+	assert.Equal(t, ArchiveFormat(TarBZIP2Archive), detectArchiveFormat("./some/path/my.tbz2"))
+	assert.Equal(t, ArchiveFormat(TarXZArchive), detectArchiveFormat("./some/path/my.tar.xz"))
+	assert.Equal(t, ArchiveFormat(TarXZArchive), detectArchiveFormat("./some/path/my.txz"))
+	assert.Equal(t, ArchiveFormat(TarZstdArchive), detectArchiveFormat("./some/path/my.tar.zst"))
+	assert.Equal(t, ArchiveFormat(TarZstdArchive), detectArchiveFormat("./some/path/my.tzst"))
+}
+
+func TestArchiveFormatRoundTrip(t *testing.T) {
+	dirName, err := ioutil.TempDir("", "")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dirName)
+
+	arch, err := NewPathArchive("./testdata/test_dir")
+	assert.NoError(t, err)
+	for ext, format := range ArchiveExts {
+		// Write the archive in each format, then read it back in.
+		path := filepath.Join(dirName, "test_dir"+ext)
+		f, err := os.Create(path)
+		assert.NoError(t, err)
+		assert.NoError(t, arch.Archive(format, f), ext)
+		assert.NoError(t, f.Close())
+
+		converted, err := NewPathArchive(path)
+		assert.NoError(t, err, ext)
+		validateTestDirArchive(t, converted)
+	}
+}
+
+// writeTestTar writes a tar file with the given members in the given order, each with a different timestamp and
+// permissions.
+func writeTestTar(t *testing.T, path string, names []string, contents map[string]string) {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for i, name := range names {
+		assert.NoError(t, tw.WriteHeader(&tar.Header{
+			Name:    name,
+			Mode:    int64(0600 + i),
+			Size:    int64(len(contents[name])),
+			ModTime: time.Unix(int64(1500000000+i*1000), 0),
+		}))
+		_, err := tw.Write([]byte(contents[name]))
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, tw.Close())
+	assert.NoError(t, ioutil.WriteFile(path, buf.Bytes(), 0600))
+}
+
+func TestDeterministicArchive(t *testing.T) {
+	dirName, err := ioutil.TempDir("", "")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dirName)
+
+	// The same files, stored in different orders with different timestamps and permissions, and as a directory.
+	contents := map[string]string{"a.txt": "a", "b/c.txt": "c", "d.txt": "d"}
+	writeTestTar(t, filepath.Join(dirName, "sorted.tar"), []string{"a.txt", "b/c.txt", "d.txt"}, contents)
+	writeTestTar(t, filepath.Join(dirName, "reversed.tar"), []string{"d.txt", "b/c.txt", "a.txt"}, contents)
+	for name, text := range contents {
+		path := filepath.Join(dirName, "dir", name)
+		assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0700))
+		assert.NoError(t, ioutil.WriteFile(path, []byte(text), 0755))
+	}
+
+	opts := ArchiveOptions{Deterministic: true}
+	var hashes []string
+	var zips [][]byte
+	for _, name := range []string{"sorted.tar", "reversed.tar", "dir"} {
+		arch := &Archive{Sig: ArchiveSig, Path: filepath.Join(dirName, name)}
+		assert.NoError(t, arch.EnsureHashWithOptions(opts))
+		hashes = append(hashes, arch.Hash)
+
+		var buf bytes.Buffer
+		assert.NoError(t, arch.ArchiveWithOptions(ZIPArchive, &buf, opts))
+		zips = append(zips, buf.Bytes())
+	}
+	assert.Equal(t, hashes[0], hashes[1])
+	assert.Equal(t, hashes[0], hashes[2])
+	assert.Equal(t, zips[0], zips[1])
+	assert.Equal(t, zips[0], zips[2])
+
+	// The members are sorted by name and have normalized timestamps and permissions.
+	zr, err := zip.NewReader(bytes.NewReader(zips[0]), int64(len(zips[0])))
+	assert.NoError(t, err)
+	var names []string
+	for _, f := range zr.File {
+		names = append(names, f.Name)
+		assert.Equal(t, os.FileMode(0644), f.Mode())
+		assert.True(t, deterministicModTime.Equal(f.Modified))
+	}
+	assert.Equal(t, []string{"a.txt", "b/c.txt", "d.txt"}, names)
+
+	// Without deterministic mode, the hashes of the tar files are those of their bytes.
+	sorted := &Archive{Sig: ArchiveSig, Path: filepath.Join(dirName, "sorted.tar")}
+	reversed := &Archive{Sig: ArchiveSig, Path: filepath.Join(dirName, "reversed.tar")}
+	assert.NoError(t, sorted.EnsureHashWithOptions(ArchiveOptions{}))
+	assert.NoError(t, reversed.EnsureHashWithOptions(ArchiveOptions{}))
+	assert.NotEqual(t, sorted.Hash, reversed.Hash)
 }
 
 func TestInvalidPathArchive(t *testing.T) {
@@ -44,6 +44,20 @@ func (m Map) Decrypt(decrypter Decrypter) (map[Key]string, error) {
 	return r, nil
 }
 
+// Copy returns a copy of the map in which every secure value has been decrypted with decrypter and re-encrypted with
+// encrypter.
+func (m Map) Copy(decrypter Decrypter, encrypter Encrypter) (Map, error) {
+	newMap := make(Map)
+	for k, c := range m {
+		val, err := c.Copy(decrypter, encrypter)
+		if err != nil {
+			return nil, err
+		}
+		newMap[k] = val
+	}
+	return newMap, nil
+}
+
 // HasSecureValue returns true if the config map contains a secure (encrypted) value.
 func (m Map) HasSecureValue() bool {
 	for _, v := range m {
@@ -72,6 +72,39 @@ func (c Value) Value(decrypter Decrypter) (string, error) {
 	return decrypter.DecryptValue(c.value)
 }
 
+// Copy returns a copy of the value in which every secure value has been decrypted with decrypter and re-encrypted
+// with encrypter. Values that are not secure are returned as-is.
+func (c Value) Copy(decrypter Decrypter, encrypter Encrypter) (Value, error) {
+	if !c.secure {
+		return c, nil
+	}
+	if c.object {
+		var obj interface{}
+		if err := json.Unmarshal([]byte(c.value), &obj); err != nil {
+			return Value{}, err
+		}
+		copiedObj, err := copyObject(obj, decrypter, encrypter)
+		if err != nil {
+			return Value{}, err
+		}
+		json, err := json.Marshal(copiedObj)
+		if err != nil {
+			return Value{}, err
+		}
+		return NewSecureObjectValue(string(json)), nil
+	}
+
+	plaintext, err := decrypter.DecryptValue(c.value)
+	if err != nil {
+		return Value{}, err
+	}
+	ciphertext, err := encrypter.EncryptValue(plaintext)
+	if err != nil {
+		return Value{}, err
+	}
+	return NewSecureValue(ciphertext), nil
+}
+
 func (c Value) SecureValues(decrypter Decrypter) ([]string, error) {
 	d := NewTrackingDecrypter(decrypter)
 	if _, err := c.Value(d); err != nil {
@@ -273,3 +306,46 @@ func decryptObject(v interface{}, decrypter Decrypter) (interface{}, error) {
 	}
 	return v, nil
 }
+
+// copyObject returns a new object with all secure values in the object decrypted with decrypter and re-encrypted with
+// encrypter.
+func copyObject(v interface{}, decrypter Decrypter, encrypter Encrypter) (interface{}, error) {
+	copyIt := func(val interface{}) (interface{}, error) {
+		if isSecure, secureVal := isSecureValue(val); isSecure {
+			plaintext, err := decrypter.DecryptValue(secureVal)
+			if err != nil {
+				return nil, err
+			}
+			ciphertext, err := encrypter.EncryptValue(plaintext)
+			if err != nil {
+				return nil, err
+			}
+			return map[string]interface{}{"secure": ciphertext}, nil
+		}
+		return copyObject(val, decrypter, encrypter)
+	}
+
+	switch t := v.(type) {
+	case map[string]interface{}:
+		m := make(map[string]interface{})
+		for key, val := range t {
+			copied, err := copyIt(val)
+			if err != nil {
+				return nil, err
+			}
+			m[key] = copied
+		}
+		return m, nil
+	case []interface{}:
+		a := make([]interface{}, len(t))
+		for i, val := range t {
+			copied, err := copyIt(val)
+			if err != nil {
+				return nil, err
+			}
+			a[i] = copied
+		}
+		return a, nil
+	}
+	return v, nil
+}
@@ -245,6 +245,58 @@ func TestSecureValues(t *testing.T) {
 	}
 }
 
+func TestCopyValue(t *testing.T) {
+	oldCrypter := NewSymmetricCrypterFromPassphrase("old", []byte("saltsalt"))
+	newCrypter := NewSymmetricCrypterFromPassphrase("new", []byte("saltsalt"))
+	encrypt := func(plaintext string) string {
+		ciphertext, err := oldCrypter.EncryptValue(plaintext)
+		assert.NoError(t, err)
+		return ciphertext
+	}
+
+	tests := []struct {
+		Value    Value
+		Expected string
+	}{
+		{
+			Value:    NewValue("value"),
+			Expected: "value",
+		},
+		{
+			Value:    NewObjectValue(`{"foo":"bar"}`),
+			Expected: `{"foo":"bar"}`,
+		},
+		{
+			Value:    NewSecureValue(encrypt("secret")),
+			Expected: "secret",
+		},
+		{
+			Value: NewSecureObjectValue(
+				`["a",{"secure":"` + encrypt("alpha") + `"},{"test":{"secure":"` + encrypt("beta") + `"}}]`),
+			Expected: `["a","alpha",{"test":"beta"}]`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%v", test.Value), func(t *testing.T) {
+			copied, err := test.Value.Copy(oldCrypter, newCrypter)
+			assert.NoError(t, err)
+			assert.Equal(t, test.Value.Secure(), copied.Secure())
+			assert.Equal(t, test.Value.Object(), copied.Object())
+
+			actual, err := copied.Value(newCrypter)
+			assert.NoError(t, err)
+			assert.Equal(t, test.Expected, actual)
+
+			// Secure values can no longer be decrypted with the old crypter.
+			if test.Value.Secure() {
+				_, err = copied.Value(oldCrypter)
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
 func roundtripValueYAML(v Value) (Value, error) {
 	return roundtripValue(v, yaml.Marshal, yaml.Unmarshal)
 }
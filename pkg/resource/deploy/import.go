@@ -0,0 +1,318 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"context"
+
+	"github.com/blang/semver"
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/diag"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/deploy/providers"
+	"github.com/pulumi/pulumi/pkg/tokens"
+	"github.com/pulumi/pulumi/pkg/util/result"
+)
+
+// An Import specifies an existing resource that should be imported into a stack without the involvement of the
+// stack's program.
+type Import struct {
+	Type     tokens.Type     // the type token for the resource. Required.
+	Name     tokens.QName    // the name of the resource. Required.
+	ID       resource.ID     // the ID of the resource. Required.
+	Parent   resource.URN    // an optional parent URN. Defaults to the stack's root resource.
+	Provider resource.URN    // an optional provider URN. Defaults to the default provider for the resource's package.
+	Version  *semver.Version // an optional version for the default provider.
+	Protect  bool            // true to protect the resource once it has been imported.
+}
+
+// importRegistration stands in for the registration event that would normally accompany a step. Imports are not
+// driven by a program, so nothing waits on the results of their steps.
+type importRegistration struct{}
+
+func (importRegistration) event()                      {}
+func (importRegistration) Goal() *resource.Goal        { return nil }
+func (importRegistration) Done(result *RegisterResult) {}
+
+// importer imports a list of resources into a plan's stack.
+//
+// Because the snapshot manager assumes that any resource it sees is registered after its parent and provider, the
+// importer first carries each of the stack's existing resources forward with a same step and creates any root stack
+// resource or default providers that the imports require. Only then are the resources themselves imported, in waves
+// that ensure that imported parents are imported before their children.
+type importer struct {
+	plan     *Plan
+	executor *stepExecutor
+	preview  bool
+}
+
+// importResources imports the resources listed in opts.Imports into the plan's stack.
+func (pe *planExecutor) importResources(callerCtx context.Context, opts Options, preview bool) result.Result {
+	ctx, cancel := context.WithCancel(callerCtx)
+	defer cancel()
+
+	i := &importer{
+		plan:     pe.plan,
+		executor: newStepExecutor(ctx, cancel, pe.plan, opts, preview, false),
+		preview:  preview,
+	}
+	res := i.importResources(ctx, opts.Imports)
+	i.executor.SignalCompletion()
+	i.executor.WaitForCompletion()
+
+	// NOTE: we use the presence of an error in the caller context in order to distinguish caller-initiated
+	// cancellation from internally-initiated cancellation.
+	canceled := callerCtx.Err() != nil
+
+	if res != nil {
+		return res
+	} else if i.executor.Errored() {
+		pe.reportExecResult("failed", preview)
+		return result.Bail()
+	} else if canceled {
+		pe.reportExecResult("canceled", preview)
+		return result.Bail()
+	}
+	return nil
+}
+
+func (i *importer) importResources(ctx context.Context, imports []Import) result.Result {
+	urns, res := i.checkImports(imports)
+	if res != nil {
+		return res
+	}
+
+	// Carry the existing resources forward and create any missing root stack resource or default providers.
+	prelude, rootURN, defaultProviders, res := i.prelude(imports, urns)
+	if res != nil {
+		return res
+	}
+	if !i.wait(ctx, i.executor.ExecuteSerial(chain(prelude))) {
+		return nil
+	}
+
+	// Now import the resources themselves. Each wave contains the imports whose parents were imported by an earlier
+	// wave (or were not imported at all).
+	waves, res := i.importSteps(imports, urns, rootURN, defaultProviders)
+	if res != nil {
+		return res
+	}
+	for _, wave := range waves {
+		if !i.wait(ctx, i.executor.ExecuteParallel(antichain(wave))) {
+			return nil
+		}
+	}
+	return nil
+}
+
+// wait waits for the given steps to complete, and returns false if execution should not continue.
+func (i *importer) wait(ctx context.Context, tok completionToken) bool {
+	tok.Wait(ctx)
+	return ctx.Err() == nil && !i.executor.Errored()
+}
+
+// checkImports validates the given imports and returns the URN of each.
+func (i *importer) checkImports(imports []Import) ([]resource.URN, result.Result) {
+	urns := make([]resource.URN, len(imports))
+	seen := make(map[resource.URN]bool)
+
+	var sawError bool
+	for idx, imp := range imports {
+		urn := i.plan.generateURN(imp.Parent, imp.Type, imp.Name)
+		urns[idx] = urn
+
+		var err error
+		switch {
+		case imp.Type == "" || imp.Name == "" || imp.ID == "":
+			err = errors.New("imports must specify a type, name, and ID")
+		case providers.IsProviderType(imp.Type) || imp.Type == resource.RootStackType:
+			err = errors.Errorf("resources of type '%v' cannot be imported", imp.Type)
+		case seen[urn]:
+			err = errors.New("the resource is imported more than once")
+		case i.plan.olds[urn] != nil:
+			err = errors.New("a resource with this URN already exists in the stack")
+		case imp.Parent != "" && !seen[imp.Parent] && i.plan.olds[imp.Parent] == nil:
+			err = errors.Errorf("unknown parent '%v'; parents must exist in the stack or be imported first",
+				imp.Parent)
+		case imp.Provider != "":
+			if old := i.plan.olds[imp.Provider]; old == nil || !providers.IsProviderType(old.Type) {
+				err = errors.Errorf("unknown provider '%v'", imp.Provider)
+			} else if providers.GetProviderPackage(old.Type) != imp.Type.Package() {
+				err = errors.Errorf("provider '%v' cannot manage resources of type '%v'", imp.Provider, imp.Type)
+			}
+		}
+		if err != nil {
+			i.plan.Diag().Errorf(diag.GetImportError(urn), urn, err)
+			sawError = true
+		}
+		seen[urn] = true
+	}
+
+	if sawError {
+		return nil, result.Bail()
+	}
+	return urns, nil
+}
+
+// prelude returns the steps that must run before any resources are imported, along with the URN of the stack's root
+// resource and the default provider steps for each package that needs one.
+func (i *importer) prelude(imports []Import, urns []resource.URN) ([]Step, resource.URN,
+	map[string]Step, result.Result) {
+
+	var steps []Step
+
+	// Carry forward every existing resource. Resources that are pending deletion are left in the base snapshot.
+	var rootURN resource.URN
+	if prev := i.plan.prev; prev != nil {
+		for _, old := range prev.Resources {
+			if old.Delete {
+				continue
+			}
+			if old.Type == resource.RootStackType && old.Parent == "" {
+				rootURN = old.URN
+			}
+
+			new := resource.NewState(old.Type, old.URN, old.Custom, false, "", old.Inputs, nil, old.Parent,
+				old.Protect, old.External, old.Dependencies, old.InitErrors, old.Provider, old.PropertyDependencies,
+				old.PendingReplacement, old.AdditionalSecretOutputs, old.Aliases, &old.CustomTimeouts)
+			steps = append(steps, NewSameStep(i.plan, importRegistration{}, old, new))
+		}
+	}
+
+	// If any of the imports will be parented to the root stack resource and no such resource exists, create one.
+	if rootURN == "" {
+		for _, imp := range imports {
+			if imp.Parent == "" {
+				rootURN = resource.DefaultRootStackURN(i.plan.Target().Name, i.plan.source.Project())
+				root := resource.NewState(resource.RootStackType, rootURN, false, false, "", resource.PropertyMap{},
+					nil, "", false, false, nil, nil, "", nil, false, nil, nil, nil)
+				steps = append(steps, NewCreateStep(i.plan, importRegistration{}, root))
+				break
+			}
+		}
+	}
+
+	// Finally, create any default providers that do not already exist.
+	defaultProviders := make(map[string]Step)
+	for idx, imp := range imports {
+		if imp.Provider != "" {
+			continue
+		}
+
+		req := providers.NewProviderRequest(imp.Version, imp.Type.Package())
+		if _, has := defaultProviders[req.String()]; has {
+			continue
+		}
+
+		urn := i.plan.generateURN("", providers.MakeProviderType(req.Package()), req.Name())
+		if old := i.plan.olds[urn]; old != nil {
+			// The same step for the existing provider has already been added to the prelude.
+			for _, s := range steps {
+				if s.URN() == urn {
+					defaultProviders[req.String()] = s
+				}
+			}
+			continue
+		}
+
+		step, err := i.newDefaultProviderStep(urn, req)
+		if err != nil {
+			i.plan.Diag().Errorf(diag.GetImportError(urns[idx]), urns[idx], err)
+			return nil, "", nil, result.Bail()
+		}
+		defaultProviders[req.String()] = step
+		steps = append(steps, step)
+	}
+
+	return steps, rootURN, defaultProviders, nil
+}
+
+// newDefaultProviderStep returns a step that creates the default provider described by the given request.
+func (i *importer) newDefaultProviderStep(urn resource.URN, req providers.ProviderRequest) (Step, error) {
+	cfg, err := i.plan.Target().GetPackageConfig(req.Package())
+	if err != nil {
+		return nil, err
+	}
+
+	inputs := make(resource.PropertyMap)
+	for k, v := range cfg {
+		inputs[resource.PropertyKey(k.Name())] = resource.NewStringProperty(v)
+	}
+	if req.Version() != nil {
+		inputs["version"] = resource.NewStringProperty(req.Version().String())
+	}
+
+	inputs, failures, err := i.plan.providers.Check(urn, nil, inputs, i.preview)
+	if err != nil {
+		return nil, err
+	}
+	if len(failures) != 0 {
+		return nil, errors.Errorf("invalid configuration for the default %v provider: %v", req.Package(),
+			failures[0].Reason)
+	}
+
+	new := resource.NewState(providers.MakeProviderType(req.Package()), urn, true, false, "", inputs, nil, "",
+		false, false, nil, nil, "", nil, false, nil, nil, nil)
+	return NewCreateStep(i.plan, importRegistration{}, new), nil
+}
+
+// importSteps returns the import step for each of the given imports, grouped into waves that may be executed in
+// order. The imports in each wave may be executed in parallel.
+func (i *importer) importSteps(imports []Import, urns []resource.URN, rootURN resource.URN,
+	defaultProviders map[string]Step) ([][]Step, result.Result) {
+
+	var waves [][]Step
+	levels := make(map[resource.URN]int)
+	for idx, imp := range imports {
+		urn := urns[idx]
+
+		parent := imp.Parent
+		if parent == "" {
+			parent = rootURN
+		}
+
+		providerURN, providerID := imp.Provider, resource.ID("")
+		if providerURN != "" {
+			providerID = i.plan.olds[providerURN].ID
+		} else {
+			req := providers.NewProviderRequest(imp.Version, imp.Type.Package())
+			prov := defaultProviders[req.String()].New()
+			providerURN, providerID = prov.URN, prov.ID
+		}
+		if providerID == "" {
+			providerID = providers.UnknownID
+		}
+		ref, err := providers.NewReference(providerURN, providerID)
+		if err != nil {
+			i.plan.Diag().Errorf(diag.GetImportError(urn), urn, err)
+			return nil, result.Bail()
+		}
+
+		new := resource.NewState(imp.Type, urn, true, false, imp.ID, resource.PropertyMap{}, nil, parent, imp.Protect,
+			false, nil, nil, ref.String(), nil, false, nil, nil, nil)
+
+		level := 0
+		if parentLevel, has := levels[imp.Parent]; has {
+			level = parentLevel + 1
+		}
+		levels[urn] = level
+		if level == len(waves) {
+			waves = append(waves, nil)
+		}
+		waves[level] = append(waves[level], NewImportDeploymentStep(i.plan, importRegistration{}, new))
+	}
+	return waves, nil
+}
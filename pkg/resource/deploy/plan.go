@@ -58,6 +58,9 @@ type Options struct {
 	TargetDependents  bool           // true if we're allowing things to proceed, even with unspecified targets
 	TrustDependencies bool           // whether or not to trust the resource dependency graph.
 	UseLegacyDiff     bool           // whether or not to use legacy diffing behavior.
+	Plan              *UpdatePlan    // an optional previously-recorded plan to which all steps must conform.
+	GeneratedPlan     *UpdatePlan    // an optional plan into which all generated steps are recorded.
+	Imports           []Import       // if non-empty, the resources to import instead of evaluating the source.
 }
 
 // DegreeOfParallelism returns the degree of parallelism that should be used during the
@@ -142,6 +142,11 @@ func (pe *planExecutor) Execute(callerCtx context.Context, opts Options, preview
 		}
 	}
 
+	// Imports do not evaluate the source: they simply add the requested resources to the stack.
+	if len(opts.Imports) != 0 {
+		return pe.importResources(callerCtx, opts, preview)
+	}
+
 	// The set of -t targets provided on hte command line.  'nil' means 'update everything'.
 	// Non-nill means 'update only in this set'.  We don't error if the user specifies an target
 	// during `update` that we don't know about because it might be the urn for a resource they
@@ -382,7 +387,10 @@ func (pe *planExecutor) handleSingleEvent(event SourceEvent) result.Result {
 // retirePendingDeletes re-uses the plan executor's step generator but uses its own step executor.
 func (pe *planExecutor) retirePendingDeletes(callerCtx context.Context, opts Options, preview bool) result.Result {
 	contract.Require(pe.stepGen != nil, "pe.stepGen != nil")
-	steps := pe.stepGen.GeneratePendingDeletes()
+	steps, res := pe.stepGen.GeneratePendingDeletes()
+	if res != nil {
+		return res
+	}
 	if len(steps) == 0 {
 		logging.V(4).Infoln("planExecutor.retirePendingDeletes(...): no pending deletions")
 		return nil
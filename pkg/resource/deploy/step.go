@@ -779,6 +779,7 @@ type ImportStep struct {
 	diffs         []resource.PropertyKey         // any keys that differed between the user's program and the actual state.
 	detailedDiff  map[string]plugin.PropertyDiff // the structured property diff.
 	ignoreChanges []string                       // a list of property paths to ignore when updating.
+	planned       bool                           // true if the resource's inputs should be taken from its state.
 }
 
 func NewImportStep(plan *Plan, reg RegisterResourceEvent, new *resource.State, ignoreChanges []string) Step {
@@ -818,6 +819,25 @@ func NewImportReplacementStep(plan *Plan, reg RegisterResourceEvent, original, n
 	}
 }
 
+// NewImportDeploymentStep creates a step that imports a resource that is not described by a program. Rather than
+// checking a program's inputs against the resource's current state, the step adopts the inputs reported by the
+// resource's provider.
+func NewImportDeploymentStep(plan *Plan, reg RegisterResourceEvent, new *resource.State) Step {
+	contract.Assert(new != nil)
+	contract.Assert(new.URN != "")
+	contract.Assert(new.ID != "")
+	contract.Assert(new.Custom)
+	contract.Assert(!new.Delete)
+	contract.Assert(!new.External)
+
+	return &ImportStep{
+		plan:    plan,
+		reg:     reg,
+		new:     new,
+		planned: true,
+	}
+}
+
 func (s *ImportStep) Op() StepOp {
 	if s.replacing {
 		return OpImportReplacement
@@ -873,6 +893,12 @@ func (s *ImportStep) Apply(preview bool) (resource.Status, StepCompleteFunc, err
 		s.new.Parent, s.new.Protect, false, s.new.Dependencies, s.new.InitErrors, s.new.Provider,
 		s.new.PropertyDependencies, false, nil, nil, &s.new.CustomTimeouts)
 
+	// If there is no program to supply the resource's inputs, take them from the provider as-is.
+	if s.planned {
+		s.new.Inputs = read.Inputs
+		return rst, complete, nil
+	}
+
 	// Check the user inputs using the provider inputs for defaults.
 	inputs, failures, err := prov.Check(s.new.URN, s.old.Inputs, s.new.Inputs, preview)
 	if err != nil {
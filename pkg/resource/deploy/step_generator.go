@@ -91,6 +91,16 @@ func (sg *stepGenerator) Errored() bool {
 // GenerateReadSteps is responsible for producing one or more steps required to service
 // a ReadResourceEvent coming from the language host.
 func (sg *stepGenerator) GenerateReadSteps(event ReadResourceEvent) ([]Step, result.Result) {
+	steps := sg.generateReadSteps(event)
+
+	urn := sg.plan.generateURN(event.Parent(), event.Type(), event.Name())
+	if res := sg.planSteps(steps, urn, event.Properties()); res != nil {
+		return nil, res
+	}
+	return steps, nil
+}
+
+func (sg *stepGenerator) generateReadSteps(event ReadResourceEvent) []Step {
 	urn := sg.plan.generateURN(event.Parent(), event.Type(), event.Name())
 	newState := resource.NewState(event.Type(),
 		urn,
@@ -133,7 +143,7 @@ func (sg *stepGenerator) GenerateReadSteps(event ReadResourceEvent) ([]Step, res
 		return []Step{
 			NewReadReplacementStep(sg.plan, event, old, newState),
 			NewReplaceStep(sg.plan, old, newState, nil, nil, nil, true),
-		}, nil
+		}
 	}
 
 	if bool(logging.V(7)) && hasOld && old.ID == event.ID() {
@@ -143,7 +153,7 @@ func (sg *stepGenerator) GenerateReadSteps(event ReadResourceEvent) ([]Step, res
 	sg.reads[urn] = true
 	return []Step{
 		NewReadStep(sg.plan, event, old, newState),
-	}, nil
+	}
 }
 
 // GenerateSteps produces one or more steps required to achieve the goal state specified by the
@@ -157,6 +167,13 @@ func (sg *stepGenerator) GenerateSteps(event RegisterResourceEvent) ([]Step, res
 		contract.Assert(len(steps) == 0)
 		return nil, res
 	}
+
+	goal := event.Goal()
+	urn := sg.plan.generateURN(goal.Parent, goal.Type, goal.Name)
+	if res := sg.planSteps(steps, urn, goal.Properties); res != nil {
+		return nil, res
+	}
+
 	if !sg.isTargetedUpdate() {
 		return steps, nil
 	}
@@ -334,10 +351,15 @@ func (sg *stepGenerator) generateSteps(event RegisterResourceEvent) ([]Step, res
 	analyzers := sg.plan.ctx.Host.ListAnalyzers()
 	for _, analyzer := range analyzers {
 		r := plugin.AnalyzerResource{
-			URN:        new.URN,
-			Type:       new.Type,
-			Name:       new.URN.Name(),
-			Properties: inputs,
+			URN:                  new.URN,
+			Type:                 new.Type,
+			Name:                 new.URN.Name(),
+			Properties:           inputs,
+			Parent:               new.Parent,
+			Dependencies:         new.Dependencies,
+			Provider:             new.Provider,
+			Protect:              new.Protect,
+			PropertyDependencies: new.PropertyDependencies,
 		}
 		diagnostics, err := analyzer.Analyze(r)
 		if err != nil {
@@ -748,6 +770,10 @@ func (sg *stepGenerator) GenerateDeletes(targetsOpt map[resource.URN]bool) ([]St
 		return nil, result.Bail()
 	}
 
+	if res := sg.planSteps(dels, "", nil); res != nil {
+		return nil, res
+	}
+
 	return dels, nil
 }
 
@@ -822,7 +848,7 @@ func (sg *stepGenerator) determineAllowedResourcesToDeleteFromTargets(
 
 // GeneratePendingDeletes generates delete steps for all resources that are pending deletion. This function should be
 // called at the start of a plan in order to find all resources that are pending deletion from the prevous plan.
-func (sg *stepGenerator) GeneratePendingDeletes() []Step {
+func (sg *stepGenerator) GeneratePendingDeletes() ([]Step, result.Result) {
 	var dels []Step
 	if prev := sg.plan.prev; prev != nil {
 		logging.V(7).Infof("stepGenerator.GeneratePendingDeletes(): scanning previous snapshot for pending deletes")
@@ -836,7 +862,42 @@ func (sg *stepGenerator) GeneratePendingDeletes() []Step {
 			}
 		}
 	}
-	return dels
+
+	if res := sg.planSteps(dels, "", nil); res != nil {
+		return nil, res
+	}
+	return dels, nil
+}
+
+// planSteps checks the given steps against the plan to which this update must conform, if any, and records them in
+// the plan being generated, if any. If inputs is non-nil, it holds the inputs supplied by the program for the
+// resource identified by urn.
+func (sg *stepGenerator) planSteps(steps []Step, urn resource.URN, inputs resource.PropertyMap) result.Result {
+	violatesPlan := false
+	for _, step := range steps {
+		var stepInputs resource.PropertyMap
+		if step.URN() == urn && step.New() != nil {
+			stepInputs = inputs
+		}
+
+		if plan := sg.opts.Plan; plan != nil {
+			if err := plan.checkStep(step, stepInputs); err != nil {
+				sg.plan.Diag().Errorf(diag.GetResourceViolatesPlanError(step.URN()), step.URN(), err)
+				sg.sawError = true
+				violatesPlan = true
+			}
+		}
+		if plan := sg.opts.GeneratedPlan; plan != nil {
+			plan.recordStep(step, stepInputs)
+		}
+	}
+
+	// As with targets, a preview keeps going so that the user hears about every violation at once, but an update
+	// must not perform a single step that the plan does not allow.
+	if violatesPlan && !sg.plan.preview {
+		return result.Bail()
+	}
+	return nil
 }
 
 // scheduleDeletes takes a list of steps that will delete resources and "schedules" them by producing a list of list of
@@ -1181,38 +1242,16 @@ func (sg *stepGenerator) calculateDependentReplacements(root *resource.State) ([
 	replaceSet := map[resource.URN]bool{root.URN: true}
 
 	requiresReplacement := func(r *resource.State) (bool, []resource.PropertyKey, result.Result) {
-		// Neither component nor external resources require replacement.
-		if !r.Custom || r.External {
-			return false, nil, nil
-		}
-
-		// If the resource's provider is in the replace set, we mustreplace this resource.
-		if r.Provider != "" {
-			ref, err := providers.ParseReference(r.Provider)
-			if err != nil {
-				return false, nil, result.FromError(err)
-			}
-			if replaceSet[ref.URN()] {
-				return true, nil, nil
-			}
-		}
-
-		// Scan the properties of this resource in order to determine whether or not any of them depend on a resource
-		// that requires replacement and build a set of input properties for the provider diff.
-		hasDependencyInReplaceSet, inputsForDiff := false, resource.PropertyMap{}
-		for pk, pv := range r.Inputs {
-			for _, propertyDep := range r.PropertyDependencies[pk] {
-				if replaceSet[propertyDep] {
-					hasDependencyInReplaceSet = true
-					pv = resource.MakeComputed(resource.NewStringProperty("<unknown>"))
-				}
-			}
-			inputsForDiff[pk] = pv
-		}
-
-		// If none of this resource's properties depend on a resource in the replace set, then none of the properties
-		// may change and this resource does not need to be replaced.
-		if !hasDependencyInReplaceSet {
+		// Determine whether a change to the resources in the replace set could require this resource's replacement.
+		// Neither component nor external resources require replacement, and a resource whose provider is in the
+		// replace set must be replaced.
+		replacement, inputsForDiff, err := graph.ReplacementOf(r, replaceSet)
+		switch {
+		case err != nil:
+			return false, nil, result.FromError(err)
+		case replacement == graph.Replaced:
+			return true, nil, nil
+		case replacement == graph.NotReplaced:
 			return false, nil, nil
 		}
 
@@ -1267,7 +1306,12 @@ func (sg *stepGenerator) AnalyzeResources() result.Result {
 			Name: v.URN.Name(),
 			// Unlike Analyze, AnalyzeStack is called on the final outputs of each resource,
 			// to verify the final stack is in a compliant state.
-			Properties: v.Outputs,
+			Properties:           v.Outputs,
+			Parent:               v.Parent,
+			Dependencies:         v.Dependencies,
+			Provider:             v.Provider,
+			Protect:              v.Protect,
+			PropertyDependencies: v.PropertyDependencies,
 		})
 	}
 
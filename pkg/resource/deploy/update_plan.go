@@ -0,0 +1,226 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/resource"
+)
+
+// UpdatePlan records the steps that an update is expected to perform. A plan is recorded while previewing an update
+// and may later be supplied to the update itself, which will then refuse to perform any step that the plan does not
+// allow. This guarantees that the changes that were reviewed are the changes that get applied.
+//
+// An UpdatePlan is not safe for concurrent use; the step generator is its only writer.
+type UpdatePlan struct {
+	Manifest      Manifest                       // a manifest describing the preview that recorded this plan.
+	ResourcePlans map[resource.URN]*ResourcePlan // the planned steps for each resource, keyed by URN.
+}
+
+// NewUpdatePlan creates a new, empty update plan.
+func NewUpdatePlan(manifest Manifest) *UpdatePlan {
+	return &UpdatePlan{
+		Manifest:      manifest,
+		ResourcePlans: make(map[resource.URN]*ResourcePlan),
+	}
+}
+
+// ResourcePlan records the steps planned for a single resource along with the program inputs and property changes
+// from which those steps were derived.
+type ResourcePlan struct {
+	Ops         []StepOp               // the operations planned for this resource, in order.
+	Inputs      resource.PropertyMap   // the inputs supplied by the program for this resource, if any.
+	ReplaceKeys []resource.PropertyKey // the keys that are expected to cause a replacement, if any.
+	ChangedKeys []resource.PropertyKey // the keys that are expected to change, if any.
+}
+
+// recordStep adds the given step to the plan. If inputs is non-nil, it is recorded as the program's inputs for the
+// step's resource.
+func (p *UpdatePlan) recordStep(step Step, inputs resource.PropertyMap) {
+	urn := step.URN()
+	rp, has := p.ResourcePlans[urn]
+	if !has {
+		rp = &ResourcePlan{}
+		p.ResourcePlans[urn] = rp
+	}
+
+	rp.Ops = append(rp.Ops, step.Op())
+	if inputs != nil {
+		rp.Inputs = inputs
+	}
+
+	keys, diffs := stepKeys(step)
+	rp.ReplaceKeys = appendMissingKeys(rp.ReplaceKeys, keys)
+	rp.ChangedKeys = appendMissingKeys(rp.ChangedKeys, diffs)
+}
+
+// checkStep returns an error if the given step is not allowed by the plan. If inputs is non-nil, the inputs must
+// match those that were recorded for the step's resource.
+func (p *UpdatePlan) checkStep(step Step, inputs resource.PropertyMap) error {
+	urn, op := step.URN(), step.Op()
+	rp, has := p.ResourcePlans[urn]
+	if !has {
+		return errors.Errorf("the plan does not include this resource, but the update would %v it", op)
+	}
+
+	// Doing nothing to a resource never strays outside of the plan.
+	if op == OpSame {
+		return nil
+	}
+
+	if !rp.hasOp(op) {
+		return errors.Errorf("the update would %v this resource, but the plan only allows %v", op, rp.describeOps())
+	}
+
+	if inputs != nil && rp.Inputs != nil {
+		if diffs := diffPlannedProperties(rp.Inputs, inputs); len(diffs) != 0 {
+			return errors.Errorf("the inputs for this resource differ from the plan: %v", joinKeys(diffs))
+		}
+	}
+
+	keys, diffs := stepKeys(step)
+	if unplanned := missingKeys(rp.ReplaceKeys, keys); len(unplanned) != 0 {
+		return errors.Errorf("the update would replace this resource due to changes not in the plan: %v",
+			joinKeys(unplanned))
+	}
+	if unplanned := missingKeys(rp.ChangedKeys, diffs); len(unplanned) != 0 {
+		return errors.Errorf("the update would change properties of this resource that are not in the plan: %v",
+			joinKeys(unplanned))
+	}
+
+	return nil
+}
+
+func (rp *ResourcePlan) hasOp(op StepOp) bool {
+	for _, planned := range rp.Ops {
+		if planned == op {
+			return true
+		}
+	}
+	return false
+}
+
+func (rp *ResourcePlan) describeOps() string {
+	ops := make([]string, len(rp.Ops))
+	for i, op := range rp.Ops {
+		ops[i] = string(op)
+	}
+	return fmt.Sprintf("[%s]", strings.Join(ops, ", "))
+}
+
+// stepKeys returns the replacement and changed keys reported by the given step, if any.
+func stepKeys(step Step) ([]resource.PropertyKey, []resource.PropertyKey) {
+	switch s := step.(type) {
+	case *CreateStep:
+		return s.Keys(), s.Diffs()
+	case *UpdateStep:
+		return nil, s.Diffs()
+	case *ReplaceStep:
+		return s.Keys(), s.Diffs()
+	default:
+		return nil, nil
+	}
+}
+
+// appendMissingKeys appends each key in keys that is not already present in dest.
+func appendMissingKeys(dest, keys []resource.PropertyKey) []resource.PropertyKey {
+	return append(dest, missingKeys(dest, keys)...)
+}
+
+// missingKeys returns each key in keys that is not present in set.
+func missingKeys(set, keys []resource.PropertyKey) []resource.PropertyKey {
+	var missing []resource.PropertyKey
+	for _, k := range keys {
+		found := false
+		for _, s := range set {
+			if s == k {
+				found = true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, k)
+		}
+	}
+	return missing
+}
+
+func joinKeys(keys []resource.PropertyKey) string {
+	strs := make([]string, len(keys))
+	for i, k := range keys {
+		strs[i] = string(k)
+	}
+	sort.Strings(strs)
+	return strings.Join(strs, ", ")
+}
+
+// diffPlannedProperties returns the keys of any properties whose values differ from their planned values. Planned
+// values that were unknown when the plan was recorded are allowed to take on any value.
+func diffPlannedProperties(planned, actual resource.PropertyMap) []resource.PropertyKey {
+	var diffs []resource.PropertyKey
+	for k, pv := range planned {
+		av, has := actual[k]
+		if !has {
+			av = resource.NewNullProperty()
+		}
+		if !plannedValueMatches(pv, av) {
+			diffs = append(diffs, k)
+		}
+	}
+	for k, av := range actual {
+		if _, has := planned[k]; !has && !av.IsNull() {
+			diffs = append(diffs, k)
+		}
+	}
+	return diffs
+}
+
+// plannedValueMatches returns true if the actual value is compatible with the planned value.
+func plannedValueMatches(planned, actual resource.PropertyValue) bool {
+	if planned.IsComputed() || planned.IsOutput() {
+		return true
+	}
+
+	// Secretness is not part of the plan: compare the underlying values.
+	if planned.IsSecret() {
+		planned = planned.SecretValue().Element
+	}
+	if actual.IsSecret() {
+		actual = actual.SecretValue().Element
+	}
+
+	switch {
+	case planned.IsArray() && actual.IsArray():
+		parr, aarr := planned.ArrayValue(), actual.ArrayValue()
+		if len(parr) != len(aarr) {
+			return false
+		}
+		for i := range parr {
+			if !plannedValueMatches(parr[i], aarr[i]) {
+				return false
+			}
+		}
+		return true
+	case planned.IsObject() && actual.IsObject():
+		return len(diffPlannedProperties(planned.ObjectValue(), actual.ObjectValue())) == 0
+	default:
+		return planned.DeepEquals(actual)
+	}
+}
@@ -0,0 +1,308 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package edit
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/resource/deploy/providers"
+	"github.com/pulumi/pulumi/pkg/tokens"
+	"github.com/pulumi/pulumi/pkg/util/contract"
+)
+
+// MoveResources moves the given resources, and every other resource that shares a URN with one of them, from the
+// source snapshot to the destination snapshot. The URNs of the moved resources, and every reference to them, are
+// rewritten for the destination stack and project. Moved resources that are parented to the source's root stack
+// resource are parented to the destination's, which is created if the destination does not have one.
+//
+// The providers of the moved resources are carried along with them: a provider is moved if no resource that remains in
+// the source uses it, and copied otherwise. If the destination already has a provider with the same URN and inputs as
+// a carried provider, the moved resources use the destination's provider instead. The carried providers that were
+// added to the destination are returned.
+//
+// It is an error to move the root stack resource or a resource with pending operations, for a resource that remains in
+// the source to depend on, descend from, or use a moved resource as its provider, for a moved resource to depend on or
+// descend from a resource that is not moved, or for the URN of a moved resource to already exist in the destination.
+// Both snapshots are verified before they are changed, and are left unchanged if an error is returned.
+func MoveResources(source, dest *deploy.Snapshot, resources []*resource.State,
+	destStack tokens.QName, destProject tokens.PackageName) ([]*resource.State, error) {
+
+	contract.Require(source != nil, "source")
+	contract.Require(dest != nil, "dest")
+
+	moveURNs := make(map[resource.URN]bool)
+	for _, res := range resources {
+		if res.Type == resource.RootStackType {
+			return nil, errors.Errorf("cannot move the root stack resource %s", res.URN)
+		}
+		moveURNs[res.URN] = true
+	}
+	for _, op := range source.PendingOperations {
+		if moveURNs[op.Resource.URN] {
+			return nil, errors.Errorf("cannot move resource %s, which has a pending %s operation",
+				op.Resource.URN, op.Type)
+		}
+	}
+
+	// Find the source's root stack resource and the providers that the moved resources use.
+	var sourceRoot resource.URN
+	providerStates := make(map[providers.Reference]*resource.State)
+	for _, res := range source.Resources {
+		if res.Type == resource.RootStackType && res.Parent == "" {
+			sourceRoot = res.URN
+		}
+		if providers.IsProviderType(res.Type) {
+			ref, err := providers.NewReference(res.URN, res.ID)
+			if err != nil {
+				return nil, err
+			}
+			providerStates[ref] = res
+		}
+	}
+	carried := make(map[*resource.State]bool)
+	for _, res := range source.Resources {
+		if !moveURNs[res.URN] || res.Provider == "" {
+			continue
+		}
+		ref, err := providers.ParseReference(res.Provider)
+		if err != nil {
+			return nil, err
+		}
+		if !moveURNs[ref.URN()] {
+			prov, ok := providerStates[ref]
+			if !ok {
+				return nil, errors.Errorf("resource %s refers to unknown provider %s", res.URN, ref)
+			}
+			carried[prov] = true
+		}
+	}
+
+	// Check that no resource that remains in the source refers to a moved resource, and determine which of the carried
+	// providers must be copied rather than moved because remaining resources use them.
+	copied := make(map[*resource.State]bool)
+	for _, res := range source.Resources {
+		if moveURNs[res.URN] {
+			continue
+		}
+		refersTo := func(urn resource.URN) error {
+			if moveURNs[urn] {
+				return errors.Errorf("cannot move resource %s, which resource %s refers to; move both resources "+
+					"together", urn, res.URN)
+			}
+			return nil
+		}
+		if err := refersTo(res.Parent); err != nil {
+			return nil, err
+		}
+		for _, dep := range res.Dependencies {
+			if err := refersTo(dep); err != nil {
+				return nil, err
+			}
+		}
+		if res.Provider != "" {
+			ref, err := providers.ParseReference(res.Provider)
+			if err != nil {
+				return nil, err
+			}
+			if err = refersTo(ref.URN()); err != nil {
+				return nil, err
+			}
+			if prov := providerStates[ref]; prov != nil && carried[prov] {
+				copied[prov] = true
+			}
+		}
+	}
+
+	// Find the destination's root stack resource, and reject moves into URNs that the destination already uses.
+	var destRoot resource.URN
+	destStates := make(map[resource.URN]*resource.State)
+	for _, res := range dest.Resources {
+		if res.Type == resource.RootStackType && res.Parent == "" {
+			destRoot = res.URN
+		}
+		destStates[res.URN] = res
+	}
+	rewriteURN := func(urn resource.URN) resource.URN {
+		return resource.NewURN(destStack, destProject, "", urn.QualifiedType(), urn.Name())
+	}
+	for urn := range moveURNs {
+		if _, has := destStates[rewriteURN(urn)]; has {
+			return nil, errors.Errorf("cannot move resource %s: the destination already has a resource %s",
+				urn, rewriteURN(urn))
+		}
+	}
+
+	// Work out which provider references change. Carried providers that the destination already has are replaced by
+	// the destination's, and the rest are rewritten along with the moved resources.
+	providerRefs := make(map[providers.Reference]string)
+	var newProviders []*resource.State
+	for _, res := range source.Resources {
+		if !carried[res] {
+			continue
+		}
+		ref, err := providers.NewReference(res.URN, res.ID)
+		contract.AssertNoError(err)
+
+		if existing, has := destStates[rewriteURN(res.URN)]; has {
+			if !existing.Inputs.DeepEquals(res.Inputs) {
+				return nil, errors.Errorf("cannot carry provider %s: the destination already has a provider %s "+
+					"with different inputs", res.URN, existing.URN)
+			}
+			destRef, err := providers.NewReference(existing.URN, existing.ID)
+			contract.AssertNoError(err)
+			providerRefs[ref] = destRef.String()
+			continue
+		}
+
+		newRef, err := providers.NewReference(rewriteURN(res.URN), res.ID)
+		contract.AssertNoError(err)
+		providerRefs[ref] = newRef.String()
+		newProviders = append(newProviders, res)
+	}
+	if destRoot == "" {
+		destRoot = resource.DefaultRootStackURN(destStack, destProject)
+	}
+
+	// Copy and rewrite the moved resources and the carried providers, in the source's order so that the destination
+	// remains topologically sorted.
+	newProviderSet := make(map[*resource.State]bool)
+	for _, prov := range newProviders {
+		newProviderSet[prov] = true
+	}
+	needsRoot := false
+	var remaining, added []*resource.State
+	for _, res := range source.Resources {
+		switch {
+		case moveURNs[res.URN] || newProviderSet[res]:
+			moved, err := rewriteMovedResource(res, moveURNs, sourceRoot, destRoot, rewriteURN, providerRefs)
+			if err != nil {
+				return nil, err
+			}
+			if moved.Parent == destRoot {
+				needsRoot = true
+			}
+			added = append(added, moved)
+			if newProviderSet[res] && copied[res] {
+				remaining = append(remaining, res)
+			}
+		default:
+			remaining = append(remaining, res)
+		}
+	}
+
+	newDest := append([]*resource.State{}, dest.Resources...)
+	if needsRoot && destStates[destRoot] == nil {
+		root := resource.NewState(resource.RootStackType, destRoot, false, false, "", resource.PropertyMap{},
+			resource.PropertyMap{}, "", false, false, nil, nil, "", nil, false, nil, nil, nil)
+		newDest = append([]*resource.State{root}, newDest...)
+	}
+	newDest = append(newDest, added...)
+
+	// Verify both results before committing either of them.
+	newSourceSnap, newDestSnap := *source, *dest
+	newSourceSnap.Resources, newDestSnap.Resources = remaining, newDest
+	if err := newSourceSnap.VerifyIntegrity(); err != nil {
+		return nil, errors.Wrap(err, "moving resources would leave the source stack's state invalid")
+	}
+	if err := newDestSnap.VerifyIntegrity(); err != nil {
+		return nil, errors.Wrap(err, "moving resources would leave the destination stack's state invalid")
+	}
+	source.Resources, dest.Resources = remaining, newDest
+
+	var carriedProviders []*resource.State
+	for _, res := range added {
+		if !moveURNs[res.URN] && providers.IsProviderType(res.Type) {
+			carriedProviders = append(carriedProviders, res)
+		}
+	}
+	return carriedProviders, nil
+}
+
+// rewriteMovedResource returns a copy of the given resource with its URN and its references to other resources
+// rewritten for the destination of a move.
+func rewriteMovedResource(res *resource.State, moveURNs map[resource.URN]bool, sourceRoot, destRoot resource.URN,
+	rewriteURN func(resource.URN) resource.URN, providerRefs map[providers.Reference]string) (*resource.State, error) {
+
+	referenced := func(urn resource.URN) (resource.URN, error) {
+		if !moveURNs[urn] {
+			return "", errors.Errorf("cannot move resource %s without resource %s, which it refers to; "+
+				"move both resources together", res.URN, urn)
+		}
+		return rewriteURN(urn), nil
+	}
+
+	moved := *res
+	moved.URN = rewriteURN(res.URN)
+
+	switch {
+	case res.Parent == "":
+	case res.Parent == sourceRoot:
+		moved.Parent = destRoot
+	default:
+		parent, err := referenced(res.Parent)
+		if err != nil {
+			return nil, err
+		}
+		moved.Parent = parent
+	}
+
+	moved.Dependencies = nil
+	for _, dep := range res.Dependencies {
+		newDep, err := referenced(dep)
+		if err != nil {
+			return nil, err
+		}
+		moved.Dependencies = append(moved.Dependencies, newDep)
+	}
+
+	if res.PropertyDependencies != nil {
+		moved.PropertyDependencies = make(map[resource.PropertyKey][]resource.URN)
+		for k, deps := range res.PropertyDependencies {
+			var newDeps []resource.URN
+			for _, dep := range deps {
+				newDep, err := referenced(dep)
+				if err != nil {
+					return nil, err
+				}
+				newDeps = append(newDeps, newDep)
+			}
+			moved.PropertyDependencies[k] = newDeps
+		}
+	}
+
+	moved.Aliases = nil
+	for _, alias := range res.Aliases {
+		moved.Aliases = append(moved.Aliases, rewriteURN(alias))
+	}
+
+	if res.Provider != "" {
+		ref, err := providers.ParseReference(res.Provider)
+		if err != nil {
+			return nil, err
+		}
+		if newRef, ok := providerRefs[ref]; ok {
+			moved.Provider = newRef
+		} else {
+			contract.Assertf(moveURNs[ref.URN()], "provider %s of moved resource %s was not carried", ref, res.URN)
+			newRef, err := providers.NewReference(rewriteURN(ref.URN()), ref.ID())
+			contract.AssertNoError(err)
+			moved.Provider = newRef.String()
+		}
+	}
+
+	return &moved, nil
+}
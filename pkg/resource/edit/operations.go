@@ -157,3 +157,152 @@ func RenameStack(snap *deploy.Snapshot, newName tokens.QName, newProject tokens.
 
 	return nil
 }
+
+// RenameResource changes the name of the given resource, and of every other resource with the same URN. The URNs of
+// these resources and of all of their descendants are regenerated, and every parent, dependency and provider
+// reference to them is rewritten.
+func RenameResource(snap *deploy.Snapshot, res *resource.State, newName tokens.QName) error {
+	contract.Require(snap != nil, "snap")
+	contract.Require(res != nil, "res")
+
+	if res.Type == resource.RootStackType {
+		return errors.Errorf("cannot rename the root stack resource %s; rename the stack instead", res.URN)
+	}
+	if newName == res.URN.Name() {
+		return errors.Errorf("resource %s is already named %s", res.URN, newName)
+	}
+	return changeResourceURN(snap, res, res.Parent, newName)
+}
+
+// ReparentResource changes the parent of the given resource, and of every other resource with the same URN, to the
+// given new parent, or to no parent if newParent is nil. The URNs of these resources and of all of their descendants
+// are regenerated, and every parent, dependency and provider reference to them is rewritten. The new parent must
+// precede the resource in the snapshot, and so cannot be one of its descendants.
+func ReparentResource(snap *deploy.Snapshot, res *resource.State, newParent *resource.State) error {
+	contract.Require(snap != nil, "snap")
+	contract.Require(res != nil, "res")
+
+	if res.Type == resource.RootStackType {
+		return errors.Errorf("cannot reparent the root stack resource %s", res.URN)
+	}
+
+	var parent resource.URN
+	if newParent != nil {
+		parent = newParent.URN
+		resIndex, parentIndex := -1, -1
+		for i, r := range snap.Resources {
+			if r.URN == res.URN && resIndex == -1 {
+				resIndex = i
+			}
+			if r == newParent {
+				parentIndex = i
+			}
+		}
+		if parentIndex == -1 || parentIndex >= resIndex {
+			return errors.Errorf("cannot reparent resource %s to resource %s, which does not precede it in the "+
+				"stack's state", res.URN, parent)
+		}
+	}
+	if parent == res.Parent {
+		return errors.Errorf("resource %s is already parented to %q", res.URN, parent)
+	}
+	return changeResourceURN(snap, res, parent, res.URN.Name())
+}
+
+// changeResourceURN gives the given resource, and every other resource with the same URN, the given parent and name,
+// regenerating their URNs and those of their descendants and rewriting every reference to them. The snapshot is left
+// unchanged if an error is returned.
+func changeResourceURN(snap *deploy.Snapshot, res *resource.State, newParent resource.URN, newName tokens.QName) error {
+	// Generate the new URNs in the same way as the engine: the type of a resource's URN is qualified by the type of
+	// its parent, unless that parent is the root stack resource.
+	generateURN := func(parent resource.URN, typ tokens.Type, name tokens.QName) resource.URN {
+		parentType := tokens.Type("")
+		if parent != "" && parent.Type() != resource.RootStackType {
+			parentType = parent.QualifiedType()
+		}
+		return resource.NewURN(res.URN.Stack(), res.URN.Project(), parentType, typ, name)
+	}
+
+	// Resources are sorted topologically, so every resource's parent is renamed before the resource itself.
+	oldURN := res.URN
+	newURNs := map[resource.URN]resource.URN{
+		oldURN: generateURN(newParent, res.Type, newName),
+	}
+	for _, r := range snap.Resources {
+		if _, has := newURNs[r.URN]; !has && r.Parent != "" {
+			if newParentURN, renamed := newURNs[r.Parent]; renamed {
+				newURNs[r.URN] = generateURN(newParentURN, r.Type, r.URN.Name())
+			}
+		}
+	}
+	if _, cycle := newURNs[newParent]; cycle {
+		return errors.Errorf("cannot parent resource %s to itself or to one of its descendants", oldURN)
+	}
+
+	existing := make(map[resource.URN]bool)
+	for _, r := range snap.Resources {
+		existing[r.URN] = true
+	}
+	for urn, newURN := range newURNs {
+		if _, renamed := newURNs[newURN]; existing[newURN] && !renamed {
+			return errors.Errorf("cannot change the URN of resource %s to %s, which is already in use",
+				urn, newURN)
+		}
+	}
+
+	rewriteURN := func(urn resource.URN) resource.URN {
+		if newURN, ok := newURNs[urn]; ok {
+			return newURN
+		}
+		return urn
+	}
+	rewritten := make(map[*resource.State]bool)
+	rewriteState := func(r *resource.State) {
+		if rewritten[r] {
+			return
+		}
+		rewritten[r] = true
+
+		if r.URN == oldURN {
+			r.Parent = newParent
+		} else {
+			r.Parent = rewriteURN(r.Parent)
+		}
+		r.URN = rewriteURN(r.URN)
+
+		for depIdx, dep := range r.Dependencies {
+			r.Dependencies[depIdx] = rewriteURN(dep)
+		}
+
+		for _, propDeps := range r.PropertyDependencies {
+			for depIdx, dep := range propDeps {
+				propDeps[depIdx] = rewriteURN(dep)
+			}
+		}
+
+		if r.Provider != "" {
+			providerRef, err := providers.ParseReference(r.Provider)
+			contract.AssertNoErrorf(err, "failed to parse provider reference from validated checkpoint")
+
+			providerRef, err = providers.NewReference(rewriteURN(providerRef.URN()), providerRef.ID())
+			contract.AssertNoErrorf(err, "failed to generate provider reference from valid reference")
+
+			r.Provider = providerRef.String()
+		}
+	}
+
+	// Provider references are parsed as we rewrite them, so make sure they are all valid before we begin.
+	if err := snap.VerifyIntegrity(); err != nil {
+		return errors.Wrap(err, "checkpoint is invalid")
+	}
+
+	for _, r := range snap.Resources {
+		rewriteState(r)
+	}
+
+	for _, op := range snap.PendingOperations {
+		rewriteState(op.Resource)
+	}
+
+	return nil
+}
@@ -321,3 +321,165 @@ func TestRenameStack(t *testing.T) {
 		assert.Len(t, LocateResource(snap, updatedResourceURN), 1)
 	})
 }
+
+func TestMoveResources(t *testing.T) {
+	root := &resource.State{
+		Type:    resource.RootStackType,
+		URN:     resource.DefaultRootStackURN("test", "test"),
+		Inputs:  resource.PropertyMap{},
+		Outputs: resource.PropertyMap{},
+	}
+	pA := NewProviderResource("a", "p1", "0")
+	pB := NewProviderResource("b", "p2", "1")
+	a := NewResource("a", pA)
+	a.Parent = root.URN
+	b := NewResource("b", pB, a.URN)
+	b.Parent = a.URN
+	b.PropertyDependencies = map[resource.PropertyKey][]resource.URN{"x": {a.URN}}
+	c := NewResource("c", pA)
+	source := NewSnapshot([]*resource.State{root, pA, pB, a, b, c})
+	dest := NewSnapshot(nil)
+
+	// c depends on a, so a cannot be moved without it.
+	c.Dependencies = []resource.URN{a.URN}
+	_, err := MoveResources(source, dest, []*resource.State{a, b}, "dest", "proj")
+	assert.Error(t, err)
+	assert.Len(t, source.Resources, 6)
+	assert.Empty(t, dest.Resources)
+
+	// b cannot be moved without its parent, a.
+	c.Dependencies = nil
+	_, err = MoveResources(source, dest, []*resource.State{b}, "dest", "proj")
+	assert.Error(t, err)
+
+	// The root stack resource cannot be moved.
+	_, err = MoveResources(source, dest, []*resource.State{root}, "dest", "proj")
+	assert.Error(t, err)
+
+	carried, err := MoveResources(source, dest, []*resource.State{a, b}, "dest", "proj")
+	assert.NoError(t, err)
+
+	// pA is still used by c, so it is copied; pB is only used by b, so it is moved.
+	assert.Equal(t, []*resource.State{root, pA, c}, source.Resources)
+	assert.Len(t, carried, 2)
+	rewrite := func(urn resource.URN) resource.URN {
+		return resource.NewURN("dest", "proj", "", urn.QualifiedType(), urn.Name())
+	}
+	destRoot := resource.DefaultRootStackURN("dest", "proj")
+	var urns []resource.URN
+	for _, res := range dest.Resources {
+		urns = append(urns, res.URN)
+	}
+	assert.Equal(t, []resource.URN{destRoot, rewrite(pA.URN), rewrite(pB.URN), rewrite(a.URN), rewrite(b.URN)}, urns)
+
+	movedA, movedB := dest.Resources[3], dest.Resources[4]
+	assert.Equal(t, destRoot, movedA.Parent)
+	assert.Equal(t, rewrite(a.URN), movedB.Parent)
+	assert.Equal(t, []resource.URN{rewrite(a.URN)}, movedB.Dependencies)
+	assert.Equal(t, []resource.URN{rewrite(a.URN)}, movedB.PropertyDependencies["x"])
+	ref, err := providers.ParseReference(movedB.Provider)
+	assert.NoError(t, err)
+	assert.Equal(t, rewrite(pB.URN), ref.URN())
+	assert.NoError(t, dest.VerifyIntegrity())
+
+	// The source's states are left untouched.
+	assert.Equal(t, root.URN, a.Parent)
+
+	// Moving c reuses the copy of pA that is already in the destination.
+	carried, err = MoveResources(source, dest, []*resource.State{c}, "dest", "proj")
+	assert.NoError(t, err)
+	assert.Empty(t, carried)
+	assert.Equal(t, []*resource.State{root, pA}, source.Resources)
+	assert.Len(t, dest.Resources, 6)
+	ref, err = providers.ParseReference(dest.Resources[5].Provider)
+	assert.NoError(t, err)
+	assert.Equal(t, rewrite(pA.URN), ref.URN())
+}
+
+func TestRenameResource(t *testing.T) {
+	root := &resource.State{
+		Type:    resource.RootStackType,
+		URN:     resource.DefaultRootStackURN("test", "test"),
+		Inputs:  resource.PropertyMap{},
+		Outputs: resource.PropertyMap{},
+	}
+	pA := NewProviderResource("a", "p1", "0")
+	comp := NewResource("comp", nil)
+	comp.Parent = root.URN
+	child := NewResource("child", pA)
+	child.Parent = comp.URN
+	child.URN = resource.NewURN("test", "test", comp.URN.QualifiedType(), child.Type, "child")
+	other := NewResource("other", pA, comp.URN, child.URN)
+	other.PropertyDependencies = map[resource.PropertyKey][]resource.URN{"x": {child.URN}}
+	snap := NewSnapshot([]*resource.State{root, pA, comp, child, other})
+
+	// Renaming a resource to a name that is already in use fails and leaves the snapshot unchanged.
+	err := RenameResource(snap, comp, "other")
+	assert.Error(t, err)
+	assert.EqualValues(t, "comp", comp.URN.Name())
+
+	err = RenameResource(snap, comp, "renamed")
+	assert.NoError(t, err)
+	assert.NoError(t, snap.VerifyIntegrity())
+
+	newComp := resource.NewURN("test", "test", "", comp.Type, "renamed")
+	newChild := resource.NewURN("test", "test", newComp.QualifiedType(), child.Type, "child")
+	assert.Equal(t, newComp, comp.URN)
+	assert.Equal(t, root.URN, comp.Parent)
+	assert.Equal(t, newChild, child.URN)
+	assert.Equal(t, newComp, child.Parent)
+	assert.Equal(t, []resource.URN{newComp, newChild}, other.Dependencies)
+	assert.Equal(t, []resource.URN{newChild}, other.PropertyDependencies["x"])
+
+	// Renaming a provider rewrites the references to it.
+	err = RenameResource(snap, pA, "p2")
+	assert.NoError(t, err)
+	ref, err := providers.ParseReference(child.Provider)
+	assert.NoError(t, err)
+	assert.Equal(t, pA.URN, ref.URN())
+	assert.NoError(t, snap.VerifyIntegrity())
+
+	// The root stack resource cannot be renamed.
+	assert.Error(t, RenameResource(snap, root, "renamed"))
+}
+
+func TestReparentResource(t *testing.T) {
+	root := &resource.State{
+		Type:    resource.RootStackType,
+		URN:     resource.DefaultRootStackURN("test", "test"),
+		Inputs:  resource.PropertyMap{},
+		Outputs: resource.PropertyMap{},
+	}
+	comp := NewResource("comp", nil)
+	comp.Parent = root.URN
+	res := NewResource("res", nil)
+	res.Parent = root.URN
+	child := NewResource("child", nil)
+	child.Parent = res.URN
+	child.URN = resource.NewURN("test", "test", res.URN.QualifiedType(), child.Type, "child")
+	dependent := NewResource("dependent", nil, child.URN)
+	snap := NewSnapshot([]*resource.State{root, comp, res, child, dependent})
+
+	// A resource cannot be parented to one of its descendants, or to a resource that follows it.
+	assert.Error(t, ReparentResource(snap, res, child))
+	assert.Error(t, ReparentResource(snap, comp, res))
+	assert.Equal(t, root.URN, res.Parent)
+
+	err := ReparentResource(snap, res, comp)
+	assert.NoError(t, err)
+	assert.NoError(t, snap.VerifyIntegrity())
+
+	newRes := resource.NewURN("test", "test", comp.URN.QualifiedType(), res.Type, "res")
+	newChild := resource.NewURN("test", "test", newRes.QualifiedType(), child.Type, "child")
+	assert.Equal(t, newRes, res.URN)
+	assert.Equal(t, comp.URN, res.Parent)
+	assert.Equal(t, newChild, child.URN)
+	assert.Equal(t, newRes, child.Parent)
+	assert.Equal(t, []resource.URN{newChild}, dependent.Dependencies)
+
+	// Reparenting to the root stack resource removes the parent type from the URN.
+	err = ReparentResource(snap, res, root)
+	assert.NoError(t, err)
+	assert.Equal(t, resource.NewURN("test", "test", "", res.Type, "res"), res.URN)
+	assert.Equal(t, resource.NewURN("test", "test", res.Type, child.Type, "child"), child.URN)
+}
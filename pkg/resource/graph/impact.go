@@ -0,0 +1,177 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graph
+
+import (
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/deploy/providers"
+	"github.com/pulumi/pulumi/pkg/util/contract"
+)
+
+// Replacement describes whether a resource must be replaced when the resources that it depends on are replaced.
+type Replacement int
+
+const (
+	// NotReplaced indicates that the resource is not replaced.
+	NotReplaced Replacement = iota
+	// MaybeReplaced indicates that some of the resource's inputs depend on replaced resources. Whether the resource
+	// is replaced is decided by its provider's diff of the inputs, with those properties made unknown.
+	MaybeReplaced
+	// Replaced indicates that the resource is replaced, because its provider is replaced.
+	Replaced
+)
+
+func (r Replacement) String() string {
+	switch r {
+	case MaybeReplaced:
+		return "may be replaced"
+	case Replaced:
+		return "replaced"
+	default:
+		return "not replaced"
+	}
+}
+
+// ReplacementOf determines whether the given resource must be replaced when the resources in replaceSet are
+// replaced. If the resource may be replaced, ReplacementOf also returns the resource's inputs with each property that
+// depends on a replaced resource made unknown, which should be passed to its provider's diff in order to decide.
+// Neither component nor external resources are ever replaced.
+func ReplacementOf(r *resource.State, replaceSet map[resource.URN]bool) (Replacement, resource.PropertyMap, error) {
+	if !r.Custom || r.External {
+		return NotReplaced, nil, nil
+	}
+
+	// If the resource's provider is in the replace set, we must replace this resource.
+	if r.Provider != "" {
+		ref, err := providers.ParseReference(r.Provider)
+		if err != nil {
+			return NotReplaced, nil, err
+		}
+		if replaceSet[ref.URN()] {
+			return Replaced, nil, nil
+		}
+	}
+
+	// Scan the properties of this resource in order to determine whether or not any of them depend on a resource
+	// that requires replacement and build a set of input properties for the provider diff.
+	hasDependencyInReplaceSet, inputsForDiff := false, resource.PropertyMap{}
+	for pk, pv := range r.Inputs {
+		for _, propertyDep := range r.PropertyDependencies[pk] {
+			if replaceSet[propertyDep] {
+				hasDependencyInReplaceSet = true
+				pv = resource.MakeComputed(resource.NewStringProperty("<unknown>"))
+			}
+		}
+		inputsForDiff[pk] = pv
+	}
+
+	// If none of this resource's properties depend on a resource in the replace set, then none of the properties
+	// may change and this resource does not need to be replaced.
+	if !hasDependencyInReplaceSet {
+		return NotReplaced, nil, nil
+	}
+	return MaybeReplaced, inputsForDiff, nil
+}
+
+// Impact describes a resource that depends on another resource, directly or indirectly.
+type Impact struct {
+	// Resource is the dependent resource.
+	Resource *resource.State
+	// Via lists the resources through which Resource directly depends on the other resource: the other resource
+	// itself, if the dependency is direct, and otherwise the other resource's dependents on which Resource depends.
+	Via []resource.URN
+	// Replacement describes whether Resource may be replaced if the other resource is replaced. Dependents that may
+	// be replaced are assumed to be replaced when determining the replacement of their own dependents.
+	Replacement Replacement
+}
+
+// ImpactOf returns every resource that directly or indirectly depends upon the given resource, either through its
+// dependencies, its provider, or its parent, in topological order. Each is described along with the resources that
+// link it to the given resource and whether it would be replaced if the given resource were replaced.
+func (dg *DependencyGraph) ImpactOf(res *resource.State) ([]Impact, error) {
+	cursorIndex, ok := dg.index[res]
+	contract.Assert(ok)
+
+	// As in DependingOn, we rely on the snapshot's topological order to find dependents with a single linear scan.
+	dependentSet := map[resource.URN]bool{res.URN: true}
+	replaceSet := map[resource.URN]bool{res.URN: true}
+	var impacts []Impact
+	for i := cursorIndex + 1; i < len(dg.resources); i++ {
+		candidate := dg.resources[i]
+
+		var via []resource.URN
+		addVia := func(urn resource.URN) {
+			if !dependentSet[urn] {
+				return
+			}
+			for _, v := range via {
+				if v == urn {
+					return
+				}
+			}
+			via = append(via, urn)
+		}
+		if candidate.Provider != "" {
+			ref, err := providers.ParseReference(candidate.Provider)
+			if err != nil {
+				return nil, err
+			}
+			addVia(ref.URN())
+		}
+		for _, dependency := range candidate.Dependencies {
+			addVia(dependency)
+		}
+		addVia(candidate.Parent)
+		if len(via) == 0 {
+			continue
+		}
+
+		replacement, _, err := ReplacementOf(candidate, replaceSet)
+		if err != nil {
+			return nil, err
+		}
+		if replacement != NotReplaced {
+			replaceSet[candidate.URN] = true
+		}
+		dependentSet[candidate.URN] = true
+		impacts = append(impacts, Impact{Resource: candidate, Via: via, Replacement: replacement})
+	}
+	return impacts, nil
+}
+
+// TransitiveDependenciesOf returns every resource upon which the given resource directly or indirectly depends,
+// including its ancestors and providers, in topological order.
+func (dg *DependencyGraph) TransitiveDependenciesOf(res *resource.State) []*resource.State {
+	set := ResourceSet{res: true}
+	frontier := []*resource.State{res}
+	for len(frontier) > 0 {
+		next := frontier[0]
+		frontier = frontier[1:]
+		for dep := range dg.DependenciesOf(next) {
+			if !set[dep] {
+				set[dep] = true
+				frontier = append(frontier, dep)
+			}
+		}
+	}
+
+	var result []*resource.State
+	for _, r := range dg.resources {
+		if r != res && set[r] {
+			result = append(result, r)
+		}
+	}
+	return result
+}
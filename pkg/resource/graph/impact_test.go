@@ -0,0 +1,83 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graph
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/resource"
+)
+
+// withInputDependency makes the given input of a custom resource depend on another resource.
+func withInputDependency(res *resource.State, key resource.PropertyKey, dep *resource.State) *resource.State {
+	res.Custom = true
+	res.Inputs[key] = resource.NewStringProperty("value")
+	if res.PropertyDependencies == nil {
+		res.PropertyDependencies = make(map[resource.PropertyKey][]resource.URN)
+	}
+	res.PropertyDependencies[key] = append(res.PropertyDependencies[key], dep.URN)
+	res.Dependencies = append(res.Dependencies, dep.URN)
+	return res
+}
+
+func TestImpactOf(t *testing.T) {
+	pA := NewProviderResource("test", "pA", "0")
+	pA.Custom = true
+	a := NewResource("a", pA)
+	a.Custom = true
+
+	// b's input depends on a, so it may be replaced; c only depends on b, so it is not.
+	b := withInputDependency(NewResource("b", pA), "x", a)
+	c := NewResource("c", pA, b.URN)
+	c.Custom = true
+
+	// d is a child of a, and e is a component that depends on b.
+	d := NewResource("d", pA)
+	d.Custom, d.Parent = true, a.URN
+	e := NewResource("e", nil, b.URN)
+
+	// pB's configuration depends on a, so everything that it manages is replaced.
+	pB := withInputDependency(NewProviderResource("test", "pB", "1"), "region", a)
+	f := NewResource("f", pB)
+	f.Custom = true
+
+	unrelated := NewResource("unrelated", pA)
+	dg := NewDependencyGraph([]*resource.State{pA, a, b, c, d, e, pB, f, unrelated})
+
+	impacts, err := dg.ImpactOf(a)
+	assert.NoError(t, err)
+	assert.Equal(t, []Impact{
+		{Resource: b, Via: []resource.URN{a.URN}, Replacement: MaybeReplaced},
+		{Resource: c, Via: []resource.URN{b.URN}, Replacement: NotReplaced},
+		{Resource: d, Via: []resource.URN{a.URN}, Replacement: NotReplaced},
+		{Resource: e, Via: []resource.URN{b.URN}, Replacement: NotReplaced},
+		{Resource: pB, Via: []resource.URN{a.URN}, Replacement: MaybeReplaced},
+		{Resource: f, Via: []resource.URN{pB.URN}, Replacement: Replaced},
+	}, impacts)
+
+	// Everything but the providers depends on pA.
+	impacts, err = dg.ImpactOf(pA)
+	assert.NoError(t, err)
+	assert.Len(t, impacts, 8)
+	for _, impact := range impacts[:3] {
+		assert.Equal(t, Replaced, impact.Replacement, string(impact.Resource.URN))
+	}
+
+	assert.Equal(t, []*resource.State{pA, a, b}, dg.TransitiveDependenciesOf(c))
+	assert.Equal(t, []*resource.State{pA, a}, dg.TransitiveDependenciesOf(d))
+	assert.Empty(t, dg.TransitiveDependenciesOf(pA))
+}
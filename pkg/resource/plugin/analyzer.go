@@ -45,10 +45,15 @@ type Analyzer interface {
 
 // AnalyzerResource mirrors a resource that is sent to the analyzer.
 type AnalyzerResource struct {
-	URN        resource.URN
-	Type       tokens.Type
-	Name       tokens.QName
-	Properties resource.PropertyMap
+	URN                  resource.URN
+	Type                 tokens.Type
+	Name                 tokens.QName
+	Properties           resource.PropertyMap
+	Parent               resource.URN                            // the resource's parent, if any.
+	Dependencies         []resource.URN                          // the resources this resource depends on.
+	Provider             string                                  // the reference to the resource's provider, if any.
+	Protect              bool                                    // true if the resource is protected.
+	PropertyDependencies map[resource.PropertyKey][]resource.URN // the resources each property depends on.
 }
 
 // AnalyzeDiagnostic indicates that resource analysis failed; it contains the property and reason
@@ -16,6 +16,11 @@ package plugin
 
 import (
 	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
 	"strings"
 
 	"github.com/blang/semver"
@@ -35,10 +40,11 @@ import (
 
 // analyzer reflects an analyzer plugin, loaded dynamically for a single suite of checks.
 type analyzer struct {
-	ctx    *Context
-	name   tokens.QName
-	plug   *plugin
-	client pulumirpc.AnalyzerClient
+	ctx      *Context
+	name     tokens.QName
+	plug     *plugin
+	client   pulumirpc.AnalyzerClient
+	buildDir string // a temporary directory holding the plugin's binary, if it was built on demand.
 }
 
 var _ Analyzer = (*analyzer)(nil)
@@ -75,10 +81,19 @@ func NewAnalyzer(host Host, ctx *Context, name tokens.QName) (Analyzer, error) {
 
 const policyAnalyzerName = "policy"
 
-// NewPolicyAnalyzer boots the nodejs analyzer plugin located at `policyPackpath`
+// NewPolicyAnalyzer boots the analyzer plugin for the policy pack located at `policyPackpath`. Policy packs written in
+// Go are built and run directly; all others are run by the nodejs policy analyzer plugin.
 func NewPolicyAnalyzer(
 	host Host, ctx *Context, name tokens.QName, policyPackPath string) (Analyzer, error) {
 
+	proj, err := loadPolicyPackProject(policyPackPath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "loading policy pack %q", string(name))
+	}
+	if proj != nil && proj.Runtime.Name() == goPolicyRuntime {
+		return newGoPolicyAnalyzer(host, ctx, name, policyPackPath, proj)
+	}
+
 	// Load the policy-booting analyzer plugin (i.e., `pulumi-analyzer-${policyAnalyzerName}`).
 	_, pluginPath, err := workspace.GetPluginPath(
 		workspace.AnalyzerPlugin, policyAnalyzerName, nil)
@@ -116,6 +131,66 @@ func NewPolicyAnalyzer(
 	}, nil
 }
 
+const goPolicyRuntime = "go"
+
+// loadPolicyPackProject loads the PulumiPolicy file in the given directory, if there is one.
+func loadPolicyPackProject(policyPackPath string) (*workspace.PolicyPackProject, error) {
+	path, err := workspace.DetectPolicyPackPathFrom(policyPackPath)
+	if err != nil || path == "" || filepath.Dir(path) != filepath.Clean(policyPackPath) {
+		return nil, err
+	}
+	return workspace.LoadPolicyPack(path)
+}
+
+// newGoPolicyAnalyzer boots the Go policy pack located at `policyPackPath`. If the pack's runtime options specify a
+// prebuilt `binary`, that binary is run; otherwise, the pack is built into a temporary directory first.
+func newGoPolicyAnalyzer(host Host, ctx *Context, name tokens.QName, policyPackPath string,
+	proj *workspace.PolicyPackProject) (Analyzer, error) {
+
+	var bin, buildDir string
+	if binary, ok := proj.Runtime.Options()["binary"].(string); ok && binary != "" {
+		bin = binary
+		if !filepath.IsAbs(bin) {
+			bin = filepath.Join(policyPackPath, bin)
+		}
+	} else {
+		dir, err := ioutil.TempDir("", "pulumi-policy-pack")
+		if err != nil {
+			return nil, errors.Wrap(err, "creating build directory")
+		}
+		buildDir, bin = dir, filepath.Join(dir, "pulumi-analyzer-policy-go")
+		if runtime.GOOS == "windows" {
+			bin += ".exe"
+		}
+
+		logging.V(7).Infof("building Go policy pack %q in %q", name, policyPackPath)
+		build := exec.Command("go", "build", "-o", bin, ".")
+		build.Dir = policyPackPath
+		if out, err := build.CombinedOutput(); err != nil {
+			contract.IgnoreError(os.RemoveAll(buildDir))
+			return nil, errors.Wrapf(err, "building policy pack %q:\n%s", string(name), out)
+		}
+	}
+
+	plug, err := newPlugin(ctx, policyPackPath, bin, fmt.Sprintf("%v (analyzer)", name),
+		[]string{host.ServerAddr(), "."})
+	if err != nil {
+		if buildDir != "" {
+			contract.IgnoreError(os.RemoveAll(buildDir))
+		}
+		return nil, errors.Wrapf(err, "policy pack %q failed to start", string(name))
+	}
+	contract.Assertf(plug != nil, "unexpected nil analyzer plugin for %s", name)
+
+	return &analyzer{
+		ctx:      ctx,
+		name:     name,
+		plug:     plug,
+		client:   pulumirpc.NewAnalyzerClient(plug.Conn),
+		buildDir: buildDir,
+	}, nil
+}
+
 func (a *analyzer) Name() tokens.QName { return a.name }
 
 // label returns a base label for tracing functions.
@@ -135,10 +210,15 @@ func (a *analyzer) Analyze(r AnalyzerResource) ([]AnalyzeDiagnostic, error) {
 	}
 
 	resp, err := a.client.Analyze(a.ctx.Request(), &pulumirpc.AnalyzeRequest{
-		Urn:        string(urn),
-		Type:       string(t),
-		Name:       string(name),
-		Properties: mprops,
+		Urn:                  string(urn),
+		Type:                 string(t),
+		Name:                 string(name),
+		Properties:           mprops,
+		Parent:               string(r.Parent),
+		Dependencies:         marshalURNs(r.Dependencies),
+		Provider:             r.Provider,
+		Protect:              r.Protect,
+		PropertyDependencies: marshalPropertyDependencies(r.PropertyDependencies),
 	})
 	if err != nil {
 		rpcError := rpcerror.Convert(err)
@@ -168,10 +248,15 @@ func (a *analyzer) AnalyzeStack(resources []AnalyzerResource) ([]AnalyzeDiagnost
 		}
 
 		protoResources[idx] = &pulumirpc.AnalyzerResource{
-			Urn:        string(resource.URN),
-			Type:       string(resource.Type),
-			Name:       string(resource.Name),
-			Properties: props,
+			Urn:                  string(resource.URN),
+			Type:                 string(resource.Type),
+			Name:                 string(resource.Name),
+			Properties:           props,
+			Parent:               string(resource.Parent),
+			Dependencies:         marshalURNs(resource.Dependencies),
+			Provider:             resource.Provider,
+			Protect:              resource.Protect,
+			PropertyDependencies: marshalPropertyDependencies(resource.PropertyDependencies),
 		}
 	}
 
@@ -266,7 +351,35 @@ func (a *analyzer) GetPluginInfo() (workspace.PluginInfo, error) {
 
 // Close tears down the underlying plugin RPC connection and process.
 func (a *analyzer) Close() error {
-	return a.plug.Close()
+	err := a.plug.Close()
+	if a.buildDir != "" {
+		contract.IgnoreError(os.RemoveAll(a.buildDir))
+	}
+	return err
+}
+
+func marshalURNs(urns []resource.URN) []string {
+	if len(urns) == 0 {
+		return nil
+	}
+	result := make([]string, len(urns))
+	for i, urn := range urns {
+		result[i] = string(urn)
+	}
+	return result
+}
+
+func marshalPropertyDependencies(
+	deps map[resource.PropertyKey][]resource.URN) map[string]*pulumirpc.AnalyzerPropertyDependencies {
+
+	if len(deps) == 0 {
+		return nil
+	}
+	result := make(map[string]*pulumirpc.AnalyzerPropertyDependencies, len(deps))
+	for k, urns := range deps {
+		result[string(k)] = &pulumirpc.AnalyzerPropertyDependencies{Urns: marshalURNs(urns)}
+	}
+	return result
 }
 
 func convertEnforcementLevel(el pulumirpc.EnforcementLevel) (apitype.EnforcementLevel, error) {
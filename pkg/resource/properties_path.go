@@ -1,6 +1,8 @@
 package resource
 
 import (
+	"fmt"
+	"regexp"
 	"strconv"
 	"strings"
 
@@ -101,6 +103,33 @@ func ParsePropertyPath(path string) (PropertyPath, error) {
 	return PropertyPath(elements), nil
 }
 
+// simplePropertyName matches property names that can be written without quotes in property path strings.
+var simplePropertyName = regexp.MustCompile(`^[a-zA-Z_$][a-zA-Z0-9_$]*$`)
+
+// String returns the property path string for the PropertyPath, which ParsePropertyPath parses back into an equal
+// PropertyPath. Property names that are not valid identifiers are quoted.
+func (p PropertyPath) String() string {
+	var sb strings.Builder
+	for i, key := range p {
+		switch key := key.(type) {
+		case int:
+			fmt.Fprintf(&sb, "[%d]", key)
+		case string:
+			switch {
+			case !simplePropertyName.MatchString(key):
+				sb.WriteString(`["` + strings.Replace(key, `"`, `\"`, -1) + `"]`)
+			case i > 0:
+				sb.WriteString("." + key)
+			default:
+				sb.WriteString(key)
+			}
+		default:
+			fmt.Fprintf(&sb, "[%v]", key)
+		}
+	}
+	return sb.String()
+}
+
 // Get attempts to get the value located by the PropertyPath inside the given PropertyValue. If any component of the
 // path does not exist, this function will return (NullPropertyValue, false).
 func (p PropertyPath) Get(v PropertyValue) (PropertyValue, bool) {
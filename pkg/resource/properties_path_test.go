@@ -120,6 +120,10 @@ func TestPropertyPath(t *testing.T) {
 			assert.NoError(t, err)
 			assert.Equal(t, c.parsed, parsed)
 
+			reparsed, err := ParsePropertyPath(parsed.String())
+			assert.NoError(t, err)
+			assert.Equal(t, c.parsed, reparsed)
+
 			v, ok := parsed.Get(value)
 			assert.True(t, ok)
 			assert.False(t, v.IsNull())
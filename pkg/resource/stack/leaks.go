@@ -0,0 +1,139 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stack
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+)
+
+// SecretLeak describes a property of a resource whose plaintext value contains a known secret value.
+type SecretLeak struct {
+	// Resource is the resource whose property contains the secret value.
+	Resource *resource.State
+	// Output is true if the property is one of the resource's outputs, and false if it is one of its inputs. The
+	// outputs of the root stack resource are the stack's outputs.
+	Output bool
+	// Path is the path to the property. For an asset, it is the path to the asset, whose text contains the value.
+	Path resource.PropertyPath
+}
+
+// SnapshotSecretValues returns the plaintext string values of the secrets in the given snapshot, sorted and without
+// duplicates.
+func SnapshotSecretValues(snap *deploy.Snapshot) []string {
+	set := map[string]bool{}
+	var visit func(v resource.PropertyValue, secret bool)
+	visit = func(v resource.PropertyValue, secret bool) {
+		switch {
+		case v.IsSecret():
+			visit(v.SecretValue().Element, true)
+		case v.IsString():
+			if secret {
+				set[v.StringValue()] = true
+			}
+		case v.IsArray():
+			for _, e := range v.ArrayValue() {
+				visit(e, secret)
+			}
+		case v.IsObject():
+			for _, e := range v.ObjectValue() {
+				visit(e, secret)
+			}
+		}
+	}
+	for _, res := range snap.Resources {
+		visit(resource.NewObjectProperty(res.Inputs), false)
+		visit(resource.NewObjectProperty(res.Outputs), false)
+	}
+
+	values := make([]string, 0, len(set))
+	for value := range set {
+		values = append(values, value)
+	}
+	sort.Strings(values)
+	return values
+}
+
+// FindSecretLeaks searches the inputs and outputs of every resource in the given snapshot, including the stack's
+// outputs, for plaintext strings and asset text that contain any of the given secret values. Values that are already
+// marked secret are not searched. Secret values shorter than minLength are ignored, since short values such as "1" or
+// "true" commonly occur by chance. The leaks are returned in snapshot order, inputs before outputs, with the
+// properties of each sorted by path.
+func FindSecretLeaks(snap *deploy.Snapshot, secretValues []string, minLength int) []SecretLeak {
+	var values []string
+	for _, value := range secretValues {
+		if value != "" && len(value) >= minLength {
+			values = append(values, value)
+		}
+	}
+	if len(values) == 0 {
+		return nil
+	}
+	containsSecret := func(s string) bool {
+		for _, value := range values {
+			if strings.Contains(s, value) {
+				return true
+			}
+		}
+		return false
+	}
+
+	var leaks []SecretLeak
+	var visit func(res *resource.State, output bool, path resource.PropertyPath, v resource.PropertyValue)
+	visit = func(res *resource.State, output bool, path resource.PropertyPath, v resource.PropertyValue) {
+		leaked := false
+		switch {
+		case v.IsString():
+			leaked = containsSecret(v.StringValue())
+		case v.IsAsset():
+			leaked = v.AssetValue().IsText() && containsSecret(v.AssetValue().Text)
+		case v.IsArray():
+			for i, e := range v.ArrayValue() {
+				visit(res, output, append(path[:len(path):len(path)], i), e)
+			}
+		case v.IsObject():
+			obj := v.ObjectValue()
+			for _, k := range obj.StableKeys() {
+				visit(res, output, append(path[:len(path):len(path)], string(k)), obj[k])
+			}
+		}
+		if leaked {
+			leaks = append(leaks, SecretLeak{Resource: res, Output: output, Path: path})
+		}
+	}
+	for _, res := range snap.Resources {
+		visit(res, false, nil, resource.NewObjectProperty(res.Inputs))
+		visit(res, true, nil, resource.NewObjectProperty(res.Outputs))
+	}
+	return leaks
+}
+
+// MarkSecretLeaks marks the properties described by the given leaks as secret, in place, so that they are encrypted
+// when the snapshot is next serialized.
+func MarkSecretLeaks(leaks []SecretLeak) {
+	for _, leak := range leaks {
+		props := leak.Resource.Inputs
+		if leak.Output {
+			props = leak.Resource.Outputs
+		}
+		root := resource.NewObjectProperty(props)
+		if v, ok := leak.Path.Get(root); ok && !v.IsSecret() {
+			leak.Path.Set(root, resource.MakeSecret(v))
+		}
+	}
+}
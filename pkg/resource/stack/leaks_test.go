@@ -0,0 +1,95 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stack
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+)
+
+func TestFindSecretLeaks(t *testing.T) {
+	text, err := resource.NewTextAsset("password=hunter22")
+	assert.NoError(t, err)
+
+	stackRes := &resource.State{
+		URN:  resource.URN("urn:pulumi:dev::proj::pulumi:pulumi:Stack::proj-dev"),
+		Type: resource.RootStackType,
+		Outputs: resource.PropertyMap{
+			"connectionString": resource.NewStringProperty("postgres://admin:hunter22@db"),
+			"dbPassword":       resource.MakeSecret(resource.NewStringProperty("hunter22")),
+		},
+	}
+	db := &resource.State{
+		URN:    resource.URN("urn:pulumi:dev::proj::aws:rds/instance:Instance::db"),
+		Type:   "aws:rds/instance:Instance",
+		Custom: true,
+		Inputs: resource.PropertyMap{
+			"password": resource.MakeSecret(resource.NewStringProperty("hunter22")),
+			"port":     resource.NewNumberProperty(5432),
+		},
+		Outputs: resource.PropertyMap{
+			"password": resource.NewStringProperty("hunter22"),
+			"tags": resource.NewObjectProperty(resource.PropertyMap{
+				"note": resource.NewArrayProperty([]resource.PropertyValue{
+					resource.NewStringProperty("ok"),
+					resource.NewStringProperty("uses api-key-1234"),
+				}),
+			}),
+		},
+	}
+	bucket := &resource.State{
+		URN:    resource.URN("urn:pulumi:dev::proj::aws:s3/bucketObject:BucketObject::conf"),
+		Type:   "aws:s3/bucketObject:BucketObject",
+		Custom: true,
+		Inputs: resource.PropertyMap{
+			"source": resource.NewAssetProperty(text),
+			"acl":    resource.NewStringProperty("private"),
+		},
+	}
+	snap := deploy.NewSnapshot(deploy.Manifest{}, nil, []*resource.State{stackRes, db, bucket}, nil)
+
+	// The snapshot's own secrets are known secret values.
+	secretValues := SnapshotSecretValues(snap)
+	assert.Equal(t, []string{"hunter22"}, secretValues)
+
+	// Short secret values, such as "ok", are ignored.
+	leaks := FindSecretLeaks(snap, append(secretValues, "api-key-1234", "ok"), 4)
+	type leakDesc struct {
+		urn    resource.URN
+		output bool
+		path   string
+	}
+	var actual []leakDesc
+	for _, leak := range leaks {
+		actual = append(actual, leakDesc{leak.Resource.URN, leak.Output, leak.Path.String()})
+	}
+	assert.Equal(t, []leakDesc{
+		{stackRes.URN, true, "connectionString"},
+		{db.URN, true, "password"},
+		{db.URN, true, "tags.note[1]"},
+		{bucket.URN, false, "source"},
+	}, actual)
+
+	// Once the leaks are marked secret, there are no more leaks.
+	MarkSecretLeaks(leaks)
+	assert.True(t, db.Outputs["tags"].ObjectValue()["note"].ArrayValue()[1].IsSecret())
+	assert.True(t, bucket.Inputs["source"].IsSecret())
+	assert.Empty(t, FindSecretLeaks(snap, []string{"hunter22", "api-key-1234"}, 4))
+	assert.Equal(t, []string{"hunter22", "postgres://admin:hunter22@db", "uses api-key-1234"}, SnapshotSecretValues(snap))
+}
@@ -0,0 +1,155 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stack
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/apitype"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/config"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/secrets"
+	"github.com/pulumi/pulumi/pkg/tokens"
+	"github.com/pulumi/pulumi/pkg/util/contract"
+)
+
+var (
+	// ErrUpdatePlanSchemaVersionTooNew is returned from `DeserializePlan` if the plan being deserialized is too new to
+	// understand.
+	ErrUpdatePlanSchemaVersionTooNew = fmt.Errorf("this plan's version is too new")
+)
+
+// SerializePlan serializes an update plan for the given stack so that it can be saved and later supplied to an
+// update. Secret values are encrypted using the given secrets manager.
+func SerializePlan(stackName tokens.QName, plan *deploy.UpdatePlan,
+	sm secrets.Manager) (*apitype.UpdatePlanV1, error) {
+
+	contract.Require(plan != nil, "plan")
+
+	var enc config.Encrypter
+	if sm != nil {
+		e, err := sm.Encrypter()
+		if err != nil {
+			return nil, errors.Wrap(err, "getting encrypter for plan")
+		}
+		enc = e
+	} else {
+		enc = config.NewPanicCrypter()
+	}
+
+	resources := make(map[resource.URN]apitype.ResourcePlanV1)
+	for urn, rp := range plan.ResourcePlans {
+		var inputs map[string]interface{}
+		if rp.Inputs != nil {
+			sinputs, err := SerializeProperties(rp.Inputs, enc)
+			if err != nil {
+				return nil, errors.Wrapf(err, "serializing inputs for %v", urn)
+			}
+			inputs = sinputs
+		}
+
+		ops := make([]string, len(rp.Ops))
+		for i, op := range rp.Ops {
+			ops[i] = string(op)
+		}
+
+		resources[urn] = apitype.ResourcePlanV1{
+			Ops:         ops,
+			Inputs:      inputs,
+			ReplaceKeys: rp.ReplaceKeys,
+			ChangedKeys: rp.ChangedKeys,
+		}
+	}
+
+	var secretsProvider *apitype.SecretsProvidersV1
+	if sm != nil {
+		secretsProvider = &apitype.SecretsProvidersV1{
+			Type: sm.Type(),
+		}
+		if state := sm.State(); state != nil {
+			rm, err := json.Marshal(state)
+			if err != nil {
+				return nil, err
+			}
+			secretsProvider.State = rm
+		}
+	}
+
+	return &apitype.UpdatePlanV1{
+		Version:          apitype.UpdatePlanSchemaVersionCurrent,
+		Stack:            stackName,
+		Manifest:         SerializeManifest(plan.Manifest),
+		SecretsProviders: secretsProvider,
+		Resources:        resources,
+	}, nil
+}
+
+// DeserializePlan deserializes a serialized update plan. DeserializePlan will return an error if the plan's version
+// is newer than `UpdatePlanSchemaVersionCurrent`.
+func DeserializePlan(plan apitype.UpdatePlanV1, secretsProv SecretsProvider) (*deploy.UpdatePlan, error) {
+	if plan.Version > apitype.UpdatePlanSchemaVersionCurrent {
+		return nil, ErrUpdatePlanSchemaVersionTooNew
+	}
+
+	manifest, err := DeserializeManifest(plan.Manifest)
+	if err != nil {
+		return nil, err
+	}
+
+	var dec config.Decrypter
+	if plan.SecretsProviders != nil && plan.SecretsProviders.Type != "" {
+		if secretsProv == nil {
+			return nil, errors.New("plan uses a SecretsProvider but no SecretsProvider was provided")
+		}
+
+		sm, err := secretsProv.OfType(plan.SecretsProviders.Type, plan.SecretsProviders.State)
+		if err != nil {
+			return nil, err
+		}
+		if dec, err = sm.Decrypter(); err != nil {
+			return nil, err
+		}
+	} else {
+		dec = config.NewPanicCrypter()
+	}
+
+	result := deploy.NewUpdatePlan(manifest)
+	for urn, rp := range plan.Resources {
+		var inputs resource.PropertyMap
+		if rp.Inputs != nil {
+			if inputs, err = DeserializeProperties(rp.Inputs, dec); err != nil {
+				return nil, errors.Wrapf(err, "deserializing inputs for %v", urn)
+			}
+		}
+
+		ops := make([]deploy.StepOp, len(rp.Ops))
+		for i, op := range rp.Ops {
+			ops[i] = deploy.StepOp(op)
+		}
+
+		result.ResourcePlans[urn] = &deploy.ResourcePlan{
+			Ops:         ops,
+			Inputs:      inputs,
+			ReplaceKeys: rp.ReplaceKeys,
+			ChangedKeys: rp.ChangedKeys,
+		}
+	}
+
+	return result, nil
+}
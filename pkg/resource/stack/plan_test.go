@@ -0,0 +1,103 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stack
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/apitype"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/secrets/b64"
+	"github.com/pulumi/pulumi/pkg/tokens"
+)
+
+// TestPlanSerialization round-trips a plan with secret and unknown inputs through its JSON representation.
+func TestPlanSerialization(t *testing.T) {
+	urnA := resource.NewURN("test", "test", "", "pkgA:m:typA", "resA")
+	urnB := resource.NewURN("test", "test", "", "pkgA:m:typA", "resB")
+
+	plan := deploy.NewUpdatePlan(deploy.Manifest{
+		Time:    time.Unix(1574800000, 0).UTC(),
+		Magic:   "magic",
+		Version: "1.6.1",
+	})
+	plan.ResourcePlans[urnA] = &deploy.ResourcePlan{
+		Ops: []deploy.StepOp{deploy.OpSame},
+		Inputs: resource.PropertyMap{
+			"string": resource.NewStringProperty("a string"),
+			"secret": resource.MakeSecret(resource.NewStringProperty("a secret")),
+			"nested": resource.NewObjectProperty(resource.PropertyMap{
+				"secret": resource.MakeSecret(resource.NewNumberProperty(42)),
+			}),
+		},
+	}
+	plan.ResourcePlans[urnB] = &deploy.ResourcePlan{
+		Ops: []deploy.StepOp{deploy.OpCreateReplacement, deploy.OpReplace, deploy.OpDeleteReplaced},
+		Inputs: resource.PropertyMap{
+			"unknown": resource.MakeComputed(resource.NewStringProperty("")),
+			"list": resource.NewArrayProperty([]resource.PropertyValue{
+				resource.NewBoolProperty(true),
+				resource.MakeComputed(resource.NewStringProperty("")),
+			}),
+		},
+		ReplaceKeys: []resource.PropertyKey{"unknown"},
+		ChangedKeys: []resource.PropertyKey{"list", "unknown"},
+	}
+
+	serialized, err := SerializePlan("test", plan, b64.NewBase64SecretsManager())
+	assert.NoError(t, err)
+	assert.Equal(t, apitype.UpdatePlanSchemaVersionCurrent, serialized.Version)
+	assert.Equal(t, tokens.QName("test"), serialized.Stack)
+	if assert.NotNil(t, serialized.SecretsProviders) {
+		assert.Equal(t, b64.Type, serialized.SecretsProviders.Type)
+	}
+
+	// Secret values are encrypted and unknown values are replaced by their sentinel.
+	bytes, err := json.Marshal(serialized)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(bytes), "a secret")
+	assert.Contains(t, string(bytes), resource.SecretSig)
+	assert.Contains(t, string(bytes), computedValuePlaceholder)
+
+	var unmarshaled apitype.UpdatePlanV1
+	assert.NoError(t, json.Unmarshal(bytes, &unmarshaled))
+	deserialized, err := DeserializePlan(unmarshaled, DefaultSecretsProvider)
+	assert.NoError(t, err)
+
+	assert.True(t, plan.Manifest.Time.Equal(deserialized.Manifest.Time))
+	assert.Equal(t, plan.Manifest.Magic, deserialized.Manifest.Magic)
+	assert.Equal(t, plan.Manifest.Version, deserialized.Manifest.Version)
+	assert.Equal(t, plan.ResourcePlans, deserialized.ResourcePlans)
+}
+
+func TestLoadTooNewPlan(t *testing.T) {
+	_, err := DeserializePlan(apitype.UpdatePlanV1{
+		Version: apitype.UpdatePlanSchemaVersionCurrent + 1,
+	}, DefaultSecretsProvider)
+	assert.Equal(t, ErrUpdatePlanSchemaVersionTooNew, err)
+}
+
+func TestLoadPlanWithoutSecretsProvider(t *testing.T) {
+	plan := deploy.NewUpdatePlan(deploy.Manifest{})
+	serialized, err := SerializePlan("test", plan, b64.NewBase64SecretsManager())
+	assert.NoError(t, err)
+	_, err = DeserializePlan(*serialized, nil)
+	assert.EqualError(t, err, "plan uses a SecretsProvider but no SecretsProvider was provided")
+}
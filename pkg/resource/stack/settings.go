@@ -0,0 +1,78 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stack
+
+import (
+	"encoding/base64"
+
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/secrets"
+	"github.com/pulumi/pulumi/pkg/secrets/cloud"
+	"github.com/pulumi/pulumi/pkg/secrets/passphrase"
+	"github.com/pulumi/pulumi/pkg/workspace"
+)
+
+// IsCloudSecretsProvider returns true if the given secrets provider, as recorded by a stack's settings, is the URL of
+// a cloud secrets provider (e.g. `awskms://...`) rather than the default or passphrase secrets provider.
+func IsCloudSecretsProvider(provider string) bool {
+	return provider != passphrase.Type && provider != "default" && provider != ""
+}
+
+// NewSettingsSecretsManager returns the secrets manager described by the given stack or config layer settings: a
+// cloud secrets manager if they name a cloud secrets provider, and a passphrase secrets manager otherwise, whose
+// passphrase is returned by readPassphrase. Keys from previous generations are used to decrypt values that were
+// encrypted before the key was rotated.
+//
+// If the settings do not yet record a data key or salt, nil is returned, and it is up to the caller to create one or
+// to use another secrets manager (e.g. the service's). passphrase.ErrIncorrectPassphrase is returned unwrapped if the
+// passphrase is incorrect, so that callers may prompt for it again.
+func NewSettingsSecretsManager(settings *workspace.ProjectStack,
+	readPassphrase func() (string, error)) (secrets.Manager, error) {
+
+	if IsCloudSecretsProvider(settings.SecretsProvider) {
+		if settings.EncryptedKey == "" {
+			return nil, nil
+		}
+		dataKey, err := base64.StdEncoding.DecodeString(settings.EncryptedKey)
+		if err != nil {
+			return nil, errors.Wrap(err, "decoding encrypted key")
+		}
+		var previousDataKeys [][]byte
+		for _, previousEncryptedKey := range settings.PreviousEncryptedKeys {
+			previousDataKey, err := base64.StdEncoding.DecodeString(previousEncryptedKey)
+			if err != nil {
+				return nil, errors.Wrap(err, "decoding previous encrypted key")
+			}
+			previousDataKeys = append(previousDataKeys, previousDataKey)
+		}
+		sm, err := cloud.NewCloudSecretsManagerWithHistory(
+			settings.SecretsProvider, dataKey, settings.SecretsKeyGeneration, previousDataKeys)
+		if err != nil {
+			return nil, err
+		}
+		return sm, nil
+	}
+
+	if settings.EncryptionSalt == "" {
+		return nil, nil
+	}
+	phrase, err := readPassphrase()
+	if err != nil {
+		return nil, err
+	}
+	return passphrase.NewPassphaseSecretsManagerWithHistory(
+		phrase, settings.EncryptionSalt, settings.SecretsKeyGeneration, settings.PreviousEncryptionSalts)
+}
@@ -0,0 +1,73 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stack
+
+import (
+	"encoding/base64"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	_ "gocloud.dev/secrets/localsecrets"
+
+	"github.com/pulumi/pulumi/pkg/secrets/cloud"
+	"github.com/pulumi/pulumi/pkg/secrets/passphrase"
+	"github.com/pulumi/pulumi/pkg/workspace"
+)
+
+const testKeeperURL = "base64key://smGbjm71Nxd1Ig5FS0wj9SlbzAIrnolCz9bQQ6uAhl4="
+
+func TestIsCloudSecretsProvider(t *testing.T) {
+	assert.False(t, IsCloudSecretsProvider(""))
+	assert.False(t, IsCloudSecretsProvider("default"))
+	assert.False(t, IsCloudSecretsProvider(passphrase.Type))
+	assert.True(t, IsCloudSecretsProvider("awskms://alias/key"))
+}
+
+func TestNewSettingsSecretsManager(t *testing.T) {
+	noPassphrase := func() (string, error) {
+		return "", errors.New("no passphrase")
+	}
+
+	// Settings without a key or salt yield no secrets manager, and the passphrase is not read.
+	sm, err := NewSettingsSecretsManager(&workspace.ProjectStack{}, noPassphrase)
+	assert.NoError(t, err)
+	assert.Nil(t, sm)
+	sm, err = NewSettingsSecretsManager(&workspace.ProjectStack{SecretsProvider: testKeeperURL}, noPassphrase)
+	assert.NoError(t, err)
+	assert.Nil(t, sm)
+
+	// Cloud secrets providers never need the passphrase.
+	dataKey, err := cloud.GenerateNewDataKey(testKeeperURL)
+	assert.NoError(t, err)
+	sm, err = NewSettingsSecretsManager(&workspace.ProjectStack{
+		SecretsProvider: testKeeperURL,
+		EncryptedKey:    base64.StdEncoding.EncodeToString(dataKey),
+	}, noPassphrase)
+	assert.NoError(t, err)
+	assert.Equal(t, cloud.Type, sm.Type())
+
+	// Otherwise, the passphrase is read and checked against the salt.
+	salt, err := passphrase.GenerateNewSalt("password")
+	assert.NoError(t, err)
+	settings := &workspace.ProjectStack{EncryptionSalt: salt}
+	_, err = NewSettingsSecretsManager(settings, noPassphrase)
+	assert.EqualError(t, err, "no passphrase")
+	_, err = NewSettingsSecretsManager(settings, func() (string, error) { return "wrong", nil })
+	assert.Equal(t, passphrase.ErrIncorrectPassphrase, err)
+	sm, err = NewSettingsSecretsManager(settings, func() (string, error) { return "password", nil })
+	assert.NoError(t, err)
+	assert.Equal(t, passphrase.Type, sm.Type())
+}
@@ -36,6 +36,10 @@ const Type = "cloud"
 type cloudSecretsManagerState struct {
 	URL          string `json:"url"`
 	EncryptedKey []byte `json:"encryptedkey"`
+	// Generation is the number of times that the data key has been rotated.
+	Generation int `json:"generation,omitempty"`
+	// PreviousEncryptedKeys are the encrypted data keys used before the data key was last rotated, newest first.
+	PreviousEncryptedKeys [][]byte `json:"previousencryptedkeys,omitempty"`
 }
 
 // NewCloudSecretsManagerFromState deserialize configuration from state and returns a secrets
@@ -47,7 +51,7 @@ func NewCloudSecretsManagerFromState(state json.RawMessage) (secrets.Manager, er
 		return nil, errors.Wrap(err, "unmarshalling state")
 	}
 
-	return NewCloudSecretsManager(s.URL, s.EncryptedKey)
+	return NewCloudSecretsManagerWithHistory(s.URL, s.EncryptedKey, s.Generation, s.PreviousEncryptedKeys)
 }
 
 // GenerateNewDataKey generates a new DataKey seeded by a fresh random 32-byte key and encrypted
@@ -68,6 +72,16 @@ func GenerateNewDataKey(url string) ([]byte, error) {
 // NewCloudSecretsManager returns a secrets manager that uses the target cloud key management
 // service to encrypt/decrypt a data key used for envelope encryption of secrets values.
 func NewCloudSecretsManager(url string, encryptedDataKey []byte) (*Manager, error) {
+	return NewCloudSecretsManagerWithHistory(url, encryptedDataKey, 0, nil)
+}
+
+// NewCloudSecretsManagerWithHistory returns a secrets manager like NewCloudSecretsManager, for a data key that has
+// been rotated generation times. previousEncryptedDataKeys are the encrypted data keys used before it, newest first.
+// The manager's decrypter falls back to the previous data keys for values that were encrypted before the data key was
+// last rotated; these are decrypted by the key management service the first time that they are needed.
+func NewCloudSecretsManagerWithHistory(url string, encryptedDataKey []byte, generation int,
+	previousEncryptedDataKeys [][]byte) (*Manager, error) {
+
 	keeper, err := gosecrets.OpenKeeper(context.Background(), url)
 	if err != nil {
 		return nil, err
@@ -77,23 +91,40 @@ func NewCloudSecretsManager(url string, encryptedDataKey []byte) (*Manager, erro
 		return nil, err
 	}
 	crypter := config.NewSymmetricCrypter(plaintextDataKey)
+
+	var previous []secrets.PreviousKey
+	for _, previousEncryptedDataKey := range previousEncryptedDataKeys {
+		previousEncryptedDataKey := previousEncryptedDataKey
+		previous = append(previous, func() (config.Decrypter, error) {
+			previousDataKey, err := keeper.Decrypt(context.Background(), previousEncryptedDataKey)
+			if err != nil {
+				return nil, errors.Wrap(err, "decrypting previous data key")
+			}
+			return config.NewSymmetricCrypter(previousDataKey), nil
+		})
+	}
+
 	return &Manager{
-		crypter: crypter,
+		crypter:   crypter,
+		decrypter: secrets.NewRotatedDecrypter(crypter, previous),
 		state: cloudSecretsManagerState{
-			URL:          url,
-			EncryptedKey: encryptedDataKey,
+			URL:                   url,
+			EncryptedKey:          encryptedDataKey,
+			Generation:            generation,
+			PreviousEncryptedKeys: previousEncryptedDataKeys,
 		},
 	}, nil
 }
 
 // Manager is the secrets.Manager implementation for cloud key management services
 type Manager struct {
-	state   cloudSecretsManagerState
-	crypter config.Crypter
+	state     cloudSecretsManagerState
+	crypter   config.Crypter
+	decrypter config.Decrypter
 }
 
 func (m *Manager) Type() string                         { return Type }
 func (m *Manager) State() interface{}                   { return m.state }
 func (m *Manager) Encrypter() (config.Encrypter, error) { return m.crypter, nil }
-func (m *Manager) Decrypter() (config.Decrypter, error) { return m.crypter, nil }
+func (m *Manager) Decrypter() (config.Decrypter, error) { return m.decrypter, nil }
 func (m *Manager) EncryptedKey() []byte                 { return m.state.EncryptedKey }
@@ -0,0 +1,76 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloud
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	_ "gocloud.dev/secrets/localsecrets" // support for base64key://
+)
+
+const testKeeperURL = "base64key://smGbjm71Nxd1Ig5FS0wj9SlbzAIrnolCz9bQQ6uAhl4="
+
+func TestRotatedCloudSecretsManager(t *testing.T) {
+	oldKey, err := GenerateNewDataKey(testKeeperURL)
+	assert.NoError(t, err)
+	oldManager, err := NewCloudSecretsManager(testKeeperURL, oldKey)
+	assert.NoError(t, err)
+	oldEncrypter, err := oldManager.Encrypter()
+	assert.NoError(t, err)
+	oldCiphertext, err := oldEncrypter.EncryptValue("old secret")
+	assert.NoError(t, err)
+
+	newKey, err := GenerateNewDataKey(testKeeperURL)
+	assert.NoError(t, err)
+	newManager, err := NewCloudSecretsManagerWithHistory(testKeeperURL, newKey, 1, [][]byte{oldKey})
+	assert.NoError(t, err)
+	newEncrypter, err := newManager.Encrypter()
+	assert.NoError(t, err)
+	newCiphertext, err := newEncrypter.EncryptValue("new secret")
+	assert.NoError(t, err)
+
+	// The rotated manager decrypts values encrypted with both its current and its previous data key.
+	decrypter, err := newManager.Decrypter()
+	assert.NoError(t, err)
+	plaintext, err := decrypter.DecryptValue(newCiphertext)
+	assert.NoError(t, err)
+	assert.Equal(t, "new secret", plaintext)
+	plaintext, err = decrypter.DecryptValue(oldCiphertext)
+	assert.NoError(t, err)
+	assert.Equal(t, "old secret", plaintext)
+
+	// The manager's state records the key generation and previous data keys, and round-trips.
+	state, err := json.Marshal(newManager.State())
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"url":"`+testKeeperURL+`","encryptedkey":"`+base64.StdEncoding.EncodeToString(newKey)+
+		`","generation":1,"previousencryptedkeys":["`+base64.StdEncoding.EncodeToString(oldKey)+`"]}`, string(state))
+	roundTripped, err := NewCloudSecretsManagerFromState(state)
+	assert.NoError(t, err)
+	assert.Equal(t, newManager.State(), roundTripped.State())
+	roundTrippedDecrypter, err := roundTripped.Decrypter()
+	assert.NoError(t, err)
+	plaintext, err = roundTrippedDecrypter.DecryptValue(oldCiphertext)
+	assert.NoError(t, err)
+	assert.Equal(t, "old secret", plaintext)
+
+	// Unrotated managers omit the history from their state.
+	state, err = json.Marshal(oldManager.State())
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"url":"`+testKeeperURL+`","encryptedkey":"`+base64.StdEncoding.EncodeToString(oldKey)+`"}`,
+		string(state))
+}
@@ -14,9 +14,12 @@
 package passphrase
 
 import (
+	cryptorand "crypto/rand"
 	"encoding/base64"
 	"encoding/json"
+	"fmt"
 	"os"
+	"strconv"
 	"strings"
 	"sync"
 
@@ -77,13 +80,18 @@ func indexN(s string, substr string, n int) int {
 
 type localSecretsManagerState struct {
 	Salt string `json:"salt"`
+	// Generation is the number of times that the key has been rotated.
+	Generation int `json:"generation,omitempty"`
+	// PreviousSalts are the salts of the keys used before the key was last rotated, newest first.
+	PreviousSalts []string `json:"previoussalts,omitempty"`
 }
 
 var _ secrets.Manager = &localSecretsManager{}
 
 type localSecretsManager struct {
-	state   localSecretsManagerState
-	crypter config.Crypter
+	state     localSecretsManagerState
+	crypter   config.Crypter
+	decrypter config.Decrypter
 }
 
 func (sm *localSecretsManager) Type() string {
@@ -96,6 +104,9 @@ func (sm *localSecretsManager) State() interface{} {
 
 func (sm *localSecretsManager) Decrypter() (config.Decrypter, error) {
 	contract.Assert(sm.crypter != nil)
+	if sm.decrypter != nil {
+		return sm.decrypter, nil
+	}
 	return sm.crypter, nil
 }
 
@@ -108,12 +119,23 @@ var lock sync.Mutex
 var cache map[string]secrets.Manager
 
 func NewPassphaseSecretsManager(phrase string, state string) (secrets.Manager, error) {
+	return NewPassphaseSecretsManagerWithHistory(phrase, state, 0, nil)
+}
+
+// NewPassphaseSecretsManagerWithHistory returns a passphrase-based secrets manager whose key is derived from the given
+// passphrase and state. generation is the number of times that the key has been rotated, and previousStates are the
+// states of the keys used before it, newest first. The manager's decrypter falls back to the keys derived from the
+// same passphrase and the previous states for values that were encrypted before the key was last rotated.
+func NewPassphaseSecretsManagerWithHistory(phrase string, state string, generation int,
+	previousStates []string) (secrets.Manager, error) {
+
 	// check the cache first, if we have already seen this state before, return a cached value.
+	cacheKey := strings.Join(append([]string{state, strconv.Itoa(generation)}, previousStates...), "\n")
 	lock.Lock()
 	if cache == nil {
 		cache = make(map[string]secrets.Manager)
 	}
-	cachedValue := cache[state]
+	cachedValue := cache[cacheKey]
 	lock.Unlock()
 
 	if cachedValue != nil {
@@ -125,19 +147,48 @@ func NewPassphaseSecretsManager(phrase string, state string) (secrets.Manager, e
 	if err != nil {
 		return nil, err
 	}
+	var previous []secrets.PreviousKey
+	for _, previousState := range previousStates {
+		previousState := previousState
+		previous = append(previous, func() (config.Decrypter, error) {
+			decrypter, err := symmetricCrypterFromPhraseAndState(phrase, previousState)
+			if err != nil {
+				return nil, errors.Wrap(err, "deriving previous key")
+			}
+			return decrypter, nil
+		})
+	}
 
 	lock.Lock()
 	defer lock.Unlock()
 	sm := &localSecretsManager{
-		crypter: crypter,
+		crypter:   crypter,
+		decrypter: secrets.NewRotatedDecrypter(crypter, previous),
 		state: localSecretsManagerState{
-			Salt: state,
+			Salt:          state,
+			Generation:    generation,
+			PreviousSalts: previousStates,
 		},
 	}
-	cache[state] = sm
+	cache[cacheKey] = sm
 	return sm, nil
 }
 
+// GenerateNewSalt generates a new random salt and returns the state that records it, along with a message encrypted
+// with the key derived from the given passphrase and the salt, which is used to check that a passphrase is correct.
+func GenerateNewSalt(phrase string) (string, error) {
+	salt := make([]byte, 8)
+	if _, err := cryptorand.Read(salt); err != nil {
+		return "", errors.Wrap(err, "reading from system random")
+	}
+
+	msg, err := config.NewSymmetricCrypterFromPassphrase(phrase, salt).EncryptValue("pulumi")
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("v1:%s:%s", base64.StdEncoding.EncodeToString(salt), msg), nil
+}
+
 // NewPassphaseSecretsManagerFromState returns a new passphrase-based secrets manager, from the
 // given state. Will use the passphrase found in PULUMI_CONFIG_PASSPHRASE.
 func NewPassphaseSecretsManagerFromState(state json.RawMessage) (secrets.Manager, error) {
@@ -151,7 +202,7 @@ func NewPassphaseSecretsManagerFromState(state json.RawMessage) (secrets.Manager
 	// (since we need to decrypt the deployment)
 	phrase := os.Getenv("PULUMI_CONFIG_PASSPHRASE")
 
-	sm, err := NewPassphaseSecretsManager(phrase, s.Salt)
+	sm, err := NewPassphaseSecretsManagerWithHistory(phrase, s.Salt, s.Generation, s.PreviousSalts)
 	switch {
 	case err == ErrIncorrectPassphrase:
 		return newLockedPasspharseSecretsManager(s), nil
@@ -0,0 +1,67 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package passphrase
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRotatedPassphraseSecretsManager(t *testing.T) {
+	oldState, err := GenerateNewSalt("password")
+	assert.NoError(t, err)
+	oldManager, err := NewPassphaseSecretsManager("password", oldState)
+	assert.NoError(t, err)
+	oldEncrypter, err := oldManager.Encrypter()
+	assert.NoError(t, err)
+	oldCiphertext, err := oldEncrypter.EncryptValue("old secret")
+	assert.NoError(t, err)
+
+	newState, err := GenerateNewSalt("password")
+	assert.NoError(t, err)
+	assert.NotEqual(t, oldState, newState)
+	newManager, err := NewPassphaseSecretsManagerWithHistory("password", newState, 1, []string{oldState})
+	assert.NoError(t, err)
+	newEncrypter, err := newManager.Encrypter()
+	assert.NoError(t, err)
+	newCiphertext, err := newEncrypter.EncryptValue("new secret")
+	assert.NoError(t, err)
+
+	// The rotated manager decrypts values encrypted with both its current and its previous key.
+	decrypter, err := newManager.Decrypter()
+	assert.NoError(t, err)
+	plaintext, err := decrypter.DecryptValue(newCiphertext)
+	assert.NoError(t, err)
+	assert.Equal(t, "new secret", plaintext)
+	plaintext, err = decrypter.DecryptValue(oldCiphertext)
+	assert.NoError(t, err)
+	assert.Equal(t, "old secret", plaintext)
+
+	// The previous manager cannot decrypt values encrypted with the new key.
+	oldDecrypter, err := oldManager.Decrypter()
+	assert.NoError(t, err)
+	_, err = oldDecrypter.DecryptValue(newCiphertext)
+	assert.Error(t, err)
+
+	// The manager's state records the key generation and previous keys, and round-trips.
+	state, err := json.Marshal(newManager.State())
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"salt":"`+newState+`","generation":1,"previoussalts":["`+oldState+`"]}`, string(state))
+	roundTripped, err := NewPassphaseSecretsManagerFromState(state)
+	assert.NoError(t, err)
+	assert.Equal(t, newManager.State(), roundTripped.State())
+}
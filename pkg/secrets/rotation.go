@@ -0,0 +1,83 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secrets
+
+import (
+	"sync"
+
+	"github.com/pulumi/pulumi/pkg/resource/config"
+)
+
+// PreviousKey constructs a decrypter for a key that a secrets manager used before its key was last rotated.
+type PreviousKey func() (config.Decrypter, error)
+
+// NewRotatedDecrypter returns a decrypter that decrypts values with the given decrypter for the current key, and falls
+// back to the decrypters for the given previous keys, newest first, for values that the current key cannot decrypt.
+// Each previous key's decrypter is constructed the first time that it is needed, and keys whose decrypters cannot be
+// constructed are skipped.
+func NewRotatedDecrypter(current config.Decrypter, previous []PreviousKey) config.Decrypter {
+	if len(previous) == 0 {
+		return current
+	}
+	return &rotatedDecrypter{
+		current:    current,
+		previous:   previous,
+		decrypters: make([]config.Decrypter, len(previous)),
+	}
+}
+
+type rotatedDecrypter struct {
+	current  config.Decrypter
+	previous []PreviousKey
+
+	lock       sync.Mutex
+	decrypters []config.Decrypter
+}
+
+func (d *rotatedDecrypter) DecryptValue(ciphertext string) (string, error) {
+	plaintext, err := d.current.DecryptValue(ciphertext)
+	if err == nil {
+		return plaintext, nil
+	}
+
+	for i := range d.previous {
+		// A previous key that cannot be constructed (e.g. because the key management service no longer has it) may
+		// not be the one that the value needs, so skip it and try the older keys.
+		decrypter, keyErr := d.previousDecrypter(i)
+		if keyErr != nil {
+			continue
+		}
+		if plaintext, prevErr := decrypter.DecryptValue(ciphertext); prevErr == nil {
+			return plaintext, nil
+		}
+	}
+
+	// None of the keys could decrypt the value, so report the current key's error.
+	return "", err
+}
+
+func (d *rotatedDecrypter) previousDecrypter(i int) (config.Decrypter, error) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	if d.decrypters[i] == nil {
+		decrypter, err := d.previous[i]()
+		if err != nil {
+			return nil, err
+		}
+		d.decrypters[i] = decrypter
+	}
+	return d.decrypters[i], nil
+}
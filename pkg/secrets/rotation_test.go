@@ -0,0 +1,80 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secrets
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/resource/config"
+)
+
+func TestRotatedDecrypter(t *testing.T) {
+	newCrypter := func(passphrase string) config.Crypter {
+		return config.NewSymmetricCrypterFromPassphrase(passphrase, []byte("saltsalt"))
+	}
+	encrypt := func(crypter config.Crypter, plaintext string) string {
+		ciphertext, err := crypter.EncryptValue(plaintext)
+		assert.NoError(t, err)
+		return ciphertext
+	}
+	current, older, oldest, unknown := newCrypter("current"), newCrypter("older"), newCrypter("oldest"),
+		newCrypter("unknown")
+
+	// Previous keys are only constructed once they are needed, and only once.
+	var constructed []string
+	previousKey := func(name string, crypter config.Crypter, err error) PreviousKey {
+		return func() (config.Decrypter, error) {
+			constructed = append(constructed, name)
+			if err != nil {
+				return nil, err
+			}
+			return crypter, nil
+		}
+	}
+	d := NewRotatedDecrypter(current, []PreviousKey{
+		previousKey("older", older, nil),
+		previousKey("missing", nil, errors.New("key not found")),
+		previousKey("oldest", oldest, nil),
+	})
+
+	plaintext, err := d.DecryptValue(encrypt(current, "current secret"))
+	assert.NoError(t, err)
+	assert.Equal(t, "current secret", plaintext)
+	assert.Empty(t, constructed)
+
+	plaintext, err = d.DecryptValue(encrypt(older, "older secret"))
+	assert.NoError(t, err)
+	assert.Equal(t, "older secret", plaintext)
+	assert.Equal(t, []string{"older"}, constructed)
+
+	// A previous key that cannot be constructed is skipped in favor of older keys.
+	plaintext, err = d.DecryptValue(encrypt(oldest, "oldest secret"))
+	assert.NoError(t, err)
+	assert.Equal(t, "oldest secret", plaintext)
+	assert.Equal(t, []string{"older", "missing", "oldest"}, constructed)
+
+	// If no key can decrypt the value, the current key's error is returned.
+	ciphertext := encrypt(unknown, "unknown secret")
+	_, currentErr := current.DecryptValue(ciphertext)
+	assert.Error(t, currentErr)
+	_, err = d.DecryptValue(ciphertext)
+	assert.Equal(t, currentErr, err)
+
+	// Without previous keys, the current key's decrypter is used as is.
+	assert.Equal(t, config.Decrypter(current), NewRotatedDecrypter(current, nil))
+}
@@ -0,0 +1,189 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workspace
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/resource/config"
+)
+
+// ConfigLayer is a file of configuration values. A stack's effective configuration is built from the project's
+// defaults layer, if any, then the layers imported by the stack's settings file, and finally the settings file itself.
+// Layers have the same format as stack settings files, and each encrypts its secrets with its own secrets provider, so
+// a layer such as `env/prod-common.yaml` may be shared by many stacks.
+//
+// The project's defaults layer is the `Pulumi.defaults.yaml` file alongside the stack settings files. It is imported
+// implicitly by every stack of the project, and holds the values that the stacks share unless they override them.
+type ConfigLayer struct {
+	// Path is the absolute path of the layer's file.
+	Path string
+	// Settings holds the contents of the layer's file.
+	Settings *ProjectStack
+}
+
+// LoadConfigLayers loads the stack settings file at the given path along with every layer that it imports, directly
+// or indirectly, and the project's defaults layer and its imports, if the defaults layer exists. Layers are returned
+// in order of increasing precedence: the defaults layer comes first, each file's imports precede it, in the order in
+// which they are listed, and the settings file itself comes last. A layer that is imported more than once appears only
+// at its first position. Import paths are relative to the directory of the importing file.
+func LoadConfigLayers(path string) ([]ConfigLayer, error) {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var layers []ConfigLayer
+	loaded := make(map[string]bool)
+	var load func(path string, chain []string) error
+	load = func(path string, chain []string) error {
+		for i, p := range chain {
+			if p == path {
+				return errors.Errorf("config layers import each other: %s",
+					strings.Join(append(chain[i:], path), " -> "))
+			}
+		}
+		if loaded[path] {
+			return nil
+		}
+
+		settings, err := LoadProjectStack(path)
+		if err != nil {
+			return errors.Wrapf(err, "loading config layer %s", path)
+		}
+		chain = append(chain, path)
+		for _, imp := range settings.Imports {
+			if !filepath.IsAbs(imp) {
+				imp = filepath.Join(filepath.Dir(path), imp)
+			}
+			if _, err := os.Stat(imp); err != nil {
+				return errors.Wrapf(err, "config layer %s imports %s", path, imp)
+			}
+			if err := load(filepath.Clean(imp), chain); err != nil {
+				return err
+			}
+		}
+
+		loaded[path] = true
+		layers = append(layers, ConfigLayer{Path: path, Settings: settings})
+		return nil
+	}
+	defaults := ConfigDefaultsLayerPath(path)
+	if defaults != path {
+		_, err := os.Stat(defaults)
+		switch {
+		case err == nil:
+			if err = load(defaults, nil); err != nil {
+				return nil, err
+			}
+		case !os.IsNotExist(err):
+			return nil, errors.Wrapf(err, "loading config layer %s", defaults)
+		}
+	}
+	if err := load(path, nil); err != nil {
+		return nil, err
+	}
+	if layers[len(layers)-1].Path != path {
+		return nil, errors.Errorf("config layer %s may not import the stack settings file %s", defaults, path)
+	}
+	return layers, nil
+}
+
+// ConfigDefaultsLayerPath returns the path of the project's defaults layer for the stack settings file at the given
+// path: the `Pulumi.defaults.yaml` file in the same directory, with the same extension as the settings file.
+func ConfigDefaultsLayerPath(path string) string {
+	return filepath.Join(filepath.Dir(path), ProjectFile+".defaults"+filepath.Ext(path))
+}
+
+// MergeConfigLayers returns the effective configuration defined by the given layers, which are in order of increasing
+// precedence, along with the layer from which each value was taken. Each key's value is taken whole from the layer
+// with the highest precedence that sets it; the values of object keys are not merged.
+func MergeConfigLayers(layers []ConfigLayer) (config.Map, map[config.Key]*ConfigLayer) {
+	cfg := make(config.Map)
+	origins := make(map[config.Key]*ConfigLayer)
+	for i := range layers {
+		for key, v := range layers[i].Settings.Config {
+			cfg[key] = v
+			origins[key] = &layers[i]
+		}
+	}
+	return cfg, origins
+}
+
+// NewConfigLayersDecrypter returns a decrypter for the secrets in an effective configuration returned by
+// MergeConfigLayers, each of which may have been encrypted by a different layer. The decrypter for each layer that
+// contributes a secret is obtained from getDecrypter. Secrets from every layer but the last, which is the stack's own
+// settings file, are decrypted up front; the stack's own secrets are decrypted on demand.
+func NewConfigLayersDecrypter(layers []ConfigLayer, cfg config.Map, origins map[config.Key]*ConfigLayer,
+	getDecrypter func(layer *ConfigLayer) (config.Decrypter, error)) (config.Decrypter, error) {
+
+	d := &configLayersDecrypter{plaintexts: make(map[string]string), own: config.NewPanicCrypter()}
+	decrypters := make(map[*ConfigLayer]config.Decrypter)
+	for key, v := range cfg {
+		if !v.Secure() {
+			continue
+		}
+
+		layer := origins[key]
+		dec, ok := decrypters[layer]
+		if !ok {
+			var err error
+			if dec, err = getDecrypter(layer); err != nil {
+				return nil, err
+			}
+			decrypters[layer] = dec
+		}
+
+		if layer == &layers[len(layers)-1] {
+			d.own = dec
+		} else if _, err := v.Value(&recordingDecrypter{decrypter: dec, plaintexts: d.plaintexts}); err != nil {
+			return nil, errors.Wrapf(err, "decrypting configuration key '%s' from config layer %s", key, layer.Path)
+		}
+	}
+	return d, nil
+}
+
+// configLayersDecrypter decrypts secrets from any of a stack's config layers. Secrets imported from other layers are
+// looked up by their ciphertext, and all others are decrypted by the decrypter for the stack's own settings file.
+type configLayersDecrypter struct {
+	plaintexts map[string]string
+	own        config.Decrypter
+}
+
+func (d *configLayersDecrypter) DecryptValue(ciphertext string) (string, error) {
+	if plaintext, ok := d.plaintexts[ciphertext]; ok {
+		return plaintext, nil
+	}
+	return d.own.DecryptValue(ciphertext)
+}
+
+// recordingDecrypter decrypts values with another decrypter and records each plaintext by its ciphertext.
+type recordingDecrypter struct {
+	decrypter  config.Decrypter
+	plaintexts map[string]string
+}
+
+func (d *recordingDecrypter) DecryptValue(ciphertext string) (string, error) {
+	plaintext, err := d.decrypter.DecryptValue(ciphertext)
+	if err != nil {
+		return "", err
+	}
+	d.plaintexts[ciphertext] = plaintext
+	return plaintext, nil
+}
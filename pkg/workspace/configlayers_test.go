@@ -0,0 +1,181 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workspace
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/resource/config"
+)
+
+// writeConfigLayers writes the given files, keyed by their paths relative to a new temporary directory, and returns
+// the directory.
+func writeConfigLayers(t *testing.T, files map[string]string) string {
+	dir, err := ioutil.TempDir("", "configlayers")
+	assert.NoError(t, err)
+	for name, contents := range files {
+		path := filepath.Join(dir, name)
+		assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0700))
+		assert.NoError(t, ioutil.WriteFile(path, []byte(contents), 0600))
+	}
+	return dir
+}
+
+func TestLoadConfigLayers(t *testing.T) {
+	dir := writeConfigLayers(t, map[string]string{
+		"Pulumi.prod.yaml": "imports:\n- env/prod-common.yaml\n- env/us-west.yaml\n" +
+			"config:\n  proj:size: large\n",
+		"env/prod-common.yaml": "imports:\n- base.yaml\nconfig:\n  proj:size: medium\n  proj:replicas: \"3\"\n",
+		"env/us-west.yaml":     "imports:\n- base.yaml\nconfig:\n  aws:region: us-west-2\n  proj:replicas: \"5\"\n",
+		"env/base.yaml":        "config:\n  aws:region: us-east-1\n  proj:owner: infra\n",
+	})
+	defer os.RemoveAll(dir)
+
+	layers, err := LoadConfigLayers(filepath.Join(dir, "Pulumi.prod.yaml"))
+	assert.NoError(t, err)
+	var paths []string
+	for _, layer := range layers {
+		rel, err := filepath.Rel(dir, layer.Path)
+		assert.NoError(t, err)
+		paths = append(paths, filepath.ToSlash(rel))
+	}
+	assert.Equal(t, []string{"env/base.yaml", "env/prod-common.yaml", "env/us-west.yaml", "Pulumi.prod.yaml"}, paths)
+
+	cfg, origins := MergeConfigLayers(layers)
+	assert.Equal(t, config.Map{
+		config.MustMakeKey("aws", "region"):    config.NewValue("us-west-2"),
+		config.MustMakeKey("proj", "owner"):    config.NewValue("infra"),
+		config.MustMakeKey("proj", "replicas"): config.NewValue("5"),
+		config.MustMakeKey("proj", "size"):     config.NewValue("large"),
+	}, cfg)
+	assert.Equal(t, &layers[2], origins[config.MustMakeKey("aws", "region")])
+	assert.Equal(t, &layers[0], origins[config.MustMakeKey("proj", "owner")])
+	assert.Equal(t, &layers[3], origins[config.MustMakeKey("proj", "size")])
+
+	// A stack without a settings file has a single, empty layer.
+	layers, err = LoadConfigLayers(filepath.Join(dir, "Pulumi.dev.yaml"))
+	assert.NoError(t, err)
+	assert.Len(t, layers, 1)
+}
+
+func TestLoadConfigDefaultsLayer(t *testing.T) {
+	dir := writeConfigLayers(t, map[string]string{
+		"Pulumi.defaults.yaml": "imports:\n- env/base.yaml\nconfig:\n  proj:size: small\n  proj:replicas: \"1\"\n",
+		"Pulumi.prod.yaml":     "imports:\n- env/prod.yaml\nconfig:\n  proj:size: large\n",
+		"env/base.yaml":        "config:\n  proj:owner: infra\n",
+		"env/prod.yaml":        "config:\n  proj:replicas: \"5\"\n",
+	})
+	defer os.RemoveAll(dir)
+
+	paths := func(layers []ConfigLayer) []string {
+		var paths []string
+		for _, layer := range layers {
+			rel, err := filepath.Rel(dir, layer.Path)
+			assert.NoError(t, err)
+			paths = append(paths, filepath.ToSlash(rel))
+		}
+		return paths
+	}
+
+	// The defaults layer and its imports precede the stack's own imports.
+	layers, err := LoadConfigLayers(filepath.Join(dir, "Pulumi.prod.yaml"))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"env/base.yaml", "Pulumi.defaults.yaml", "env/prod.yaml", "Pulumi.prod.yaml"},
+		paths(layers))
+	cfg, _ := MergeConfigLayers(layers)
+	assert.Equal(t, config.Map{
+		config.MustMakeKey("proj", "owner"):    config.NewValue("infra"),
+		config.MustMakeKey("proj", "replicas"): config.NewValue("5"),
+		config.MustMakeKey("proj", "size"):     config.NewValue("large"),
+	}, cfg)
+
+	// Stacks without settings files still get the defaults.
+	layers, err = LoadConfigLayers(filepath.Join(dir, "Pulumi.dev.yaml"))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"env/base.yaml", "Pulumi.defaults.yaml", "Pulumi.dev.yaml"}, paths(layers))
+
+	// A stack named defaults does not import itself.
+	layers, err = LoadConfigLayers(filepath.Join(dir, "Pulumi.defaults.yaml"))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"env/base.yaml", "Pulumi.defaults.yaml"}, paths(layers))
+
+	// The defaults layer may not import a stack's settings file.
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "Pulumi.defaults.yaml"),
+		[]byte("imports:\n- Pulumi.prod.yaml\n"), 0600))
+	_, err = LoadConfigLayers(filepath.Join(dir, "Pulumi.prod.yaml"))
+	assert.Error(t, err)
+}
+
+func TestLoadConfigLayersErrors(t *testing.T) {
+	dir := writeConfigLayers(t, map[string]string{
+		"Pulumi.a.yaml": "imports:\n- a.yaml\n",
+		"a.yaml":        "imports:\n- b.yaml\n",
+		"b.yaml":        "imports:\n- a.yaml\n",
+		"Pulumi.b.yaml": "imports:\n- missing.yaml\n",
+	})
+	defer os.RemoveAll(dir)
+
+	_, err := LoadConfigLayers(filepath.Join(dir, "Pulumi.a.yaml"))
+	assert.EqualError(t, err, "config layers import each other: "+
+		filepath.Join(dir, "a.yaml")+" -> "+filepath.Join(dir, "b.yaml")+" -> "+filepath.Join(dir, "a.yaml"))
+
+	_, err = LoadConfigLayers(filepath.Join(dir, "Pulumi.b.yaml"))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "imports "+filepath.Join(dir, "missing.yaml"))
+}
+
+func TestConfigLayersDecrypter(t *testing.T) {
+	sharedCrypter := config.NewSymmetricCrypterFromPassphrase("shared", []byte("saltsalt"))
+	stackCrypter := config.NewSymmetricCrypterFromPassphrase("stack", []byte("saltsalt"))
+	encrypt := func(crypter config.Crypter, plaintext string) config.Value {
+		ciphertext, err := crypter.EncryptValue(plaintext)
+		assert.NoError(t, err)
+		return config.NewSecureValue(ciphertext)
+	}
+
+	layers := []ConfigLayer{
+		{Path: "shared.yaml", Settings: &ProjectStack{Config: config.Map{
+			config.MustMakeKey("proj", "token"):    encrypt(sharedCrypter, "shared-token"),
+			config.MustMakeKey("proj", "password"): encrypt(sharedCrypter, "shared-password"),
+		}}},
+		{Path: "Pulumi.dev.yaml", Settings: &ProjectStack{Config: config.Map{
+			config.MustMakeKey("proj", "password"): encrypt(stackCrypter, "stack-password"),
+		}}},
+	}
+	cfg, origins := MergeConfigLayers(layers)
+
+	var requested []string
+	decrypter, err := NewConfigLayersDecrypter(layers, cfg, origins, func(layer *ConfigLayer) (config.Decrypter, error) {
+		requested = append(requested, layer.Path)
+		if layer.Path == "shared.yaml" {
+			return sharedCrypter, nil
+		}
+		return stackCrypter, nil
+	})
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"shared.yaml", "Pulumi.dev.yaml"}, requested)
+
+	decrypted, err := cfg.Decrypt(decrypter)
+	assert.NoError(t, err)
+	assert.Equal(t, map[config.Key]string{
+		config.MustMakeKey("proj", "token"):    "shared-token",
+		config.MustMakeKey("proj", "password"): "stack-password",
+	}, decrypted)
+}
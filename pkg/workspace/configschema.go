@@ -0,0 +1,521 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workspace
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/texttheater/golang-levenshtein/levenshtein"
+
+	"github.com/pulumi/pulumi/pkg/resource/config"
+)
+
+// ConfigType is the type of a configuration value declared by a project's config schema.
+type ConfigType string
+
+const (
+	// ConfigTypeString is the type of string configuration values. It is the default type.
+	ConfigTypeString ConfigType = "string"
+	// ConfigTypeInteger is the type of integral configuration values.
+	ConfigTypeInteger ConfigType = "integer"
+	// ConfigTypeNumber is the type of numeric configuration values.
+	ConfigTypeNumber ConfigType = "number"
+	// ConfigTypeBoolean is the type of boolean configuration values.
+	ConfigTypeBoolean ConfigType = "boolean"
+	// ConfigTypeArray is the type of configuration values that are lists, e.g. those set with `--path names[0]`.
+	ConfigTypeArray ConfigType = "array"
+	// ConfigTypeObject is the type of configuration values that are maps, e.g. those set with `--path outer.inner`.
+	ConfigTypeObject ConfigType = "object"
+)
+
+// ProjectConfig is the `config` section of a project. For compatibility with older projects, the section may be a
+// string, in which case it is the directory that holds the project's Pulumi.<stack-name>.yaml files. Otherwise, the
+// section is a map that declares the project's configuration keys, and the stack files are stored next to Pulumi.yaml.
+//
+// Keys are declared by name: a plain name such as `region` belongs to the project's namespace, while a name such as
+// `aws:region` belongs to the given namespace. Once any key is declared, every key set in the project's namespace must
+// be declared, so that typos are caught before the program runs.
+type ProjectConfig struct {
+	// Dir is the directory, relative to Pulumi.yaml, that holds the project's stack files.
+	Dir string
+	// Keys declares the project's configuration keys, by name.
+	Keys map[string]ConfigKeySchema
+}
+
+// ConfigKeySchema declares a single configuration key.
+type ConfigKeySchema struct {
+	// Type is the type of the key's value. If empty, the value is a string.
+	Type ConfigType `json:"type,omitempty" yaml:"type,omitempty"`
+	// Description is an optional informational description of the key.
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	// Default is an optional value that is used if the key is not set.
+	Default interface{} `json:"default,omitempty" yaml:"default,omitempty"`
+	// Required may be set to true to indicate that the key must be set if it has no default.
+	Required bool `json:"required,omitempty" yaml:"required,omitempty"`
+	// Secret may be set to true to indicate that the key's value must be encrypted.
+	Secret bool `json:"secret,omitempty" yaml:"secret,omitempty"`
+	// Enum optionally restricts the key's value to one of the given values.
+	Enum []interface{} `json:"enum,omitempty" yaml:"enum,omitempty"`
+}
+
+func (c ProjectConfig) MarshalYAML() (interface{}, error) {
+	if len(c.Keys) == 0 {
+		return c.Dir, nil
+	}
+	if c.Dir != "" {
+		return nil, errors.New("config section cannot both declare keys and set a directory")
+	}
+	return c.Keys, nil
+}
+
+func (c ProjectConfig) MarshalJSON() ([]byte, error) {
+	if len(c.Keys) == 0 {
+		return json.Marshal(c.Dir)
+	}
+	if c.Dir != "" {
+		return nil, errors.New("config section cannot both declare keys and set a directory")
+	}
+	return json.Marshal(c.Keys)
+}
+
+func (c *ProjectConfig) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &c.Dir); err == nil {
+		return nil
+	}
+	if err := json.Unmarshal(data, &c.Keys); err == nil {
+		return nil
+	}
+	return errors.New("config section must be a string or a map of configuration keys")
+}
+
+func (c *ProjectConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	if err := unmarshal(&c.Dir); err == nil {
+		return nil
+	}
+	if err := unmarshal(&c.Keys); err == nil {
+		// YAML decodes nested maps with interface{} keys; convert them so that values match those decoded from JSON.
+		for name, schema := range c.Keys {
+			schema.Default = normalizeYAMLValue(schema.Default)
+			for i, v := range schema.Enum {
+				schema.Enum[i] = normalizeYAMLValue(v)
+			}
+			c.Keys[name] = schema
+		}
+		return nil
+	}
+	return errors.New("config section must be a string or a map of configuration keys")
+}
+
+// normalizeYAMLValue converts any maps with interface{} keys in the given value into maps with string keys.
+func normalizeYAMLValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for k, e := range v {
+			m[fmt.Sprintf("%v", k)] = normalizeYAMLValue(e)
+		}
+		return m
+	case []interface{}:
+		a := make([]interface{}, len(v))
+		for i, e := range v {
+			a[i] = normalizeYAMLValue(e)
+		}
+		return a
+	default:
+		return v
+	}
+}
+
+// ConfigDir returns the directory, relative to Pulumi.yaml, that holds the project's stack files.
+func (proj *Project) ConfigDir() string {
+	if proj.Config == nil {
+		return ""
+	}
+	return proj.Config.Dir
+}
+
+// ConfigSchema returns the configuration keys declared by the project, keyed by their fully qualified names. If the
+// project declares no keys, an empty map is returned.
+func (proj *Project) ConfigSchema() (map[config.Key]ConfigKeySchema, error) {
+	schema := make(map[config.Key]ConfigKeySchema)
+	if proj.Config == nil {
+		return schema, nil
+	}
+	for name, s := range proj.Config.Keys {
+		key, err := proj.configKey(name)
+		if err != nil {
+			return nil, err
+		}
+		schema[key] = s
+	}
+	return schema, nil
+}
+
+// LookupConfigKey returns the declaration of the given configuration key, if any.
+func (proj *Project) LookupConfigKey(key config.Key) (ConfigKeySchema, bool, error) {
+	schema, err := proj.ConfigSchema()
+	if err != nil {
+		return ConfigKeySchema{}, false, err
+	}
+	s, ok := schema[key]
+	return s, ok, nil
+}
+
+// configKey resolves the name of a declared configuration key to its fully qualified form.
+func (proj *Project) configKey(name string) (config.Key, error) {
+	if !strings.Contains(name, ":") {
+		name = string(proj.Name) + ":" + name
+	}
+	key, err := config.ParseKey(name)
+	if err != nil {
+		return config.Key{}, errors.Wrapf(err, "invalid configuration key '%s' in config section", name)
+	}
+	return key, nil
+}
+
+// validateConfigSchema checks that the project's configuration key declarations are well-formed.
+func (proj *Project) validateConfigSchema() error {
+	schema, err := proj.ConfigSchema()
+	if err != nil {
+		return err
+	}
+	for key, s := range schema {
+		if err := s.validate(); err != nil {
+			return errors.Wrapf(err, "invalid declaration for configuration key '%s'", key)
+		}
+	}
+	return nil
+}
+
+// ValidateConfigValue checks the given value for the given configuration key against the project's config schema. The
+// value is the plaintext of the value that will be set; secure indicates whether it will be encrypted.
+func (proj *Project) ValidateConfigValue(key config.Key, value string, secure bool) error {
+	schema, err := proj.ConfigSchema()
+	if err != nil {
+		return err
+	}
+	s, ok := schema[key]
+	if !ok {
+		if len(schema) > 0 && key.Namespace() == string(proj.Name) {
+			return proj.undeclaredKeyError(key, schema)
+		}
+		return nil
+	}
+	if s.Secret && !secure {
+		return errors.Errorf("configuration key '%s' must be a secret; set it with --secret", key)
+	}
+	if _, err = s.Parse(value); err != nil && secure {
+		// Don't reveal the plaintext of the secret.
+		err = errors.Errorf("secret value is not a valid %s", s.ValueType())
+	}
+	return errors.Wrapf(err, "invalid value for configuration key '%s'", key)
+}
+
+// ValidateConfig checks the given stack configuration against the project's config schema and returns an error that
+// lists every problem found, if any. The decrypter is used to check the values of secret keys; if it is nil, the
+// values of secret keys are not checked.
+func (proj *Project) ValidateConfig(cfg config.Map, decrypter config.Decrypter) error {
+	schema, err := proj.ConfigSchema()
+	if err != nil || len(schema) == 0 {
+		return err
+	}
+
+	var problems []string
+	for key, v := range cfg {
+		s, ok := schema[key]
+		if !ok {
+			if key.Namespace() == string(proj.Name) {
+				problems = append(problems, proj.undeclaredKeyError(key, schema).Error())
+			}
+			continue
+		}
+
+		if s.Secret && !v.Secure() {
+			problems = append(problems, fmt.Sprintf(
+				"configuration key '%s' must be a secret; set it with `pulumi config set --secret`", key))
+		}
+		if v.Secure() && decrypter == nil {
+			continue
+		}
+		value, err := v.Value(decrypter)
+		if err != nil {
+			return errors.Wrapf(err, "could not decrypt configuration key '%s'", key)
+		}
+		if _, err := s.Parse(value); err != nil {
+			if v.Secure() {
+				// Don't reveal the plaintext of the secret.
+				err = errors.Errorf("secret value is not a valid %s", s.ValueType())
+			}
+			problems = append(problems, fmt.Sprintf("invalid value for configuration key '%s': %v", key, err))
+		}
+	}
+	for key, s := range schema {
+		if _, ok := cfg[key]; !ok && s.Required && s.Default == nil {
+			// `pulumi config set` puts names without a namespace in the project's namespace.
+			name := key.String()
+			if key.Namespace() == string(proj.Name) {
+				name = key.Name()
+			}
+			problems = append(problems, fmt.Sprintf(
+				"missing required configuration key '%s'; run `pulumi config set %s <value>` to set it",
+				key, name))
+		}
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+	sort.Strings(problems)
+	return errors.Errorf("stack configuration does not match the project's config schema:\n    %s",
+		strings.Join(problems, "\n    "))
+}
+
+// ApplyConfigDefaults returns a copy of the given stack configuration in which each key that is declared with a
+// default but is not set has its default value.
+func (proj *Project) ApplyConfigDefaults(cfg config.Map) (config.Map, error) {
+	schema, err := proj.ConfigSchema()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(config.Map, len(cfg))
+	for key, v := range cfg {
+		result[key] = v
+	}
+	for key, s := range schema {
+		if _, ok := cfg[key]; ok || s.Default == nil {
+			continue
+		}
+		v, err := s.defaultValue()
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid default for configuration key '%s'", key)
+		}
+		result[key] = v
+	}
+	return result, nil
+}
+
+// undeclaredKeyError returns the error for a key in the project's namespace that the schema does not declare. If a
+// declared key has a similar name, the error suggests it.
+func (proj *Project) undeclaredKeyError(key config.Key, schema map[config.Key]ConfigKeySchema) error {
+	var suggestion string
+	best := len(key.Name())/2 + 1
+	for declared := range schema {
+		if declared.Namespace() != key.Namespace() {
+			continue
+		}
+		d := levenshtein.DistanceForStrings([]rune(strings.ToLower(declared.Name())),
+			[]rune(strings.ToLower(key.Name())), levenshtein.DefaultOptions)
+		if d < best || (d == best && suggestion != "" && declared.Name() < suggestion) {
+			best, suggestion = d, declared.Name()
+		}
+	}
+	if suggestion != "" {
+		return errors.Errorf("configuration key '%s' is not declared by the project; did you mean '%s'?",
+			key, suggestion)
+	}
+	return errors.Errorf("configuration key '%s' is not declared by the project", key)
+}
+
+// ValueType returns the type of the key's value.
+func (s ConfigKeySchema) ValueType() ConfigType {
+	if s.Type == "" {
+		return ConfigTypeString
+	}
+	return s.Type
+}
+
+// Parse converts the given configuration value to the key's type: a string, int, float64, bool, []interface{}, or
+// map[string]interface{}. An error is returned if the value does not have the key's type or is not one of the key's
+// allowed values.
+func (s ConfigKeySchema) Parse(value string) (interface{}, error) {
+	var v interface{}
+	var err error
+	switch s.ValueType() {
+	case ConfigTypeString:
+		v = value
+	case ConfigTypeInteger:
+		if v, err = strconv.Atoi(value); err != nil {
+			return nil, errors.Errorf("'%s' is not an integer", value)
+		}
+	case ConfigTypeNumber:
+		if v, err = strconv.ParseFloat(value, 64); err != nil {
+			return nil, errors.Errorf("'%s' is not a number", value)
+		}
+	case ConfigTypeBoolean:
+		if v, err = strconv.ParseBool(value); err != nil {
+			return nil, errors.Errorf("'%s' is not a boolean", value)
+		}
+	case ConfigTypeArray:
+		var a []interface{}
+		if err = json.Unmarshal([]byte(value), &a); err != nil || a == nil {
+			return nil, errors.Errorf("'%s' is not an array", value)
+		}
+		v = a
+	case ConfigTypeObject:
+		var m map[string]interface{}
+		if err = json.Unmarshal([]byte(value), &m); err != nil || m == nil {
+			return nil, errors.Errorf("'%s' is not an object", value)
+		}
+		v = m
+	default:
+		return nil, errors.Errorf("unknown type '%s'", s.Type)
+	}
+
+	if err := s.checkEnum(v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// DefaultValue returns the key's default value, converted to the key's type as by Parse. If the key has no default,
+// nil is returned.
+func (s ConfigKeySchema) DefaultValue() (interface{}, error) {
+	if s.Default == nil {
+		return nil, nil
+	}
+	return s.convert(s.Default)
+}
+
+// validate checks that the key declaration is well-formed.
+func (s ConfigKeySchema) validate() error {
+	switch s.ValueType() {
+	case ConfigTypeString, ConfigTypeInteger, ConfigTypeNumber, ConfigTypeBoolean:
+	case ConfigTypeArray, ConfigTypeObject:
+		if len(s.Enum) > 0 {
+			return errors.Errorf("enum is not supported for keys of type '%s'", s.Type)
+		}
+	default:
+		return errors.Errorf("unknown type '%s'; expected one of string, integer, number, boolean, array, or object",
+			s.Type)
+	}
+
+	for _, e := range s.Enum {
+		if _, err := s.convert(e); err != nil {
+			return errors.Wrap(err, "invalid enum value")
+		}
+	}
+	if s.Default != nil {
+		if s.Secret {
+			return errors.New("secret keys cannot have a default")
+		}
+		if _, err := s.DefaultValue(); err != nil {
+			return errors.Wrap(err, "invalid default")
+		}
+	}
+	return nil
+}
+
+// convert converts a value from the schema itself, such as a default or an enum value, to the key's type.
+func (s ConfigKeySchema) convert(v interface{}) (interface{}, error) {
+	var result interface{}
+	switch s.ValueType() {
+	case ConfigTypeString:
+		if s, ok := v.(string); ok {
+			result = s
+		}
+	case ConfigTypeInteger:
+		switch n := v.(type) {
+		case int:
+			result = n
+		case int64:
+			result = int(n)
+		case float64:
+			if n == math.Trunc(n) {
+				result = int(n)
+			}
+		}
+	case ConfigTypeNumber:
+		switch n := v.(type) {
+		case int:
+			result = float64(n)
+		case int64:
+			result = float64(n)
+		case float64:
+			result = n
+		}
+	case ConfigTypeBoolean:
+		if b, ok := v.(bool); ok {
+			result = b
+		}
+	case ConfigTypeArray:
+		if a, ok := v.([]interface{}); ok {
+			result = a
+		}
+	case ConfigTypeObject:
+		if m, ok := v.(map[string]interface{}); ok {
+			result = m
+		}
+	}
+	if result == nil {
+		return nil, errors.Errorf("%v is not of type %s", v, s.ValueType())
+	}
+	if err := s.checkEnum(result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// checkEnum checks that the given value, which has the key's type, is one of the key's allowed values.
+func (s ConfigKeySchema) checkEnum(v interface{}) error {
+	if len(s.Enum) == 0 {
+		return nil
+	}
+
+	var allowed []string
+	for _, e := range s.Enum {
+		// Enum values are converted without checking the enum, so that this does not recurse.
+		e, err := ConfigKeySchema{Type: s.Type}.convert(e)
+		if err != nil {
+			return err
+		}
+		if reflect.DeepEqual(e, v) {
+			return nil
+		}
+		allowed = append(allowed, fmt.Sprintf("%v", e))
+	}
+	return errors.Errorf("%v is not one of the allowed values: %s", v, strings.Join(allowed, ", "))
+}
+
+// defaultValue returns the key's default as a configuration value.
+func (s ConfigKeySchema) defaultValue() (config.Value, error) {
+	v, err := s.DefaultValue()
+	if err != nil {
+		return config.Value{}, err
+	}
+	switch v := v.(type) {
+	case string:
+		return config.NewValue(v), nil
+	case int:
+		return config.NewValue(strconv.Itoa(v)), nil
+	case float64:
+		return config.NewValue(strconv.FormatFloat(v, 'g', -1, 64)), nil
+	case bool:
+		return config.NewValue(strconv.FormatBool(v)), nil
+	default:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return config.Value{}, err
+		}
+		return config.NewObjectValue(string(b)), nil
+	}
+}
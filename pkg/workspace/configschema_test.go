@@ -0,0 +1,196 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workspace
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v2"
+
+	"github.com/pulumi/pulumi/pkg/resource/config"
+)
+
+const configSchemaProject = `name: proj
+runtime: go
+config:
+  region:
+    description: The region to deploy to.
+    enum: [us-east-1, us-west-2]
+    default: us-west-2
+  instanceCount:
+    type: integer
+    required: true
+  ratio:
+    type: number
+    default: 1
+  tags:
+    type: object
+    default:
+      env: dev
+  dbPassword:
+    secret: true
+  aws:region:
+    required: true
+`
+
+func loadTestProject(t *testing.T, contents string) *Project {
+	dir, err := ioutil.TempDir("", "configschema")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "Pulumi.yaml")
+	assert.NoError(t, ioutil.WriteFile(path, []byte(contents), 0600))
+	proj, err := LoadProject(path)
+	assert.NoError(t, err)
+	return proj
+}
+
+func TestProjectConfigRoundtrip(t *testing.T) {
+	doTest := func(marshal func(interface{}) ([]byte, error), unmarshal func([]byte, interface{}) error) {
+		byts, err := marshal(ProjectConfig{Dir: "config"})
+		assert.NoError(t, err)
+		var roundtrip ProjectConfig
+		assert.NoError(t, unmarshal(byts, &roundtrip))
+		assert.Equal(t, ProjectConfig{Dir: "config"}, roundtrip)
+
+		keys := map[string]ConfigKeySchema{
+			"size": {Type: ConfigTypeObject, Default: map[string]interface{}{"a": "b"}},
+		}
+		byts, err = marshal(ProjectConfig{Keys: keys})
+		assert.NoError(t, err)
+		roundtrip = ProjectConfig{}
+		assert.NoError(t, unmarshal(byts, &roundtrip))
+		assert.Equal(t, ProjectConfig{Keys: keys}, roundtrip)
+
+		_, err = marshal(ProjectConfig{Dir: "config", Keys: keys})
+		assert.Error(t, err)
+	}
+
+	doTest(yaml.Marshal, yaml.Unmarshal)
+	doTest(json.Marshal, json.Unmarshal)
+}
+
+func TestLoadProjectConfigSchema(t *testing.T) {
+	proj := loadTestProject(t, "name: proj\nruntime: go\nconfig: config\n")
+	assert.Equal(t, "config", proj.ConfigDir())
+
+	proj = loadTestProject(t, configSchemaProject)
+	assert.Equal(t, "", proj.ConfigDir())
+	schema, err := proj.ConfigSchema()
+	assert.NoError(t, err)
+	assert.Len(t, schema, 6)
+	assert.Equal(t, ConfigTypeInteger, schema[config.MustMakeKey("proj", "instanceCount")].Type)
+	assert.True(t, schema[config.MustMakeKey("aws", "region")].Required)
+
+	// Malformed declarations are rejected when the project is loaded.
+	for _, decl := range []string{
+		"  a:\n    type: float\n",
+		"  a:\n    type: integer\n    default: abc\n",
+		"  a:\n    enum: [x, y]\n    default: z\n",
+		"  a:\n    type: boolean\n    enum: [1, 2]\n",
+		"  a:\n    type: array\n    enum: [[1]]\n",
+		"  a:\n    secret: true\n    default: hunter2\n",
+	} {
+		p := &Project{Name: "proj", Runtime: NewProjectRuntimeInfo("go", nil)}
+		assert.NoError(t, yaml.Unmarshal([]byte("config:\n"+decl), p))
+		assert.Error(t, p.Validate(), decl)
+	}
+}
+
+func TestValidateConfig(t *testing.T) {
+	proj := loadTestProject(t, configSchemaProject)
+
+	valid := config.Map{
+		config.MustMakeKey("proj", "instanceCount"): config.NewValue("3"),
+		config.MustMakeKey("proj", "region"):        config.NewValue("us-east-1"),
+		config.MustMakeKey("proj", "dbPassword"):    config.NewSecureValue("secret"),
+		config.MustMakeKey("aws", "region"):         config.NewValue("us-east-1"),
+		config.MustMakeKey("aws", "profile"):        config.NewValue("dev"),
+	}
+	assert.NoError(t, proj.ValidateConfig(valid, config.NopDecrypter))
+
+	invalid := config.Map{
+		config.MustMakeKey("proj", "instanceCount"): config.NewValue("three"),
+		config.MustMakeKey("proj", "region"):        config.NewValue("eu-west-1"),
+		config.MustMakeKey("proj", "regoin"):        config.NewValue("us-east-1"),
+		config.MustMakeKey("proj", "dbPassword"):    config.NewValue("hunter2"),
+		config.MustMakeKey("proj", "tags"):          config.NewValue("[1]"),
+	}
+	err := proj.ValidateConfig(invalid, config.NopDecrypter)
+	assert.EqualError(t, err, "stack configuration does not match the project's config schema:\n"+
+		"    configuration key 'proj:dbPassword' must be a secret; set it with `pulumi config set --secret`\n"+
+		"    configuration key 'proj:regoin' is not declared by the project; did you mean 'region'?\n"+
+		"    invalid value for configuration key 'proj:instanceCount': 'three' is not an integer\n"+
+		"    invalid value for configuration key 'proj:region': "+
+		"eu-west-1 is not one of the allowed values: us-east-1, us-west-2\n"+
+		"    invalid value for configuration key 'proj:tags': '[1]' is not an object\n"+
+		"    missing required configuration key 'aws:region'; run `pulumi config set aws:region <value>` to set it")
+
+	// Secret values are checked without revealing them.
+	secret := config.Map{
+		config.MustMakeKey("proj", "instanceCount"): config.NewSecureValue("three"),
+		config.MustMakeKey("aws", "region"):         config.NewValue("us-east-1"),
+	}
+	err = proj.ValidateConfig(secret, config.NopDecrypter)
+	assert.EqualError(t, err, "stack configuration does not match the project's config schema:\n"+
+		"    invalid value for configuration key 'proj:instanceCount': secret value is not a valid integer")
+	assert.NoError(t, proj.ValidateConfig(secret, nil))
+
+	// Keys in the project's namespace can be set without their namespace.
+	required := &Project{Name: "proj", Config: &ProjectConfig{Keys: map[string]ConfigKeySchema{
+		"name": {Required: true},
+	}}}
+	err = required.ValidateConfig(config.Map{}, nil)
+	assert.EqualError(t, err, "stack configuration does not match the project's config schema:\n"+
+		"    missing required configuration key 'proj:name'; run `pulumi config set name <value>` to set it")
+
+	// Projects without a schema accept any configuration.
+	assert.NoError(t, (&Project{Name: "proj"}).ValidateConfig(invalid, nil))
+}
+
+func TestValidateConfigValue(t *testing.T) {
+	proj := loadTestProject(t, configSchemaProject)
+
+	assert.NoError(t, proj.ValidateConfigValue(config.MustMakeKey("proj", "ratio"), "0.5", false))
+	assert.NoError(t, proj.ValidateConfigValue(config.MustMakeKey("proj", "dbPassword"), "hunter2", true))
+	assert.NoError(t, proj.ValidateConfigValue(config.MustMakeKey("gcp", "zone"), "a", false))
+	assert.EqualError(t, proj.ValidateConfigValue(config.MustMakeKey("proj", "ratio"), "half", false),
+		"invalid value for configuration key 'proj:ratio': 'half' is not a number")
+	assert.EqualError(t, proj.ValidateConfigValue(config.MustMakeKey("proj", "dbPassword"), "hunter2", false),
+		"configuration key 'proj:dbPassword' must be a secret; set it with --secret")
+	assert.EqualError(t, proj.ValidateConfigValue(config.MustMakeKey("proj", "count"), "1", false),
+		"configuration key 'proj:count' is not declared by the project")
+}
+
+func TestApplyConfigDefaults(t *testing.T) {
+	proj := loadTestProject(t, configSchemaProject)
+
+	cfg := config.Map{
+		config.MustMakeKey("proj", "region"): config.NewValue("us-east-1"),
+	}
+	withDefaults, err := proj.ApplyConfigDefaults(cfg)
+	assert.NoError(t, err)
+	assert.Len(t, cfg, 1)
+	assert.Equal(t, config.Map{
+		config.MustMakeKey("proj", "region"): config.NewValue("us-east-1"),
+		config.MustMakeKey("proj", "ratio"):  config.NewValue("1"),
+		config.MustMakeKey("proj", "tags"):   config.NewObjectValue(`{"env":"dev"}`),
+	}, withDefaults)
+}
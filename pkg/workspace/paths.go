@@ -39,6 +39,8 @@ const (
 	GitDir = ".git"
 	// HistoryDir is the name of the directory that holds historical information for projects.
 	HistoryDir = "history"
+	// LockDir is the name of the directory that holds locks for stacks.
+	LockDir = "locks"
 	// PluginDir is the name of the directory containing plugins.
 	PluginDir = "plugins"
 	// PolicyDir is the name of the directory that holds policy packs.
@@ -96,8 +98,14 @@ func DetectProjectStackPath(stackName tokens.QName) (string, error) {
 		return "", err
 	}
 
-	return filepath.Join(filepath.Dir(projPath), proj.Config, fmt.Sprintf("%s.%s%s", ProjectFile, qnameFileName(stackName),
-		filepath.Ext(projPath))), nil
+	return ProjectStackPath(projPath, proj, stackName), nil
+}
+
+// ProjectStackPath returns the path of the settings file for the given stack of the project whose Pulumi.yaml file
+// is at the given path.
+func ProjectStackPath(projPath string, proj *Project, stackName tokens.QName) string {
+	return filepath.Join(filepath.Dir(projPath), proj.ConfigDir(),
+		fmt.Sprintf("%s.%s%s", ProjectFile, qnameFileName(stackName), filepath.Ext(projPath)))
 }
 
 // DetectProjectPathFrom locates the closest project from the given path, searching "upwards" in the directory
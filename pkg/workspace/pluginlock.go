@@ -0,0 +1,217 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workspace
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/blang/semver"
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/util/contract"
+)
+
+// PluginLockFile is the name of the file, stored next to a project's Pulumi.yaml, that records the exact plugins
+// used by the project.
+const PluginLockFile = "Pulumi.lock.json"
+
+// PluginLock records the exact version of each plugin required by a project, along with the SHA-256 of the
+// plugin's tarball for each platform on which the project is used. Downloads of locked plugins are verified against
+// these checksums.
+type PluginLock struct {
+	Plugins []LockedPlugin `json:"plugins"`
+}
+
+// LockedPlugin is a single plugin recorded in a plugin lockfile.
+type LockedPlugin struct {
+	Kind      PluginKind                `json:"kind"`
+	Name      string                    `json:"name"`
+	Version   string                    `json:"version"`
+	ServerURL string                    `json:"server,omitempty"`
+	Checksums map[PluginPlatform]string `json:"checksums,omitempty"` // tarball SHA-256s, by platform.
+}
+
+// Info returns the plugin info for the locked plugin. The info's checksum is set for the given platform.
+func (p LockedPlugin) Info(platform PluginPlatform) (PluginInfo, error) {
+	info := PluginInfo{Kind: p.Kind, Name: p.Name, ServerURL: p.ServerURL, Checksum: p.Checksums[platform]}
+	if p.Version != "" {
+		version, err := semver.ParseTolerant(p.Version)
+		if err != nil {
+			return PluginInfo{}, errors.Wrapf(err, "invalid version for %s plugin %s", p.Kind, p.Name)
+		}
+		info.Version = &version
+	}
+	return info, nil
+}
+
+// PluginLockPath returns the path of the plugin lockfile for the project whose Pulumi.yaml file is at the given path.
+func PluginLockPath(projPath string) string {
+	return filepath.Join(filepath.Dir(projPath), PluginLockFile)
+}
+
+// LoadPluginLock reads the plugin lockfile at the given path. If there is no such file, nil is returned.
+func LoadPluginLock(path string) (*PluginLock, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var lock PluginLock
+	if err := json.Unmarshal(b, &lock); err != nil {
+		return nil, errors.Wrapf(err, "could not unmarshal plugin lockfile %s", path)
+	}
+	for _, p := range lock.Plugins {
+		if !IsPluginKind(string(p.Kind)) {
+			return nil, errors.Errorf("plugin lockfile %s: unrecognized kind %q for plugin %s", path, p.Kind, p.Name)
+		}
+	}
+	return &lock, nil
+}
+
+// DetectPluginLock reads the plugin lockfile for the closest project to the given directory. If there is no directory,
+// no project, or the project has no plugin lockfile, nil is returned.
+func DetectPluginLock(dir string) (*PluginLock, error) {
+	if dir == "" {
+		return nil, nil
+	}
+	projPath, err := DetectProjectPathFrom(dir)
+	if err != nil || projPath == "" {
+		return nil, err
+	}
+	return LoadPluginLock(PluginLockPath(projPath))
+}
+
+// Save writes the plugin lockfile to the given path. Plugins are sorted so that the file is stable.
+func (lock *PluginLock) Save(path string) error {
+	sort.Slice(lock.Plugins, func(i, j int) bool {
+		pi, pj := lock.Plugins[i], lock.Plugins[j]
+		if pi.Kind != pj.Kind {
+			return pi.Kind < pj.Kind
+		}
+		return pi.Name < pj.Name
+	})
+
+	b, err := json.MarshalIndent(lock, "", "    ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, append(b, '\n'), 0644)
+}
+
+// Lookup returns the locked plugin with the given kind and name, if any.
+func (lock *PluginLock) Lookup(kind PluginKind, name string) (LockedPlugin, bool) {
+	if lock != nil {
+		for _, p := range lock.Plugins {
+			if p.Kind == kind && p.Name == name {
+				return p, true
+			}
+		}
+	}
+	return LockedPlugin{}, false
+}
+
+// Verify checks the given plugin against the lockfile. If the plugin is locked to a different version, an error is
+// returned. Otherwise, the plugin's checksum is set to the checksum locked for the given platform, if any.
+func (lock *PluginLock) Verify(info *PluginInfo, platform PluginPlatform) error {
+	p, ok := lock.Lookup(info.Kind, info.Name)
+	if !ok {
+		return nil
+	}
+
+	locked, err := p.Info(platform)
+	if err != nil {
+		return err
+	}
+	if locked.Version != nil && info.Version != nil && !locked.Version.EQ(*info.Version) {
+		return errors.Errorf("%s plugin %s is locked to version %s, but version %s is required; "+
+			"run `pulumi plugin lock` to update the lockfile", info.Kind, info.Name, locked.Version, info.Version)
+	}
+	if locked.Checksum != "" {
+		info.Checksum = locked.Checksum
+	}
+	return nil
+}
+
+// LockPlugin computes the locked form of the given plugin by downloading the plugin's tarball for each of the given
+// platforms from the given sources and recording its SHA-256. Each checksum is verified against the checksum manifest
+// published by the sources, if any, and against the checksum recorded for the same version of the plugin in the given
+// existing lock entry, if any. Checksums for other platforms are carried over from the existing entry.
+//
+// Language plugins are distributed with the CLI rather than downloaded, so only their versions are recorded.
+func LockPlugin(info PluginInfo, platforms []PluginPlatform, sources PluginSources,
+	existing *LockedPlugin) (LockedPlugin, error) {
+
+	locked := LockedPlugin{Kind: info.Kind, Name: info.Name, ServerURL: info.ServerURL}
+	if info.Version != nil {
+		locked.Version = info.Version.String()
+	}
+	if info.Kind == LanguagePlugin || info.Version == nil {
+		return locked, nil
+	}
+
+	locked.Checksums = make(map[PluginPlatform]string)
+	if existing != nil && existing.Version == locked.Version && existing.ServerURL == locked.ServerURL {
+		for platform, checksum := range existing.Checksums {
+			locked.Checksums[platform] = checksum
+		}
+	}
+
+	for _, platform := range platforms {
+		actual, err := downloadChecksum(info, platform, sources)
+		if err != nil {
+			return LockedPlugin{}, err
+		}
+
+		published, err := sources.PublishedChecksum(info, platform)
+		if err != nil {
+			return LockedPlugin{}, err
+		}
+		for _, expected := range []string{published, locked.Checksums[platform]} {
+			if expected != "" && !strings.EqualFold(expected, actual) {
+				return LockedPlugin{}, &ChecksumError{Info: info, Expected: expected, Actual: actual}
+			}
+		}
+
+		locked.Checksums[platform] = actual
+	}
+
+	return locked, nil
+}
+
+// downloadChecksum downloads the given plugin's tarball for the given platform and returns its SHA-256.
+func downloadChecksum(info PluginInfo, platform PluginPlatform, sources PluginSources) (string, error) {
+	tarball, _, err := sources.Download(info, platform)
+	if err != nil {
+		return "", errors.Wrapf(err, "downloading %s plugin %s for %s", info.Kind, info, platform)
+	}
+	defer contract.IgnoreClose(tarball)
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, tarball); err != nil {
+		return "", errors.Wrapf(err, "downloading %s plugin %s for %s", info.Kind, info, platform)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
@@ -0,0 +1,152 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workspace
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/blang/semver"
+	"github.com/stretchr/testify/assert"
+)
+
+// newPluginServer starts a server that serves the given files.
+func newPluginServer(files map[string][]byte) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, ok := files[filepath.Base(r.URL.Path)]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, err := w.Write(b)
+		if err != nil {
+			panic(err)
+		}
+	}))
+}
+
+func TestLockPlugin(t *testing.T) {
+	version := semver.MustParse("1.2.3")
+	info := PluginInfo{Kind: ResourcePlugin, Name: "test", Version: &version}
+	linux, darwin := PluginPlatform("linux-amd64"), PluginPlatform("darwin-amd64")
+
+	linuxTarball, darwinTarball := []byte("linux"), []byte("darwin")
+	linuxSum, darwinSum := TarballChecksum(linuxTarball), TarballChecksum(darwinTarball)
+	files := map[string][]byte{
+		info.TarballName(linux):  linuxTarball,
+		info.TarballName(darwin): darwinTarball,
+	}
+	server := newPluginServer(files)
+	defer server.Close()
+	info.ServerURL = server.URL
+
+	// Without a published manifest, the downloaded tarballs are trusted.
+	locked, err := LockPlugin(info, []PluginPlatform{linux, darwin}, nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, LockedPlugin{
+		Kind:      ResourcePlugin,
+		Name:      "test",
+		Version:   "1.2.3",
+		ServerURL: server.URL,
+		Checksums: map[PluginPlatform]string{linux: linuxSum, darwin: darwinSum},
+	}, locked)
+
+	// Refreshing the lock for one platform keeps the checksums for the others.
+	relocked, err := LockPlugin(info, []PluginPlatform{linux}, nil, &locked)
+	assert.NoError(t, err)
+	assert.Equal(t, locked, relocked)
+
+	// A tarball that doesn't match the previously locked checksum is rejected.
+	files[info.TarballName(linux)] = []byte("tampered")
+	_, err = LockPlugin(info, []PluginPlatform{linux}, nil, &locked)
+	if assert.IsType(t, &ChecksumError{}, err) {
+		assert.Equal(t, linuxSum, err.(*ChecksumError).Expected)
+	}
+
+	// As is a tarball that doesn't match the published manifest.
+	files[info.ChecksumsName()] = []byte(fmt.Sprintf("%s  %s\n%s *%s\n",
+		linuxSum, info.TarballName(linux), darwinSum, info.TarballName(darwin)))
+	_, err = LockPlugin(info, []PluginPlatform{linux}, nil, nil)
+	assert.IsType(t, &ChecksumError{}, err)
+
+	published, err := info.PublishedChecksum(darwin)
+	assert.NoError(t, err)
+	assert.Equal(t, darwinSum, published)
+
+	// Language plugins only record their versions.
+	locked, err = LockPlugin(PluginInfo{Kind: LanguagePlugin, Name: "nodejs"}, []PluginPlatform{linux}, nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, LockedPlugin{Kind: LanguagePlugin, Name: "nodejs"}, locked)
+}
+
+func TestPluginLockVerify(t *testing.T) {
+	dir, err := ioutil.TempDir("", "plugin-lock")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, PluginLockFile)
+
+	lock, err := LoadPluginLock(path)
+	assert.NoError(t, err)
+	assert.Nil(t, lock)
+
+	lock = &PluginLock{Plugins: []LockedPlugin{
+		{Kind: ResourcePlugin, Name: "b", Version: "2.0.0",
+			Checksums: map[PluginPlatform]string{"linux-amd64": "abc"}},
+		{Kind: ResourcePlugin, Name: "a", Version: "1.0.0"},
+	}}
+	assert.NoError(t, lock.Save(path))
+	lock, err = LoadPluginLock(path)
+	assert.NoError(t, err)
+	if assert.Len(t, lock.Plugins, 2) {
+		assert.Equal(t, "a", lock.Plugins[0].Name)
+	}
+
+	v1, v2 := semver.MustParse("1.0.0"), semver.MustParse("2.0.0")
+	info := PluginInfo{Kind: ResourcePlugin, Name: "b", Version: &v2}
+	assert.NoError(t, lock.Verify(&info, "linux-amd64"))
+	assert.Equal(t, "abc", info.Checksum)
+
+	info = PluginInfo{Kind: ResourcePlugin, Name: "b", Version: &v1}
+	assert.Error(t, lock.Verify(&info, "linux-amd64"))
+
+	info = PluginInfo{Kind: ResourcePlugin, Name: "c", Version: &v1}
+	assert.NoError(t, lock.Verify(&info, "linux-amd64"))
+	assert.Equal(t, "", info.Checksum)
+
+	// A nil lock verifies everything.
+	var none *PluginLock
+	assert.NoError(t, none.Verify(&info, "linux-amd64"))
+}
+
+func TestInstallVerifiesChecksum(t *testing.T) {
+	home, err := ioutil.TempDir("", "plugin-home")
+	assert.NoError(t, err)
+	defer os.RemoveAll(home)
+	oldHome := os.Getenv(PulumiHomeEnvVar)
+	assert.NoError(t, os.Setenv(PulumiHomeEnvVar, home))
+	defer func() { assert.NoError(t, os.Setenv(PulumiHomeEnvVar, oldHome)) }()
+
+	version := semver.MustParse("1.0.0")
+	info := PluginInfo{Kind: ResourcePlugin, Name: "test", Version: &version, Checksum: TarballChecksum(nil)}
+	err = info.Install(ioutil.NopCloser(bytes.NewReader([]byte("not a tarball"))))
+	assert.IsType(t, &ChecksumError{}, err)
+	assert.False(t, HasPlugin(info))
+}
@@ -15,10 +15,11 @@
 package workspace
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"io/ioutil"
-	"net/http"
 	"os"
 	"os/exec"
 	"path"
@@ -26,6 +27,7 @@ import (
 	"regexp"
 	"runtime"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/pulumi/pulumi/pkg/util/archive"
@@ -37,9 +39,7 @@ import (
 
 	"github.com/pulumi/pulumi/pkg/diag/colors"
 	"github.com/pulumi/pulumi/pkg/util/contract"
-	"github.com/pulumi/pulumi/pkg/util/httputil"
 	"github.com/pulumi/pulumi/pkg/util/logging"
-	"github.com/pulumi/pulumi/pkg/version"
 )
 
 const (
@@ -86,6 +86,7 @@ type PluginInfo struct {
 	InstallTime  time.Time       // the time the plugin was installed.
 	LastUsedTime time.Time       // the last time the plugin was used.
 	ServerURL    string          // an optional server to use when downloading this plugin.
+	Checksum     string          // the expected SHA-256 of the plugin's tarball for this platform, if known.
 }
 
 // Dir gets the expected plugin directory for this plugin.
@@ -171,51 +172,98 @@ func (info *PluginInfo) SetFileMetadata(path string) error {
 
 // Download fetches an io.ReadCloser for this plugin and also returns the size of the response (if known).
 func (info PluginInfo) Download() (io.ReadCloser, int64, error) {
-	// Figure out the OS/ARCH pair for the download URL.
-	var os string
-	switch runtime.GOOS {
+	platform, err := CurrentPluginPlatform()
+	if err != nil {
+		return nil, -1, err
+	}
+	return info.DownloadForPlatform(platform)
+}
+
+// DownloadForPlatform fetches an io.ReadCloser for this plugin's tarball for the given platform from the plugin's
+// server and also returns the size of the response (if known).
+func (info PluginInfo) DownloadForPlatform(platform PluginPlatform) (io.ReadCloser, int64, error) {
+	return PluginSources(nil).Download(info, platform)
+}
+
+// TarballName returns the name of this plugin's tarball for the given platform.
+func (info PluginInfo) TarballName(platform PluginPlatform) string {
+	return fmt.Sprintf("pulumi-%s-%s-v%s-%s.tar.gz", info.Kind, info.Name, info.Version, platform)
+}
+
+// ChecksumsName returns the name of the manifest that lists the checksums of this plugin's tarballs.
+func (info PluginInfo) ChecksumsName() string {
+	return fmt.Sprintf("pulumi-%s-%s-v%s-checksums.txt", info.Kind, info.Name, info.Version)
+}
+
+// PublishedChecksum fetches the SHA-256 of this plugin's tarball for the given platform from the checksum manifest
+// published alongside the plugin's tarballs on the plugin's server. If the server does not publish a manifest, or the
+// manifest does not list the tarball, the empty string is returned.
+func (info PluginInfo) PublishedChecksum(platform PluginPlatform) (string, error) {
+	return PluginSources(nil).PublishedChecksum(info, platform)
+}
+
+// serverURL returns the server from which this plugin should be downloaded. If the plugin has no server associated
+// with it, the "default" location, which is hosted by Pulumi, is used.
+func (info PluginInfo) serverURL() string {
+	if info.ServerURL != "" {
+		return info.ServerURL
+	}
+	return "https://api.pulumi.com/releases/plugins"
+}
+
+// PluginPlatform is an OS/architecture pair for which plugin tarballs are published, e.g. `linux-amd64`.
+type PluginPlatform string
+
+// CurrentPluginPlatform returns the platform of the current machine.
+func CurrentPluginPlatform() (PluginPlatform, error) {
+	return NewPluginPlatform(runtime.GOOS, runtime.GOARCH)
+}
+
+// NewPluginPlatform returns the platform for the given OS and architecture, or an error if plugins are not published
+// for that platform.
+func NewPluginPlatform(goos, goarch string) (PluginPlatform, error) {
+	switch goos {
 	case "darwin", "linux", "windows":
-		os = runtime.GOOS
 	default:
-		return nil, -1, errors.Errorf("unsupported plugin OS: %s", runtime.GOOS)
+		return "", errors.Errorf("unsupported plugin OS: %s", goos)
 	}
-	var arch string
-	switch runtime.GOARCH {
+	switch goarch {
 	case "amd64":
-		arch = runtime.GOARCH
 	default:
-		return nil, -1, errors.Errorf("unsupported plugin architecture: %s", runtime.GOARCH)
+		return "", errors.Errorf("unsupported plugin architecture: %s", goarch)
 	}
+	return PluginPlatform(goos + "-" + goarch), nil
+}
 
-	// If the plugin has a server, associated with it, download from there.  Otherwise use the "default" location, which
-	// is hosted by Pulumi.
-	serverURL := info.ServerURL
-	if serverURL == "" {
-		serverURL = "https://api.pulumi.com/releases/plugins"
+// ParsePluginPlatform parses a platform of the form `<os>-<arch>`.
+func ParsePluginPlatform(s string) (PluginPlatform, error) {
+	parts := strings.Split(s, "-")
+	if len(parts) != 2 {
+		return "", errors.Errorf("invalid plugin platform %q: must be of the form <os>-<arch>", s)
 	}
+	return NewPluginPlatform(parts[0], parts[1])
+}
 
-	endpoint := fmt.Sprintf("%s/pulumi-%s-%s-v%s-%s-%s.tar.gz", serverURL, info.Kind, info.Name, info.Version, os, arch)
-	req, err := http.NewRequest("GET", endpoint, nil)
-	if err != nil {
-		return nil, -1, err
-	}
-
-	userAgent := fmt.Sprintf("pulumi-cli/1 (%s; %s)", version.Version, runtime.GOOS)
-	req.Header.Set("User-Agent", userAgent)
-
-	resp, err := httputil.DoWithRetry(req, http.DefaultClient)
-	if err != nil {
-		return nil, -1, err
-	}
+// ChecksumError is returned when a plugin's tarball does not match its expected checksum.
+type ChecksumError struct {
+	Info     PluginInfo // the plugin whose tarball did not match.
+	Expected string     // the expected SHA-256 of the tarball.
+	Actual   string     // the actual SHA-256 of the tarball.
+}
 
-	if resp.StatusCode < 200 || resp.StatusCode > 299 {
-		return nil, -1, errors.Errorf("%d HTTP error fetching plugin from %s", resp.StatusCode, endpoint)
-	}
+func (err *ChecksumError) Error() string {
+	return fmt.Sprintf("%s plugin %s failed verification: expected SHA-256 %s, got %s",
+		err.Info.Kind, err.Info, err.Expected, err.Actual)
+}
 
-	return resp.Body, resp.ContentLength, nil
+// TarballChecksum returns the hex-encoded SHA-256 of the given plugin tarball.
+func TarballChecksum(tarball []byte) string {
+	sum := sha256.Sum256(tarball)
+	return hex.EncodeToString(sum[:])
 }
 
-// Install installs a plugin's tarball into the cache.  It validates that plugin names are in the expected format.
+// Install installs a plugin's tarball into the cache.  It validates that plugin names are in the expected format and,
+// if the plugin's checksum is known, that the tarball matches it.
 func (info PluginInfo) Install(tarball io.ReadCloser) error {
 	// Fetch the directory into which we will expand this tarball, and create it.
 	finalDir, err := info.DirPath()
@@ -249,6 +297,13 @@ func (info PluginInfo) Install(tarball io.ReadCloser) error {
 			return err
 		}
 
+		// If we know what the tarball should contain, make sure that it does before unpacking it.
+		if info.Checksum != "" {
+			if actual := TarballChecksum(tarballBytes); !strings.EqualFold(actual, info.Checksum) {
+				return &ChecksumError{Info: info, Expected: info.Checksum, Actual: actual}
+			}
+		}
+
 		return archive.Untgz(tarballBytes, tempDir)
 	})()
 	if err != nil {
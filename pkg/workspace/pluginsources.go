@@ -0,0 +1,283 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workspace
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/util/contract"
+	"github.com/pulumi/pulumi/pkg/util/httputil"
+	"github.com/pulumi/pulumi/pkg/util/logging"
+	"github.com/pulumi/pulumi/pkg/version"
+)
+
+// PluginSourcesEnvVar is a comma-separated list of plugin sources that are consulted before those configured by the
+// current project.
+const PluginSourcesEnvVar = "PULUMI_PLUGIN_SOURCES"
+
+// PluginSources is an ordered list of locations from which plugins are downloaded. Each location is either the URL of
+// an HTTP(S) server or the absolute path of a local directory, optionally written as a file:// URL. A location holds
+// plugin tarballs named as by PluginInfo.TarballName, along with any checksum manifests named as by
+// PluginInfo.ChecksumsName; `pulumi plugin mirror` creates a directory in this layout.
+//
+// If no sources are configured, each plugin is downloaded from its own server.
+type PluginSources []string
+
+// GetPluginSources returns the plugin sources configured by the PULUMI_PLUGIN_SOURCES environment variable, followed
+// by those configured by the given project, if any.
+func GetPluginSources(proj *Project) PluginSources {
+	var sources PluginSources
+	for _, source := range strings.Split(os.Getenv(PluginSourcesEnvVar), ",") {
+		if source = strings.TrimSpace(source); source != "" {
+			sources = append(sources, source)
+		}
+	}
+	if proj != nil {
+		sources = append(sources, proj.PluginSources...)
+	}
+	return sources
+}
+
+// Download fetches an io.ReadCloser for the given plugin's tarball for the given platform from the first source that
+// has it and also returns the size of the tarball (if known).
+func (sources PluginSources) Download(info PluginInfo, platform PluginPlatform) (io.ReadCloser, int64, error) {
+	var errs []string
+	for _, source := range sources.forPlugin(info) {
+		tarball, size, err := openPluginFile(source, info.TarballName(platform))
+		if err == nil {
+			return tarball, size, nil
+		}
+		logging.V(7).Infof("Download(%s): %v", info, err)
+		errs = append(errs, err.Error())
+	}
+
+	if len(errs) == 1 {
+		return nil, -1, errors.New(errs[0])
+	}
+	return nil, -1, errors.Errorf("could not fetch plugin from any source:\n    %s", strings.Join(errs, "\n    "))
+}
+
+// PublishedChecksum fetches the SHA-256 of the given plugin's tarball for the given platform from the first checksum
+// manifest among the sources that lists it. If no source publishes such a manifest, the empty string is returned.
+//
+// The manifest is in the format written by `sha256sum`: each line holds a hex-encoded checksum, whitespace, and the
+// name of the file to which the checksum belongs.
+func (sources PluginSources) PublishedChecksum(info PluginInfo, platform PluginPlatform) (string, error) {
+	for _, source := range sources.forPlugin(info) {
+		checksums, err := readPluginChecksums(source, info.ChecksumsName())
+		if err != nil {
+			return "", err
+		}
+		if checksum, ok := checksums[info.TarballName(platform)]; ok {
+			return checksum, nil
+		}
+	}
+	return "", nil
+}
+
+// forPlugin returns the locations from which the given plugin should be downloaded.
+func (sources PluginSources) forPlugin(info PluginInfo) []string {
+	if len(sources) > 0 {
+		return sources
+	}
+	return []string{info.serverURL()}
+}
+
+// MirrorPlugin downloads the given plugin's tarball for each of the given platforms from the given sources and stores
+// it in the given directory, alongside a checksum manifest for the plugin. Each tarball is verified against the
+// checksum locked for it by the given lockfile, if any, or else against the checksum published by the sources, if any.
+// Language plugins are distributed with the CLI rather than downloaded, so they are skipped.
+func MirrorPlugin(info PluginInfo, platforms []PluginPlatform, sources PluginSources, lock *PluginLock,
+	dir string) error {
+
+	if info.Kind == LanguagePlugin || info.Version == nil {
+		return nil
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return errors.Wrapf(err, "creating mirror directory %s", dir)
+	}
+
+	manifestPath := filepath.Join(dir, info.ChecksumsName())
+	checksums, err := readPluginChecksums(dir, info.ChecksumsName())
+	if err != nil {
+		return err
+	}
+
+	for _, platform := range platforms {
+		expected := info
+		if err := lock.Verify(&expected, platform); err != nil {
+			return err
+		}
+		if expected.Checksum == "" {
+			if expected.Checksum, err = sources.PublishedChecksum(info, platform); err != nil {
+				return err
+			}
+		}
+
+		actual, err := mirrorTarball(info, platform, sources, dir)
+		if err != nil {
+			return err
+		}
+		if expected.Checksum != "" && !strings.EqualFold(expected.Checksum, actual) {
+			contract.IgnoreError(os.Remove(filepath.Join(dir, info.TarballName(platform))))
+			return &ChecksumError{Info: info, Expected: expected.Checksum, Actual: actual}
+		}
+		checksums[info.TarballName(platform)] = actual
+	}
+
+	// Write the manifest, including the checksums of any tarballs that were mirrored previously.
+	var names []string
+	for name := range checksums {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var manifest strings.Builder
+	for _, name := range names {
+		fmt.Fprintf(&manifest, "%s  %s\n", checksums[name], name)
+	}
+	if err := ioutil.WriteFile(manifestPath, []byte(manifest.String()), 0644); err != nil {
+		return errors.Wrapf(err, "writing checksum manifest %s", manifestPath)
+	}
+	return nil
+}
+
+// mirrorTarball downloads the given plugin's tarball for the given platform into the given directory and returns its
+// SHA-256.
+func mirrorTarball(info PluginInfo, platform PluginPlatform, sources PluginSources, dir string) (string, error) {
+	tarball, _, err := sources.Download(info, platform)
+	if err != nil {
+		return "", errors.Wrapf(err, "downloading %s plugin %s for %s", info.Kind, info, platform)
+	}
+	defer contract.IgnoreClose(tarball)
+
+	// Download to a temporary file so that a failed download doesn't leave a partial tarball in the mirror.
+	temp, err := ioutil.TempFile(dir, info.TarballName(platform)+".tmp")
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		contract.IgnoreError(os.Remove(temp.Name()))
+	}()
+
+	hash := sha256.New()
+	_, err = io.Copy(io.MultiWriter(temp, hash), tarball)
+	if closeErr := temp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return "", errors.Wrapf(err, "downloading %s plugin %s for %s", info.Kind, info, platform)
+	}
+
+	if err := os.Rename(temp.Name(), filepath.Join(dir, info.TarballName(platform))); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// pluginFileNotFoundError is returned by openPluginFile if a source does not hold the requested file.
+type pluginFileNotFoundError struct {
+	location string
+}
+
+func (err *pluginFileNotFoundError) Error() string {
+	return fmt.Sprintf("%s not found", err.location)
+}
+
+// openPluginFile opens the file with the given name in the given plugin source and also returns the size of the file
+// (if known). If the source does not hold the file, a *pluginFileNotFoundError is returned.
+func openPluginFile(source, name string) (io.ReadCloser, int64, error) {
+	u, err := url.Parse(source)
+	if err == nil && (u.Scheme == "http" || u.Scheme == "https") {
+		endpoint := strings.TrimSuffix(source, "/") + "/" + name
+		req, err := http.NewRequest("GET", endpoint, nil)
+		if err != nil {
+			return nil, -1, err
+		}
+		userAgent := fmt.Sprintf("pulumi-cli/1 (%s; %s)", version.Version, runtime.GOOS)
+		req.Header.Set("User-Agent", userAgent)
+
+		resp, err := httputil.DoWithRetry(req, http.DefaultClient)
+		if err != nil {
+			return nil, -1, err
+		}
+		if resp.StatusCode < 200 || resp.StatusCode > 299 {
+			contract.IgnoreClose(resp.Body)
+			if resp.StatusCode == http.StatusNotFound {
+				return nil, -1, &pluginFileNotFoundError{location: endpoint}
+			}
+			return nil, -1, errors.Errorf("%d HTTP error fetching %s", resp.StatusCode, endpoint)
+		}
+		return resp.Body, resp.ContentLength, nil
+	}
+
+	dir := source
+	if err == nil && u.Scheme == "file" {
+		dir = filepath.FromSlash(u.Path)
+	}
+	path := filepath.Join(dir, name)
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, -1, &pluginFileNotFoundError{location: path}
+		}
+		return nil, -1, err
+	}
+	stat, err := f.Stat()
+	if err != nil {
+		contract.IgnoreClose(f)
+		return nil, -1, err
+	}
+	return f, stat.Size(), nil
+}
+
+// readPluginChecksums reads the checksum manifest with the given name from the given plugin source and returns the
+// checksums it lists, keyed by file name. If the source does not hold the manifest, an empty map is returned.
+func readPluginChecksums(source, name string) (map[string]string, error) {
+	checksums := make(map[string]string)
+	manifest, _, err := openPluginFile(source, name)
+	if err != nil {
+		if _, ok := err.(*pluginFileNotFoundError); ok {
+			logging.V(7).Infof("readPluginChecksums(%s): %v", name, err)
+			return checksums, nil
+		}
+		return nil, errors.Wrapf(err, "fetching plugin checksums %s from %s", name, source)
+	}
+	defer contract.IgnoreClose(manifest)
+
+	scanner := bufio.NewScanner(manifest)
+	for scanner.Scan() {
+		if fields := strings.Fields(scanner.Text()); len(fields) == 2 {
+			checksums[strings.TrimPrefix(fields[1], "*")] = strings.ToLower(fields[0])
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrapf(err, "reading plugin checksums %s from %s", name, source)
+	}
+	return checksums, nil
+}
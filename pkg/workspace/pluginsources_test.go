@@ -0,0 +1,100 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workspace
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/blang/semver"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetPluginSources(t *testing.T) {
+	oldSources := os.Getenv(PluginSourcesEnvVar)
+	defer func() { assert.NoError(t, os.Setenv(PluginSourcesEnvVar, oldSources)) }()
+
+	assert.NoError(t, os.Setenv(PluginSourcesEnvVar, ""))
+	assert.Nil(t, GetPluginSources(nil))
+
+	assert.NoError(t, os.Setenv(PluginSourcesEnvVar, " /mnt/plugins, ,https://mirror.example.com/plugins"))
+	proj := &Project{PluginSources: []string{"file:///opt/plugins"}}
+	assert.Equal(t, PluginSources{"/mnt/plugins", "https://mirror.example.com/plugins", "file:///opt/plugins"},
+		GetPluginSources(proj))
+}
+
+func readAllAndClose(t *testing.T, sources PluginSources, info PluginInfo, platform PluginPlatform) string {
+	tarball, _, err := sources.Download(info, platform)
+	if !assert.NoError(t, err) {
+		return ""
+	}
+	defer func() { assert.NoError(t, tarball.Close()) }()
+	b, err := ioutil.ReadAll(tarball)
+	assert.NoError(t, err)
+	return string(b)
+}
+
+func TestMirrorPlugin(t *testing.T) {
+	dir, err := ioutil.TempDir("", "plugin-mirror")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	mirror := filepath.Join(dir, "mirror")
+
+	version := semver.MustParse("1.2.3")
+	info := PluginInfo{Kind: ResourcePlugin, Name: "test", Version: &version}
+	linux, darwin := PluginPlatform("linux-amd64"), PluginPlatform("darwin-amd64")
+
+	files := map[string][]byte{
+		info.TarballName(linux):  []byte("linux"),
+		info.TarballName(darwin): []byte("darwin"),
+	}
+	server := newPluginServer(files)
+	defer server.Close()
+
+	// Sources are consulted in order, so the missing directory is skipped.
+	sources := PluginSources{filepath.Join(dir, "missing"), server.URL}
+	assert.NoError(t, MirrorPlugin(info, []PluginPlatform{linux}, sources, nil, mirror))
+	assert.NoError(t, MirrorPlugin(info, []PluginPlatform{darwin}, sources, nil, mirror))
+
+	manifest, err := ioutil.ReadFile(filepath.Join(mirror, info.ChecksumsName()))
+	assert.NoError(t, err)
+	assert.Equal(t, fmt.Sprintf("%s  %s\n%s  %s\n",
+		TarballChecksum([]byte("darwin")), info.TarballName(darwin),
+		TarballChecksum([]byte("linux")), info.TarballName(linux)), string(manifest))
+
+	// The mirror can be used as a source, either directly or as a file:// URL.
+	for _, source := range []string{mirror, "file://" + filepath.ToSlash(mirror)} {
+		sources := PluginSources{source}
+		assert.Equal(t, "linux", readAllAndClose(t, sources, info, linux))
+		checksum, err := sources.PublishedChecksum(info, darwin)
+		assert.NoError(t, err)
+		assert.Equal(t, TarballChecksum([]byte("darwin")), checksum)
+	}
+
+	// Missing tarballs are reported.
+	_, _, err = PluginSources{mirror}.Download(info, "windows-amd64")
+	assert.Error(t, err)
+
+	// Tarballs that don't match the lockfile are not mirrored.
+	lock := &PluginLock{Plugins: []LockedPlugin{{Kind: ResourcePlugin, Name: "test", Version: "1.2.3",
+		Checksums: map[PluginPlatform]string{linux: TarballChecksum([]byte("other"))}}}}
+	err = MirrorPlugin(info, []PluginPlatform{linux}, PluginSources{server.URL}, lock, filepath.Join(dir, "locked"))
+	assert.IsType(t, &ChecksumError{}, err)
+	_, err = os.Stat(filepath.Join(dir, "locked", info.TarballName(linux)))
+	assert.True(t, os.IsNotExist(err))
+}
@@ -84,14 +84,18 @@ type Project struct {
 	// License is the optional license governing this project's usage.
 	License *string `json:"license,omitempty" yaml:"license,omitempty"`
 
-	// Config indicates where to store the Pulumi.<stack-name>.yaml files, combined with the folder Pulumi.yaml is in.
-	Config string `json:"config,omitempty" yaml:"config,omitempty"`
+	// Config is either the directory in which to store the Pulumi.<stack-name>.yaml files, combined with the folder
+	// Pulumi.yaml is in, or a schema that declares the project's configuration keys. See ProjectConfig.
+	Config *ProjectConfig `json:"config,omitempty" yaml:"config,omitempty"`
 
 	// Template is an optional template manifest, if this project is a template.
 	Template *ProjectTemplate `json:"template,omitempty" yaml:"template,omitempty"`
 
 	// Backend is an optional backend configuration
 	Backend *ProjectBackend `json:"backend,omitempty" yaml:"backend,omitempty"`
+
+	// PluginSources is an optional ordered list of locations from which to download plugins. See PluginSources.
+	PluginSources []string `json:"pluginSources,omitempty" yaml:"pluginSources,omitempty"`
 }
 
 func (proj *Project) Validate() error {
@@ -101,6 +105,9 @@ func (proj *Project) Validate() error {
 	if proj.Runtime.Name() == "" {
 		return errors.New("project is missing a 'runtime' attribute")
 	}
+	if err := proj.validateConfigSchema(); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -165,6 +172,16 @@ type ProjectStack struct {
 	// EncryptionSalt is this stack's base64 encoded encryption salt.  Only used for
 	// passphrase-based secrets providers.
 	EncryptionSalt string `json:"encryptionsalt,omitempty" yaml:"encryptionsalt,omitempty"`
+	// SecretsKeyGeneration is the number of times that this stack's data key or encryption salt has been rotated.
+	SecretsKeyGeneration int `json:"secretskeygeneration,omitempty" yaml:"secretskeygeneration,omitempty"`
+	// PreviousEncryptedKeys are the KMS-encrypted ciphertexts for the data keys used before EncryptedKey, newest
+	// first. Only used for cloud-based secrets providers.
+	PreviousEncryptedKeys []string `json:"previousencryptedkeys,omitempty" yaml:"previousencryptedkeys,omitempty"`
+	// PreviousEncryptionSalts are the encryption salts used before EncryptionSalt, newest first. Only used for
+	// passphrase-based secrets providers.
+	PreviousEncryptionSalts []string `json:"previousencryptionsalts,omitempty" yaml:"previousencryptionsalts,omitempty"`
+	// Imports is an optional list of config layers whose values this stack inherits. See ConfigLayer.
+	Imports []string `json:"imports,omitempty" yaml:"imports,omitempty"`
 	// Config is an optional config bag.
 	Config config.Map `json:"config,omitempty" yaml:"config,omitempty"`
 }
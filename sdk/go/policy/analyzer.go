@@ -0,0 +1,204 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	pbempty "github.com/golang/protobuf/ptypes/empty"
+	structpb "github.com/golang/protobuf/ptypes/struct"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+
+	"github.com/pulumi/pulumi/pkg/apitype"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/plugin"
+	"github.com/pulumi/pulumi/pkg/tokens"
+	"github.com/pulumi/pulumi/pkg/util/contract"
+	pulumirpc "github.com/pulumi/pulumi/sdk/proto/go"
+)
+
+// analyzer implements the analyzer plugin protocol for a policy pack.
+type analyzer struct {
+	pack PolicyPack
+}
+
+var _ pulumirpc.AnalyzerServer = (*analyzer)(nil)
+
+func newAnalyzer(pack PolicyPack) (*analyzer, error) {
+	names := make(map[string]bool)
+	for _, p := range pack.Policies {
+		if p.Name == "" {
+			return nil, errors.New("policies must have a name")
+		}
+		if names[p.Name] {
+			return nil, errors.Errorf("duplicate policy %q", p.Name)
+		}
+		names[p.Name] = true
+
+		if _, err := marshalEnforcementLevel(p.EnforcementLevel); err != nil {
+			return nil, errors.Wrapf(err, "policy %q", p.Name)
+		}
+	}
+	return &analyzer{pack: pack}, nil
+}
+
+// Analyze validates a single resource against each of the pack's resource policies.
+func (a *analyzer) Analyze(ctx context.Context, req *pulumirpc.AnalyzeRequest) (*pulumirpc.AnalyzeResponse, error) {
+	r, err := unmarshalResource(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var diagnostics []*pulumirpc.AnalyzeDiagnostic
+	for _, p := range a.pack.Policies {
+		if p.ValidateResource == nil {
+			continue
+		}
+		p.ValidateResource(r, func(message string) {
+			diagnostics = append(diagnostics, a.diagnostic(p, message, r.URN))
+		})
+	}
+	return &pulumirpc.AnalyzeResponse{Diagnostics: diagnostics}, nil
+}
+
+// AnalyzeStack validates all of a stack's resources against each of the pack's stack policies.
+func (a *analyzer) AnalyzeStack(ctx context.Context,
+	req *pulumirpc.AnalyzeStackRequest) (*pulumirpc.AnalyzeResponse, error) {
+
+	resources := make([]*Resource, len(req.GetResources()))
+	for i, res := range req.GetResources() {
+		r, err := unmarshalResource(res)
+		if err != nil {
+			return nil, err
+		}
+		resources[i] = r
+	}
+	s := NewStack(resources)
+
+	var diagnostics []*pulumirpc.AnalyzeDiagnostic
+	for _, p := range a.pack.Policies {
+		if p.ValidateStack == nil {
+			continue
+		}
+		p.ValidateStack(s, func(message string, urn resource.URN) {
+			diagnostics = append(diagnostics, a.diagnostic(p, message, urn))
+		})
+	}
+	return &pulumirpc.AnalyzeResponse{Diagnostics: diagnostics}, nil
+}
+
+// GetAnalyzerInfo returns metadata about the pack's policies.
+func (a *analyzer) GetAnalyzerInfo(context.Context, *pbempty.Empty) (*pulumirpc.AnalyzerInfo, error) {
+	policies := make([]*pulumirpc.PolicyInfo, len(a.pack.Policies))
+	for i, p := range a.pack.Policies {
+		level, err := marshalEnforcementLevel(p.EnforcementLevel)
+		contract.AssertNoError(err)
+
+		policies[i] = &pulumirpc.PolicyInfo{
+			Name:             p.Name,
+			Description:      p.Description,
+			EnforcementLevel: level,
+		}
+	}
+	return &pulumirpc.AnalyzerInfo{
+		Name:     a.pack.Name,
+		Policies: policies,
+	}, nil
+}
+
+// GetPluginInfo returns the pack's version.
+func (a *analyzer) GetPluginInfo(context.Context, *pbempty.Empty) (*pulumirpc.PluginInfo, error) {
+	return &pulumirpc.PluginInfo{Version: a.pack.Version}, nil
+}
+
+func (a *analyzer) diagnostic(p Policy, message string, urn resource.URN) *pulumirpc.AnalyzeDiagnostic {
+	level, err := marshalEnforcementLevel(p.EnforcementLevel)
+	contract.AssertNoError(err)
+
+	return &pulumirpc.AnalyzeDiagnostic{
+		PolicyName:        p.Name,
+		PolicyPackName:    a.pack.Name,
+		PolicyPackVersion: a.pack.Version,
+		Description:       p.Description,
+		Message:           message,
+		EnforcementLevel:  level,
+		Urn:               string(urn),
+	}
+}
+
+func marshalEnforcementLevel(level apitype.EnforcementLevel) (pulumirpc.EnforcementLevel, error) {
+	switch level {
+	case "", apitype.Advisory:
+		return pulumirpc.EnforcementLevel_ADVISORY, nil
+	case apitype.Mandatory:
+		return pulumirpc.EnforcementLevel_MANDATORY, nil
+	default:
+		return 0, errors.Errorf("invalid enforcement level %q", level)
+	}
+}
+
+// analyzerResource is implemented by both of the messages that carry resources to an analyzer.
+type analyzerResource interface {
+	GetUrn() string
+	GetType() string
+	GetName() string
+	GetProperties() *structpb.Struct
+	GetParent() string
+	GetDependencies() []string
+	GetProvider() string
+	GetProtect() bool
+	GetPropertyDependencies() map[string]*pulumirpc.AnalyzerPropertyDependencies
+}
+
+var _ analyzerResource = (*pulumirpc.AnalyzeRequest)(nil)
+var _ analyzerResource = (*pulumirpc.AnalyzerResource)(nil)
+
+func unmarshalResource(res analyzerResource) (*Resource, error) {
+	props, err := plugin.UnmarshalProperties(res.GetProperties(),
+		plugin.MarshalOptions{KeepUnknowns: true, KeepSecrets: true})
+	if err != nil {
+		return nil, errors.Wrapf(err, "unmarshaling properties of %s", res.GetUrn())
+	}
+
+	var propertyDependencies map[resource.PropertyKey][]resource.URN
+	if deps := res.GetPropertyDependencies(); len(deps) != 0 {
+		propertyDependencies = make(map[resource.PropertyKey][]resource.URN, len(deps))
+		for k, v := range deps {
+			propertyDependencies[resource.PropertyKey(k)] = unmarshalURNs(v.GetUrns())
+		}
+	}
+
+	return &Resource{
+		URN:                  resource.URN(res.GetUrn()),
+		Type:                 tokens.Type(res.GetType()),
+		Name:                 tokens.QName(res.GetName()),
+		Properties:           props,
+		Parent:               resource.URN(res.GetParent()),
+		Dependencies:         unmarshalURNs(res.GetDependencies()),
+		Provider:             res.GetProvider(),
+		Protect:              res.GetProtect(),
+		PropertyDependencies: propertyDependencies,
+	}, nil
+}
+
+func unmarshalURNs(urns []string) []resource.URN {
+	if len(urns) == 0 {
+		return nil
+	}
+	result := make([]resource.URN, len(urns))
+	for i, urn := range urns {
+		result[i] = resource.URN(urn)
+	}
+	return result
+}
@@ -0,0 +1,237 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"fmt"
+	"testing"
+
+	pbempty "github.com/golang/protobuf/ptypes/empty"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+
+	"github.com/pulumi/pulumi/pkg/apitype"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/plugin"
+	pulumirpc "github.com/pulumi/pulumi/sdk/proto/go"
+)
+
+func testURN(typ, name string) resource.URN {
+	return resource.URN("urn:pulumi:test::test::" + typ + "::" + name)
+}
+
+func testResource(t *testing.T, typ, name string, parent resource.URN,
+	deps ...resource.URN) *pulumirpc.AnalyzerResource {
+
+	props, err := plugin.MarshalProperties(resource.PropertyMap{"name": resource.NewStringProperty(name)},
+		plugin.MarshalOptions{KeepUnknowns: true, KeepSecrets: true})
+	assert.NoError(t, err)
+
+	var urns []string
+	for _, dep := range deps {
+		urns = append(urns, string(dep))
+	}
+	return &pulumirpc.AnalyzerResource{
+		Urn:          string(testURN(typ, name)),
+		Type:         typ,
+		Name:         name,
+		Properties:   props,
+		Parent:       string(parent),
+		Dependencies: urns,
+	}
+}
+
+// graphPack returns a pack with policies that require every bucket to have a component parent and that limit the
+// number of instances that may depend on a single security group.
+func graphPack(maxInstances int) PolicyPack {
+	return PolicyPack{
+		Name:    "graph",
+		Version: "1.0.0",
+		Policies: []Policy{
+			{
+				Name:             "bucket-parent",
+				Description:      "Buckets must be parented to a component.",
+				EnforcementLevel: apitype.Mandatory,
+				ValidateStack: func(s *Stack, report ReportStackViolation) {
+					for _, r := range s.Resources {
+						if r.Type != "aws:s3/bucket:Bucket" {
+							continue
+						}
+						if parent := s.Parent(r); parent == nil || parent.Type != "test:index:Component" {
+							report("bucket has no component parent", r.URN)
+						}
+					}
+				},
+			},
+			{
+				Name:        "security-group-fanout",
+				Description: "Security groups may not be shared by too many instances.",
+				ValidateStack: func(s *Stack, report ReportStackViolation) {
+					for _, r := range s.Resources {
+						if r.Type != "aws:ec2/securityGroup:SecurityGroup" {
+							continue
+						}
+						if n := len(s.Dependents(r)); n > maxInstances {
+							report(fmt.Sprintf("security group is used by %d instances", n), r.URN)
+						}
+					}
+				},
+			},
+			{
+				Name:        "protected",
+				Description: "Security groups must be protected.",
+				ValidateResource: func(r *Resource, report ReportViolation) {
+					if r.Type == "aws:ec2/securityGroup:SecurityGroup" && !r.Protect {
+						report("security group is not protected")
+					}
+				},
+			},
+		},
+	}
+}
+
+func TestAnalyzeStackGraph(t *testing.T) {
+	a, err := newAnalyzer(graphPack(1))
+	assert.NoError(t, err)
+
+	comp := testResource(t, "test:index:Component", "comp", "")
+	sg := testResource(t, "aws:ec2/securityGroup:SecurityGroup", "sg", "")
+	req := &pulumirpc.AnalyzeStackRequest{
+		Resources: []*pulumirpc.AnalyzerResource{
+			comp,
+			testResource(t, "aws:s3/bucket:Bucket", "good", resource.URN(comp.Urn)),
+			testResource(t, "aws:s3/bucket:Bucket", "bad", ""),
+			sg,
+			testResource(t, "aws:ec2/instance:Instance", "a", "", resource.URN(sg.Urn)),
+			testResource(t, "aws:ec2/instance:Instance", "b", "", resource.URN(sg.Urn)),
+		},
+	}
+
+	resp, err := a.AnalyzeStack(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Equal(t, []*pulumirpc.AnalyzeDiagnostic{
+		{
+			PolicyName:        "bucket-parent",
+			PolicyPackName:    "graph",
+			PolicyPackVersion: "1.0.0",
+			Description:       "Buckets must be parented to a component.",
+			Message:           "bucket has no component parent",
+			EnforcementLevel:  pulumirpc.EnforcementLevel_MANDATORY,
+			Urn:               string(testURN("aws:s3/bucket:Bucket", "bad")),
+		},
+		{
+			PolicyName:        "security-group-fanout",
+			PolicyPackName:    "graph",
+			PolicyPackVersion: "1.0.0",
+			Description:       "Security groups may not be shared by too many instances.",
+			Message:           "security group is used by 2 instances",
+			EnforcementLevel:  pulumirpc.EnforcementLevel_ADVISORY,
+			Urn:               sg.Urn,
+		},
+	}, resp.GetDiagnostics())
+
+	// Raising the limit removes the fan-out violation.
+	a, err = newAnalyzer(graphPack(2))
+	assert.NoError(t, err)
+	resp, err = a.AnalyzeStack(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Len(t, resp.GetDiagnostics(), 1)
+}
+
+func TestAnalyzeResource(t *testing.T) {
+	a, err := newAnalyzer(graphPack(1))
+	assert.NoError(t, err)
+
+	req := &pulumirpc.AnalyzeRequest{
+		Urn:  string(testURN("aws:ec2/securityGroup:SecurityGroup", "sg")),
+		Type: "aws:ec2/securityGroup:SecurityGroup",
+		Name: "sg",
+	}
+	resp, err := a.Analyze(context.Background(), req)
+	assert.NoError(t, err)
+	if assert.Len(t, resp.GetDiagnostics(), 1) {
+		assert.Equal(t, "protected", resp.GetDiagnostics()[0].PolicyName)
+		assert.Equal(t, req.Urn, resp.GetDiagnostics()[0].Urn)
+	}
+
+	req.Protect = true
+	resp, err = a.Analyze(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Empty(t, resp.GetDiagnostics())
+}
+
+func TestUnmarshalResource(t *testing.T) {
+	res := testResource(t, "aws:ec2/instance:Instance", "a", testURN("test:index:Component", "comp"),
+		testURN("aws:ec2/securityGroup:SecurityGroup", "sg"))
+	res.Provider = string(testURN("pulumi:providers:aws", "default")) + "::id"
+	res.Protect = true
+	res.PropertyDependencies = map[string]*pulumirpc.AnalyzerPropertyDependencies{
+		"securityGroups": {Urns: []string{string(testURN("aws:ec2/securityGroup:SecurityGroup", "sg"))}},
+	}
+
+	r, err := unmarshalResource(res)
+	assert.NoError(t, err)
+	assert.Equal(t, testURN("aws:ec2/instance:Instance", "a"), r.URN)
+	assert.Equal(t, resource.NewStringProperty("a"), r.Properties["name"])
+	assert.Equal(t, testURN("test:index:Component", "comp"), r.Parent)
+	assert.Equal(t, []resource.URN{testURN("aws:ec2/securityGroup:SecurityGroup", "sg")}, r.Dependencies)
+	assert.True(t, r.Protect)
+	assert.Equal(t, map[resource.PropertyKey][]resource.URN{
+		"securityGroups": {testURN("aws:ec2/securityGroup:SecurityGroup", "sg")},
+	}, r.PropertyDependencies)
+
+	provider := testResource(t, "pulumi:providers:aws", "default", "")
+	s := NewStack([]*Resource{r, mustUnmarshalResource(t, provider)})
+	prov, err := s.Provider(r)
+	assert.NoError(t, err)
+	if assert.NotNil(t, prov) {
+		assert.Equal(t, resource.URN(provider.Urn), prov.URN)
+	}
+}
+
+func mustUnmarshalResource(t *testing.T, res *pulumirpc.AnalyzerResource) *Resource {
+	r, err := unmarshalResource(res)
+	assert.NoError(t, err)
+	return r
+}
+
+func TestInvalidPolicyPack(t *testing.T) {
+	_, err := newAnalyzer(PolicyPack{Policies: []Policy{{Name: "a"}, {Name: "a"}}})
+	assert.Error(t, err)
+
+	_, err = newAnalyzer(PolicyPack{Policies: []Policy{{Name: ""}}})
+	assert.Error(t, err)
+
+	_, err = newAnalyzer(PolicyPack{Policies: []Policy{{Name: "a", EnforcementLevel: "sometimes"}}})
+	assert.Error(t, err)
+}
+
+func TestGetAnalyzerInfo(t *testing.T) {
+	a, err := newAnalyzer(graphPack(1))
+	assert.NoError(t, err)
+
+	info, err := a.GetAnalyzerInfo(context.Background(), &pbempty.Empty{})
+	assert.NoError(t, err)
+	assert.Equal(t, "graph", info.Name)
+	if assert.Len(t, info.Policies, 3) {
+		assert.Equal(t, "bucket-parent", info.Policies[0].Name)
+		assert.Equal(t, pulumirpc.EnforcementLevel_MANDATORY, info.Policies[0].EnforcementLevel)
+		assert.Equal(t, pulumirpc.EnforcementLevel_ADVISORY, info.Policies[1].EnforcementLevel)
+	}
+
+	pluginInfo, err := a.GetPluginInfo(context.Background(), &pbempty.Empty{})
+	assert.NoError(t, err)
+	assert.Equal(t, "1.0.0", pluginInfo.Version)
+}
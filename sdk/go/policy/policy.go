@@ -0,0 +1,147 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package policy is an SDK for authoring Pulumi policy packs in Go. A policy pack is a program that passes a
+// PolicyPack to Run. The Pulumi engine runs the program as an analyzer plugin, and sends it each of a stack's
+// resources, along with the resource's place in the stack's resource graph, to be validated.
+package policy
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/apitype"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/deploy/providers"
+	"github.com/pulumi/pulumi/pkg/tokens"
+)
+
+// PolicyPack is a named and versioned collection of policies.
+type PolicyPack struct {
+	Name     string   // the name of the policy pack.
+	Version  string   // the version of the policy pack.
+	Policies []Policy // the policies in the policy pack.
+}
+
+// Policy is a single rule in a policy pack. A policy may validate each resource before it is created or updated, the
+// stack's resources as a whole once all of them have been processed, or both.
+type Policy struct {
+	// Name is the name of the policy, which must be unique within its pack.
+	Name string
+	// Description describes the rule that the policy enforces.
+	Description string
+	// EnforcementLevel is the severity of violations of the policy. Defaults to apitype.Advisory.
+	EnforcementLevel apitype.EnforcementLevel
+	// ValidateResource, if non-nil, validates the inputs of a single resource before it is created or updated.
+	ValidateResource func(r *Resource, report ReportViolation)
+	// ValidateStack, if non-nil, validates the outputs of all of the stack's resources after a preview or update.
+	ValidateStack func(s *Stack, report ReportStackViolation)
+}
+
+// ReportViolation reports that the resource being validated violates a policy.
+type ReportViolation func(message string)
+
+// ReportStackViolation reports that a stack violates a policy. If the violation pertains to a particular resource,
+// its URN should be passed; otherwise, the URN may be empty.
+type ReportStackViolation func(message string, urn resource.URN)
+
+// Resource is the view of a resource that is sent to a policy pack for validation, including its place in the stack's
+// resource graph.
+type Resource struct {
+	URN                  resource.URN                            // the resource's URN.
+	Type                 tokens.Type                             // the resource's type.
+	Name                 tokens.QName                            // the resource's name.
+	Properties           resource.PropertyMap                    // the resource's inputs or outputs.
+	Parent               resource.URN                            // the resource's parent, if any.
+	Dependencies         []resource.URN                          // the resources this resource depends on.
+	Provider             string                                  // the reference to the resource's provider, if any.
+	Protect              bool                                    // true if the resource is protected.
+	PropertyDependencies map[resource.PropertyKey][]resource.URN // the resources each property depends on.
+}
+
+// Stack is the view of a stack's resources that is sent to a policy pack for validation. In addition to the resources
+// themselves, it provides access to the resource graph formed by their parents, dependencies, and providers.
+type Stack struct {
+	Resources []*Resource // the stack's resources.
+
+	urns       map[resource.URN]*Resource
+	children   map[resource.URN][]*Resource
+	dependents map[resource.URN][]*Resource
+}
+
+// NewStack creates a new view of a stack that contains the given resources.
+func NewStack(resources []*Resource) *Stack {
+	s := &Stack{
+		Resources:  resources,
+		urns:       make(map[resource.URN]*Resource),
+		children:   make(map[resource.URN][]*Resource),
+		dependents: make(map[resource.URN][]*Resource),
+	}
+	for _, r := range resources {
+		s.urns[r.URN] = r
+		if r.Parent != "" {
+			s.children[r.Parent] = append(s.children[r.Parent], r)
+		}
+		for _, dep := range r.Dependencies {
+			s.dependents[dep] = append(s.dependents[dep], r)
+		}
+	}
+	return s
+}
+
+// Resource returns the resource in the stack with the given URN, or nil if there is no such resource.
+func (s *Stack) Resource(urn resource.URN) *Resource {
+	return s.urns[urn]
+}
+
+// Parent returns the parent of the given resource, or nil if it has no parent in the stack.
+func (s *Stack) Parent(r *Resource) *Resource {
+	if r.Parent == "" {
+		return nil
+	}
+	return s.urns[r.Parent]
+}
+
+// Children returns the resources in the stack whose parent is the given resource.
+func (s *Stack) Children(r *Resource) []*Resource {
+	return s.children[r.URN]
+}
+
+// Dependencies returns the resources in the stack that the given resource depends on.
+func (s *Stack) Dependencies(r *Resource) []*Resource {
+	var deps []*Resource
+	for _, urn := range r.Dependencies {
+		if dep, ok := s.urns[urn]; ok {
+			deps = append(deps, dep)
+		}
+	}
+	return deps
+}
+
+// Dependents returns the resources in the stack that depend on the given resource.
+func (s *Stack) Dependents(r *Resource) []*Resource {
+	return s.dependents[r.URN]
+}
+
+// Provider returns the provider resource for the given resource, or nil if it has no provider in the stack. An error
+// is returned if the resource's provider reference is malformed.
+func (s *Stack) Provider(r *Resource) (*Resource, error) {
+	if r.Provider == "" {
+		return nil, nil
+	}
+	ref, err := providers.ParseReference(r.Provider)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parsing provider reference for %s", r.URN)
+	}
+	return s.urns[ref.URN()], nil
+}
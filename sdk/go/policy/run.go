@@ -0,0 +1,71 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+
+	"github.com/pulumi/pulumi/pkg/util/cmdutil"
+	"github.com/pulumi/pulumi/pkg/util/logging"
+	"github.com/pulumi/pulumi/pkg/util/rpcutil"
+	pulumirpc "github.com/pulumi/pulumi/sdk/proto/go"
+)
+
+// Run serves the policies in the given pack to the Pulumi engine, which runs the program as an analyzer plugin. If
+// the pack is invalid or cannot be served, the process will be terminated and the function will not return.
+func Run(pack PolicyPack) {
+	if err := RunErr(pack); err != nil {
+		fmt.Fprintf(os.Stderr, "error: policy pack failed: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// RunErr serves the policies in the given pack to the Pulumi engine until the engine shuts the plugin down.
+func RunErr(pack PolicyPack) error {
+	var tracing string
+	flag.StringVar(&tracing, "tracing", "", "Emit tracing to a Zipkin-compatible tracing endpoint")
+	flag.Parse()
+
+	// Initialize loggers before going any further.
+	logging.InitLogging(false, 0, false)
+	cmdutil.InitTracing(pack.Name, pack.Name, tracing)
+
+	a, err := newAnalyzer(pack)
+	if err != nil {
+		return errors.Wrapf(err, "invalid policy pack %q", pack.Name)
+	}
+
+	// Fire up a gRPC server, letting the kernel choose a free port for us.
+	port, done, err := rpcutil.Serve(0, nil, []func(*grpc.Server) error{
+		func(srv *grpc.Server) error {
+			pulumirpc.RegisterAnalyzerServer(srv, a)
+			return nil
+		},
+	}, nil)
+	if err != nil {
+		return errors.Wrap(err, "serving policy pack")
+	}
+
+	// The analyzer protocol requires that we now write out the port we have chosen to listen on.
+	fmt.Printf("%d\n", port)
+
+	// Finally, wait for the server to stop serving.
+	return <-done
+}
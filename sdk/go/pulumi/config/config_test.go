@@ -20,6 +20,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/pulumi/pulumi/pkg/workspace"
 	"github.com/pulumi/pulumi/sdk/go/pulumi"
 )
 
@@ -149,3 +150,69 @@ func TestConfig(t *testing.T) {
 	_, err = cfg.Try("missing")
 	assert.NotNil(t, err)
 }
+
+// TestTyped tests typed access to config declared by a project's config schema.
+func TestTyped(t *testing.T) {
+	proj := &workspace.Project{
+		Name: "testpkg",
+		Config: &workspace.ProjectConfig{
+			Keys: map[string]workspace.ConfigKeySchema{
+				"count":  {Type: workspace.ConfigTypeInteger},
+				"debug":  {Type: workspace.ConfigTypeBoolean},
+				"tags":   {Type: workspace.ConfigTypeObject},
+				"ratio":  {Type: workspace.ConfigTypeNumber},
+				"secret": {Type: workspace.ConfigTypeInteger, Secret: true},
+				"region": {Default: "us-west-2"},
+				"zone":   {},
+			},
+		},
+	}
+	schema, err := proj.ConfigSchema()
+	assert.Nil(t, err)
+
+	// The schema is supplied with the context, so the program's working directory does not matter.
+	ctx, err := pulumi.NewContext(context.Background(), pulumi.RunInfo{
+		Config: map[string]string{
+			"testpkg:count":  "3",
+			"testpkg:debug":  "true",
+			"testpkg:tags":   `{"env":"dev"}`,
+			"testpkg:ratio":  "half",
+			"testpkg:secret": "hunter2",
+		},
+		ConfigSchema: schema,
+	})
+	assert.Nil(t, err)
+
+	v, err := TryTyped(ctx, "testpkg:count")
+	assert.Nil(t, err)
+	assert.Equal(t, 3, v)
+	v, err = TryTyped(ctx, "testpkg:debug")
+	assert.Nil(t, err)
+	assert.Equal(t, true, v)
+	v, err = TryTyped(ctx, "testpkg:tags")
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{"env": "dev"}, v)
+	v, err = TryTyped(ctx, "testpkg:region")
+	assert.Nil(t, err)
+	assert.Equal(t, "us-west-2", v)
+
+	_, err = TryTyped(ctx, "testpkg:ratio")
+	assert.EqualError(t, err, "configuration variable 'testpkg:ratio': 'half' is not a number")
+	_, err = TryTyped(ctx, "testpkg:secret")
+	assert.EqualError(t, err, "configuration variable 'testpkg:secret' is not a valid integer")
+	_, err = TryTyped(ctx, "testpkg:zone")
+	assert.NotNil(t, err)
+	_, err = TryTyped(ctx, "testpkg:undeclared")
+	assert.EqualError(t, err, "configuration variable 'testpkg:undeclared' is not declared by the project's config schema")
+
+	// The typed variants check the declared type.
+	cfg := New(ctx, "testpkg")
+	assert.Equal(t, 3, cfg.RequireTypedInt("count"))
+	assert.Equal(t, true, cfg.RequireTypedBool("debug"))
+	assert.Equal(t, map[string]interface{}{"env": "dev"}, cfg.RequireTypedObject("tags"))
+	assert.Equal(t, "us-west-2", cfg.RequireTypedString("region"))
+	_, err = cfg.TryTypedString("count")
+	assert.EqualError(t, err, "configuration variable 'testpkg:count' is declared with type integer, not string")
+	_, err = TryTypedFloat64(ctx, "testpkg:ratio")
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,274 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/resource/config"
+	"github.com/pulumi/pulumi/pkg/util/contract"
+	"github.com/pulumi/pulumi/pkg/workspace"
+	"github.com/pulumi/pulumi/sdk/go/pulumi"
+)
+
+// TryTyped loads a configuration value by its key and converts it to the type declared for the key by the project's
+// config schema: a string, int, float64, bool, []interface{}, or map[string]interface{}. If the key is not set, its
+// declared default is returned. A non-nil error is returned if the key is not declared, if it is neither set nor has
+// a default, or if its value does not match its declaration.
+//
+// The schema is the one supplied with the context by the runtime, if any, and otherwise the one in the Pulumi.yaml of
+// the program's working directory, which is the project's directory when the program is run by a language host.
+func TryTyped(ctx *pulumi.Context, key string) (interface{}, error) {
+	return tryTyped(ctx, key, "")
+}
+
+// TryTypedString loads a configuration value declared with type string by the project's config schema, as TryTyped
+// does. A non-nil error is also returned if the key is declared with a different type.
+func TryTypedString(ctx *pulumi.Context, key string) (string, error) {
+	v, err := tryTyped(ctx, key, workspace.ConfigTypeString)
+	if err != nil {
+		return "", err
+	}
+	return v.(string), nil
+}
+
+// TryTypedInt loads a configuration value declared with type integer by the project's config schema, as TryTyped
+// does. A non-nil error is also returned if the key is declared with a different type.
+func TryTypedInt(ctx *pulumi.Context, key string) (int, error) {
+	v, err := tryTyped(ctx, key, workspace.ConfigTypeInteger)
+	if err != nil {
+		return 0, err
+	}
+	return v.(int), nil
+}
+
+// TryTypedFloat64 loads a configuration value declared with type number by the project's config schema, as TryTyped
+// does. A non-nil error is also returned if the key is declared with a different type.
+func TryTypedFloat64(ctx *pulumi.Context, key string) (float64, error) {
+	v, err := tryTyped(ctx, key, workspace.ConfigTypeNumber)
+	if err != nil {
+		return 0, err
+	}
+	return v.(float64), nil
+}
+
+// TryTypedBool loads a configuration value declared with type boolean by the project's config schema, as TryTyped
+// does. A non-nil error is also returned if the key is declared with a different type.
+func TryTypedBool(ctx *pulumi.Context, key string) (bool, error) {
+	v, err := tryTyped(ctx, key, workspace.ConfigTypeBoolean)
+	if err != nil {
+		return false, err
+	}
+	return v.(bool), nil
+}
+
+// TryTypedArray loads a configuration value declared with type array by the project's config schema, as TryTyped
+// does. A non-nil error is also returned if the key is declared with a different type.
+func TryTypedArray(ctx *pulumi.Context, key string) ([]interface{}, error) {
+	v, err := tryTyped(ctx, key, workspace.ConfigTypeArray)
+	if err != nil {
+		return nil, err
+	}
+	return v.([]interface{}), nil
+}
+
+// TryTypedObject loads a configuration value declared with type object by the project's config schema, as TryTyped
+// does. A non-nil error is also returned if the key is declared with a different type.
+func TryTypedObject(ctx *pulumi.Context, key string) (map[string]interface{}, error) {
+	v, err := tryTyped(ctx, key, workspace.ConfigTypeObject)
+	if err != nil {
+		return nil, err
+	}
+	return v.(map[string]interface{}), nil
+}
+
+// RequireTyped loads a configuration value by its key and converts it to the type declared for the key by the
+// project's config schema, as TryTyped does, or panics if that fails.
+func RequireTyped(ctx *pulumi.Context, key string) interface{} {
+	v, err := TryTyped(ctx, key)
+	requireTyped(err)
+	return v
+}
+
+// RequireTypedString loads a configuration value declared with type string, as TryTypedString does, or panics.
+func RequireTypedString(ctx *pulumi.Context, key string) string {
+	v, err := TryTypedString(ctx, key)
+	requireTyped(err)
+	return v
+}
+
+// RequireTypedInt loads a configuration value declared with type integer, as TryTypedInt does, or panics.
+func RequireTypedInt(ctx *pulumi.Context, key string) int {
+	v, err := TryTypedInt(ctx, key)
+	requireTyped(err)
+	return v
+}
+
+// RequireTypedFloat64 loads a configuration value declared with type number, as TryTypedFloat64 does, or panics.
+func RequireTypedFloat64(ctx *pulumi.Context, key string) float64 {
+	v, err := TryTypedFloat64(ctx, key)
+	requireTyped(err)
+	return v
+}
+
+// RequireTypedBool loads a configuration value declared with type boolean, as TryTypedBool does, or panics.
+func RequireTypedBool(ctx *pulumi.Context, key string) bool {
+	v, err := TryTypedBool(ctx, key)
+	requireTyped(err)
+	return v
+}
+
+// RequireTypedArray loads a configuration value declared with type array, as TryTypedArray does, or panics.
+func RequireTypedArray(ctx *pulumi.Context, key string) []interface{} {
+	v, err := TryTypedArray(ctx, key)
+	requireTyped(err)
+	return v
+}
+
+// RequireTypedObject loads a configuration value declared with type object, as TryTypedObject does, or panics.
+func RequireTypedObject(ctx *pulumi.Context, key string) map[string]interface{} {
+	v, err := TryTypedObject(ctx, key)
+	requireTyped(err)
+	return v
+}
+
+// TryTyped loads a configuration value by its key and converts it to the type declared for the key by the project's
+// config schema, or returns an error. See the package-level TryTyped.
+func (c *Config) TryTyped(key string) (interface{}, error) {
+	return TryTyped(c.ctx, c.fullKey(key))
+}
+
+// TryTypedString loads a configuration value declared with type string, or returns an error.
+func (c *Config) TryTypedString(key string) (string, error) {
+	return TryTypedString(c.ctx, c.fullKey(key))
+}
+
+// TryTypedInt loads a configuration value declared with type integer, or returns an error.
+func (c *Config) TryTypedInt(key string) (int, error) {
+	return TryTypedInt(c.ctx, c.fullKey(key))
+}
+
+// TryTypedFloat64 loads a configuration value declared with type number, or returns an error.
+func (c *Config) TryTypedFloat64(key string) (float64, error) {
+	return TryTypedFloat64(c.ctx, c.fullKey(key))
+}
+
+// TryTypedBool loads a configuration value declared with type boolean, or returns an error.
+func (c *Config) TryTypedBool(key string) (bool, error) {
+	return TryTypedBool(c.ctx, c.fullKey(key))
+}
+
+// TryTypedArray loads a configuration value declared with type array, or returns an error.
+func (c *Config) TryTypedArray(key string) ([]interface{}, error) {
+	return TryTypedArray(c.ctx, c.fullKey(key))
+}
+
+// TryTypedObject loads a configuration value declared with type object, or returns an error.
+func (c *Config) TryTypedObject(key string) (map[string]interface{}, error) {
+	return TryTypedObject(c.ctx, c.fullKey(key))
+}
+
+// RequireTyped loads a configuration value by its key and converts it to the type declared for the key by the
+// project's config schema, or panics. See the package-level RequireTyped.
+func (c *Config) RequireTyped(key string) interface{} {
+	return RequireTyped(c.ctx, c.fullKey(key))
+}
+
+// RequireTypedString loads a configuration value declared with type string, or panics.
+func (c *Config) RequireTypedString(key string) string {
+	return RequireTypedString(c.ctx, c.fullKey(key))
+}
+
+// RequireTypedInt loads a configuration value declared with type integer, or panics.
+func (c *Config) RequireTypedInt(key string) int {
+	return RequireTypedInt(c.ctx, c.fullKey(key))
+}
+
+// RequireTypedFloat64 loads a configuration value declared with type number, or panics.
+func (c *Config) RequireTypedFloat64(key string) float64 {
+	return RequireTypedFloat64(c.ctx, c.fullKey(key))
+}
+
+// RequireTypedBool loads a configuration value declared with type boolean, or panics.
+func (c *Config) RequireTypedBool(key string) bool {
+	return RequireTypedBool(c.ctx, c.fullKey(key))
+}
+
+// RequireTypedArray loads a configuration value declared with type array, or panics.
+func (c *Config) RequireTypedArray(key string) []interface{} {
+	return RequireTypedArray(c.ctx, c.fullKey(key))
+}
+
+// RequireTypedObject loads a configuration value declared with type object, or panics.
+func (c *Config) RequireTypedObject(key string) map[string]interface{} {
+	return RequireTypedObject(c.ctx, c.fullKey(key))
+}
+
+func requireTyped(err error) {
+	if err != nil {
+		contract.Failf("%v", err)
+	}
+}
+
+// configSchema returns the declarations of the project's configuration keys: those supplied with the context, if
+// any, and otherwise those in the Pulumi.yaml of the program's working directory.
+func configSchema(ctx *pulumi.Context) (map[config.Key]workspace.ConfigKeySchema, error) {
+	if schema := ctx.ConfigSchema(); schema != nil {
+		return schema, nil
+	}
+	proj, err := workspace.DetectProject()
+	if err != nil {
+		return nil, errors.Wrap(err, "loading the project's config schema")
+	}
+	return proj.ConfigSchema()
+}
+
+// tryTyped loads a configuration value by its key and converts it to the type declared for the key by the project's
+// config schema. If typ is non-empty, the key must be declared with that type.
+func tryTyped(ctx *pulumi.Context, key string, typ workspace.ConfigType) (interface{}, error) {
+	k, err := config.ParseKey(key)
+	if err != nil {
+		return nil, err
+	}
+	schema, err := configSchema(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s, ok := schema[k]
+	if !ok {
+		return nil, errors.Errorf("configuration variable '%s' is not declared by the project's config schema", key)
+	}
+	if typ != "" && s.ValueType() != typ {
+		return nil, errors.Errorf("configuration variable '%s' is declared with type %s, not %s",
+			key, s.ValueType(), typ)
+	}
+
+	v, ok := ctx.GetConfig(key)
+	if !ok {
+		if s.Default == nil {
+			return nil, errors.Errorf("missing required configuration variable '%s'; run `pulumi config` to set", key)
+		}
+		return s.DefaultValue()
+	}
+	result, err := s.Parse(v)
+	if err != nil {
+		if s.Secret {
+			// Don't reveal the plaintext of the secret.
+			return nil, errors.Errorf("configuration variable '%s' is not a valid %s", key, s.ValueType())
+		}
+		return nil, errors.Wrapf(err, "configuration variable '%s'", key)
+	}
+	return result, nil
+}
@@ -15,6 +15,7 @@
 package pulumi
 
 import (
+	"reflect"
 	"sort"
 	"strings"
 	"sync"
@@ -25,7 +26,9 @@ import (
 	"golang.org/x/net/context"
 	"google.golang.org/grpc"
 
+	"github.com/pulumi/pulumi/pkg/resource/config"
 	"github.com/pulumi/pulumi/pkg/util/logging"
+	"github.com/pulumi/pulumi/pkg/workspace"
 	pulumirpc "github.com/pulumi/pulumi/sdk/proto/go"
 )
 
@@ -34,6 +37,7 @@ type Context struct {
 	ctx         context.Context
 	info        RunInfo
 	stackR      URN
+	stackXforms []ResourceTransformation // transformations applied to every resource without a parent.
 	exports     map[string]interface{}
 	monitor     pulumirpc.ResourceMonitorClient
 	monitorConn *grpc.ClientConn
@@ -111,6 +115,10 @@ func (ctx *Context) Parallel() int { return ctx.info.Parallel }
 // DryRun is true when evaluating a program for purposes of planning, instead of performing a true deployment.
 func (ctx *Context) DryRun() bool { return ctx.info.DryRun }
 
+// ConfigSchema returns the declarations of the project's configuration keys, if the runtime supplied them with the
+// run request, or nil otherwise.
+func (ctx *Context) ConfigSchema() map[config.Key]workspace.ConfigKeySchema { return ctx.info.ConfigSchema }
+
 // GetConfig returns the config value, as a string, and a bool indicating whether it exists or not.
 func (ctx *Context) GetConfig(key string) (string, bool) {
 	v, ok := ctx.info.Config[key]
@@ -189,23 +197,62 @@ func (ctx *Context) Invoke(tok string, args map[string]interface{}, opts ...Invo
 // way will not be part of the resulting stack's state, as they are presumed to belong to another.
 func (ctx *Context) ReadResource(
 	t, name string, id ID, props map[string]interface{}, opts ...ResourceOpt) (*ResourceState, error) {
-	if t == "" {
-		return nil, errors.New("resource type argument cannot be empty")
-	} else if name == "" {
-		return nil, errors.New("resource name argument (for URN creation) cannot be empty")
+	if err := checkResourceArgs(t, name); err != nil {
+		return nil, err
 	} else if id == "" {
 		return nil, errors.New("resource ID is required for lookup and cannot be empty")
 	}
 
-	// Note that we're about to make an outstanding RPC request, so that we can rendezvous during shutdown.
-	if err := ctx.beginRPC(); err != nil {
+	// Apply any transformations, then create resolvers for the resource's outputs.
+	res := makeResourceState(true, nil)
+	t, props, opt, err := ctx.prepareResource(t, name, res, res, props, opts...)
+	if err != nil {
 		return nil, err
 	}
+	res.addOutputs(props)
+	if err = ctx.readResource(t, name, id, props, res, opt); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// ReadCustomResource reads an existing custom resource's state from the resource monitor, storing its outputs in the
+// given resource. args may be nil, a map[string]interface{}, or a struct (or pointer to a struct) whose fields are
+// tagged with `pulumi:"name"`. resource must be a pointer to a struct that embeds ResourceState; its fields that are
+// tagged with `pulumi:"name"` must be of output types (e.g. StringOutput), and will be resolved to the corresponding
+// properties of the resource once the read has completed.
+func (ctx *Context) ReadCustomResource(
+	t, name string, id ID, args interface{}, resource CustomResource, opts ...ResourceOpt) error {
+	if err := checkResourceArgs(t, name); err != nil {
+		return err
+	} else if id == "" {
+		return errors.New("resource ID is required for lookup and cannot be empty")
+	}
+
+	props, err := marshalArgs(args)
+	if err != nil {
+		return err
+	}
+	res, err := makeTypedResourceState(true, resource)
+	if err != nil {
+		return err
+	}
+	t, props, opt, err := ctx.prepareResource(t, name, resource, res, props, opts...)
+	if err != nil {
+		return err
+	}
+	return ctx.readResource(t, name, id, props, res, opt)
+}
 
-	// Create resolvers for the resource's outputs.
-	res := makeResourceState(true, props)
+func (ctx *Context) readResource(
+	t, name string, id ID, props map[string]interface{}, res *ResourceState, opt ResourceOpt) error {
 
-	res.providers = mergeProviders(t, opts...)
+	// Note that we're about to make an outstanding RPC request, so that we can rendezvous during shutdown.
+	if err := ctx.beginRPC(); err != nil {
+		return err
+	}
+
+	res.providers = mergeProviders(t, opt)
 
 	// Kick off the resource read operation.  This will happen asynchronously and resolve the above properties.
 	go func() {
@@ -219,19 +266,20 @@ func (ctx *Context) ReadResource(
 		}()
 
 		// Prepare the inputs for an impending operation.
-		inputs, err := ctx.prepareResourceInputs(props, t, res.providers, opts...)
+		inputs, err := ctx.prepareResourceInputs(res, props, t, name, opt)
 		if err != nil {
 			return
 		}
 
 		logging.V(9).Infof("ReadResource(%s, %s): Goroutine spawned, RPC call being made", t, name)
 		resp, err := ctx.monitor.ReadResource(ctx.ctx, &pulumirpc.ReadResourceRequest{
-			Type:       t,
-			Name:       name,
-			Parent:     inputs.parent,
-			Properties: inputs.rpcProps,
-			Provider:   inputs.provider,
-			Id:         string(id),
+			Type:          t,
+			Name:          name,
+			Parent:        inputs.parent,
+			Properties:    inputs.rpcProps,
+			Provider:      inputs.provider,
+			Id:            string(id),
+			AcceptSecrets: true,
 		})
 		if err != nil {
 			logging.V(9).Infof("RegisterResource(%s, %s): error: %v", t, name, err)
@@ -244,7 +292,7 @@ func (ctx *Context) ReadResource(
 		}
 	}()
 
-	return res, nil
+	return nil
 }
 
 // RegisterResource creates and registers a new resource object.  t is the fully qualified type token and name is
@@ -252,21 +300,226 @@ func (ctx *Context) ReadResource(
 // for the resource object and opts contains optional settings that govern the way the resource is created.
 func (ctx *Context) RegisterResource(
 	t, name string, custom bool, props map[string]interface{}, opts ...ResourceOpt) (*ResourceState, error) {
-	if t == "" {
-		return nil, errors.New("resource type argument cannot be empty")
-	} else if name == "" {
-		return nil, errors.New("resource name argument (for URN creation) cannot be empty")
+	if err := checkResourceArgs(t, name); err != nil {
+		return nil, err
 	}
 
-	// Note that we're about to make an outstanding RPC request, so that we can rendezvous during shutdown.
-	if err := ctx.beginRPC(); err != nil {
+	// Apply any transformations, then create resolvers for the resource's outputs.
+	res := makeResourceState(custom, nil)
+	t, props, opt, err := ctx.prepareResource(t, name, res, res, props, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res.addOutputs(props)
+	if err = ctx.registerResource(t, name, custom, props, res, opt); err != nil {
 		return nil, err
 	}
+	return res, nil
+}
+
+// RegisterCustomResource creates and registers a new custom resource object, storing its outputs in the given
+// resource. args may be nil, a map[string]interface{}, or a struct (or pointer to a struct) whose fields are tagged
+// with `pulumi:"name"`. resource must be a pointer to a struct that embeds ResourceState; its fields that are tagged
+// with `pulumi:"name"` must be of output types (e.g. StringOutput), and will be resolved to the corresponding
+// properties of the resource once registration has completed. For example:
+//
+//     type Bucket struct {
+//         pulumi.ResourceState
+//
+//         Arn  pulumi.StringOutput    `pulumi:"arn"`
+//         Tags pulumi.StringMapOutput `pulumi:"tags"`
+//     }
+//
+//     type BucketArgs struct {
+//         Tags pulumi.StringMapInput `pulumi:"tags"`
+//     }
+//
+//     var bucket Bucket
+//     err := ctx.RegisterCustomResource("aws:s3/bucket:Bucket", "my-bucket", &BucketArgs{
+//         Tags: pulumi.StringMap{"owner": pulumi.String("me")},
+//     }, &bucket)
+func (ctx *Context) RegisterCustomResource(
+	t, name string, args interface{}, resource CustomResource, opts ...ResourceOpt) error {
+	if err := checkResourceArgs(t, name); err != nil {
+		return err
+	}
 
-	// Create resolvers for the resource's outputs.
-	res := makeResourceState(custom, props)
+	props, err := marshalArgs(args)
+	if err != nil {
+		return err
+	}
+	res, err := makeTypedResourceState(true, resource)
+	if err != nil {
+		return err
+	}
+	t, props, opt, err := ctx.prepareResource(t, name, resource, res, props, opts...)
+	if err != nil {
+		return err
+	}
+	return ctx.registerResource(t, name, true, props, res, opt)
+}
+
+// RegisterComponentResource creates and registers a new component resource object, storing its state in the given
+// resource, which must be a pointer to a struct that embeds ResourceState. The component's children are registered
+// by passing the component as their Parent; children inherit the component's providers, protection, aliases, and
+// transformations. Once its children have been registered, a component may publish its outputs using
+// RegisterComponentResourceOutputs. For example:
+//
+//     type WebSite struct {
+//         pulumi.ResourceState
+//
+//         URL pulumi.StringOutput
+//     }
+//
+//     var site WebSite
+//     if err := ctx.RegisterComponentResource("my:web:WebSite", "site", &site); err != nil {
+//         return err
+//     }
+//     var bucket Bucket
+//     if err := ctx.RegisterCustomResource("aws:s3/bucket:Bucket", "site-bucket", nil, &bucket,
+//         pulumi.ResourceOpt{Parent: &site}); err != nil {
+//         return err
+//     }
+//     site.URL = bucket.WebsiteEndpoint
+//     return ctx.RegisterComponentResourceOutputs(&site, map[string]interface{}{"url": site.URL})
+func (ctx *Context) RegisterComponentResource(
+	t, name string, resource ComponentResource, opts ...ResourceOpt) error {
+	if err := checkResourceArgs(t, name); err != nil {
+		return err
+	}
+
+	res, err := makeTypedResourceState(false, resource)
+	if err != nil {
+		return err
+	}
+	t, props, opt, err := ctx.prepareResource(t, name, resource, res, nil, opts...)
+	if err != nil {
+		return err
+	}
+	return ctx.registerResource(t, name, false, props, res, opt)
+}
+
+// RegisterComponentResourceOutputs completes the registration of the given component resource, attaching an
+// optional set of computed outputs. This waits for the component's own registration to complete.
+func (ctx *Context) RegisterComponentResourceOutputs(resource ComponentResource, outs map[string]interface{}) error {
+	urn, _, err := resource.URN().await(context.TODO())
+	if err != nil {
+		return err
+	}
+	return ctx.RegisterResourceOutputs(urn, outs)
+}
 
-	res.providers = mergeProviders(t, opts...)
+// RegisterStackTransformation adds a transformation to the current stack. The transformation is applied to every
+// resource that is subsequently registered without an explicit parent, and to all of the descendants of such
+// resources.
+func (ctx *Context) RegisterStackTransformation(t ResourceTransformation) error {
+	if t == nil {
+		return errors.New("stack transformation cannot be nil")
+	}
+	ctx.stackXforms = append(ctx.stackXforms, t)
+	return nil
+}
+
+// prepareResource merges the given options and applies any transformations to the type, properties, and options of
+// the given resource. It records the resource's name, protection bit, and transformations in its state so that they
+// can be inherited by the resource's children.
+func (ctx *Context) prepareResource(t, name string, resource Resource, state *ResourceState,
+	props map[string]interface{}, opts ...ResourceOpt) (string, map[string]interface{}, ResourceOpt, error) {
+
+	opt := mergeResourceOpts(opts...)
+
+	// The resource's own transformations run first, followed by those of its parent (which include those of all of
+	// its ancestors) or, if it has no parent, those of the stack.
+	transformations := append([]ResourceTransformation(nil), opt.Transformations...)
+	if opt.Parent != nil {
+		if parent, ok := opt.Parent.(resourceStateHolder); ok {
+			transformations = append(transformations, parent.getResourceState().transformations...)
+		}
+	} else {
+		transformations = append(transformations, ctx.stackXforms...)
+	}
+
+	for _, transformation := range transformations {
+		result := transformation(&ResourceTransformationArgs{
+			Resource: resource,
+			Type:     t,
+			Name:     name,
+			Props:    props,
+			Opts:     opt,
+		})
+		if result == nil {
+			continue
+		}
+		if result.Opts.Parent != opt.Parent {
+			return "", nil, ResourceOpt{}, errors.Errorf("transformations cannot change the parent of resource %s", name)
+		}
+		if result.Type != "" {
+			t = result.Type
+		}
+		props, opt = result.Props, result.Opts
+	}
+
+	// Children of protected resources are protected.
+	if !opt.Protect && opt.Parent != nil {
+		if parent, ok := opt.Parent.(resourceStateHolder); ok && parent.getResourceState().protect {
+			opt.Protect = true
+		}
+	}
+
+	state.name = name
+	state.protect = opt.Protect
+	state.transformations = transformations
+	return t, props, opt, nil
+}
+
+// mergeResourceOpts merges the given options into a single set of options. For most options, the first value that is
+// set takes precedence. Protect and DeleteBeforeReplace are set if any of the options set them, CustomTimeouts are
+// taken from the last options that set them, and Aliases and Transformations are concatenated.
+func mergeResourceOpts(opts ...ResourceOpt) ResourceOpt {
+	var result ResourceOpt
+	for _, opt := range opts {
+		if result.Parent == nil && opt.Parent != nil {
+			result.Parent = opt.Parent
+		}
+		if result.DependsOn == nil && opt.DependsOn != nil {
+			result.DependsOn = opt.DependsOn
+		}
+		if !result.Protect && opt.Protect {
+			result.Protect = true
+		}
+		if result.Provider == nil && opt.Provider != nil {
+			result.Provider = opt.Provider
+		}
+		if len(result.Providers) == 0 && opt.Providers != nil {
+			result.Providers = opt.Providers
+		}
+		if !result.DeleteBeforeReplace && opt.DeleteBeforeReplace {
+			result.DeleteBeforeReplace = true
+		}
+		if result.Import == "" && opt.Import != "" {
+			result.Import = opt.Import
+		}
+		if opt.CustomTimeouts != nil {
+			result.CustomTimeouts = opt.CustomTimeouts
+		}
+		if result.IgnoreChanges == nil && opt.IgnoreChanges != nil {
+			result.IgnoreChanges = opt.IgnoreChanges
+		}
+		result.Aliases = append(result.Aliases, opt.Aliases...)
+		result.Transformations = append(result.Transformations, opt.Transformations...)
+	}
+	return result
+}
+
+func (ctx *Context) registerResource(
+	t, name string, custom bool, props map[string]interface{}, res *ResourceState, opt ResourceOpt) error {
+
+	// Note that we're about to make an outstanding RPC request, so that we can rendezvous during shutdown.
+	if err := ctx.beginRPC(); err != nil {
+		return err
+	}
+
+	res.providers = mergeProviders(t, opt)
 
 	// Kick off the resource registration.  If we are actually performing a deployment, the resulting properties
 	// will be resolved asynchronously as the RPC operation completes.  If we're just planning, values won't resolve.
@@ -281,7 +534,7 @@ func (ctx *Context) RegisterResource(
 		}()
 
 		// Prepare the inputs for an impending operation.
-		inputs, err := ctx.prepareResourceInputs(props, t, res.providers, opts...)
+		inputs, err := ctx.prepareResourceInputs(res, props, t, name, opt)
 		if err != nil {
 			return
 		}
@@ -301,6 +554,8 @@ func (ctx *Context) RegisterResource(
 			ImportId:             inputs.importID,
 			CustomTimeouts:       inputs.customTimeouts,
 			IgnoreChanges:        inputs.ignoreChanges,
+			Aliases:              inputs.aliases,
+			AcceptSecrets:        true,
 		})
 		if err != nil {
 			logging.V(9).Infof("RegisterResource(%s, %s): error: %v", t, name, err)
@@ -313,7 +568,17 @@ func (ctx *Context) RegisterResource(
 		}
 	}()
 
-	return res, nil
+	return nil
+}
+
+// checkResourceArgs checks that the given type token and resource name are valid.
+func checkResourceArgs(t, name string) error {
+	if t == "" {
+		return errors.New("resource type argument cannot be empty")
+	} else if name == "" {
+		return errors.New("resource name argument (for URN creation) cannot be empty")
+	}
+	return nil
 }
 
 // ResourceState contains the results of a resource registration operation.
@@ -326,6 +591,24 @@ type ResourceState struct {
 	State Outputs
 	// Map from pkg to provider
 	providers map[string]ProviderResource
+	// name is the name of the resource.
+	name string
+	// protect is true if the resource is protected; the children of a protected resource are protected.
+	protect bool
+	// aliases are the URNs of the resource's aliases. They are set before the resource's URN is resolved.
+	aliases []URN
+	// transformations are the transformations applied to the resource and its children.
+	transformations []ResourceTransformation
+}
+
+// getResourceState returns the resource state. This method is promoted to structs that embed ResourceState.
+func (state *ResourceState) getResourceState() *ResourceState {
+	return state
+}
+
+// resourceStateHolder is implemented by ResourceState and by any struct that embeds it.
+type resourceStateHolder interface {
+	getResourceState() *ResourceState
 }
 
 // URN will resolve to the resource's URN after registration has completed.
@@ -359,9 +642,9 @@ func mergeProviders(t string, opts ...ResourceOpt) map[string]ProviderResource {
 
 	// copy parent providers, giving precedence to existing providers
 	if parent != nil {
-		rs, ok := parent.(*ResourceState)
+		rs, ok := parent.(resourceStateHolder)
 		if ok {
-			for k, v := range rs.providers {
+			for k, v := range rs.getResourceState().providers {
 				if _, has := providers[k]; !has {
 					providers[k] = v
 				}
@@ -399,30 +682,95 @@ func getPackage(t string) string {
 // properties.
 func makeResourceState(custom bool, props map[string]interface{}) *ResourceState {
 	state := &ResourceState{}
+	state.init(custom, state)
+	state.addOutputs(props)
+	return state
+}
 
-	state.urn = URNOutput(newOutput(state))
+// addOutputs creates an output for each of the given properties.
+func (state *ResourceState) addOutputs(props map[string]interface{}) {
+	for key := range props {
+		state.State[key] = newOutput(state)
+	}
+}
 
-	if custom {
-		state.id = IDOutput(newOutput(state))
+// makeTypedResourceState initializes the state embedded in the given resource, which must be a pointer to a struct
+// that embeds ResourceState. For custom resources, an output is created for each of the struct's fields that is
+// tagged with `pulumi:"name"`; these fields must be of output types. The fields of component resources are left
+// for the component to set.
+func makeTypedResourceState(custom bool, resource Resource) (*ResourceState, error) {
+	holder, ok := resource.(resourceStateHolder)
+	if !ok {
+		return nil, errors.Errorf("resource of type %T must embed pulumi.ResourceState", resource)
+	}
+	rv := reflect.ValueOf(resource)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return nil, errors.Errorf("resource must be a non-nil pointer to a struct, not %T", resource)
 	}
 
-	state.State = make(map[string]Output)
-	for key := range props {
-		state.State[key] = newOutput(state)
+	state := holder.getResourceState()
+	state.init(custom, resource)
+	if !custom {
+		return state, nil
 	}
 
-	state.providers = make(map[string]ProviderResource)
+	rv = rv.Elem()
+	for i := 0; i < rv.NumField(); i++ {
+		field := rv.Type().Field(i)
+		name, ok := propertyName(field)
+		if !ok {
+			continue
+		}
+		if !field.Type.ConvertibleTo(outputType) {
+			return nil, errors.Errorf("field %s of %T has type %v, which is not an output type",
+				field.Name, resource, field.Type)
+		}
 
-	return state
+		out := newOutput(resource)
+		rv.Field(i).Set(reflect.ValueOf(out).Convert(field.Type))
+		state.State[name] = out
+	}
+	return state, nil
+}
+
+// propertyName returns the name of the Pulumi property for the given struct field, if any. Only exported fields
+// that are tagged with `pulumi:"name"` are properties.
+func propertyName(field reflect.StructField) (string, bool) {
+	if field.PkgPath != "" {
+		return "", false
+	}
+	tag, ok := field.Tag.Lookup("pulumi")
+	if !ok {
+		return "", false
+	}
+	name := strings.Split(tag, ",")[0]
+	if name == "" || name == "-" {
+		return "", false
+	}
+	return name, true
+}
+
+// init initializes the resolvers for the URN and ID of the given resource, which owns this state.
+func (state *ResourceState) init(custom bool, resource Resource) {
+	state.urn = URNOutput(newOutput(resource))
+	if custom {
+		state.id = IDOutput(newOutput(resource))
+	}
+	state.State = make(map[string]Output)
+	state.providers = make(map[string]ProviderResource)
 }
 
 // resolve resolves the resource outputs using the given error and/or values.
 func (state *ResourceState) resolve(dryrun bool, err error, inputs map[string]interface{}, urn, id string,
 	result *structpb.Struct) {
 	var outprops map[string]interface{}
+	var secrets map[string]bool
 	if err == nil {
 		outprops, err = unmarshalOutputs(result)
 	}
+	if err == nil {
+		secrets, err = unmarshalSecrets(result)
+	}
 	if err != nil {
 		// If there was an error, we must reject everything: URN, ID, and state properties.
 		state.urn.s.reject(err)
@@ -456,7 +804,7 @@ func (state *ResourceState) resolve(dryrun bool, err error, inputs map[string]in
 			// if any exists.
 			v = inputs[k]
 		}
-		o.s.resolve(v, isKnown(v))
+		o.s.fulfillValue(v, isKnown(v), secrets[k], nil)
 	}
 }
 
@@ -472,20 +820,28 @@ type resourceInputs struct {
 	importID            string
 	customTimeouts      *pulumirpc.RegisterResourceRequest_CustomTimeouts
 	ignoreChanges       []string
+	aliases             []string
 }
 
 // prepareResourceInputs prepares the inputs for a resource operation, shared between read and register.
-func (ctx *Context) prepareResourceInputs(props map[string]interface{}, t string,
-	providers map[string]ProviderResource, opts ...ResourceOpt) (*resourceInputs, error) {
+func (ctx *Context) prepareResourceInputs(res *ResourceState, props map[string]interface{}, t, name string,
+	opt ResourceOpt) (*resourceInputs, error) {
 	// Get the parent and dependency URNs from the options, in addition to the protection bit.  If there wasn't an
 	// explicit parent, and a root stack resource exists, we will automatically parent to that.
 	parent, optDeps, protect, provider, deleteBeforeReplace,
-		importID, ignoreChanges, err := ctx.getOpts(t, providers, opts...)
+		importID, ignoreChanges, err := ctx.getOpts(t, res.providers, opt)
 	if err != nil {
 		return nil, errors.Wrap(err, "resolving options")
 	}
 
-	timeouts := ctx.getTimeouts(opts...)
+	timeouts := ctx.getTimeouts(opt)
+
+	// Compute the URNs of the resource's aliases. These are recorded so that the resource's children can inherit them.
+	aliases, err := ctx.collapseAliases(opt.Aliases, t, name, opt.Parent, parent)
+	if err != nil {
+		return nil, errors.Wrap(err, "resolving aliases")
+	}
+	res.aliases = aliases
 
 	// Serialize all properties, first by awaiting them, and then marshaling them to the requisite gRPC values.
 	keepUnknowns := ctx.DryRun()
@@ -523,6 +879,11 @@ func (ctx *Context) prepareResourceInputs(props map[string]interface{}, t string
 	}
 	sort.Strings(deps)
 
+	aliasStrings := make([]string, len(aliases))
+	for i, alias := range aliases {
+		aliasStrings[i] = string(alias)
+	}
+
 	return &resourceInputs{
 		parent:              string(parent),
 		deps:                deps,
@@ -534,9 +895,100 @@ func (ctx *Context) prepareResourceInputs(props map[string]interface{}, t string
 		importID:            string(importID),
 		customTimeouts:      timeouts,
 		ignoreChanges:       ignoreChanges,
+		aliases:             aliasStrings,
 	}, nil
 }
 
+// collapseAliases computes the URNs of the given aliases of a resource, along with the aliases that the resource
+// inherits from its parent. parentURN is the URN of the resource's current parent.
+func (ctx *Context) collapseAliases(aliases []Alias, t, name string, parent Resource, parentURN URN) ([]URN, error) {
+	var urns []URN
+	for _, alias := range aliases {
+		if alias.URN != "" {
+			urns = append(urns, alias.URN)
+			continue
+		}
+
+		aliasName, aliasType := name, t
+		if alias.Name != "" {
+			aliasName = alias.Name
+		}
+		if alias.Type != "" {
+			aliasType = alias.Type
+		}
+		project, stack := ctx.Project(), ctx.Stack()
+		if alias.Project != "" {
+			project = alias.Project
+		}
+		if alias.Stack != "" {
+			stack = alias.Stack
+		}
+
+		aliasParent := parentURN
+		switch {
+		case alias.NoParent:
+			aliasParent = ""
+		case alias.Parent != nil:
+			urn, _, err := alias.Parent.URN().await(context.TODO())
+			if err != nil {
+				return nil, err
+			}
+			aliasParent = urn
+		case alias.ParentURN != "":
+			aliasParent = alias.ParentURN
+		}
+
+		urns = append(urns, createURN(aliasName, aliasType, aliasParent, project, stack))
+	}
+
+	// If the parent has aliases, the resource has a corresponding alias for each of them. If the resource's name is
+	// prefixed with its parent's name, the prefix is replaced with the name of the parent's alias.
+	if parent != nil {
+		if holder, ok := parent.(resourceStateHolder); ok {
+			parentState := holder.getResourceState()
+			for _, parentAlias := range parentState.aliases {
+				aliasName := name
+				if strings.HasPrefix(name, parentState.name) {
+					aliasName = nameOfURN(parentAlias) + strings.TrimPrefix(name, parentState.name)
+				}
+				urns = append(urns, createURN(aliasName, t, parentAlias, ctx.Project(), ctx.Stack()))
+			}
+		}
+	}
+	return urns, nil
+}
+
+// createURN computes the URN of a resource with the given name, type, and parent. Resources that are parented to a
+// root stack resource have the same URNs as resources that have no parent.
+func createURN(name, t string, parent URN, project, stack string) URN {
+	var prefix string
+	if parent == "" || typeOfURN(parent) == rootStackType {
+		prefix = "urn:pulumi:" + stack + "::" + project + "::"
+	} else {
+		prefix = string(parent[:strings.LastIndex(string(parent), "::")]) + "$"
+	}
+	return URN(prefix + t + "::" + name)
+}
+
+// rootStackType is the type token of a root stack resource.
+const rootStackType = "pulumi:pulumi:Stack"
+
+// typeOfURN returns the type token of the resource with the given URN.
+func typeOfURN(urn URN) string {
+	parts := strings.Split(string(urn), "::")
+	if len(parts) < 4 {
+		return ""
+	}
+	qualifiedType := parts[2]
+	return qualifiedType[strings.LastIndex(qualifiedType, "$")+1:]
+}
+
+// nameOfURN returns the name of the resource with the given URN.
+func nameOfURN(urn URN) string {
+	s := string(urn)
+	return s[strings.LastIndex(s, "::")+2:]
+}
+
 func (ctx *Context) getTimeouts(opts ...ResourceOpt) *pulumirpc.RegisterResourceRequest_CustomTimeouts {
 	var timeouts pulumirpc.RegisterResourceRequest_CustomTimeouts
 	for _, opt := range opts {
@@ -0,0 +1,143 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pulumi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestContext(t *testing.T) *Context {
+	ctx, err := NewContext(context.Background(), RunInfo{Project: "proj", Stack: "stack"})
+	assert.NoError(t, err)
+	ctx.stackR = "urn:pulumi:stack::proj::pulumi:pulumi:Stack::proj-stack"
+	return ctx
+}
+
+func TestCreateURN(t *testing.T) {
+	assert.Equal(t, URN("urn:pulumi:stack::proj::a:b:c::res"), createURN("res", "a:b:c", "", "proj", "stack"))
+	assert.Equal(t, URN("urn:pulumi:stack::proj::a:b:c::res"),
+		createURN("res", "a:b:c", "urn:pulumi:stack::proj::pulumi:pulumi:Stack::proj-stack", "proj", "stack"))
+	assert.Equal(t, URN("urn:pulumi:stack::proj::my:comp:C$a:b:c::res"),
+		createURN("res", "a:b:c", "urn:pulumi:stack::proj::my:comp:C::comp", "proj", "stack"))
+	assert.Equal(t, URN("urn:pulumi:stack::proj::my:comp:D$my:comp:C$a:b:c::res"),
+		createURN("res", "a:b:c", "urn:pulumi:stack::proj::my:comp:D$my:comp:C::comp", "proj", "stack"))
+}
+
+func TestCollapseAliases(t *testing.T) {
+	ctx := newTestContext(t)
+
+	parent := makeResourceState(false, nil)
+	parent.name = "comp"
+	parent.aliases = []URN{"urn:pulumi:stack::proj::my:comp:Old::old"}
+	parentURN := URN("urn:pulumi:stack::proj::my:comp:C::comp")
+
+	urns, err := ctx.collapseAliases([]Alias{
+		{URN: "urn:pulumi:stack::proj::x:y:z::explicit"},
+		{Name: "renamed"},
+		{Type: "a:b:old"},
+		{NoParent: true},
+		{Stack: "other", Project: "other-proj", NoParent: true},
+		{ParentURN: "urn:pulumi:stack::proj::my:comp:P::p"},
+	}, "a:b:c", "comp-child", parent, parentURN)
+	assert.NoError(t, err)
+	assert.Equal(t, []URN{
+		"urn:pulumi:stack::proj::x:y:z::explicit",
+		"urn:pulumi:stack::proj::my:comp:C$a:b:c::renamed",
+		"urn:pulumi:stack::proj::my:comp:C$a:b:old::comp-child",
+		"urn:pulumi:stack::proj::a:b:c::comp-child",
+		"urn:pulumi:other::other-proj::a:b:c::comp-child",
+		"urn:pulumi:stack::proj::my:comp:P$a:b:c::comp-child",
+		// The alias inherited from the parent replaces the parent's name prefix.
+		"urn:pulumi:stack::proj::my:comp:Old$a:b:c::old-child",
+	}, urns)
+}
+
+func TestPrepareResource(t *testing.T) {
+	ctx := newTestContext(t)
+
+	var calls []string
+	err := ctx.RegisterStackTransformation(func(args *ResourceTransformationArgs) *ResourceTransformationResult {
+		calls = append(calls, "stack:"+args.Name)
+		return nil
+	})
+	assert.NoError(t, err)
+
+	// The parent's transformations run after the stack's transformation, and it is protected.
+	parent := makeResourceState(false, nil)
+	_, _, _, err = ctx.prepareResource("my:comp:C", "comp", parent, parent, nil, ResourceOpt{
+		Protect: true,
+		Transformations: []ResourceTransformation{
+			func(args *ResourceTransformationArgs) *ResourceTransformationResult {
+				calls = append(calls, "parent:"+args.Name)
+				props := map[string]interface{}{"tag": "parent"}
+				for k, v := range args.Props {
+					props[k] = v
+				}
+				return &ResourceTransformationResult{Props: props, Opts: args.Opts}
+			},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"parent:comp", "stack:comp"}, calls)
+	assert.True(t, parent.protect)
+
+	// The child's transformations run first, followed by the parent's and the stack's.
+	calls = nil
+	child := makeResourceState(true, nil)
+	ty, props, opt, err := ctx.prepareResource("a:b:c", "child", child, child, map[string]interface{}{"foo": "bar"},
+		ResourceOpt{
+			Parent: parent,
+			Transformations: []ResourceTransformation{
+				func(args *ResourceTransformationArgs) *ResourceTransformationResult {
+					calls = append(calls, "child:"+args.Name)
+					return &ResourceTransformationResult{Type: "a:b:d", Props: args.Props, Opts: args.Opts}
+				},
+			},
+		})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"child:child", "parent:child", "stack:child"}, calls)
+	assert.Equal(t, "a:b:d", ty)
+	assert.Equal(t, map[string]interface{}{"foo": "bar", "tag": "parent"}, props)
+	assert.True(t, opt.Protect)
+	assert.True(t, child.protect)
+
+	// Transformations may not change the parent of a resource.
+	other := makeResourceState(true, nil)
+	_, _, _, err = ctx.prepareResource("a:b:c", "other", other, other, nil, ResourceOpt{
+		Transformations: []ResourceTransformation{
+			func(args *ResourceTransformationArgs) *ResourceTransformationResult {
+				opts := args.Opts
+				opts.Parent = parent
+				return &ResourceTransformationResult{Props: args.Props, Opts: opts}
+			},
+		},
+	})
+	assert.Error(t, err)
+}
+
+func TestMergeResourceOpts(t *testing.T) {
+	parent := makeResourceState(false, nil)
+	opt := mergeResourceOpts(
+		ResourceOpt{Parent: parent, Aliases: []Alias{{Name: "a"}}, CustomTimeouts: &CustomTimeouts{Create: "1m"}},
+		ResourceOpt{Protect: true, Aliases: []Alias{{Name: "b"}}, CustomTimeouts: &CustomTimeouts{Create: "2m"}},
+	)
+	assert.Equal(t, parent, opt.Parent)
+	assert.True(t, opt.Protect)
+	assert.Equal(t, []Alias{{Name: "a"}, {Name: "b"}}, opt.Aliases)
+	assert.Equal(t, "2m", opt.CustomTimeouts.Create)
+}
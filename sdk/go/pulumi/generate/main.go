@@ -0,0 +1,309 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// generate produces the typed inputs and outputs for the Go SDK's builtin types. It is run by `go generate` in the
+// sdk/go/pulumi directory.
+// nolint: lll
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"os"
+	"strings"
+	"text/template"
+)
+
+// builtin describes a builtin type for which typed inputs and outputs are generated.
+type builtin struct {
+	Name        string // the name of the type, e.g. "String".
+	Type        string // the Go element type, e.g. "string".
+	InputType   string // the concrete input type, if one should be declared.
+	HasInput    bool   // true if the type has an input interface and a concrete input type.
+	Collections bool   // true if array and map types should be generated for this type.
+}
+
+func (b builtin) Lower() string {
+	return lower(b.Name)
+}
+
+// lower lowercases the leading initialism or word of the given name, e.g. "URNArray" becomes "urnArray".
+func lower(name string) string {
+	i := 0
+	for i < len(name) && name[i] >= 'A' && name[i] <= 'Z' {
+		i++
+	}
+	switch {
+	case i == 0:
+		return name
+	case i == 1 || i == len(name):
+		return strings.ToLower(name[:i]) + name[i:]
+	default:
+		// Keep the last uppercase letter if it begins the next word.
+		if name[i] >= 'a' && name[i] <= 'z' {
+			i--
+		}
+		return strings.ToLower(name[:i]) + name[i:]
+	}
+}
+
+// collection describes an array or map of a builtin type.
+type collection struct {
+	Name    string // the name of the collection type, e.g. "StringArray".
+	Type    string // the Go element type of the collection, e.g. "[]string".
+	Input   string // the concrete input type of the collection, e.g. "[]StringInput".
+	Elem    builtin
+	IsArray bool
+}
+
+func (c collection) Lower() string {
+	return lower(c.Name)
+}
+
+var builtins = []builtin{
+	{Name: "Archive", Type: "asset.Archive"},
+	{Name: "Array", Type: "[]interface{}", InputType: "[]Input", HasInput: true},
+	{Name: "Asset", Type: "asset.Asset"},
+	{Name: "Bool", Type: "bool", InputType: "bool", HasInput: true, Collections: true},
+	{Name: "Float32", Type: "float32", InputType: "float32", HasInput: true, Collections: true},
+	{Name: "Float64", Type: "float64", InputType: "float64", HasInput: true, Collections: true},
+	{Name: "ID", Type: "ID", HasInput: true, Collections: true},
+	{Name: "Int", Type: "int", InputType: "int", HasInput: true, Collections: true},
+	{Name: "Int8", Type: "int8", InputType: "int8", HasInput: true, Collections: true},
+	{Name: "Int16", Type: "int16", InputType: "int16", HasInput: true, Collections: true},
+	{Name: "Int32", Type: "int32", InputType: "int32", HasInput: true, Collections: true},
+	{Name: "Int64", Type: "int64", InputType: "int64", HasInput: true, Collections: true},
+	{Name: "Map", Type: "map[string]interface{}", InputType: "map[string]Input", HasInput: true},
+	{Name: "String", Type: "string", InputType: "string", HasInput: true, Collections: true},
+	{Name: "Uint", Type: "uint", InputType: "uint", HasInput: true, Collections: true},
+	{Name: "Uint8", Type: "uint8", InputType: "uint8", HasInput: true, Collections: true},
+	{Name: "Uint16", Type: "uint16", InputType: "uint16", HasInput: true, Collections: true},
+	{Name: "Uint32", Type: "uint32", InputType: "uint32", HasInput: true, Collections: true},
+	{Name: "Uint64", Type: "uint64", InputType: "uint64", HasInput: true, Collections: true},
+	{Name: "URN", Type: "URN", HasInput: true, Collections: true},
+}
+
+const header = `// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by generate/main.go; DO NOT EDIT.
+
+// nolint: lll
+package pulumi
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/pulumi/pulumi/sdk/go/pulumi/asset"
+)
+`
+
+var builtinTemplate = template.Must(template.New("builtin").Parse(`
+var {{.Lower}}Type = reflect.TypeOf((*{{.Type}})(nil)).Elem()
+{{if .HasInput}}
+// {{.Name}}Input is an input type that accepts {{.Name}} and {{.Name}}Output values.
+type {{.Name}}Input interface {
+	Input
+
+	To{{.Name}}Output() {{.Name}}Output
+}
+{{if .InputType}}
+// {{.Name}} is an input type for {{.Type}} values.
+type {{.Name}} {{.InputType}}
+{{end}}
+// ElementType returns the element type of this Input ({{.Type}}).
+func ({{.Name}}) ElementType() reflect.Type {
+	return {{.Lower}}Type
+}
+
+// To{{.Name}}Output converts this input to a {{.Name}}Output.
+func (in {{.Name}}) To{{.Name}}Output() {{.Name}}Output {
+	return {{.Name}}Output(ToOutput(in))
+}
+{{end}}
+// {{.Name}}Output is an Output that is typed to return {{.Type}} values.
+type {{.Name}}Output Output
+
+// ElementType returns the element type of this Output ({{.Type}}).
+func ({{.Name}}Output) ElementType() reflect.Type {
+	return {{.Lower}}Type
+}
+
+// To{{.Name}}Output returns this output.
+func (out {{.Name}}Output) To{{.Name}}Output() {{.Name}}Output {
+	return out
+}
+
+// Apply applies a transformation to the {{.Type}} value when it is available.
+func (out {{.Name}}Output) Apply(applier func({{.Type}}) (interface{}, error)) Output {
+	return out.ApplyWithContext(context.Background(), func(_ context.Context, v {{.Type}}) (interface{}, error) {
+		return applier(v)
+	})
+}
+
+// ApplyWithContext applies a transformation to the {{.Type}} value when it is available.
+func (out {{.Name}}Output) ApplyWithContext(ctx context.Context, applier func(context.Context, {{.Type}}) (interface{}, error)) Output {
+	return Output(out).ApplyWithContext(ctx, func(ctx context.Context, v interface{}) (interface{}, error) {
+		return applier(ctx, convert(v, {{.Lower}}Type).({{.Type}}))
+	})
+}
+
+// ApplyT applies a transformation to the {{.Type}} value when it is available. The applier must be a function that
+// accepts a {{.Type}} and returns a single value, optionally followed by an error. See Output.ApplyT for details.
+func (out {{.Name}}Output) ApplyT(applier interface{}) Output {
+	return out.ApplyTWithContext(context.Background(), applier)
+}
+
+// ApplyTWithContext applies a transformation to the {{.Type}} value when it is available. See Output.ApplyTWithContext
+// for details.
+func (out {{.Name}}Output) ApplyTWithContext(ctx context.Context, applier interface{}) Output {
+	return applyT(ctx, Output(out), {{.Lower}}Type, applier)
+}
+`))
+
+var collectionTemplate = template.Must(template.New("collection").Parse(`
+var {{.Lower}}Type = reflect.TypeOf((*{{.Type}})(nil)).Elem()
+
+// {{.Name}}Input is an input type that accepts {{.Name}} and {{.Name}}Output values.
+type {{.Name}}Input interface {
+	Input
+
+	To{{.Name}}Output() {{.Name}}Output
+}
+
+// {{.Name}} is an input type for {{.Type}} values.
+type {{.Name}} {{.Input}}
+
+// ElementType returns the element type of this Input ({{.Type}}).
+func ({{.Name}}) ElementType() reflect.Type {
+	return {{.Lower}}Type
+}
+
+// To{{.Name}}Output converts this input to a {{.Name}}Output.
+func (in {{.Name}}) To{{.Name}}Output() {{.Name}}Output {
+	return {{.Name}}Output(ToOutput(in))
+}
+
+// {{.Name}}Output is an Output that is typed to return {{.Type}} values.
+type {{.Name}}Output Output
+
+// ElementType returns the element type of this Output ({{.Type}}).
+func ({{.Name}}Output) ElementType() reflect.Type {
+	return {{.Lower}}Type
+}
+
+// To{{.Name}}Output returns this output.
+func (out {{.Name}}Output) To{{.Name}}Output() {{.Name}}Output {
+	return out
+}
+
+// Apply applies a transformation to the {{.Type}} value when it is available.
+func (out {{.Name}}Output) Apply(applier func({{.Type}}) (interface{}, error)) Output {
+	return out.ApplyWithContext(context.Background(), func(_ context.Context, v {{.Type}}) (interface{}, error) {
+		return applier(v)
+	})
+}
+
+// ApplyWithContext applies a transformation to the {{.Type}} value when it is available.
+func (out {{.Name}}Output) ApplyWithContext(ctx context.Context, applier func(context.Context, {{.Type}}) (interface{}, error)) Output {
+	return Output(out).ApplyWithContext(ctx, func(ctx context.Context, v interface{}) (interface{}, error) {
+		return applier(ctx, convert(v, {{.Lower}}Type).({{.Type}}))
+	})
+}
+
+// ApplyT applies a transformation to the {{.Type}} value when it is available. The applier must be a function that
+// accepts a {{.Type}} and returns a single value, optionally followed by an error. See Output.ApplyT for details.
+func (out {{.Name}}Output) ApplyT(applier interface{}) Output {
+	return out.ApplyTWithContext(context.Background(), applier)
+}
+
+// ApplyTWithContext applies a transformation to the {{.Type}} value when it is available. See Output.ApplyTWithContext
+// for details.
+func (out {{.Name}}Output) ApplyTWithContext(ctx context.Context, applier interface{}) Output {
+	return applyT(ctx, Output(out), {{.Lower}}Type, applier)
+}
+{{if .IsArray}}
+// Index returns an output that resolves to the element of the array at the given index. If the index is out of
+// range, the output resolves to the zero value.
+func (out {{.Name}}Output) Index(i IntInput) {{.Elem.Name}}Output {
+	return {{.Elem.Name}}Output(All(out, i).ApplyT(func(vs []interface{}) {{.Elem.Type}} {
+		arr, idx := convert(vs[0], {{.Lower}}Type).({{.Type}}), convert(vs[1], intType).(int)
+		if idx < 0 || idx >= len(arr) {
+			var zero {{.Elem.Type}}
+			return zero
+		}
+		return arr[idx]
+	}))
+}
+{{else}}
+// MapIndex returns an output that resolves to the element of the map with the given key. If the key is not present,
+// the output resolves to the zero value.
+func (out {{.Name}}Output) MapIndex(k StringInput) {{.Elem.Name}}Output {
+	return {{.Elem.Name}}Output(All(out, k).ApplyT(func(vs []interface{}) {{.Elem.Type}} {
+		m, key := convert(vs[0], {{.Lower}}Type).({{.Type}}), convert(vs[1], stringType).(string)
+		return m[key]
+	}))
+}
+{{end}}`))
+
+func main() {
+	var buf bytes.Buffer
+	buf.WriteString(header)
+
+	for _, b := range builtins {
+		if err := builtinTemplate.Execute(&buf, b); err != nil {
+			fail(err)
+		}
+	}
+	for _, b := range builtins {
+		if !b.Collections {
+			continue
+		}
+		for _, c := range []collection{
+			{Name: b.Name + "Array", Type: "[]" + b.Type, Input: "[]" + b.Name + "Input", Elem: b, IsArray: true},
+			{Name: b.Name + "Map", Type: "map[string]" + b.Type, Input: "map[string]" + b.Name + "Input", Elem: b},
+		} {
+			if err := collectionTemplate.Execute(&buf, c); err != nil {
+				fail(err)
+			}
+		}
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		fail(err)
+	}
+	if err = ioutil.WriteFile("types_builtins.go", src, 0600); err != nil {
+		fail(err)
+	}
+}
+
+func fail(err error) {
+	fmt.Fprintf(os.Stderr, "error: %v\n", err)
+	os.Exit(1)
+}
@@ -21,7 +21,6 @@ import (
 	"sync"
 
 	"github.com/pkg/errors"
-	"github.com/pulumi/pulumi/sdk/go/pulumi/asset"
 )
 
 const (
@@ -45,9 +44,10 @@ type outputState struct {
 
 	state uint32 // one of output{Pending,Resolved,Rejected}
 
-	value interface{} // the value of this output if it is resolved.
-	err   error       // the error associated with this output if it is rejected.
-	known bool        // true if this output's value is known.
+	value  interface{} // the value of this output if it is resolved.
+	err    error       // the error associated with this output if it is rejected.
+	known  bool        // true if this output's value is known.
+	secret bool        // true if this output's value is secret.
 
 	deps []Resource // the dependencies associated with this output property.
 }
@@ -60,6 +60,10 @@ func (o *outputState) dependencies() []Resource {
 }
 
 func (o *outputState) fulfill(value interface{}, known bool, err error) {
+	o.fulfillValue(value, known, false, err)
+}
+
+func (o *outputState) fulfillValue(value interface{}, known, secret bool, err error) {
 	if o == nil {
 		return
 	}
@@ -77,7 +81,7 @@ func (o *outputState) fulfill(value interface{}, known bool, err error) {
 	if err != nil {
 		o.state, o.err, o.known = outputRejected, err, true
 	} else {
-		o.state, o.value, o.known = outputResolved, value, known
+		o.state, o.value, o.known, o.secret = outputResolved, value, known, secret
 	}
 }
 
@@ -90,28 +94,37 @@ func (o *outputState) reject(err error) {
 }
 
 func (o *outputState) await(ctx context.Context) (interface{}, bool, error) {
+	value, known, _, err := o.awaitValue(ctx)
+	return value, known, err
+}
+
+// awaitValue awaits the value of this output, following any outputs that it resolves to. The value is secret if this
+// output or any of the outputs that it resolves to is secret.
+func (o *outputState) awaitValue(ctx context.Context) (interface{}, bool, bool, error) {
+	secret := false
 	for {
 		if o == nil {
 			// If the state is nil, treat its value as resolved and unknown.
-			return nil, false, nil
+			return nil, false, secret, nil
 		}
 
 		o.mutex.Lock()
 		for o.state == outputPending {
 			if ctx.Err() != nil {
-				return nil, true, ctx.Err()
+				return nil, true, secret, ctx.Err()
 			}
 			o.cond.Wait()
 		}
 		o.mutex.Unlock()
 
+		secret = secret || o.secret
 		if !o.known || o.err != nil {
-			return nil, o.known, o.err
+			return nil, o.known, secret, o.err
 		}
 
 		ov, ok := isOutput(o.value)
 		if !ok {
-			return o.value, true, nil
+			return o.value, true, secret, nil
 		}
 		o = ov.s
 	}
@@ -156,6 +169,18 @@ func NewOutput() (Output, func(interface{}), func(error)) {
 	return out, resolve, reject
 }
 
+// ToSecret returns an Output that resolves to the same value as the given input, but whose value is marked as a secret.
+// Secret values are encrypted when they are stored in a stack's state.
+func ToSecret(v interface{}) Output {
+	out := ToOutput(v)
+	result := newOutput(out.s.deps...)
+	go func() {
+		value, known, _, err := out.s.awaitValue(context.Background())
+		result.s.fulfillValue(value, known, true, err)
+	}()
+	return result
+}
+
 // ApplyWithContext transforms the data of the output property using the applier func. The result remains an output
 // property, and accumulates all implicated dependencies, so that resources can be properly tracked using a DAG.
 // This function does not block awaiting the value; instead, it spawns a Goroutine that will await its availability.
@@ -174,9 +199,9 @@ func (out Output) ApplyWithContext(ctx context.Context,
 
 	result := newOutput(out.s.deps...)
 	go func() {
-		v, known, err := out.s.await(ctx)
+		v, known, secret, err := out.s.awaitValue(ctx)
 		if err != nil || !known {
-			result.s.fulfill(nil, known, err)
+			result.s.fulfillValue(nil, known, secret, err)
 			return
 		}
 
@@ -187,8 +212,8 @@ func (out Output) ApplyWithContext(ctx context.Context,
 			return
 		}
 
-		// Fulfill the result.
-		result.s.fulfill(u, true, nil)
+		// Fulfill the result. The result of applying a function to a secret is itself a secret.
+		result.s.fulfillValue(u, true, secret, nil)
 	}()
 	return result
 }
@@ -196,402 +221,27 @@ func (out Output) ApplyWithContext(ctx context.Context,
 // Outputs is a map of property name to value, one for each resource output property.
 type Outputs map[string]Output
 
-// ArchiveOutput is an Output that is typed to return archive values.
-type ArchiveOutput Output
-
-var archiveType = reflect.TypeOf((*asset.Archive)(nil)).Elem()
-
-// Apply applies a transformation to the archive value when it is available.
-func (out ArchiveOutput) Apply(applier func(asset.Archive) (interface{}, error)) Output {
-	return out.ApplyWithContext(context.Background(), func(_ context.Context, v asset.Archive) (interface{}, error) {
-		return applier(v)
-	})
-}
-
-// ApplyWithContext applies a transformation to the archive value when it is available.
-func (out ArchiveOutput) ApplyWithContext(ctx context.Context, applier func(context.Context, asset.Archive) (interface{}, error)) Output {
-	return Output(out).ApplyWithContext(ctx, func(ctx context.Context, v interface{}) (interface{}, error) {
-		return applier(ctx, convert(v, archiveType).(asset.Archive))
-	})
-}
-
-// ArrayOutput is an Output that is typed to return arrays of values.
-type ArrayOutput Output
-
-var arrayType = reflect.TypeOf((*[]interface{})(nil)).Elem()
-
-// Apply applies a transformation to the archive value when it is available.
-func (out ArrayOutput) Apply(applier func([]interface{}) (interface{}, error)) Output {
-	return out.ApplyWithContext(context.Background(), func(_ context.Context, v []interface{}) (interface{}, error) {
-		return applier(v)
-	})
-}
-
-// ApplyWithContext applies a transformation to the archive value when it is available.
-func (out ArrayOutput) ApplyWithContext(ctx context.Context, applier func(context.Context, []interface{}) (interface{}, error)) Output {
-	return Output(out).ApplyWithContext(ctx, func(ctx context.Context, v interface{}) (interface{}, error) {
-		return applier(ctx, convert(v, arrayType).([]interface{}))
-	})
-}
-
-// AssetOutput is an Output that is typed to return asset values.
-type AssetOutput Output
-
-var assetType = reflect.TypeOf((*asset.Asset)(nil)).Elem()
-
-// Apply applies a transformation to the archive value when it is available.
-func (out AssetOutput) Apply(applier func(asset.Asset) (interface{}, error)) Output {
-	return out.ApplyWithContext(context.Background(), func(_ context.Context, v asset.Asset) (interface{}, error) {
-		return applier(v)
-	})
-}
-
-// ApplyWithContext applies a transformation to the archive value when it is available.
-func (out AssetOutput) ApplyWithContext(ctx context.Context, applier func(context.Context, asset.Asset) (interface{}, error)) Output {
-	return Output(out).ApplyWithContext(ctx, func(ctx context.Context, v interface{}) (interface{}, error) {
-		return applier(ctx, convert(v, assetType).(asset.Asset))
-	})
-}
-
-// BoolOutput is an Output that is typed to return bool values.
-type BoolOutput Output
-
-var boolType = reflect.TypeOf(false)
-
-// Apply applies a transformation to the archive value when it is available.
-func (out BoolOutput) Apply(applier func(bool) (interface{}, error)) Output {
-	return out.ApplyWithContext(context.Background(), func(_ context.Context, v bool) (interface{}, error) {
-		return applier(v)
-	})
-}
-
-// ApplyWithContext applies a transformation to the archive value when it is available.
-func (out BoolOutput) ApplyWithContext(ctx context.Context, applier func(context.Context, bool) (interface{}, error)) Output {
-	return Output(out).ApplyWithContext(ctx, func(ctx context.Context, v interface{}) (interface{}, error) {
-		return applier(ctx, convert(v, boolType).(bool))
-	})
-}
-
-// Float32Output is an Output that is typed to return float32 values.
-type Float32Output Output
-
-var float32Type = reflect.TypeOf(float32(0))
-
-// Apply applies a transformation to the archive value when it is available.
-func (out Float32Output) Apply(applier func(float32) (interface{}, error)) Output {
-	return out.ApplyWithContext(context.Background(), func(_ context.Context, v float32) (interface{}, error) {
-		return applier(v)
-	})
-}
-
-// ApplyWithContext applies a transformation to the archive value when it is available.
-func (out Float32Output) ApplyWithContext(ctx context.Context, applier func(context.Context, float32) (interface{}, error)) Output {
-	return Output(out).ApplyWithContext(ctx, func(ctx context.Context, v interface{}) (interface{}, error) {
-		return applier(ctx, convert(v, float32Type).(float32))
-	})
-}
-
-// Float64Output is an Output that is typed to return float64 values.
-type Float64Output Output
-
-var float64Type = reflect.TypeOf(float64(0))
-
-// Apply applies a transformation to the archive value when it is available.
-func (out Float64Output) Apply(applier func(float64) (interface{}, error)) Output {
-	return out.ApplyWithContext(context.Background(), func(_ context.Context, v float64) (interface{}, error) {
-		return applier(v)
-	})
-}
-
-// ApplyWithContext applies a transformation to the archive value when it is available.
-func (out Float64Output) ApplyWithContext(ctx context.Context, applier func(context.Context, float64) (interface{}, error)) Output {
-	return Output(out).ApplyWithContext(ctx, func(ctx context.Context, v interface{}) (interface{}, error) {
-		return applier(ctx, convert(v, float64Type).(float64))
-	})
-}
-
-// IDOutput is an Output that is typed to return ID values.
-type IDOutput Output
-
-var stringType = reflect.TypeOf("")
-
 func (out IDOutput) await(ctx context.Context) (ID, bool, error) {
 	id, known, err := out.s.await(ctx)
 	if !known || err != nil {
 		return "", known, err
 	}
-	return ID(convert(id, stringType).(string)), true, nil
+	return convert(id, idType).(ID), true, nil
 }
 
-// Apply applies a transformation to the archive value when it is available.
-func (out IDOutput) Apply(applier func(ID) (interface{}, error)) Output {
-	return out.ApplyWithContext(context.Background(), func(_ context.Context, v ID) (interface{}, error) {
-		return applier(v)
-	})
-}
-
-// ApplyWithContext applies a transformation to the archive value when it is available.
-func (out IDOutput) ApplyWithContext(ctx context.Context, applier func(context.Context, ID) (interface{}, error)) Output {
-	return Output(out).ApplyWithContext(ctx, func(ctx context.Context, v interface{}) (interface{}, error) {
-		return applier(ctx, ID(convert(v, stringType).(string)))
-	})
-}
-
-// IntOutput is an Output that is typed to return int values.
-type IntOutput Output
-
-var intType = reflect.TypeOf(int(0))
-
-// Apply applies a transformation to the archive value when it is available.
-func (out IntOutput) Apply(applier func(int) (interface{}, error)) Output {
-	return out.ApplyWithContext(context.Background(), func(_ context.Context, v int) (interface{}, error) {
-		return applier(v)
-	})
-}
-
-// ApplyWithContext applies a transformation to the archive value when it is available.
-func (out IntOutput) ApplyWithContext(ctx context.Context, applier func(context.Context, int) (interface{}, error)) Output {
-	return Output(out).ApplyWithContext(ctx, func(ctx context.Context, v interface{}) (interface{}, error) {
-		return applier(ctx, convert(v, intType).(int))
-	})
-}
-
-// Int8Output is an Output that is typed to return int8 values.
-type Int8Output Output
-
-var int8Type = reflect.TypeOf(int8(0))
-
-// Apply applies a transformation to the archive value when it is available.
-func (out Int8Output) Apply(applier func(int8) (interface{}, error)) Output {
-	return out.ApplyWithContext(context.Background(), func(_ context.Context, v int8) (interface{}, error) {
-		return applier(v)
-	})
-}
-
-// ApplyWithContext applies a transformation to the archive value when it is available.
-func (out Int8Output) ApplyWithContext(ctx context.Context, applier func(context.Context, int8) (interface{}, error)) Output {
-	return Output(out).ApplyWithContext(ctx, func(ctx context.Context, v interface{}) (interface{}, error) {
-		return applier(ctx, convert(v, int8Type).(int8))
-	})
-}
-
-// Int16Output is an Output that is typed to return int16 values.
-type Int16Output Output
-
-var int16Type = reflect.TypeOf(int16(0))
-
-// Apply applies a transformation to the archive value when it is available.
-func (out Int16Output) Apply(applier func(int16) (interface{}, error)) Output {
-	return out.ApplyWithContext(context.Background(), func(_ context.Context, v int16) (interface{}, error) {
-		return applier(v)
-	})
-}
-
-// ApplyWithContext applies a transformation to the archive value when it is available.
-func (out Int16Output) ApplyWithContext(ctx context.Context, applier func(context.Context, int16) (interface{}, error)) Output {
-	return Output(out).ApplyWithContext(ctx, func(ctx context.Context, v interface{}) (interface{}, error) {
-		return applier(ctx, convert(v, int16Type).(int16))
-	})
-}
-
-// Int32Output is an Output that is typed to return int32 values.
-type Int32Output Output
-
-var int32Type = reflect.TypeOf(int32(0))
-
-// Apply applies a transformation to the archive value when it is available.
-func (out Int32Output) Apply(applier func(int32) (interface{}, error)) Output {
-	return out.ApplyWithContext(context.Background(), func(_ context.Context, v int32) (interface{}, error) {
-		return applier(v)
-	})
-}
-
-// ApplyWithContext applies a transformation to the archive value when it is available.
-func (out Int32Output) ApplyWithContext(ctx context.Context, applier func(context.Context, int32) (interface{}, error)) Output {
-	return Output(out).ApplyWithContext(ctx, func(ctx context.Context, v interface{}) (interface{}, error) {
-		return applier(ctx, convert(v, int32Type).(int32))
-	})
-}
-
-// Int64Output is an Output that is typed to return int64 values.
-type Int64Output Output
-
-var int64Type = reflect.TypeOf(int64(0))
-
-// Apply applies a transformation to the archive value when it is available.
-func (out Int64Output) Apply(applier func(int64) (interface{}, error)) Output {
-	return out.ApplyWithContext(context.Background(), func(_ context.Context, v int64) (interface{}, error) {
-		return applier(v)
-	})
-}
-
-// ApplyWithContext applies a transformation to the archive value when it is available.
-func (out Int64Output) ApplyWithContext(ctx context.Context, applier func(context.Context, int64) (interface{}, error)) Output {
-	return Output(out).ApplyWithContext(ctx, func(ctx context.Context, v interface{}) (interface{}, error) {
-		return applier(ctx, convert(v, int64Type).(int64))
-	})
-}
-
-// MapOutput is an Output that is typed to return map values.
-type MapOutput Output
-
-var mapType = reflect.TypeOf(map[string]interface{}{})
-
-// Apply applies a transformation to the number value when it is available.
-func (out MapOutput) Apply(applier func(map[string]interface{}) (interface{}, error)) Output {
-	return out.ApplyWithContext(context.Background(), func(_ context.Context, v map[string]interface{}) (interface{}, error) {
-		return applier(v)
-	})
-}
-
-// ApplyWithContext applies a transformation to the number value when it is available.
-func (out MapOutput) ApplyWithContext(ctx context.Context, applier func(context.Context, map[string]interface{}) (interface{}, error)) Output {
-	return Output(out).ApplyWithContext(ctx, func(ctx context.Context, v interface{}) (interface{}, error) {
-		return applier(ctx, convert(v, mapType).(map[string]interface{}))
-	})
-}
-
-// StringOutput is an Output that is typed to return number values.
-type StringOutput Output
-
-// Apply applies a transformation to the archive value when it is available.
-func (out StringOutput) Apply(applier func(string) (interface{}, error)) Output {
-	return out.ApplyWithContext(context.Background(), func(_ context.Context, v string) (interface{}, error) {
-		return applier(v)
-	})
-}
-
-// ApplyWithContext applies a transformation to the archive value when it is available.
-func (out StringOutput) ApplyWithContext(ctx context.Context, applier func(context.Context, string) (interface{}, error)) Output {
-	return Output(out).ApplyWithContext(ctx, func(ctx context.Context, v interface{}) (interface{}, error) {
-		return applier(ctx, convert(v, stringType).(string))
-	})
-}
-
-// UintOutput is an Output that is typed to return uint values.
-type UintOutput Output
-
-var uintType = reflect.TypeOf(uint(0))
-
-// Apply applies a transformation to the archive value when it is available.
-func (out UintOutput) Apply(applier func(uint) (interface{}, error)) Output {
-	return out.ApplyWithContext(context.Background(), func(_ context.Context, v uint) (interface{}, error) {
-		return applier(v)
-	})
-}
-
-// ApplyWithContext applies a transformation to the archive value when it is available.
-func (out UintOutput) ApplyWithContext(ctx context.Context, applier func(context.Context, uint) (interface{}, error)) Output {
-	return Output(out).ApplyWithContext(ctx, func(ctx context.Context, v interface{}) (interface{}, error) {
-		return applier(ctx, convert(v, uintType).(uint))
-	})
-}
-
-// Uint8Output is an Output that is typed to return uint8 values.
-type Uint8Output Output
-
-var uint8Type = reflect.TypeOf(uint8(0))
-
-// Apply applies a transformation to the archive value when it is available.
-func (out Uint8Output) Apply(applier func(uint8) (interface{}, error)) Output {
-	return out.ApplyWithContext(context.Background(), func(_ context.Context, v uint8) (interface{}, error) {
-		return applier(v)
-	})
-}
-
-// ApplyWithContext applies a transformation to the archive value when it is available.
-func (out Uint8Output) ApplyWithContext(ctx context.Context, applier func(context.Context, uint8) (interface{}, error)) Output {
-	return Output(out).ApplyWithContext(ctx, func(ctx context.Context, v interface{}) (interface{}, error) {
-		return applier(ctx, convert(v, uint8Type).(uint8))
-	})
-}
-
-// Uint16Output is an Output that is typed to return uint16 values.
-type Uint16Output Output
-
-var uint16Type = reflect.TypeOf(uint16(0))
-
-// Apply applies a transformation to the archive value when it is available.
-func (out Uint16Output) Apply(applier func(uint16) (interface{}, error)) Output {
-	return out.ApplyWithContext(context.Background(), func(_ context.Context, v uint16) (interface{}, error) {
-		return applier(v)
-	})
-}
-
-// ApplyWithContext applies a transformation to the archive value when it is available.
-func (out Uint16Output) ApplyWithContext(ctx context.Context, applier func(context.Context, uint16) (interface{}, error)) Output {
-	return Output(out).ApplyWithContext(ctx, func(ctx context.Context, v interface{}) (interface{}, error) {
-		return applier(ctx, convert(v, uint16Type).(uint16))
-	})
-}
-
-// Uint32Output is an Output that is typed to return uint32 values.
-type Uint32Output Output
-
-var uint32Type = reflect.TypeOf(uint32(0))
-
-// Apply applies a transformation to the archive value when it is available.
-func (out Uint32Output) Apply(applier func(uint32) (interface{}, error)) Output {
-	return out.ApplyWithContext(context.Background(), func(_ context.Context, v uint32) (interface{}, error) {
-		return applier(v)
-	})
-}
-
-// ApplyWithContext applies a transformation to the archive value when it is available.
-func (out Uint32Output) ApplyWithContext(ctx context.Context, applier func(context.Context, uint32) (interface{}, error)) Output {
-	return Output(out).ApplyWithContext(ctx, func(ctx context.Context, v interface{}) (interface{}, error) {
-		return applier(ctx, convert(v, uint32Type).(uint32))
-	})
-}
-
-// Uint64Output is an Output that is typed to return uint64 values.
-type Uint64Output Output
-
-var uint64Type = reflect.TypeOf(uint64(0))
-
-// Apply applies a transformation to the archive value when it is available.
-func (out Uint64Output) Apply(applier func(uint64) (interface{}, error)) Output {
-	return out.ApplyWithContext(context.Background(), func(_ context.Context, v uint64) (interface{}, error) {
-		return applier(v)
-	})
-}
-
-// ApplyWithContext applies a transformation to the archive value when it is available.
-func (out Uint64Output) ApplyWithContext(ctx context.Context, applier func(context.Context, uint64) (interface{}, error)) Output {
-	return Output(out).ApplyWithContext(ctx, func(ctx context.Context, v interface{}) (interface{}, error) {
-		return applier(ctx, convert(v, uint64Type).(uint64))
-	})
-}
-
-// URNOutput is an Output that is typed to return URN values.
-type URNOutput Output
-
 func (out URNOutput) await(ctx context.Context) (URN, bool, error) {
 	urn, known, err := out.s.await(ctx)
 	if !known || err != nil {
 		return "", known, err
 	}
-	return URN(convert(urn, stringType).(string)), true, nil
-}
-
-// Apply applies a transformation to the archive value when it is available.
-func (out URNOutput) Apply(applier func(URN) (interface{}, error)) Output {
-	return out.ApplyWithContext(context.Background(), func(_ context.Context, v URN) (interface{}, error) {
-		return applier(v)
-	})
-}
-
-// ApplyWithContext applies a transformation to the archive value when it is available.
-func (out URNOutput) ApplyWithContext(ctx context.Context, applier func(context.Context, URN) (interface{}, error)) Output {
-	return Output(out).ApplyWithContext(ctx, func(ctx context.Context, v interface{}) (interface{}, error) {
-		return applier(ctx, URN(convert(v, stringType).(string)))
-	})
+	return convert(urn, urnType).(URN), true, nil
 }
 
+// convert converts the given output value to the given type, panicking if the value cannot be converted.
 func convert(v interface{}, to reflect.Type) interface{} {
-	rv := reflect.ValueOf(v)
-	if !rv.Type().ConvertibleTo(to) {
-		panic(errors.Errorf("cannot convert output value of type %s to %s", rv.Type(), to))
+	rv, err := convertValue(reflect.ValueOf(v), to)
+	if err != nil {
+		panic(errors.Wrap(err, "converting output value"))
 	}
-	return rv.Convert(to).Interface()
+	return rv.Interface()
 }
@@ -268,3 +268,44 @@ func TestOutputApply(t *testing.T) {
 		assert.Nil(t, v)
 	}
 }
+
+func TestSecretOutputs(t *testing.T) {
+	secret := ToSecret(String("shh"))
+	v, known, isSecret, err := secret.s.awaitValue(context.Background())
+	assert.NoError(t, err)
+	assert.True(t, known)
+	assert.True(t, isSecret)
+	assert.Equal(t, "shh", v)
+
+	// Secretness flows through applies and into outputs that contain secrets.
+	applied := secret.ApplyT(func(v string) string { return v + "!" })
+	_, _, isSecret, err = applied.s.awaitValue(context.Background())
+	assert.NoError(t, err)
+	assert.True(t, isSecret)
+
+	all := All(String("a"), StringOutput(secret))
+	_, _, isSecret, err = all.s.awaitValue(context.Background())
+	assert.NoError(t, err)
+	assert.True(t, isSecret)
+
+	_, _, isSecret, err = ToOutput(String("a")).s.awaitValue(context.Background())
+	assert.NoError(t, err)
+	assert.False(t, isSecret)
+
+	// Secrets are marshaled as secret values, and secret outputs are marked as such when resources are resolved.
+	m, _, _, err := marshalInputs(map[string]interface{}{"a": applied, "b": "plain"}, true)
+	assert.NoError(t, err)
+	secrets, err := unmarshalSecrets(m)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]bool{"a": true}, secrets)
+
+	state := makeResourceState(true, map[string]interface{}{"a": nil, "b": nil})
+	state.resolve(false, nil, nil, "urn", "id", m)
+	v, _, isSecret, err = state.State["a"].s.awaitValue(context.Background())
+	assert.NoError(t, err)
+	assert.True(t, isSecret)
+	assert.Equal(t, "shh!", v)
+	_, _, isSecret, err = state.State["b"].s.awaitValue(context.Background())
+	assert.NoError(t, err)
+	assert.False(t, isSecret)
+}
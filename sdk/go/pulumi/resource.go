@@ -73,6 +73,63 @@ type ResourceOpt struct {
 	CustomTimeouts *CustomTimeouts
 	// Ignore changes to any of the specified properties.
 	IgnoreChanges []string
+	// Aliases is an optional list of identifiers used to find and use existing resources. Each alias describes a
+	// name, type, parent, stack, or project that this resource previously had; any fields that are left unset
+	// default to the values of this resource.
+	Aliases []Alias
+	// Transformations is an optional list of transformations to apply to this resource and to all of its children.
+	// Transformations are applied in order, and before the transformations of any of the resource's ancestors.
+	Transformations []ResourceTransformation
+}
+
+// Alias is a partial description of a prior name used for a resource. Any fields that are left unset default to the
+// corresponding values of the resource being aliased.
+type Alias struct {
+	// URN is the previous URN of the resource. If URN is set, all of the other fields are ignored.
+	URN URN
+	// Name is the previous name of the resource.
+	Name string
+	// Type is the previous type of the resource.
+	Type string
+	// Parent is the previous parent of the resource.
+	Parent Resource
+	// ParentURN is the URN of the previous parent of the resource. It is ignored if Parent is set.
+	ParentURN URN
+	// NoParent, when set to true, indicates that the resource previously had no parent.
+	NoParent bool
+	// Stack is the name of the previous stack of the resource.
+	Stack string
+	// Project is the name of the previous project of the resource.
+	Project string
+}
+
+// ResourceTransformation is a callback that can be used to modify the type, properties, and options of a resource
+// before it is registered. A transformation returns nil to leave the resource unchanged.
+type ResourceTransformation func(args *ResourceTransformationArgs) *ResourceTransformationResult
+
+// ResourceTransformationArgs are the arguments passed to a ResourceTransformation.
+type ResourceTransformationArgs struct {
+	// Resource is the resource that is being transformed.
+	Resource Resource
+	// Type is the type token of the resource.
+	Type string
+	// Name is the name of the resource.
+	Name string
+	// Props are the original properties of the resource.
+	Props map[string]interface{}
+	// Opts are the original options of the resource.
+	Opts ResourceOpt
+}
+
+// ResourceTransformationResult is the result of a ResourceTransformation. The result replaces the type, properties,
+// and options of the resource being transformed; note that the resource's parent may not be changed.
+type ResourceTransformationResult struct {
+	// Type is the new type token of the resource. If empty, the resource's type is left unchanged.
+	Type string
+	// Props are the new properties of the resource.
+	Props map[string]interface{}
+	// Opts are the new options of the resource.
+	Opts ResourceOpt
 }
 
 // InvokeOpt contains optional settings that control an invoke's behavior.
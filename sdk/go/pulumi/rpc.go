@@ -64,6 +64,52 @@ func marshalInputs(props map[string]interface{},
 	return m, pdeps, depURNs, err
 }
 
+// marshalArgs turns a resource's arguments into a map of resource property inputs. args may be nil, a map with string
+// keys, or a struct (or pointer to a struct) whose exported fields are tagged with `pulumi:"name"`. Struct fields
+// that are nil are omitted.
+func marshalArgs(args interface{}) (map[string]interface{}, error) {
+	props := make(map[string]interface{})
+	if args == nil {
+		return props, nil
+	}
+
+	rv := reflect.ValueOf(args)
+	if rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return props, nil
+		}
+		rv = rv.Elem()
+	}
+
+	switch rv.Kind() {
+	case reflect.Map:
+		if rv.Type().Key().Kind() != reflect.String {
+			return nil, errors.Errorf("expected args map keys to be strings; got %v", rv.Type().Key())
+		}
+		for _, k := range rv.MapKeys() {
+			props[k.String()] = rv.MapIndex(k).Interface()
+		}
+	case reflect.Struct:
+		for i := 0; i < rv.NumField(); i++ {
+			name, ok := propertyName(rv.Type().Field(i))
+			if !ok {
+				continue
+			}
+			field := rv.Field(i)
+			switch field.Kind() {
+			case reflect.Interface, reflect.Ptr, reflect.Map, reflect.Slice:
+				if field.IsNil() {
+					continue
+				}
+			}
+			props[name] = field.Interface()
+		}
+	default:
+		return nil, errors.Errorf("args must be a map or a struct, not %T", args)
+	}
+	return props, nil
+}
+
 // `gosec` thinks these are credentials, but they are not.
 // nolint: gosec
 const (
@@ -204,17 +250,23 @@ func marshalInput(v interface{}) (interface{}, []Resource, error) {
 
 func marshalInputOutput(out Output) (interface{}, []Resource, error) {
 	// Await the value and return its raw value.
-	ov, known, err := out.s.await(context.TODO())
+	ov, known, secret, err := out.s.awaitValue(context.TODO())
 	if err != nil {
 		return nil, nil, err
 	}
 
-	// If the value is known, marshal it.
+	// If the value is known, marshal it. Secret values are wrapped so that the engine knows to encrypt them.
 	if known {
 		e, d, merr := marshalInput(ov)
 		if merr != nil {
 			return nil, nil, merr
 		}
+		if secret {
+			e = map[string]interface{}{
+				rpcTokenSpecialSigKey: rpcTokenSpecialSecretSig,
+				"value":               e,
+			}
+		}
 		return e, append(out.s.dependencies(), d...), nil
 	}
 
@@ -239,6 +291,22 @@ func unmarshalOutputs(outs *structpb.Struct) (map[string]interface{}, error) {
 	return result, nil
 }
 
+// unmarshalSecrets returns the set of outputs that contain secret values.
+func unmarshalSecrets(outs *structpb.Struct) (map[string]bool, error) {
+	outprops, err := plugin.UnmarshalProperties(outs, plugin.MarshalOptions{KeepSecrets: true})
+	if err != nil {
+		return nil, err
+	}
+
+	secrets := make(map[string]bool)
+	for k, v := range outprops {
+		if v.ContainsSecrets() {
+			secrets[string(k)] = true
+		}
+	}
+	return secrets, nil
+}
+
 // unmarshalOutput unmarshals a single output variable into its runtime representation.  For the most part, this just
 // returns the raw value.  In a small number of cases, we need to change a type.
 func unmarshalOutput(v interface{}) (interface{}, error) {
@@ -309,7 +377,7 @@ func unmarshalOutput(v interface{}) (interface{}, error) {
 				return nil, errors.Errorf("expected map keys to be strings; got %v", reflect.TypeOf(key.Interface()))
 			}
 			value := rv.MapIndex(key)
-			mv, err := unmarshalOutput(value)
+			mv, err := unmarshalOutput(value.Interface())
 			if err != nil {
 				return nil, err
 			}
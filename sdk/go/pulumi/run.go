@@ -24,7 +24,9 @@ import (
 	"github.com/pkg/errors"
 	"golang.org/x/net/context"
 
+	"github.com/pulumi/pulumi/pkg/resource/config"
 	"github.com/pulumi/pulumi/pkg/util/contract"
+	"github.com/pulumi/pulumi/pkg/workspace"
 )
 
 // Run executes the body of a Pulumi program, granting it access to a deployment context that it may use
@@ -73,7 +75,7 @@ func RunWithContext(ctx *Context, body RunFunc) error {
 
 	// Create a root stack resource that we'll parent everything to.
 	reg, err := ctx.RegisterResource(
-		"pulumi:pulumi:Stack", fmt.Sprintf("%s-%s", info.Project, info.Stack), false, nil)
+		rootStackType, fmt.Sprintf("%s-%s", info.Project, info.Stack), false, nil)
 	if err != nil {
 		return err
 	}
@@ -114,6 +116,9 @@ type RunInfo struct {
 	DryRun      bool
 	MonitorAddr string
 	EngineAddr  string
+	// ConfigSchema, if non-nil, declares the project's configuration keys. Runtimes that host programs in-process
+	// supply it; programs run by a language host read it from the Pulumi.yaml in their working directory instead.
+	ConfigSchema map[config.Key]workspace.ConfigKeySchema
 }
 
 // getEnvInfo reads various program information from the process environment.
@@ -0,0 +1,120 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pulumi
+
+import (
+	"context"
+)
+
+// stackReferenceType is the type token of the builtin StackReference resource.
+const stackReferenceType = "pulumi:pulumi:StackReference"
+
+// StackReference manages a reference to a Pulumi stack. The referenced stack's outputs are available via its Outputs
+// property or its GetOutput methods.
+type StackReference struct {
+	ResourceState
+
+	// Name is the name of the referenced stack.
+	Name StringOutput `pulumi:"name"`
+	// Outputs resolves to the outputs of the referenced stack. If any of the outputs are secrets, the entire map is
+	// secret; use GetOutput to fetch individual outputs without needlessly marking them as secrets.
+	Outputs MapOutput `pulumi:"outputs"`
+	// SecretOutputNames resolves to the names of the referenced stack's outputs that contain secrets.
+	SecretOutputNames StringArrayOutput `pulumi:"secretOutputNames"`
+}
+
+// StackReferenceArgs is the set of arguments for constructing a StackReference resource.
+type StackReferenceArgs struct {
+	// Name is the name of the stack to reference. If empty, the name of the StackReference resource is used.
+	Name string
+}
+
+// NewStackReference creates a stack reference with the given unique name. The referenced stack's outputs are read
+// from the backend that manages the current stack.
+func NewStackReference(ctx *Context, name string, args *StackReferenceArgs,
+	opts ...ResourceOpt) (*StackReference, error) {
+
+	stack := name
+	if args != nil && args.Name != "" {
+		stack = args.Name
+	}
+
+	props := map[string]interface{}{
+		"name":              stack,
+		"outputs":           nil,
+		"secretOutputNames": nil,
+	}
+
+	var ref StackReference
+	if err := ctx.ReadCustomResource(stackReferenceType, name, ID(stack), props, &ref, opts...); err != nil {
+		return nil, err
+	}
+	return &ref, nil
+}
+
+// GetOutput returns an output that resolves to the value of the named stack output, or to nil if the referenced stack
+// has no such output. The result is a secret if and only if the stack output is a secret.
+func (s *StackReference) GetOutput(name StringInput) Output {
+	return s.getOutput(name, false)
+}
+
+// GetStringOutput returns an output that resolves to the value of the named stack output, which must be a string, or
+// to the empty string if the referenced stack has no such output. The result is a secret if and only if the stack
+// output is a secret.
+func (s *StackReference) GetStringOutput(name StringInput) StringOutput {
+	return StringOutput(s.GetOutput(name).ApplyT(func(v string) string { return v }))
+}
+
+// GetSecretOutput returns an output that resolves to the value of the named stack output, or to nil if the referenced
+// stack has no such output. The result is always a secret, regardless of the secretness of the stack output.
+func (s *StackReference) GetSecretOutput(name StringInput) Output {
+	return s.getOutput(name, true)
+}
+
+// getOutput implements GetOutput and GetSecretOutput. Note that the result must not simply be an application of the
+// stack's Outputs, as that map is secret if any of its values are secret.
+func (s *StackReference) getOutput(name StringInput, secret bool) Output {
+	result := newOutput(s)
+	go func() {
+		ctx := context.Background()
+
+		n, known, err := ToOutput(name).s.await(ctx)
+		if !known || err != nil {
+			result.s.fulfillValue(nil, known, secret, err)
+			return
+		}
+		outputName := convert(n, stringType).(string)
+
+		outputs, known, _, err := s.Outputs.s.awaitValue(ctx)
+		if !known || err != nil {
+			result.s.fulfillValue(nil, known, secret, err)
+			return
+		}
+		names, known, err := s.SecretOutputNames.s.await(ctx)
+		if !known || err != nil {
+			result.s.fulfillValue(nil, known, secret, err)
+			return
+		}
+
+		for _, secretName := range convert(names, stringArrayType).([]string) {
+			if secretName == outputName {
+				secret = true
+			}
+		}
+		value := convert(outputs, mapType).(map[string]interface{})[outputName]
+		result.s.fulfillValue(value, true, secret, nil)
+	}()
+	return result
+}
@@ -0,0 +1,70 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pulumi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/plugin"
+)
+
+func TestStackReferenceOutputs(t *testing.T) {
+	var ref StackReference
+	state, err := makeTypedResourceState(true, &ref)
+	assert.NoError(t, err)
+
+	// The outputs map is secret as a whole if any of its values are secret.
+	outputs, err := plugin.MarshalProperties(resource.PropertyMap{
+		"name": resource.NewStringProperty("other"),
+		"outputs": resource.NewObjectProperty(resource.PropertyMap{
+			"plain":  resource.NewStringProperty("foo"),
+			"secret": resource.MakeSecret(resource.NewStringProperty("bar")),
+			"number": resource.NewNumberProperty(42),
+		}),
+		"secretOutputNames": resource.NewArrayProperty([]resource.PropertyValue{
+			resource.NewStringProperty("secret"),
+		}),
+	}, plugin.MarshalOptions{KeepSecrets: true})
+	assert.NoError(t, err)
+	state.resolve(false, nil, nil, "urn", "other", outputs)
+
+	tests := []struct {
+		out    Output
+		value  interface{}
+		secret bool
+	}{
+		{ref.GetOutput(String("plain")), "foo", false},
+		{ref.GetOutput(String("secret")), "bar", true},
+		{ref.GetOutput(String("missing")), nil, false},
+		{Output(ref.GetStringOutput(String("plain"))), "foo", false},
+		{Output(ref.GetStringOutput(String("secret"))), "bar", true},
+		{ref.GetSecretOutput(String("plain")), "foo", true},
+	}
+	for _, test := range tests {
+		v, known, secret, err := test.out.s.awaitValue(context.Background())
+		assert.NoError(t, err)
+		assert.True(t, known)
+		assert.Equal(t, test.value, v)
+		assert.Equal(t, test.secret, secret)
+	}
+
+	// Non-string outputs cannot be fetched as strings.
+	_, _, err = awaitOutput(Output(ref.GetStringOutput(String("number"))))
+	assert.Error(t, err)
+}
@@ -0,0 +1,303 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:generate go run generate/main.go
+
+package pulumi
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/pkg/errors"
+)
+
+// Input is the type of a generic input value for a Pulumi resource. This type is used in conjunction with Output
+// to provide polymorphism over strongly-typed input values.
+//
+// The intended pattern for nested Pulumi value types is to define an input interface and a plain, input, and output
+// variant of the value type. For example, given a type T with a single string field, the plain type T would contain
+// a string, the input type TArgs would contain a StringInput, and the output type TOutput would resolve to T. Each of
+// the builtin types (String, Int, StringArray, and so on) follows this pattern.
+type Input interface {
+	// ElementType returns the type of the value that this input will resolve to.
+	ElementType() reflect.Type
+}
+
+var anyType = reflect.TypeOf((*interface{})(nil)).Elem()
+var contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+// ElementType returns the element type of this Output (interface{}).
+func (out Output) ElementType() reflect.Type {
+	return anyType
+}
+
+// ApplyT transforms the data of the output property using the applier func. The result remains an output property,
+// and accumulates all implicated dependencies, so that resources can be properly tracked using a DAG. This function
+// does not block awaiting the value; instead, it spawns a Goroutine that will await its availability.
+//
+// The applier must be a function with one of the following signatures:
+//
+//     func(v T) U
+//     func(v T) (U, error)
+//     func(ctx context.Context, v T) U
+//     func(ctx context.Context, v T) (U, error)
+//
+// where T is a type to which the output's element type is assignable. The untyped Output's values may be converted
+// to any type T; values that cannot be converted reject the resulting output. ApplyT panics if the applier's
+// signature is invalid.
+//
+// The result may be converted to a typed output for U, e.g. StringOutput(out.ApplyT(func(v int) string { ... })).
+func (out Output) ApplyT(applier interface{}) Output {
+	return out.ApplyTWithContext(context.Background(), applier)
+}
+
+// ApplyTWithContext transforms the data of the output property using the applier func. The provided context can be
+// used to reject the output as canceled. See ApplyT for details.
+func (out Output) ApplyTWithContext(ctx context.Context, applier interface{}) Output {
+	return applyT(ctx, out, anyType, applier)
+}
+
+// checkApplier checks that the given applier is a function that can be applied to values of the given element type.
+// It returns the applier's value along with the type of its value parameter, and panics if the applier is invalid.
+func checkApplier(applier interface{}, elementType reflect.Type) (reflect.Value, reflect.Type) {
+	fn := reflect.ValueOf(applier)
+	if !fn.IsValid() || fn.Kind() != reflect.Func {
+		panic(errors.Errorf("applier must be a function, not %T", applier))
+	}
+	ft := fn.Type()
+	if ft.IsVariadic() {
+		panic(errors.Errorf("applier must not be variadic, got %v", ft))
+	}
+
+	switch ft.NumIn() {
+	case 1:
+	case 2:
+		if ft.In(0) != contextType {
+			panic(errors.Errorf("applier's first of two parameters must be a context.Context, got %v", ft.In(0)))
+		}
+	default:
+		panic(errors.Errorf("applier must accept a value and optionally a context.Context, got %v", ft))
+	}
+	valueType := ft.In(ft.NumIn() - 1)
+	if elementType != anyType && !elementType.AssignableTo(valueType) {
+		panic(errors.Errorf("applier's parameter of type %v cannot accept values of type %v", valueType, elementType))
+	}
+
+	switch ft.NumOut() {
+	case 1:
+	case 2:
+		if ft.Out(1) != errorType {
+			panic(errors.Errorf("applier's second of two results must be an error, got %v", ft.Out(1)))
+		}
+	default:
+		panic(errors.Errorf("applier must return a value and optionally an error, got %v", ft))
+	}
+
+	return fn, valueType
+}
+
+// applyT implements ApplyT for outputs of the given element type.
+func applyT(ctx context.Context, out Output, elementType reflect.Type, applier interface{}) Output {
+	fn, valueType := checkApplier(applier, elementType)
+	hasContext, hasError := fn.Type().NumIn() == 2, fn.Type().NumOut() == 2
+
+	return out.ApplyWithContext(ctx, func(ctx context.Context, v interface{}) (interface{}, error) {
+		rv, err := convertValue(reflect.ValueOf(v), valueType)
+		if err != nil {
+			return nil, err
+		}
+
+		args := []reflect.Value{rv}
+		if hasContext {
+			args = []reflect.Value{reflect.ValueOf(ctx), rv}
+		}
+		results := fn.Call(args)
+		if hasError && !results[1].IsNil() {
+			return nil, results[1].Interface().(error)
+		}
+		return results[0].Interface(), nil
+	})
+}
+
+// ToOutput returns an Output that will resolve when all Inputs contained in the given value have resolved. Typed
+// inputs are resolved to their element types: for example, a StringArray that contains a StringOutput resolves to
+// a []string.
+func ToOutput(v interface{}) Output {
+	return ToOutputWithContext(context.Background(), v)
+}
+
+// ToOutputWithContext returns an Output that will resolve when all Inputs contained in the given value have resolved.
+// The provided context can be used to reject the output as canceled.
+func ToOutputWithContext(ctx context.Context, v interface{}) Output {
+	if out, ok := isOutput(v); ok {
+		return out
+	}
+
+	result := newOutput(gatherDependencies(v)...)
+	go func() {
+		value, known, secret, err := awaitInputs(ctx, v)
+		result.s.fulfillValue(value, known, secret, err)
+	}()
+	return result
+}
+
+// All returns an ArrayOutput that will resolve to the values of the given inputs once all of them have resolved. The
+// output is unknown if any of the inputs are unknown.
+func All(inputs ...Input) ArrayOutput {
+	return AllWithContext(context.Background(), inputs...)
+}
+
+// AllWithContext returns an ArrayOutput that will resolve to the values of the given inputs once all of them have
+// resolved. The provided context can be used to reject the output as canceled.
+func AllWithContext(ctx context.Context, inputs ...Input) ArrayOutput {
+	return ArrayOutput(ToOutputWithContext(ctx, Array(inputs)))
+}
+
+// gatherDependencies returns the dependencies of any outputs contained in the given value.
+func gatherDependencies(v interface{}) []Resource {
+	if v == nil {
+		return nil
+	}
+	if out, ok := isOutput(v); ok {
+		return out.s.dependencies()
+	}
+
+	var deps []Resource
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Array, reflect.Slice:
+		for i := 0; i < rv.Len(); i++ {
+			deps = append(deps, gatherDependencies(rv.Index(i).Interface())...)
+		}
+	case reflect.Map:
+		for _, k := range rv.MapKeys() {
+			deps = append(deps, gatherDependencies(rv.MapIndex(k).Interface())...)
+		}
+	}
+	return deps
+}
+
+// awaitInputs awaits any outputs contained in the given value and returns the resolved value. If the value is a typed
+// Input, it is converted to its element type. The value is secret if any of the outputs it contains are secret.
+func awaitInputs(ctx context.Context, v interface{}) (interface{}, bool, bool, error) {
+	if v == nil {
+		return nil, true, false, nil
+	}
+	if out, ok := isOutput(v); ok {
+		value, known, secret, err := out.s.awaitValue(ctx)
+		if !known || err != nil {
+			return nil, known, secret, err
+		}
+		value, known, elementSecret, err := awaitInputs(ctx, value)
+		return value, known, secret || elementSecret, err
+	}
+
+	// If this is a typed input, its value will be converted to its element type.
+	var elementType reflect.Type
+	if in, ok := v.(Input); ok && in.ElementType() != anyType {
+		elementType = in.ElementType()
+	}
+
+	secret := false
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Array, reflect.Slice:
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			break
+		}
+		arr := make([]interface{}, rv.Len())
+		for i := range arr {
+			e, known, elementSecret, err := awaitInputs(ctx, rv.Index(i).Interface())
+			secret = secret || elementSecret
+			if !known || err != nil {
+				return nil, known, secret, err
+			}
+			arr[i] = e
+		}
+		v = arr
+	case reflect.Map:
+		if rv.Type().Key().Kind() != reflect.String {
+			break
+		}
+		m := make(map[string]interface{}, rv.Len())
+		for _, k := range rv.MapKeys() {
+			e, known, elementSecret, err := awaitInputs(ctx, rv.MapIndex(k).Interface())
+			secret = secret || elementSecret
+			if !known || err != nil {
+				return nil, known, secret, err
+			}
+			m[k.String()] = e
+		}
+		v = m
+	}
+
+	if elementType == nil {
+		return v, true, secret, nil
+	}
+	ev, err := convertValue(reflect.ValueOf(v), elementType)
+	if err != nil {
+		return nil, true, secret, err
+	}
+	return ev.Interface(), true, secret, nil
+}
+
+// convertValue converts the given value to the given type. Nil values are converted to the type's zero value, and
+// arrays and maps are converted element-wise.
+func convertValue(rv reflect.Value, to reflect.Type) (reflect.Value, error) {
+	for rv.IsValid() && rv.Kind() == reflect.Interface {
+		rv = rv.Elem()
+	}
+	if !rv.IsValid() {
+		return reflect.Zero(to), nil
+	}
+
+	from := rv.Type()
+	switch {
+	case from.AssignableTo(to):
+		if to.Kind() == reflect.Interface {
+			v := reflect.New(to).Elem()
+			v.Set(rv)
+			return v, nil
+		}
+		return rv, nil
+	case to.Kind() == reflect.Slice && (from.Kind() == reflect.Slice || from.Kind() == reflect.Array):
+		result := reflect.MakeSlice(to, rv.Len(), rv.Len())
+		for i := 0; i < rv.Len(); i++ {
+			e, err := convertValue(rv.Index(i), to.Elem())
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			result.Index(i).Set(e)
+		}
+		return result, nil
+	case to.Kind() == reflect.Map && from.Kind() == reflect.Map && to.Key().Kind() == reflect.String &&
+		from.Key().Kind() == reflect.String:
+		result := reflect.MakeMapWithSize(to, rv.Len())
+		for _, k := range rv.MapKeys() {
+			e, err := convertValue(rv.MapIndex(k), to.Elem())
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			result.SetMapIndex(k.Convert(to.Key()), e)
+		}
+		return result, nil
+	case to.Kind() == reflect.String && from.Kind() != reflect.String:
+		// Although Go permits conversions from integers to strings, these produce runes rather than decimal strings.
+	case from.ConvertibleTo(to) && from.Kind() != reflect.Slice && from.Kind() != reflect.Map:
+		return rv.Convert(to), nil
+	}
+	return reflect.Value{}, errors.Errorf("cannot convert value of type %v to %v", from, to)
+}
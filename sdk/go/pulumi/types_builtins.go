@@ -0,0 +1,3503 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by generate/main.go; DO NOT EDIT.
+
+// nolint: lll
+package pulumi
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/pulumi/pulumi/sdk/go/pulumi/asset"
+)
+
+var archiveType = reflect.TypeOf((*asset.Archive)(nil)).Elem()
+
+// ArchiveOutput is an Output that is typed to return asset.Archive values.
+type ArchiveOutput Output
+
+// ElementType returns the element type of this Output (asset.Archive).
+func (ArchiveOutput) ElementType() reflect.Type {
+	return archiveType
+}
+
+// ToArchiveOutput returns this output.
+func (out ArchiveOutput) ToArchiveOutput() ArchiveOutput {
+	return out
+}
+
+// Apply applies a transformation to the asset.Archive value when it is available.
+func (out ArchiveOutput) Apply(applier func(asset.Archive) (interface{}, error)) Output {
+	return out.ApplyWithContext(context.Background(), func(_ context.Context, v asset.Archive) (interface{}, error) {
+		return applier(v)
+	})
+}
+
+// ApplyWithContext applies a transformation to the asset.Archive value when it is available.
+func (out ArchiveOutput) ApplyWithContext(ctx context.Context, applier func(context.Context, asset.Archive) (interface{}, error)) Output {
+	return Output(out).ApplyWithContext(ctx, func(ctx context.Context, v interface{}) (interface{}, error) {
+		return applier(ctx, convert(v, archiveType).(asset.Archive))
+	})
+}
+
+// ApplyT applies a transformation to the asset.Archive value when it is available. The applier must be a function that
+// accepts a asset.Archive and returns a single value, optionally followed by an error. See Output.ApplyT for details.
+func (out ArchiveOutput) ApplyT(applier interface{}) Output {
+	return out.ApplyTWithContext(context.Background(), applier)
+}
+
+// ApplyTWithContext applies a transformation to the asset.Archive value when it is available. See Output.ApplyTWithContext
+// for details.
+func (out ArchiveOutput) ApplyTWithContext(ctx context.Context, applier interface{}) Output {
+	return applyT(ctx, Output(out), archiveType, applier)
+}
+
+var arrayType = reflect.TypeOf((*[]interface{})(nil)).Elem()
+
+// ArrayInput is an input type that accepts Array and ArrayOutput values.
+type ArrayInput interface {
+	Input
+
+	ToArrayOutput() ArrayOutput
+}
+
+// Array is an input type for []interface{} values.
+type Array []Input
+
+// ElementType returns the element type of this Input ([]interface{}).
+func (Array) ElementType() reflect.Type {
+	return arrayType
+}
+
+// ToArrayOutput converts this input to a ArrayOutput.
+func (in Array) ToArrayOutput() ArrayOutput {
+	return ArrayOutput(ToOutput(in))
+}
+
+// ArrayOutput is an Output that is typed to return []interface{} values.
+type ArrayOutput Output
+
+// ElementType returns the element type of this Output ([]interface{}).
+func (ArrayOutput) ElementType() reflect.Type {
+	return arrayType
+}
+
+// ToArrayOutput returns this output.
+func (out ArrayOutput) ToArrayOutput() ArrayOutput {
+	return out
+}
+
+// Apply applies a transformation to the []interface{} value when it is available.
+func (out ArrayOutput) Apply(applier func([]interface{}) (interface{}, error)) Output {
+	return out.ApplyWithContext(context.Background(), func(_ context.Context, v []interface{}) (interface{}, error) {
+		return applier(v)
+	})
+}
+
+// ApplyWithContext applies a transformation to the []interface{} value when it is available.
+func (out ArrayOutput) ApplyWithContext(ctx context.Context, applier func(context.Context, []interface{}) (interface{}, error)) Output {
+	return Output(out).ApplyWithContext(ctx, func(ctx context.Context, v interface{}) (interface{}, error) {
+		return applier(ctx, convert(v, arrayType).([]interface{}))
+	})
+}
+
+// ApplyT applies a transformation to the []interface{} value when it is available. The applier must be a function that
+// accepts a []interface{} and returns a single value, optionally followed by an error. See Output.ApplyT for details.
+func (out ArrayOutput) ApplyT(applier interface{}) Output {
+	return out.ApplyTWithContext(context.Background(), applier)
+}
+
+// ApplyTWithContext applies a transformation to the []interface{} value when it is available. See Output.ApplyTWithContext
+// for details.
+func (out ArrayOutput) ApplyTWithContext(ctx context.Context, applier interface{}) Output {
+	return applyT(ctx, Output(out), arrayType, applier)
+}
+
+var assetType = reflect.TypeOf((*asset.Asset)(nil)).Elem()
+
+// AssetOutput is an Output that is typed to return asset.Asset values.
+type AssetOutput Output
+
+// ElementType returns the element type of this Output (asset.Asset).
+func (AssetOutput) ElementType() reflect.Type {
+	return assetType
+}
+
+// ToAssetOutput returns this output.
+func (out AssetOutput) ToAssetOutput() AssetOutput {
+	return out
+}
+
+// Apply applies a transformation to the asset.Asset value when it is available.
+func (out AssetOutput) Apply(applier func(asset.Asset) (interface{}, error)) Output {
+	return out.ApplyWithContext(context.Background(), func(_ context.Context, v asset.Asset) (interface{}, error) {
+		return applier(v)
+	})
+}
+
+// ApplyWithContext applies a transformation to the asset.Asset value when it is available.
+func (out AssetOutput) ApplyWithContext(ctx context.Context, applier func(context.Context, asset.Asset) (interface{}, error)) Output {
+	return Output(out).ApplyWithContext(ctx, func(ctx context.Context, v interface{}) (interface{}, error) {
+		return applier(ctx, convert(v, assetType).(asset.Asset))
+	})
+}
+
+// ApplyT applies a transformation to the asset.Asset value when it is available. The applier must be a function that
+// accepts a asset.Asset and returns a single value, optionally followed by an error. See Output.ApplyT for details.
+func (out AssetOutput) ApplyT(applier interface{}) Output {
+	return out.ApplyTWithContext(context.Background(), applier)
+}
+
+// ApplyTWithContext applies a transformation to the asset.Asset value when it is available. See Output.ApplyTWithContext
+// for details.
+func (out AssetOutput) ApplyTWithContext(ctx context.Context, applier interface{}) Output {
+	return applyT(ctx, Output(out), assetType, applier)
+}
+
+var boolType = reflect.TypeOf((*bool)(nil)).Elem()
+
+// BoolInput is an input type that accepts Bool and BoolOutput values.
+type BoolInput interface {
+	Input
+
+	ToBoolOutput() BoolOutput
+}
+
+// Bool is an input type for bool values.
+type Bool bool
+
+// ElementType returns the element type of this Input (bool).
+func (Bool) ElementType() reflect.Type {
+	return boolType
+}
+
+// ToBoolOutput converts this input to a BoolOutput.
+func (in Bool) ToBoolOutput() BoolOutput {
+	return BoolOutput(ToOutput(in))
+}
+
+// BoolOutput is an Output that is typed to return bool values.
+type BoolOutput Output
+
+// ElementType returns the element type of this Output (bool).
+func (BoolOutput) ElementType() reflect.Type {
+	return boolType
+}
+
+// ToBoolOutput returns this output.
+func (out BoolOutput) ToBoolOutput() BoolOutput {
+	return out
+}
+
+// Apply applies a transformation to the bool value when it is available.
+func (out BoolOutput) Apply(applier func(bool) (interface{}, error)) Output {
+	return out.ApplyWithContext(context.Background(), func(_ context.Context, v bool) (interface{}, error) {
+		return applier(v)
+	})
+}
+
+// ApplyWithContext applies a transformation to the bool value when it is available.
+func (out BoolOutput) ApplyWithContext(ctx context.Context, applier func(context.Context, bool) (interface{}, error)) Output {
+	return Output(out).ApplyWithContext(ctx, func(ctx context.Context, v interface{}) (interface{}, error) {
+		return applier(ctx, convert(v, boolType).(bool))
+	})
+}
+
+// ApplyT applies a transformation to the bool value when it is available. The applier must be a function that
+// accepts a bool and returns a single value, optionally followed by an error. See Output.ApplyT for details.
+func (out BoolOutput) ApplyT(applier interface{}) Output {
+	return out.ApplyTWithContext(context.Background(), applier)
+}
+
+// ApplyTWithContext applies a transformation to the bool value when it is available. See Output.ApplyTWithContext
+// for details.
+func (out BoolOutput) ApplyTWithContext(ctx context.Context, applier interface{}) Output {
+	return applyT(ctx, Output(out), boolType, applier)
+}
+
+var float32Type = reflect.TypeOf((*float32)(nil)).Elem()
+
+// Float32Input is an input type that accepts Float32 and Float32Output values.
+type Float32Input interface {
+	Input
+
+	ToFloat32Output() Float32Output
+}
+
+// Float32 is an input type for float32 values.
+type Float32 float32
+
+// ElementType returns the element type of this Input (float32).
+func (Float32) ElementType() reflect.Type {
+	return float32Type
+}
+
+// ToFloat32Output converts this input to a Float32Output.
+func (in Float32) ToFloat32Output() Float32Output {
+	return Float32Output(ToOutput(in))
+}
+
+// Float32Output is an Output that is typed to return float32 values.
+type Float32Output Output
+
+// ElementType returns the element type of this Output (float32).
+func (Float32Output) ElementType() reflect.Type {
+	return float32Type
+}
+
+// ToFloat32Output returns this output.
+func (out Float32Output) ToFloat32Output() Float32Output {
+	return out
+}
+
+// Apply applies a transformation to the float32 value when it is available.
+func (out Float32Output) Apply(applier func(float32) (interface{}, error)) Output {
+	return out.ApplyWithContext(context.Background(), func(_ context.Context, v float32) (interface{}, error) {
+		return applier(v)
+	})
+}
+
+// ApplyWithContext applies a transformation to the float32 value when it is available.
+func (out Float32Output) ApplyWithContext(ctx context.Context, applier func(context.Context, float32) (interface{}, error)) Output {
+	return Output(out).ApplyWithContext(ctx, func(ctx context.Context, v interface{}) (interface{}, error) {
+		return applier(ctx, convert(v, float32Type).(float32))
+	})
+}
+
+// ApplyT applies a transformation to the float32 value when it is available. The applier must be a function that
+// accepts a float32 and returns a single value, optionally followed by an error. See Output.ApplyT for details.
+func (out Float32Output) ApplyT(applier interface{}) Output {
+	return out.ApplyTWithContext(context.Background(), applier)
+}
+
+// ApplyTWithContext applies a transformation to the float32 value when it is available. See Output.ApplyTWithContext
+// for details.
+func (out Float32Output) ApplyTWithContext(ctx context.Context, applier interface{}) Output {
+	return applyT(ctx, Output(out), float32Type, applier)
+}
+
+var float64Type = reflect.TypeOf((*float64)(nil)).Elem()
+
+// Float64Input is an input type that accepts Float64 and Float64Output values.
+type Float64Input interface {
+	Input
+
+	ToFloat64Output() Float64Output
+}
+
+// Float64 is an input type for float64 values.
+type Float64 float64
+
+// ElementType returns the element type of this Input (float64).
+func (Float64) ElementType() reflect.Type {
+	return float64Type
+}
+
+// ToFloat64Output converts this input to a Float64Output.
+func (in Float64) ToFloat64Output() Float64Output {
+	return Float64Output(ToOutput(in))
+}
+
+// Float64Output is an Output that is typed to return float64 values.
+type Float64Output Output
+
+// ElementType returns the element type of this Output (float64).
+func (Float64Output) ElementType() reflect.Type {
+	return float64Type
+}
+
+// ToFloat64Output returns this output.
+func (out Float64Output) ToFloat64Output() Float64Output {
+	return out
+}
+
+// Apply applies a transformation to the float64 value when it is available.
+func (out Float64Output) Apply(applier func(float64) (interface{}, error)) Output {
+	return out.ApplyWithContext(context.Background(), func(_ context.Context, v float64) (interface{}, error) {
+		return applier(v)
+	})
+}
+
+// ApplyWithContext applies a transformation to the float64 value when it is available.
+func (out Float64Output) ApplyWithContext(ctx context.Context, applier func(context.Context, float64) (interface{}, error)) Output {
+	return Output(out).ApplyWithContext(ctx, func(ctx context.Context, v interface{}) (interface{}, error) {
+		return applier(ctx, convert(v, float64Type).(float64))
+	})
+}
+
+// ApplyT applies a transformation to the float64 value when it is available. The applier must be a function that
+// accepts a float64 and returns a single value, optionally followed by an error. See Output.ApplyT for details.
+func (out Float64Output) ApplyT(applier interface{}) Output {
+	return out.ApplyTWithContext(context.Background(), applier)
+}
+
+// ApplyTWithContext applies a transformation to the float64 value when it is available. See Output.ApplyTWithContext
+// for details.
+func (out Float64Output) ApplyTWithContext(ctx context.Context, applier interface{}) Output {
+	return applyT(ctx, Output(out), float64Type, applier)
+}
+
+var idType = reflect.TypeOf((*ID)(nil)).Elem()
+
+// IDInput is an input type that accepts ID and IDOutput values.
+type IDInput interface {
+	Input
+
+	ToIDOutput() IDOutput
+}
+
+// ElementType returns the element type of this Input (ID).
+func (ID) ElementType() reflect.Type {
+	return idType
+}
+
+// ToIDOutput converts this input to a IDOutput.
+func (in ID) ToIDOutput() IDOutput {
+	return IDOutput(ToOutput(in))
+}
+
+// IDOutput is an Output that is typed to return ID values.
+type IDOutput Output
+
+// ElementType returns the element type of this Output (ID).
+func (IDOutput) ElementType() reflect.Type {
+	return idType
+}
+
+// ToIDOutput returns this output.
+func (out IDOutput) ToIDOutput() IDOutput {
+	return out
+}
+
+// Apply applies a transformation to the ID value when it is available.
+func (out IDOutput) Apply(applier func(ID) (interface{}, error)) Output {
+	return out.ApplyWithContext(context.Background(), func(_ context.Context, v ID) (interface{}, error) {
+		return applier(v)
+	})
+}
+
+// ApplyWithContext applies a transformation to the ID value when it is available.
+func (out IDOutput) ApplyWithContext(ctx context.Context, applier func(context.Context, ID) (interface{}, error)) Output {
+	return Output(out).ApplyWithContext(ctx, func(ctx context.Context, v interface{}) (interface{}, error) {
+		return applier(ctx, convert(v, idType).(ID))
+	})
+}
+
+// ApplyT applies a transformation to the ID value when it is available. The applier must be a function that
+// accepts a ID and returns a single value, optionally followed by an error. See Output.ApplyT for details.
+func (out IDOutput) ApplyT(applier interface{}) Output {
+	return out.ApplyTWithContext(context.Background(), applier)
+}
+
+// ApplyTWithContext applies a transformation to the ID value when it is available. See Output.ApplyTWithContext
+// for details.
+func (out IDOutput) ApplyTWithContext(ctx context.Context, applier interface{}) Output {
+	return applyT(ctx, Output(out), idType, applier)
+}
+
+var intType = reflect.TypeOf((*int)(nil)).Elem()
+
+// IntInput is an input type that accepts Int and IntOutput values.
+type IntInput interface {
+	Input
+
+	ToIntOutput() IntOutput
+}
+
+// Int is an input type for int values.
+type Int int
+
+// ElementType returns the element type of this Input (int).
+func (Int) ElementType() reflect.Type {
+	return intType
+}
+
+// ToIntOutput converts this input to a IntOutput.
+func (in Int) ToIntOutput() IntOutput {
+	return IntOutput(ToOutput(in))
+}
+
+// IntOutput is an Output that is typed to return int values.
+type IntOutput Output
+
+// ElementType returns the element type of this Output (int).
+func (IntOutput) ElementType() reflect.Type {
+	return intType
+}
+
+// ToIntOutput returns this output.
+func (out IntOutput) ToIntOutput() IntOutput {
+	return out
+}
+
+// Apply applies a transformation to the int value when it is available.
+func (out IntOutput) Apply(applier func(int) (interface{}, error)) Output {
+	return out.ApplyWithContext(context.Background(), func(_ context.Context, v int) (interface{}, error) {
+		return applier(v)
+	})
+}
+
+// ApplyWithContext applies a transformation to the int value when it is available.
+func (out IntOutput) ApplyWithContext(ctx context.Context, applier func(context.Context, int) (interface{}, error)) Output {
+	return Output(out).ApplyWithContext(ctx, func(ctx context.Context, v interface{}) (interface{}, error) {
+		return applier(ctx, convert(v, intType).(int))
+	})
+}
+
+// ApplyT applies a transformation to the int value when it is available. The applier must be a function that
+// accepts a int and returns a single value, optionally followed by an error. See Output.ApplyT for details.
+func (out IntOutput) ApplyT(applier interface{}) Output {
+	return out.ApplyTWithContext(context.Background(), applier)
+}
+
+// ApplyTWithContext applies a transformation to the int value when it is available. See Output.ApplyTWithContext
+// for details.
+func (out IntOutput) ApplyTWithContext(ctx context.Context, applier interface{}) Output {
+	return applyT(ctx, Output(out), intType, applier)
+}
+
+var int8Type = reflect.TypeOf((*int8)(nil)).Elem()
+
+// Int8Input is an input type that accepts Int8 and Int8Output values.
+type Int8Input interface {
+	Input
+
+	ToInt8Output() Int8Output
+}
+
+// Int8 is an input type for int8 values.
+type Int8 int8
+
+// ElementType returns the element type of this Input (int8).
+func (Int8) ElementType() reflect.Type {
+	return int8Type
+}
+
+// ToInt8Output converts this input to a Int8Output.
+func (in Int8) ToInt8Output() Int8Output {
+	return Int8Output(ToOutput(in))
+}
+
+// Int8Output is an Output that is typed to return int8 values.
+type Int8Output Output
+
+// ElementType returns the element type of this Output (int8).
+func (Int8Output) ElementType() reflect.Type {
+	return int8Type
+}
+
+// ToInt8Output returns this output.
+func (out Int8Output) ToInt8Output() Int8Output {
+	return out
+}
+
+// Apply applies a transformation to the int8 value when it is available.
+func (out Int8Output) Apply(applier func(int8) (interface{}, error)) Output {
+	return out.ApplyWithContext(context.Background(), func(_ context.Context, v int8) (interface{}, error) {
+		return applier(v)
+	})
+}
+
+// ApplyWithContext applies a transformation to the int8 value when it is available.
+func (out Int8Output) ApplyWithContext(ctx context.Context, applier func(context.Context, int8) (interface{}, error)) Output {
+	return Output(out).ApplyWithContext(ctx, func(ctx context.Context, v interface{}) (interface{}, error) {
+		return applier(ctx, convert(v, int8Type).(int8))
+	})
+}
+
+// ApplyT applies a transformation to the int8 value when it is available. The applier must be a function that
+// accepts a int8 and returns a single value, optionally followed by an error. See Output.ApplyT for details.
+func (out Int8Output) ApplyT(applier interface{}) Output {
+	return out.ApplyTWithContext(context.Background(), applier)
+}
+
+// ApplyTWithContext applies a transformation to the int8 value when it is available. See Output.ApplyTWithContext
+// for details.
+func (out Int8Output) ApplyTWithContext(ctx context.Context, applier interface{}) Output {
+	return applyT(ctx, Output(out), int8Type, applier)
+}
+
+var int16Type = reflect.TypeOf((*int16)(nil)).Elem()
+
+// Int16Input is an input type that accepts Int16 and Int16Output values.
+type Int16Input interface {
+	Input
+
+	ToInt16Output() Int16Output
+}
+
+// Int16 is an input type for int16 values.
+type Int16 int16
+
+// ElementType returns the element type of this Input (int16).
+func (Int16) ElementType() reflect.Type {
+	return int16Type
+}
+
+// ToInt16Output converts this input to a Int16Output.
+func (in Int16) ToInt16Output() Int16Output {
+	return Int16Output(ToOutput(in))
+}
+
+// Int16Output is an Output that is typed to return int16 values.
+type Int16Output Output
+
+// ElementType returns the element type of this Output (int16).
+func (Int16Output) ElementType() reflect.Type {
+	return int16Type
+}
+
+// ToInt16Output returns this output.
+func (out Int16Output) ToInt16Output() Int16Output {
+	return out
+}
+
+// Apply applies a transformation to the int16 value when it is available.
+func (out Int16Output) Apply(applier func(int16) (interface{}, error)) Output {
+	return out.ApplyWithContext(context.Background(), func(_ context.Context, v int16) (interface{}, error) {
+		return applier(v)
+	})
+}
+
+// ApplyWithContext applies a transformation to the int16 value when it is available.
+func (out Int16Output) ApplyWithContext(ctx context.Context, applier func(context.Context, int16) (interface{}, error)) Output {
+	return Output(out).ApplyWithContext(ctx, func(ctx context.Context, v interface{}) (interface{}, error) {
+		return applier(ctx, convert(v, int16Type).(int16))
+	})
+}
+
+// ApplyT applies a transformation to the int16 value when it is available. The applier must be a function that
+// accepts a int16 and returns a single value, optionally followed by an error. See Output.ApplyT for details.
+func (out Int16Output) ApplyT(applier interface{}) Output {
+	return out.ApplyTWithContext(context.Background(), applier)
+}
+
+// ApplyTWithContext applies a transformation to the int16 value when it is available. See Output.ApplyTWithContext
+// for details.
+func (out Int16Output) ApplyTWithContext(ctx context.Context, applier interface{}) Output {
+	return applyT(ctx, Output(out), int16Type, applier)
+}
+
+var int32Type = reflect.TypeOf((*int32)(nil)).Elem()
+
+// Int32Input is an input type that accepts Int32 and Int32Output values.
+type Int32Input interface {
+	Input
+
+	ToInt32Output() Int32Output
+}
+
+// Int32 is an input type for int32 values.
+type Int32 int32
+
+// ElementType returns the element type of this Input (int32).
+func (Int32) ElementType() reflect.Type {
+	return int32Type
+}
+
+// ToInt32Output converts this input to a Int32Output.
+func (in Int32) ToInt32Output() Int32Output {
+	return Int32Output(ToOutput(in))
+}
+
+// Int32Output is an Output that is typed to return int32 values.
+type Int32Output Output
+
+// ElementType returns the element type of this Output (int32).
+func (Int32Output) ElementType() reflect.Type {
+	return int32Type
+}
+
+// ToInt32Output returns this output.
+func (out Int32Output) ToInt32Output() Int32Output {
+	return out
+}
+
+// Apply applies a transformation to the int32 value when it is available.
+func (out Int32Output) Apply(applier func(int32) (interface{}, error)) Output {
+	return out.ApplyWithContext(context.Background(), func(_ context.Context, v int32) (interface{}, error) {
+		return applier(v)
+	})
+}
+
+// ApplyWithContext applies a transformation to the int32 value when it is available.
+func (out Int32Output) ApplyWithContext(ctx context.Context, applier func(context.Context, int32) (interface{}, error)) Output {
+	return Output(out).ApplyWithContext(ctx, func(ctx context.Context, v interface{}) (interface{}, error) {
+		return applier(ctx, convert(v, int32Type).(int32))
+	})
+}
+
+// ApplyT applies a transformation to the int32 value when it is available. The applier must be a function that
+// accepts a int32 and returns a single value, optionally followed by an error. See Output.ApplyT for details.
+func (out Int32Output) ApplyT(applier interface{}) Output {
+	return out.ApplyTWithContext(context.Background(), applier)
+}
+
+// ApplyTWithContext applies a transformation to the int32 value when it is available. See Output.ApplyTWithContext
+// for details.
+func (out Int32Output) ApplyTWithContext(ctx context.Context, applier interface{}) Output {
+	return applyT(ctx, Output(out), int32Type, applier)
+}
+
+var int64Type = reflect.TypeOf((*int64)(nil)).Elem()
+
+// Int64Input is an input type that accepts Int64 and Int64Output values.
+type Int64Input interface {
+	Input
+
+	ToInt64Output() Int64Output
+}
+
+// Int64 is an input type for int64 values.
+type Int64 int64
+
+// ElementType returns the element type of this Input (int64).
+func (Int64) ElementType() reflect.Type {
+	return int64Type
+}
+
+// ToInt64Output converts this input to a Int64Output.
+func (in Int64) ToInt64Output() Int64Output {
+	return Int64Output(ToOutput(in))
+}
+
+// Int64Output is an Output that is typed to return int64 values.
+type Int64Output Output
+
+// ElementType returns the element type of this Output (int64).
+func (Int64Output) ElementType() reflect.Type {
+	return int64Type
+}
+
+// ToInt64Output returns this output.
+func (out Int64Output) ToInt64Output() Int64Output {
+	return out
+}
+
+// Apply applies a transformation to the int64 value when it is available.
+func (out Int64Output) Apply(applier func(int64) (interface{}, error)) Output {
+	return out.ApplyWithContext(context.Background(), func(_ context.Context, v int64) (interface{}, error) {
+		return applier(v)
+	})
+}
+
+// ApplyWithContext applies a transformation to the int64 value when it is available.
+func (out Int64Output) ApplyWithContext(ctx context.Context, applier func(context.Context, int64) (interface{}, error)) Output {
+	return Output(out).ApplyWithContext(ctx, func(ctx context.Context, v interface{}) (interface{}, error) {
+		return applier(ctx, convert(v, int64Type).(int64))
+	})
+}
+
+// ApplyT applies a transformation to the int64 value when it is available. The applier must be a function that
+// accepts a int64 and returns a single value, optionally followed by an error. See Output.ApplyT for details.
+func (out Int64Output) ApplyT(applier interface{}) Output {
+	return out.ApplyTWithContext(context.Background(), applier)
+}
+
+// ApplyTWithContext applies a transformation to the int64 value when it is available. See Output.ApplyTWithContext
+// for details.
+func (out Int64Output) ApplyTWithContext(ctx context.Context, applier interface{}) Output {
+	return applyT(ctx, Output(out), int64Type, applier)
+}
+
+var mapType = reflect.TypeOf((*map[string]interface{})(nil)).Elem()
+
+// MapInput is an input type that accepts Map and MapOutput values.
+type MapInput interface {
+	Input
+
+	ToMapOutput() MapOutput
+}
+
+// Map is an input type for map[string]interface{} values.
+type Map map[string]Input
+
+// ElementType returns the element type of this Input (map[string]interface{}).
+func (Map) ElementType() reflect.Type {
+	return mapType
+}
+
+// ToMapOutput converts this input to a MapOutput.
+func (in Map) ToMapOutput() MapOutput {
+	return MapOutput(ToOutput(in))
+}
+
+// MapOutput is an Output that is typed to return map[string]interface{} values.
+type MapOutput Output
+
+// ElementType returns the element type of this Output (map[string]interface{}).
+func (MapOutput) ElementType() reflect.Type {
+	return mapType
+}
+
+// ToMapOutput returns this output.
+func (out MapOutput) ToMapOutput() MapOutput {
+	return out
+}
+
+// Apply applies a transformation to the map[string]interface{} value when it is available.
+func (out MapOutput) Apply(applier func(map[string]interface{}) (interface{}, error)) Output {
+	return out.ApplyWithContext(context.Background(), func(_ context.Context, v map[string]interface{}) (interface{}, error) {
+		return applier(v)
+	})
+}
+
+// ApplyWithContext applies a transformation to the map[string]interface{} value when it is available.
+func (out MapOutput) ApplyWithContext(ctx context.Context, applier func(context.Context, map[string]interface{}) (interface{}, error)) Output {
+	return Output(out).ApplyWithContext(ctx, func(ctx context.Context, v interface{}) (interface{}, error) {
+		return applier(ctx, convert(v, mapType).(map[string]interface{}))
+	})
+}
+
+// ApplyT applies a transformation to the map[string]interface{} value when it is available. The applier must be a function that
+// accepts a map[string]interface{} and returns a single value, optionally followed by an error. See Output.ApplyT for details.
+func (out MapOutput) ApplyT(applier interface{}) Output {
+	return out.ApplyTWithContext(context.Background(), applier)
+}
+
+// ApplyTWithContext applies a transformation to the map[string]interface{} value when it is available. See Output.ApplyTWithContext
+// for details.
+func (out MapOutput) ApplyTWithContext(ctx context.Context, applier interface{}) Output {
+	return applyT(ctx, Output(out), mapType, applier)
+}
+
+var stringType = reflect.TypeOf((*string)(nil)).Elem()
+
+// StringInput is an input type that accepts String and StringOutput values.
+type StringInput interface {
+	Input
+
+	ToStringOutput() StringOutput
+}
+
+// String is an input type for string values.
+type String string
+
+// ElementType returns the element type of this Input (string).
+func (String) ElementType() reflect.Type {
+	return stringType
+}
+
+// ToStringOutput converts this input to a StringOutput.
+func (in String) ToStringOutput() StringOutput {
+	return StringOutput(ToOutput(in))
+}
+
+// StringOutput is an Output that is typed to return string values.
+type StringOutput Output
+
+// ElementType returns the element type of this Output (string).
+func (StringOutput) ElementType() reflect.Type {
+	return stringType
+}
+
+// ToStringOutput returns this output.
+func (out StringOutput) ToStringOutput() StringOutput {
+	return out
+}
+
+// Apply applies a transformation to the string value when it is available.
+func (out StringOutput) Apply(applier func(string) (interface{}, error)) Output {
+	return out.ApplyWithContext(context.Background(), func(_ context.Context, v string) (interface{}, error) {
+		return applier(v)
+	})
+}
+
+// ApplyWithContext applies a transformation to the string value when it is available.
+func (out StringOutput) ApplyWithContext(ctx context.Context, applier func(context.Context, string) (interface{}, error)) Output {
+	return Output(out).ApplyWithContext(ctx, func(ctx context.Context, v interface{}) (interface{}, error) {
+		return applier(ctx, convert(v, stringType).(string))
+	})
+}
+
+// ApplyT applies a transformation to the string value when it is available. The applier must be a function that
+// accepts a string and returns a single value, optionally followed by an error. See Output.ApplyT for details.
+func (out StringOutput) ApplyT(applier interface{}) Output {
+	return out.ApplyTWithContext(context.Background(), applier)
+}
+
+// ApplyTWithContext applies a transformation to the string value when it is available. See Output.ApplyTWithContext
+// for details.
+func (out StringOutput) ApplyTWithContext(ctx context.Context, applier interface{}) Output {
+	return applyT(ctx, Output(out), stringType, applier)
+}
+
+var uintType = reflect.TypeOf((*uint)(nil)).Elem()
+
+// UintInput is an input type that accepts Uint and UintOutput values.
+type UintInput interface {
+	Input
+
+	ToUintOutput() UintOutput
+}
+
+// Uint is an input type for uint values.
+type Uint uint
+
+// ElementType returns the element type of this Input (uint).
+func (Uint) ElementType() reflect.Type {
+	return uintType
+}
+
+// ToUintOutput converts this input to a UintOutput.
+func (in Uint) ToUintOutput() UintOutput {
+	return UintOutput(ToOutput(in))
+}
+
+// UintOutput is an Output that is typed to return uint values.
+type UintOutput Output
+
+// ElementType returns the element type of this Output (uint).
+func (UintOutput) ElementType() reflect.Type {
+	return uintType
+}
+
+// ToUintOutput returns this output.
+func (out UintOutput) ToUintOutput() UintOutput {
+	return out
+}
+
+// Apply applies a transformation to the uint value when it is available.
+func (out UintOutput) Apply(applier func(uint) (interface{}, error)) Output {
+	return out.ApplyWithContext(context.Background(), func(_ context.Context, v uint) (interface{}, error) {
+		return applier(v)
+	})
+}
+
+// ApplyWithContext applies a transformation to the uint value when it is available.
+func (out UintOutput) ApplyWithContext(ctx context.Context, applier func(context.Context, uint) (interface{}, error)) Output {
+	return Output(out).ApplyWithContext(ctx, func(ctx context.Context, v interface{}) (interface{}, error) {
+		return applier(ctx, convert(v, uintType).(uint))
+	})
+}
+
+// ApplyT applies a transformation to the uint value when it is available. The applier must be a function that
+// accepts a uint and returns a single value, optionally followed by an error. See Output.ApplyT for details.
+func (out UintOutput) ApplyT(applier interface{}) Output {
+	return out.ApplyTWithContext(context.Background(), applier)
+}
+
+// ApplyTWithContext applies a transformation to the uint value when it is available. See Output.ApplyTWithContext
+// for details.
+func (out UintOutput) ApplyTWithContext(ctx context.Context, applier interface{}) Output {
+	return applyT(ctx, Output(out), uintType, applier)
+}
+
+var uint8Type = reflect.TypeOf((*uint8)(nil)).Elem()
+
+// Uint8Input is an input type that accepts Uint8 and Uint8Output values.
+type Uint8Input interface {
+	Input
+
+	ToUint8Output() Uint8Output
+}
+
+// Uint8 is an input type for uint8 values.
+type Uint8 uint8
+
+// ElementType returns the element type of this Input (uint8).
+func (Uint8) ElementType() reflect.Type {
+	return uint8Type
+}
+
+// ToUint8Output converts this input to a Uint8Output.
+func (in Uint8) ToUint8Output() Uint8Output {
+	return Uint8Output(ToOutput(in))
+}
+
+// Uint8Output is an Output that is typed to return uint8 values.
+type Uint8Output Output
+
+// ElementType returns the element type of this Output (uint8).
+func (Uint8Output) ElementType() reflect.Type {
+	return uint8Type
+}
+
+// ToUint8Output returns this output.
+func (out Uint8Output) ToUint8Output() Uint8Output {
+	return out
+}
+
+// Apply applies a transformation to the uint8 value when it is available.
+func (out Uint8Output) Apply(applier func(uint8) (interface{}, error)) Output {
+	return out.ApplyWithContext(context.Background(), func(_ context.Context, v uint8) (interface{}, error) {
+		return applier(v)
+	})
+}
+
+// ApplyWithContext applies a transformation to the uint8 value when it is available.
+func (out Uint8Output) ApplyWithContext(ctx context.Context, applier func(context.Context, uint8) (interface{}, error)) Output {
+	return Output(out).ApplyWithContext(ctx, func(ctx context.Context, v interface{}) (interface{}, error) {
+		return applier(ctx, convert(v, uint8Type).(uint8))
+	})
+}
+
+// ApplyT applies a transformation to the uint8 value when it is available. The applier must be a function that
+// accepts a uint8 and returns a single value, optionally followed by an error. See Output.ApplyT for details.
+func (out Uint8Output) ApplyT(applier interface{}) Output {
+	return out.ApplyTWithContext(context.Background(), applier)
+}
+
+// ApplyTWithContext applies a transformation to the uint8 value when it is available. See Output.ApplyTWithContext
+// for details.
+func (out Uint8Output) ApplyTWithContext(ctx context.Context, applier interface{}) Output {
+	return applyT(ctx, Output(out), uint8Type, applier)
+}
+
+var uint16Type = reflect.TypeOf((*uint16)(nil)).Elem()
+
+// Uint16Input is an input type that accepts Uint16 and Uint16Output values.
+type Uint16Input interface {
+	Input
+
+	ToUint16Output() Uint16Output
+}
+
+// Uint16 is an input type for uint16 values.
+type Uint16 uint16
+
+// ElementType returns the element type of this Input (uint16).
+func (Uint16) ElementType() reflect.Type {
+	return uint16Type
+}
+
+// ToUint16Output converts this input to a Uint16Output.
+func (in Uint16) ToUint16Output() Uint16Output {
+	return Uint16Output(ToOutput(in))
+}
+
+// Uint16Output is an Output that is typed to return uint16 values.
+type Uint16Output Output
+
+// ElementType returns the element type of this Output (uint16).
+func (Uint16Output) ElementType() reflect.Type {
+	return uint16Type
+}
+
+// ToUint16Output returns this output.
+func (out Uint16Output) ToUint16Output() Uint16Output {
+	return out
+}
+
+// Apply applies a transformation to the uint16 value when it is available.
+func (out Uint16Output) Apply(applier func(uint16) (interface{}, error)) Output {
+	return out.ApplyWithContext(context.Background(), func(_ context.Context, v uint16) (interface{}, error) {
+		return applier(v)
+	})
+}
+
+// ApplyWithContext applies a transformation to the uint16 value when it is available.
+func (out Uint16Output) ApplyWithContext(ctx context.Context, applier func(context.Context, uint16) (interface{}, error)) Output {
+	return Output(out).ApplyWithContext(ctx, func(ctx context.Context, v interface{}) (interface{}, error) {
+		return applier(ctx, convert(v, uint16Type).(uint16))
+	})
+}
+
+// ApplyT applies a transformation to the uint16 value when it is available. The applier must be a function that
+// accepts a uint16 and returns a single value, optionally followed by an error. See Output.ApplyT for details.
+func (out Uint16Output) ApplyT(applier interface{}) Output {
+	return out.ApplyTWithContext(context.Background(), applier)
+}
+
+// ApplyTWithContext applies a transformation to the uint16 value when it is available. See Output.ApplyTWithContext
+// for details.
+func (out Uint16Output) ApplyTWithContext(ctx context.Context, applier interface{}) Output {
+	return applyT(ctx, Output(out), uint16Type, applier)
+}
+
+var uint32Type = reflect.TypeOf((*uint32)(nil)).Elem()
+
+// Uint32Input is an input type that accepts Uint32 and Uint32Output values.
+type Uint32Input interface {
+	Input
+
+	ToUint32Output() Uint32Output
+}
+
+// Uint32 is an input type for uint32 values.
+type Uint32 uint32
+
+// ElementType returns the element type of this Input (uint32).
+func (Uint32) ElementType() reflect.Type {
+	return uint32Type
+}
+
+// ToUint32Output converts this input to a Uint32Output.
+func (in Uint32) ToUint32Output() Uint32Output {
+	return Uint32Output(ToOutput(in))
+}
+
+// Uint32Output is an Output that is typed to return uint32 values.
+type Uint32Output Output
+
+// ElementType returns the element type of this Output (uint32).
+func (Uint32Output) ElementType() reflect.Type {
+	return uint32Type
+}
+
+// ToUint32Output returns this output.
+func (out Uint32Output) ToUint32Output() Uint32Output {
+	return out
+}
+
+// Apply applies a transformation to the uint32 value when it is available.
+func (out Uint32Output) Apply(applier func(uint32) (interface{}, error)) Output {
+	return out.ApplyWithContext(context.Background(), func(_ context.Context, v uint32) (interface{}, error) {
+		return applier(v)
+	})
+}
+
+// ApplyWithContext applies a transformation to the uint32 value when it is available.
+func (out Uint32Output) ApplyWithContext(ctx context.Context, applier func(context.Context, uint32) (interface{}, error)) Output {
+	return Output(out).ApplyWithContext(ctx, func(ctx context.Context, v interface{}) (interface{}, error) {
+		return applier(ctx, convert(v, uint32Type).(uint32))
+	})
+}
+
+// ApplyT applies a transformation to the uint32 value when it is available. The applier must be a function that
+// accepts a uint32 and returns a single value, optionally followed by an error. See Output.ApplyT for details.
+func (out Uint32Output) ApplyT(applier interface{}) Output {
+	return out.ApplyTWithContext(context.Background(), applier)
+}
+
+// ApplyTWithContext applies a transformation to the uint32 value when it is available. See Output.ApplyTWithContext
+// for details.
+func (out Uint32Output) ApplyTWithContext(ctx context.Context, applier interface{}) Output {
+	return applyT(ctx, Output(out), uint32Type, applier)
+}
+
+var uint64Type = reflect.TypeOf((*uint64)(nil)).Elem()
+
+// Uint64Input is an input type that accepts Uint64 and Uint64Output values.
+type Uint64Input interface {
+	Input
+
+	ToUint64Output() Uint64Output
+}
+
+// Uint64 is an input type for uint64 values.
+type Uint64 uint64
+
+// ElementType returns the element type of this Input (uint64).
+func (Uint64) ElementType() reflect.Type {
+	return uint64Type
+}
+
+// ToUint64Output converts this input to a Uint64Output.
+func (in Uint64) ToUint64Output() Uint64Output {
+	return Uint64Output(ToOutput(in))
+}
+
+// Uint64Output is an Output that is typed to return uint64 values.
+type Uint64Output Output
+
+// ElementType returns the element type of this Output (uint64).
+func (Uint64Output) ElementType() reflect.Type {
+	return uint64Type
+}
+
+// ToUint64Output returns this output.
+func (out Uint64Output) ToUint64Output() Uint64Output {
+	return out
+}
+
+// Apply applies a transformation to the uint64 value when it is available.
+func (out Uint64Output) Apply(applier func(uint64) (interface{}, error)) Output {
+	return out.ApplyWithContext(context.Background(), func(_ context.Context, v uint64) (interface{}, error) {
+		return applier(v)
+	})
+}
+
+// ApplyWithContext applies a transformation to the uint64 value when it is available.
+func (out Uint64Output) ApplyWithContext(ctx context.Context, applier func(context.Context, uint64) (interface{}, error)) Output {
+	return Output(out).ApplyWithContext(ctx, func(ctx context.Context, v interface{}) (interface{}, error) {
+		return applier(ctx, convert(v, uint64Type).(uint64))
+	})
+}
+
+// ApplyT applies a transformation to the uint64 value when it is available. The applier must be a function that
+// accepts a uint64 and returns a single value, optionally followed by an error. See Output.ApplyT for details.
+func (out Uint64Output) ApplyT(applier interface{}) Output {
+	return out.ApplyTWithContext(context.Background(), applier)
+}
+
+// ApplyTWithContext applies a transformation to the uint64 value when it is available. See Output.ApplyTWithContext
+// for details.
+func (out Uint64Output) ApplyTWithContext(ctx context.Context, applier interface{}) Output {
+	return applyT(ctx, Output(out), uint64Type, applier)
+}
+
+var urnType = reflect.TypeOf((*URN)(nil)).Elem()
+
+// URNInput is an input type that accepts URN and URNOutput values.
+type URNInput interface {
+	Input
+
+	ToURNOutput() URNOutput
+}
+
+// ElementType returns the element type of this Input (URN).
+func (URN) ElementType() reflect.Type {
+	return urnType
+}
+
+// ToURNOutput converts this input to a URNOutput.
+func (in URN) ToURNOutput() URNOutput {
+	return URNOutput(ToOutput(in))
+}
+
+// URNOutput is an Output that is typed to return URN values.
+type URNOutput Output
+
+// ElementType returns the element type of this Output (URN).
+func (URNOutput) ElementType() reflect.Type {
+	return urnType
+}
+
+// ToURNOutput returns this output.
+func (out URNOutput) ToURNOutput() URNOutput {
+	return out
+}
+
+// Apply applies a transformation to the URN value when it is available.
+func (out URNOutput) Apply(applier func(URN) (interface{}, error)) Output {
+	return out.ApplyWithContext(context.Background(), func(_ context.Context, v URN) (interface{}, error) {
+		return applier(v)
+	})
+}
+
+// ApplyWithContext applies a transformation to the URN value when it is available.
+func (out URNOutput) ApplyWithContext(ctx context.Context, applier func(context.Context, URN) (interface{}, error)) Output {
+	return Output(out).ApplyWithContext(ctx, func(ctx context.Context, v interface{}) (interface{}, error) {
+		return applier(ctx, convert(v, urnType).(URN))
+	})
+}
+
+// ApplyT applies a transformation to the URN value when it is available. The applier must be a function that
+// accepts a URN and returns a single value, optionally followed by an error. See Output.ApplyT for details.
+func (out URNOutput) ApplyT(applier interface{}) Output {
+	return out.ApplyTWithContext(context.Background(), applier)
+}
+
+// ApplyTWithContext applies a transformation to the URN value when it is available. See Output.ApplyTWithContext
+// for details.
+func (out URNOutput) ApplyTWithContext(ctx context.Context, applier interface{}) Output {
+	return applyT(ctx, Output(out), urnType, applier)
+}
+
+var boolArrayType = reflect.TypeOf((*[]bool)(nil)).Elem()
+
+// BoolArrayInput is an input type that accepts BoolArray and BoolArrayOutput values.
+type BoolArrayInput interface {
+	Input
+
+	ToBoolArrayOutput() BoolArrayOutput
+}
+
+// BoolArray is an input type for []bool values.
+type BoolArray []BoolInput
+
+// ElementType returns the element type of this Input ([]bool).
+func (BoolArray) ElementType() reflect.Type {
+	return boolArrayType
+}
+
+// ToBoolArrayOutput converts this input to a BoolArrayOutput.
+func (in BoolArray) ToBoolArrayOutput() BoolArrayOutput {
+	return BoolArrayOutput(ToOutput(in))
+}
+
+// BoolArrayOutput is an Output that is typed to return []bool values.
+type BoolArrayOutput Output
+
+// ElementType returns the element type of this Output ([]bool).
+func (BoolArrayOutput) ElementType() reflect.Type {
+	return boolArrayType
+}
+
+// ToBoolArrayOutput returns this output.
+func (out BoolArrayOutput) ToBoolArrayOutput() BoolArrayOutput {
+	return out
+}
+
+// Apply applies a transformation to the []bool value when it is available.
+func (out BoolArrayOutput) Apply(applier func([]bool) (interface{}, error)) Output {
+	return out.ApplyWithContext(context.Background(), func(_ context.Context, v []bool) (interface{}, error) {
+		return applier(v)
+	})
+}
+
+// ApplyWithContext applies a transformation to the []bool value when it is available.
+func (out BoolArrayOutput) ApplyWithContext(ctx context.Context, applier func(context.Context, []bool) (interface{}, error)) Output {
+	return Output(out).ApplyWithContext(ctx, func(ctx context.Context, v interface{}) (interface{}, error) {
+		return applier(ctx, convert(v, boolArrayType).([]bool))
+	})
+}
+
+// ApplyT applies a transformation to the []bool value when it is available. The applier must be a function that
+// accepts a []bool and returns a single value, optionally followed by an error. See Output.ApplyT for details.
+func (out BoolArrayOutput) ApplyT(applier interface{}) Output {
+	return out.ApplyTWithContext(context.Background(), applier)
+}
+
+// ApplyTWithContext applies a transformation to the []bool value when it is available. See Output.ApplyTWithContext
+// for details.
+func (out BoolArrayOutput) ApplyTWithContext(ctx context.Context, applier interface{}) Output {
+	return applyT(ctx, Output(out), boolArrayType, applier)
+}
+
+// Index returns an output that resolves to the element of the array at the given index. If the index is out of
+// range, the output resolves to the zero value.
+func (out BoolArrayOutput) Index(i IntInput) BoolOutput {
+	return BoolOutput(All(out, i).ApplyT(func(vs []interface{}) bool {
+		arr, idx := convert(vs[0], boolArrayType).([]bool), convert(vs[1], intType).(int)
+		if idx < 0 || idx >= len(arr) {
+			var zero bool
+			return zero
+		}
+		return arr[idx]
+	}))
+}
+
+var boolMapType = reflect.TypeOf((*map[string]bool)(nil)).Elem()
+
+// BoolMapInput is an input type that accepts BoolMap and BoolMapOutput values.
+type BoolMapInput interface {
+	Input
+
+	ToBoolMapOutput() BoolMapOutput
+}
+
+// BoolMap is an input type for map[string]bool values.
+type BoolMap map[string]BoolInput
+
+// ElementType returns the element type of this Input (map[string]bool).
+func (BoolMap) ElementType() reflect.Type {
+	return boolMapType
+}
+
+// ToBoolMapOutput converts this input to a BoolMapOutput.
+func (in BoolMap) ToBoolMapOutput() BoolMapOutput {
+	return BoolMapOutput(ToOutput(in))
+}
+
+// BoolMapOutput is an Output that is typed to return map[string]bool values.
+type BoolMapOutput Output
+
+// ElementType returns the element type of this Output (map[string]bool).
+func (BoolMapOutput) ElementType() reflect.Type {
+	return boolMapType
+}
+
+// ToBoolMapOutput returns this output.
+func (out BoolMapOutput) ToBoolMapOutput() BoolMapOutput {
+	return out
+}
+
+// Apply applies a transformation to the map[string]bool value when it is available.
+func (out BoolMapOutput) Apply(applier func(map[string]bool) (interface{}, error)) Output {
+	return out.ApplyWithContext(context.Background(), func(_ context.Context, v map[string]bool) (interface{}, error) {
+		return applier(v)
+	})
+}
+
+// ApplyWithContext applies a transformation to the map[string]bool value when it is available.
+func (out BoolMapOutput) ApplyWithContext(ctx context.Context, applier func(context.Context, map[string]bool) (interface{}, error)) Output {
+	return Output(out).ApplyWithContext(ctx, func(ctx context.Context, v interface{}) (interface{}, error) {
+		return applier(ctx, convert(v, boolMapType).(map[string]bool))
+	})
+}
+
+// ApplyT applies a transformation to the map[string]bool value when it is available. The applier must be a function that
+// accepts a map[string]bool and returns a single value, optionally followed by an error. See Output.ApplyT for details.
+func (out BoolMapOutput) ApplyT(applier interface{}) Output {
+	return out.ApplyTWithContext(context.Background(), applier)
+}
+
+// ApplyTWithContext applies a transformation to the map[string]bool value when it is available. See Output.ApplyTWithContext
+// for details.
+func (out BoolMapOutput) ApplyTWithContext(ctx context.Context, applier interface{}) Output {
+	return applyT(ctx, Output(out), boolMapType, applier)
+}
+
+// MapIndex returns an output that resolves to the element of the map with the given key. If the key is not present,
+// the output resolves to the zero value.
+func (out BoolMapOutput) MapIndex(k StringInput) BoolOutput {
+	return BoolOutput(All(out, k).ApplyT(func(vs []interface{}) bool {
+		m, key := convert(vs[0], boolMapType).(map[string]bool), convert(vs[1], stringType).(string)
+		return m[key]
+	}))
+}
+
+var float32ArrayType = reflect.TypeOf((*[]float32)(nil)).Elem()
+
+// Float32ArrayInput is an input type that accepts Float32Array and Float32ArrayOutput values.
+type Float32ArrayInput interface {
+	Input
+
+	ToFloat32ArrayOutput() Float32ArrayOutput
+}
+
+// Float32Array is an input type for []float32 values.
+type Float32Array []Float32Input
+
+// ElementType returns the element type of this Input ([]float32).
+func (Float32Array) ElementType() reflect.Type {
+	return float32ArrayType
+}
+
+// ToFloat32ArrayOutput converts this input to a Float32ArrayOutput.
+func (in Float32Array) ToFloat32ArrayOutput() Float32ArrayOutput {
+	return Float32ArrayOutput(ToOutput(in))
+}
+
+// Float32ArrayOutput is an Output that is typed to return []float32 values.
+type Float32ArrayOutput Output
+
+// ElementType returns the element type of this Output ([]float32).
+func (Float32ArrayOutput) ElementType() reflect.Type {
+	return float32ArrayType
+}
+
+// ToFloat32ArrayOutput returns this output.
+func (out Float32ArrayOutput) ToFloat32ArrayOutput() Float32ArrayOutput {
+	return out
+}
+
+// Apply applies a transformation to the []float32 value when it is available.
+func (out Float32ArrayOutput) Apply(applier func([]float32) (interface{}, error)) Output {
+	return out.ApplyWithContext(context.Background(), func(_ context.Context, v []float32) (interface{}, error) {
+		return applier(v)
+	})
+}
+
+// ApplyWithContext applies a transformation to the []float32 value when it is available.
+func (out Float32ArrayOutput) ApplyWithContext(ctx context.Context, applier func(context.Context, []float32) (interface{}, error)) Output {
+	return Output(out).ApplyWithContext(ctx, func(ctx context.Context, v interface{}) (interface{}, error) {
+		return applier(ctx, convert(v, float32ArrayType).([]float32))
+	})
+}
+
+// ApplyT applies a transformation to the []float32 value when it is available. The applier must be a function that
+// accepts a []float32 and returns a single value, optionally followed by an error. See Output.ApplyT for details.
+func (out Float32ArrayOutput) ApplyT(applier interface{}) Output {
+	return out.ApplyTWithContext(context.Background(), applier)
+}
+
+// ApplyTWithContext applies a transformation to the []float32 value when it is available. See Output.ApplyTWithContext
+// for details.
+func (out Float32ArrayOutput) ApplyTWithContext(ctx context.Context, applier interface{}) Output {
+	return applyT(ctx, Output(out), float32ArrayType, applier)
+}
+
+// Index returns an output that resolves to the element of the array at the given index. If the index is out of
+// range, the output resolves to the zero value.
+func (out Float32ArrayOutput) Index(i IntInput) Float32Output {
+	return Float32Output(All(out, i).ApplyT(func(vs []interface{}) float32 {
+		arr, idx := convert(vs[0], float32ArrayType).([]float32), convert(vs[1], intType).(int)
+		if idx < 0 || idx >= len(arr) {
+			var zero float32
+			return zero
+		}
+		return arr[idx]
+	}))
+}
+
+var float32MapType = reflect.TypeOf((*map[string]float32)(nil)).Elem()
+
+// Float32MapInput is an input type that accepts Float32Map and Float32MapOutput values.
+type Float32MapInput interface {
+	Input
+
+	ToFloat32MapOutput() Float32MapOutput
+}
+
+// Float32Map is an input type for map[string]float32 values.
+type Float32Map map[string]Float32Input
+
+// ElementType returns the element type of this Input (map[string]float32).
+func (Float32Map) ElementType() reflect.Type {
+	return float32MapType
+}
+
+// ToFloat32MapOutput converts this input to a Float32MapOutput.
+func (in Float32Map) ToFloat32MapOutput() Float32MapOutput {
+	return Float32MapOutput(ToOutput(in))
+}
+
+// Float32MapOutput is an Output that is typed to return map[string]float32 values.
+type Float32MapOutput Output
+
+// ElementType returns the element type of this Output (map[string]float32).
+func (Float32MapOutput) ElementType() reflect.Type {
+	return float32MapType
+}
+
+// ToFloat32MapOutput returns this output.
+func (out Float32MapOutput) ToFloat32MapOutput() Float32MapOutput {
+	return out
+}
+
+// Apply applies a transformation to the map[string]float32 value when it is available.
+func (out Float32MapOutput) Apply(applier func(map[string]float32) (interface{}, error)) Output {
+	return out.ApplyWithContext(context.Background(), func(_ context.Context, v map[string]float32) (interface{}, error) {
+		return applier(v)
+	})
+}
+
+// ApplyWithContext applies a transformation to the map[string]float32 value when it is available.
+func (out Float32MapOutput) ApplyWithContext(ctx context.Context, applier func(context.Context, map[string]float32) (interface{}, error)) Output {
+	return Output(out).ApplyWithContext(ctx, func(ctx context.Context, v interface{}) (interface{}, error) {
+		return applier(ctx, convert(v, float32MapType).(map[string]float32))
+	})
+}
+
+// ApplyT applies a transformation to the map[string]float32 value when it is available. The applier must be a function that
+// accepts a map[string]float32 and returns a single value, optionally followed by an error. See Output.ApplyT for details.
+func (out Float32MapOutput) ApplyT(applier interface{}) Output {
+	return out.ApplyTWithContext(context.Background(), applier)
+}
+
+// ApplyTWithContext applies a transformation to the map[string]float32 value when it is available. See Output.ApplyTWithContext
+// for details.
+func (out Float32MapOutput) ApplyTWithContext(ctx context.Context, applier interface{}) Output {
+	return applyT(ctx, Output(out), float32MapType, applier)
+}
+
+// MapIndex returns an output that resolves to the element of the map with the given key. If the key is not present,
+// the output resolves to the zero value.
+func (out Float32MapOutput) MapIndex(k StringInput) Float32Output {
+	return Float32Output(All(out, k).ApplyT(func(vs []interface{}) float32 {
+		m, key := convert(vs[0], float32MapType).(map[string]float32), convert(vs[1], stringType).(string)
+		return m[key]
+	}))
+}
+
+var float64ArrayType = reflect.TypeOf((*[]float64)(nil)).Elem()
+
+// Float64ArrayInput is an input type that accepts Float64Array and Float64ArrayOutput values.
+type Float64ArrayInput interface {
+	Input
+
+	ToFloat64ArrayOutput() Float64ArrayOutput
+}
+
+// Float64Array is an input type for []float64 values.
+type Float64Array []Float64Input
+
+// ElementType returns the element type of this Input ([]float64).
+func (Float64Array) ElementType() reflect.Type {
+	return float64ArrayType
+}
+
+// ToFloat64ArrayOutput converts this input to a Float64ArrayOutput.
+func (in Float64Array) ToFloat64ArrayOutput() Float64ArrayOutput {
+	return Float64ArrayOutput(ToOutput(in))
+}
+
+// Float64ArrayOutput is an Output that is typed to return []float64 values.
+type Float64ArrayOutput Output
+
+// ElementType returns the element type of this Output ([]float64).
+func (Float64ArrayOutput) ElementType() reflect.Type {
+	return float64ArrayType
+}
+
+// ToFloat64ArrayOutput returns this output.
+func (out Float64ArrayOutput) ToFloat64ArrayOutput() Float64ArrayOutput {
+	return out
+}
+
+// Apply applies a transformation to the []float64 value when it is available.
+func (out Float64ArrayOutput) Apply(applier func([]float64) (interface{}, error)) Output {
+	return out.ApplyWithContext(context.Background(), func(_ context.Context, v []float64) (interface{}, error) {
+		return applier(v)
+	})
+}
+
+// ApplyWithContext applies a transformation to the []float64 value when it is available.
+func (out Float64ArrayOutput) ApplyWithContext(ctx context.Context, applier func(context.Context, []float64) (interface{}, error)) Output {
+	return Output(out).ApplyWithContext(ctx, func(ctx context.Context, v interface{}) (interface{}, error) {
+		return applier(ctx, convert(v, float64ArrayType).([]float64))
+	})
+}
+
+// ApplyT applies a transformation to the []float64 value when it is available. The applier must be a function that
+// accepts a []float64 and returns a single value, optionally followed by an error. See Output.ApplyT for details.
+func (out Float64ArrayOutput) ApplyT(applier interface{}) Output {
+	return out.ApplyTWithContext(context.Background(), applier)
+}
+
+// ApplyTWithContext applies a transformation to the []float64 value when it is available. See Output.ApplyTWithContext
+// for details.
+func (out Float64ArrayOutput) ApplyTWithContext(ctx context.Context, applier interface{}) Output {
+	return applyT(ctx, Output(out), float64ArrayType, applier)
+}
+
+// Index returns an output that resolves to the element of the array at the given index. If the index is out of
+// range, the output resolves to the zero value.
+func (out Float64ArrayOutput) Index(i IntInput) Float64Output {
+	return Float64Output(All(out, i).ApplyT(func(vs []interface{}) float64 {
+		arr, idx := convert(vs[0], float64ArrayType).([]float64), convert(vs[1], intType).(int)
+		if idx < 0 || idx >= len(arr) {
+			var zero float64
+			return zero
+		}
+		return arr[idx]
+	}))
+}
+
+var float64MapType = reflect.TypeOf((*map[string]float64)(nil)).Elem()
+
+// Float64MapInput is an input type that accepts Float64Map and Float64MapOutput values.
+type Float64MapInput interface {
+	Input
+
+	ToFloat64MapOutput() Float64MapOutput
+}
+
+// Float64Map is an input type for map[string]float64 values.
+type Float64Map map[string]Float64Input
+
+// ElementType returns the element type of this Input (map[string]float64).
+func (Float64Map) ElementType() reflect.Type {
+	return float64MapType
+}
+
+// ToFloat64MapOutput converts this input to a Float64MapOutput.
+func (in Float64Map) ToFloat64MapOutput() Float64MapOutput {
+	return Float64MapOutput(ToOutput(in))
+}
+
+// Float64MapOutput is an Output that is typed to return map[string]float64 values.
+type Float64MapOutput Output
+
+// ElementType returns the element type of this Output (map[string]float64).
+func (Float64MapOutput) ElementType() reflect.Type {
+	return float64MapType
+}
+
+// ToFloat64MapOutput returns this output.
+func (out Float64MapOutput) ToFloat64MapOutput() Float64MapOutput {
+	return out
+}
+
+// Apply applies a transformation to the map[string]float64 value when it is available.
+func (out Float64MapOutput) Apply(applier func(map[string]float64) (interface{}, error)) Output {
+	return out.ApplyWithContext(context.Background(), func(_ context.Context, v map[string]float64) (interface{}, error) {
+		return applier(v)
+	})
+}
+
+// ApplyWithContext applies a transformation to the map[string]float64 value when it is available.
+func (out Float64MapOutput) ApplyWithContext(ctx context.Context, applier func(context.Context, map[string]float64) (interface{}, error)) Output {
+	return Output(out).ApplyWithContext(ctx, func(ctx context.Context, v interface{}) (interface{}, error) {
+		return applier(ctx, convert(v, float64MapType).(map[string]float64))
+	})
+}
+
+// ApplyT applies a transformation to the map[string]float64 value when it is available. The applier must be a function that
+// accepts a map[string]float64 and returns a single value, optionally followed by an error. See Output.ApplyT for details.
+func (out Float64MapOutput) ApplyT(applier interface{}) Output {
+	return out.ApplyTWithContext(context.Background(), applier)
+}
+
+// ApplyTWithContext applies a transformation to the map[string]float64 value when it is available. See Output.ApplyTWithContext
+// for details.
+func (out Float64MapOutput) ApplyTWithContext(ctx context.Context, applier interface{}) Output {
+	return applyT(ctx, Output(out), float64MapType, applier)
+}
+
+// MapIndex returns an output that resolves to the element of the map with the given key. If the key is not present,
+// the output resolves to the zero value.
+func (out Float64MapOutput) MapIndex(k StringInput) Float64Output {
+	return Float64Output(All(out, k).ApplyT(func(vs []interface{}) float64 {
+		m, key := convert(vs[0], float64MapType).(map[string]float64), convert(vs[1], stringType).(string)
+		return m[key]
+	}))
+}
+
+var idArrayType = reflect.TypeOf((*[]ID)(nil)).Elem()
+
+// IDArrayInput is an input type that accepts IDArray and IDArrayOutput values.
+type IDArrayInput interface {
+	Input
+
+	ToIDArrayOutput() IDArrayOutput
+}
+
+// IDArray is an input type for []ID values.
+type IDArray []IDInput
+
+// ElementType returns the element type of this Input ([]ID).
+func (IDArray) ElementType() reflect.Type {
+	return idArrayType
+}
+
+// ToIDArrayOutput converts this input to a IDArrayOutput.
+func (in IDArray) ToIDArrayOutput() IDArrayOutput {
+	return IDArrayOutput(ToOutput(in))
+}
+
+// IDArrayOutput is an Output that is typed to return []ID values.
+type IDArrayOutput Output
+
+// ElementType returns the element type of this Output ([]ID).
+func (IDArrayOutput) ElementType() reflect.Type {
+	return idArrayType
+}
+
+// ToIDArrayOutput returns this output.
+func (out IDArrayOutput) ToIDArrayOutput() IDArrayOutput {
+	return out
+}
+
+// Apply applies a transformation to the []ID value when it is available.
+func (out IDArrayOutput) Apply(applier func([]ID) (interface{}, error)) Output {
+	return out.ApplyWithContext(context.Background(), func(_ context.Context, v []ID) (interface{}, error) {
+		return applier(v)
+	})
+}
+
+// ApplyWithContext applies a transformation to the []ID value when it is available.
+func (out IDArrayOutput) ApplyWithContext(ctx context.Context, applier func(context.Context, []ID) (interface{}, error)) Output {
+	return Output(out).ApplyWithContext(ctx, func(ctx context.Context, v interface{}) (interface{}, error) {
+		return applier(ctx, convert(v, idArrayType).([]ID))
+	})
+}
+
+// ApplyT applies a transformation to the []ID value when it is available. The applier must be a function that
+// accepts a []ID and returns a single value, optionally followed by an error. See Output.ApplyT for details.
+func (out IDArrayOutput) ApplyT(applier interface{}) Output {
+	return out.ApplyTWithContext(context.Background(), applier)
+}
+
+// ApplyTWithContext applies a transformation to the []ID value when it is available. See Output.ApplyTWithContext
+// for details.
+func (out IDArrayOutput) ApplyTWithContext(ctx context.Context, applier interface{}) Output {
+	return applyT(ctx, Output(out), idArrayType, applier)
+}
+
+// Index returns an output that resolves to the element of the array at the given index. If the index is out of
+// range, the output resolves to the zero value.
+func (out IDArrayOutput) Index(i IntInput) IDOutput {
+	return IDOutput(All(out, i).ApplyT(func(vs []interface{}) ID {
+		arr, idx := convert(vs[0], idArrayType).([]ID), convert(vs[1], intType).(int)
+		if idx < 0 || idx >= len(arr) {
+			var zero ID
+			return zero
+		}
+		return arr[idx]
+	}))
+}
+
+var idMapType = reflect.TypeOf((*map[string]ID)(nil)).Elem()
+
+// IDMapInput is an input type that accepts IDMap and IDMapOutput values.
+type IDMapInput interface {
+	Input
+
+	ToIDMapOutput() IDMapOutput
+}
+
+// IDMap is an input type for map[string]ID values.
+type IDMap map[string]IDInput
+
+// ElementType returns the element type of this Input (map[string]ID).
+func (IDMap) ElementType() reflect.Type {
+	return idMapType
+}
+
+// ToIDMapOutput converts this input to a IDMapOutput.
+func (in IDMap) ToIDMapOutput() IDMapOutput {
+	return IDMapOutput(ToOutput(in))
+}
+
+// IDMapOutput is an Output that is typed to return map[string]ID values.
+type IDMapOutput Output
+
+// ElementType returns the element type of this Output (map[string]ID).
+func (IDMapOutput) ElementType() reflect.Type {
+	return idMapType
+}
+
+// ToIDMapOutput returns this output.
+func (out IDMapOutput) ToIDMapOutput() IDMapOutput {
+	return out
+}
+
+// Apply applies a transformation to the map[string]ID value when it is available.
+func (out IDMapOutput) Apply(applier func(map[string]ID) (interface{}, error)) Output {
+	return out.ApplyWithContext(context.Background(), func(_ context.Context, v map[string]ID) (interface{}, error) {
+		return applier(v)
+	})
+}
+
+// ApplyWithContext applies a transformation to the map[string]ID value when it is available.
+func (out IDMapOutput) ApplyWithContext(ctx context.Context, applier func(context.Context, map[string]ID) (interface{}, error)) Output {
+	return Output(out).ApplyWithContext(ctx, func(ctx context.Context, v interface{}) (interface{}, error) {
+		return applier(ctx, convert(v, idMapType).(map[string]ID))
+	})
+}
+
+// ApplyT applies a transformation to the map[string]ID value when it is available. The applier must be a function that
+// accepts a map[string]ID and returns a single value, optionally followed by an error. See Output.ApplyT for details.
+func (out IDMapOutput) ApplyT(applier interface{}) Output {
+	return out.ApplyTWithContext(context.Background(), applier)
+}
+
+// ApplyTWithContext applies a transformation to the map[string]ID value when it is available. See Output.ApplyTWithContext
+// for details.
+func (out IDMapOutput) ApplyTWithContext(ctx context.Context, applier interface{}) Output {
+	return applyT(ctx, Output(out), idMapType, applier)
+}
+
+// MapIndex returns an output that resolves to the element of the map with the given key. If the key is not present,
+// the output resolves to the zero value.
+func (out IDMapOutput) MapIndex(k StringInput) IDOutput {
+	return IDOutput(All(out, k).ApplyT(func(vs []interface{}) ID {
+		m, key := convert(vs[0], idMapType).(map[string]ID), convert(vs[1], stringType).(string)
+		return m[key]
+	}))
+}
+
+var intArrayType = reflect.TypeOf((*[]int)(nil)).Elem()
+
+// IntArrayInput is an input type that accepts IntArray and IntArrayOutput values.
+type IntArrayInput interface {
+	Input
+
+	ToIntArrayOutput() IntArrayOutput
+}
+
+// IntArray is an input type for []int values.
+type IntArray []IntInput
+
+// ElementType returns the element type of this Input ([]int).
+func (IntArray) ElementType() reflect.Type {
+	return intArrayType
+}
+
+// ToIntArrayOutput converts this input to a IntArrayOutput.
+func (in IntArray) ToIntArrayOutput() IntArrayOutput {
+	return IntArrayOutput(ToOutput(in))
+}
+
+// IntArrayOutput is an Output that is typed to return []int values.
+type IntArrayOutput Output
+
+// ElementType returns the element type of this Output ([]int).
+func (IntArrayOutput) ElementType() reflect.Type {
+	return intArrayType
+}
+
+// ToIntArrayOutput returns this output.
+func (out IntArrayOutput) ToIntArrayOutput() IntArrayOutput {
+	return out
+}
+
+// Apply applies a transformation to the []int value when it is available.
+func (out IntArrayOutput) Apply(applier func([]int) (interface{}, error)) Output {
+	return out.ApplyWithContext(context.Background(), func(_ context.Context, v []int) (interface{}, error) {
+		return applier(v)
+	})
+}
+
+// ApplyWithContext applies a transformation to the []int value when it is available.
+func (out IntArrayOutput) ApplyWithContext(ctx context.Context, applier func(context.Context, []int) (interface{}, error)) Output {
+	return Output(out).ApplyWithContext(ctx, func(ctx context.Context, v interface{}) (interface{}, error) {
+		return applier(ctx, convert(v, intArrayType).([]int))
+	})
+}
+
+// ApplyT applies a transformation to the []int value when it is available. The applier must be a function that
+// accepts a []int and returns a single value, optionally followed by an error. See Output.ApplyT for details.
+func (out IntArrayOutput) ApplyT(applier interface{}) Output {
+	return out.ApplyTWithContext(context.Background(), applier)
+}
+
+// ApplyTWithContext applies a transformation to the []int value when it is available. See Output.ApplyTWithContext
+// for details.
+func (out IntArrayOutput) ApplyTWithContext(ctx context.Context, applier interface{}) Output {
+	return applyT(ctx, Output(out), intArrayType, applier)
+}
+
+// Index returns an output that resolves to the element of the array at the given index. If the index is out of
+// range, the output resolves to the zero value.
+func (out IntArrayOutput) Index(i IntInput) IntOutput {
+	return IntOutput(All(out, i).ApplyT(func(vs []interface{}) int {
+		arr, idx := convert(vs[0], intArrayType).([]int), convert(vs[1], intType).(int)
+		if idx < 0 || idx >= len(arr) {
+			var zero int
+			return zero
+		}
+		return arr[idx]
+	}))
+}
+
+var intMapType = reflect.TypeOf((*map[string]int)(nil)).Elem()
+
+// IntMapInput is an input type that accepts IntMap and IntMapOutput values.
+type IntMapInput interface {
+	Input
+
+	ToIntMapOutput() IntMapOutput
+}
+
+// IntMap is an input type for map[string]int values.
+type IntMap map[string]IntInput
+
+// ElementType returns the element type of this Input (map[string]int).
+func (IntMap) ElementType() reflect.Type {
+	return intMapType
+}
+
+// ToIntMapOutput converts this input to a IntMapOutput.
+func (in IntMap) ToIntMapOutput() IntMapOutput {
+	return IntMapOutput(ToOutput(in))
+}
+
+// IntMapOutput is an Output that is typed to return map[string]int values.
+type IntMapOutput Output
+
+// ElementType returns the element type of this Output (map[string]int).
+func (IntMapOutput) ElementType() reflect.Type {
+	return intMapType
+}
+
+// ToIntMapOutput returns this output.
+func (out IntMapOutput) ToIntMapOutput() IntMapOutput {
+	return out
+}
+
+// Apply applies a transformation to the map[string]int value when it is available.
+func (out IntMapOutput) Apply(applier func(map[string]int) (interface{}, error)) Output {
+	return out.ApplyWithContext(context.Background(), func(_ context.Context, v map[string]int) (interface{}, error) {
+		return applier(v)
+	})
+}
+
+// ApplyWithContext applies a transformation to the map[string]int value when it is available.
+func (out IntMapOutput) ApplyWithContext(ctx context.Context, applier func(context.Context, map[string]int) (interface{}, error)) Output {
+	return Output(out).ApplyWithContext(ctx, func(ctx context.Context, v interface{}) (interface{}, error) {
+		return applier(ctx, convert(v, intMapType).(map[string]int))
+	})
+}
+
+// ApplyT applies a transformation to the map[string]int value when it is available. The applier must be a function that
+// accepts a map[string]int and returns a single value, optionally followed by an error. See Output.ApplyT for details.
+func (out IntMapOutput) ApplyT(applier interface{}) Output {
+	return out.ApplyTWithContext(context.Background(), applier)
+}
+
+// ApplyTWithContext applies a transformation to the map[string]int value when it is available. See Output.ApplyTWithContext
+// for details.
+func (out IntMapOutput) ApplyTWithContext(ctx context.Context, applier interface{}) Output {
+	return applyT(ctx, Output(out), intMapType, applier)
+}
+
+// MapIndex returns an output that resolves to the element of the map with the given key. If the key is not present,
+// the output resolves to the zero value.
+func (out IntMapOutput) MapIndex(k StringInput) IntOutput {
+	return IntOutput(All(out, k).ApplyT(func(vs []interface{}) int {
+		m, key := convert(vs[0], intMapType).(map[string]int), convert(vs[1], stringType).(string)
+		return m[key]
+	}))
+}
+
+var int8ArrayType = reflect.TypeOf((*[]int8)(nil)).Elem()
+
+// Int8ArrayInput is an input type that accepts Int8Array and Int8ArrayOutput values.
+type Int8ArrayInput interface {
+	Input
+
+	ToInt8ArrayOutput() Int8ArrayOutput
+}
+
+// Int8Array is an input type for []int8 values.
+type Int8Array []Int8Input
+
+// ElementType returns the element type of this Input ([]int8).
+func (Int8Array) ElementType() reflect.Type {
+	return int8ArrayType
+}
+
+// ToInt8ArrayOutput converts this input to a Int8ArrayOutput.
+func (in Int8Array) ToInt8ArrayOutput() Int8ArrayOutput {
+	return Int8ArrayOutput(ToOutput(in))
+}
+
+// Int8ArrayOutput is an Output that is typed to return []int8 values.
+type Int8ArrayOutput Output
+
+// ElementType returns the element type of this Output ([]int8).
+func (Int8ArrayOutput) ElementType() reflect.Type {
+	return int8ArrayType
+}
+
+// ToInt8ArrayOutput returns this output.
+func (out Int8ArrayOutput) ToInt8ArrayOutput() Int8ArrayOutput {
+	return out
+}
+
+// Apply applies a transformation to the []int8 value when it is available.
+func (out Int8ArrayOutput) Apply(applier func([]int8) (interface{}, error)) Output {
+	return out.ApplyWithContext(context.Background(), func(_ context.Context, v []int8) (interface{}, error) {
+		return applier(v)
+	})
+}
+
+// ApplyWithContext applies a transformation to the []int8 value when it is available.
+func (out Int8ArrayOutput) ApplyWithContext(ctx context.Context, applier func(context.Context, []int8) (interface{}, error)) Output {
+	return Output(out).ApplyWithContext(ctx, func(ctx context.Context, v interface{}) (interface{}, error) {
+		return applier(ctx, convert(v, int8ArrayType).([]int8))
+	})
+}
+
+// ApplyT applies a transformation to the []int8 value when it is available. The applier must be a function that
+// accepts a []int8 and returns a single value, optionally followed by an error. See Output.ApplyT for details.
+func (out Int8ArrayOutput) ApplyT(applier interface{}) Output {
+	return out.ApplyTWithContext(context.Background(), applier)
+}
+
+// ApplyTWithContext applies a transformation to the []int8 value when it is available. See Output.ApplyTWithContext
+// for details.
+func (out Int8ArrayOutput) ApplyTWithContext(ctx context.Context, applier interface{}) Output {
+	return applyT(ctx, Output(out), int8ArrayType, applier)
+}
+
+// Index returns an output that resolves to the element of the array at the given index. If the index is out of
+// range, the output resolves to the zero value.
+func (out Int8ArrayOutput) Index(i IntInput) Int8Output {
+	return Int8Output(All(out, i).ApplyT(func(vs []interface{}) int8 {
+		arr, idx := convert(vs[0], int8ArrayType).([]int8), convert(vs[1], intType).(int)
+		if idx < 0 || idx >= len(arr) {
+			var zero int8
+			return zero
+		}
+		return arr[idx]
+	}))
+}
+
+var int8MapType = reflect.TypeOf((*map[string]int8)(nil)).Elem()
+
+// Int8MapInput is an input type that accepts Int8Map and Int8MapOutput values.
+type Int8MapInput interface {
+	Input
+
+	ToInt8MapOutput() Int8MapOutput
+}
+
+// Int8Map is an input type for map[string]int8 values.
+type Int8Map map[string]Int8Input
+
+// ElementType returns the element type of this Input (map[string]int8).
+func (Int8Map) ElementType() reflect.Type {
+	return int8MapType
+}
+
+// ToInt8MapOutput converts this input to a Int8MapOutput.
+func (in Int8Map) ToInt8MapOutput() Int8MapOutput {
+	return Int8MapOutput(ToOutput(in))
+}
+
+// Int8MapOutput is an Output that is typed to return map[string]int8 values.
+type Int8MapOutput Output
+
+// ElementType returns the element type of this Output (map[string]int8).
+func (Int8MapOutput) ElementType() reflect.Type {
+	return int8MapType
+}
+
+// ToInt8MapOutput returns this output.
+func (out Int8MapOutput) ToInt8MapOutput() Int8MapOutput {
+	return out
+}
+
+// Apply applies a transformation to the map[string]int8 value when it is available.
+func (out Int8MapOutput) Apply(applier func(map[string]int8) (interface{}, error)) Output {
+	return out.ApplyWithContext(context.Background(), func(_ context.Context, v map[string]int8) (interface{}, error) {
+		return applier(v)
+	})
+}
+
+// ApplyWithContext applies a transformation to the map[string]int8 value when it is available.
+func (out Int8MapOutput) ApplyWithContext(ctx context.Context, applier func(context.Context, map[string]int8) (interface{}, error)) Output {
+	return Output(out).ApplyWithContext(ctx, func(ctx context.Context, v interface{}) (interface{}, error) {
+		return applier(ctx, convert(v, int8MapType).(map[string]int8))
+	})
+}
+
+// ApplyT applies a transformation to the map[string]int8 value when it is available. The applier must be a function that
+// accepts a map[string]int8 and returns a single value, optionally followed by an error. See Output.ApplyT for details.
+func (out Int8MapOutput) ApplyT(applier interface{}) Output {
+	return out.ApplyTWithContext(context.Background(), applier)
+}
+
+// ApplyTWithContext applies a transformation to the map[string]int8 value when it is available. See Output.ApplyTWithContext
+// for details.
+func (out Int8MapOutput) ApplyTWithContext(ctx context.Context, applier interface{}) Output {
+	return applyT(ctx, Output(out), int8MapType, applier)
+}
+
+// MapIndex returns an output that resolves to the element of the map with the given key. If the key is not present,
+// the output resolves to the zero value.
+func (out Int8MapOutput) MapIndex(k StringInput) Int8Output {
+	return Int8Output(All(out, k).ApplyT(func(vs []interface{}) int8 {
+		m, key := convert(vs[0], int8MapType).(map[string]int8), convert(vs[1], stringType).(string)
+		return m[key]
+	}))
+}
+
+var int16ArrayType = reflect.TypeOf((*[]int16)(nil)).Elem()
+
+// Int16ArrayInput is an input type that accepts Int16Array and Int16ArrayOutput values.
+type Int16ArrayInput interface {
+	Input
+
+	ToInt16ArrayOutput() Int16ArrayOutput
+}
+
+// Int16Array is an input type for []int16 values.
+type Int16Array []Int16Input
+
+// ElementType returns the element type of this Input ([]int16).
+func (Int16Array) ElementType() reflect.Type {
+	return int16ArrayType
+}
+
+// ToInt16ArrayOutput converts this input to a Int16ArrayOutput.
+func (in Int16Array) ToInt16ArrayOutput() Int16ArrayOutput {
+	return Int16ArrayOutput(ToOutput(in))
+}
+
+// Int16ArrayOutput is an Output that is typed to return []int16 values.
+type Int16ArrayOutput Output
+
+// ElementType returns the element type of this Output ([]int16).
+func (Int16ArrayOutput) ElementType() reflect.Type {
+	return int16ArrayType
+}
+
+// ToInt16ArrayOutput returns this output.
+func (out Int16ArrayOutput) ToInt16ArrayOutput() Int16ArrayOutput {
+	return out
+}
+
+// Apply applies a transformation to the []int16 value when it is available.
+func (out Int16ArrayOutput) Apply(applier func([]int16) (interface{}, error)) Output {
+	return out.ApplyWithContext(context.Background(), func(_ context.Context, v []int16) (interface{}, error) {
+		return applier(v)
+	})
+}
+
+// ApplyWithContext applies a transformation to the []int16 value when it is available.
+func (out Int16ArrayOutput) ApplyWithContext(ctx context.Context, applier func(context.Context, []int16) (interface{}, error)) Output {
+	return Output(out).ApplyWithContext(ctx, func(ctx context.Context, v interface{}) (interface{}, error) {
+		return applier(ctx, convert(v, int16ArrayType).([]int16))
+	})
+}
+
+// ApplyT applies a transformation to the []int16 value when it is available. The applier must be a function that
+// accepts a []int16 and returns a single value, optionally followed by an error. See Output.ApplyT for details.
+func (out Int16ArrayOutput) ApplyT(applier interface{}) Output {
+	return out.ApplyTWithContext(context.Background(), applier)
+}
+
+// ApplyTWithContext applies a transformation to the []int16 value when it is available. See Output.ApplyTWithContext
+// for details.
+func (out Int16ArrayOutput) ApplyTWithContext(ctx context.Context, applier interface{}) Output {
+	return applyT(ctx, Output(out), int16ArrayType, applier)
+}
+
+// Index returns an output that resolves to the element of the array at the given index. If the index is out of
+// range, the output resolves to the zero value.
+func (out Int16ArrayOutput) Index(i IntInput) Int16Output {
+	return Int16Output(All(out, i).ApplyT(func(vs []interface{}) int16 {
+		arr, idx := convert(vs[0], int16ArrayType).([]int16), convert(vs[1], intType).(int)
+		if idx < 0 || idx >= len(arr) {
+			var zero int16
+			return zero
+		}
+		return arr[idx]
+	}))
+}
+
+var int16MapType = reflect.TypeOf((*map[string]int16)(nil)).Elem()
+
+// Int16MapInput is an input type that accepts Int16Map and Int16MapOutput values.
+type Int16MapInput interface {
+	Input
+
+	ToInt16MapOutput() Int16MapOutput
+}
+
+// Int16Map is an input type for map[string]int16 values.
+type Int16Map map[string]Int16Input
+
+// ElementType returns the element type of this Input (map[string]int16).
+func (Int16Map) ElementType() reflect.Type {
+	return int16MapType
+}
+
+// ToInt16MapOutput converts this input to a Int16MapOutput.
+func (in Int16Map) ToInt16MapOutput() Int16MapOutput {
+	return Int16MapOutput(ToOutput(in))
+}
+
+// Int16MapOutput is an Output that is typed to return map[string]int16 values.
+type Int16MapOutput Output
+
+// ElementType returns the element type of this Output (map[string]int16).
+func (Int16MapOutput) ElementType() reflect.Type {
+	return int16MapType
+}
+
+// ToInt16MapOutput returns this output.
+func (out Int16MapOutput) ToInt16MapOutput() Int16MapOutput {
+	return out
+}
+
+// Apply applies a transformation to the map[string]int16 value when it is available.
+func (out Int16MapOutput) Apply(applier func(map[string]int16) (interface{}, error)) Output {
+	return out.ApplyWithContext(context.Background(), func(_ context.Context, v map[string]int16) (interface{}, error) {
+		return applier(v)
+	})
+}
+
+// ApplyWithContext applies a transformation to the map[string]int16 value when it is available.
+func (out Int16MapOutput) ApplyWithContext(ctx context.Context, applier func(context.Context, map[string]int16) (interface{}, error)) Output {
+	return Output(out).ApplyWithContext(ctx, func(ctx context.Context, v interface{}) (interface{}, error) {
+		return applier(ctx, convert(v, int16MapType).(map[string]int16))
+	})
+}
+
+// ApplyT applies a transformation to the map[string]int16 value when it is available. The applier must be a function that
+// accepts a map[string]int16 and returns a single value, optionally followed by an error. See Output.ApplyT for details.
+func (out Int16MapOutput) ApplyT(applier interface{}) Output {
+	return out.ApplyTWithContext(context.Background(), applier)
+}
+
+// ApplyTWithContext applies a transformation to the map[string]int16 value when it is available. See Output.ApplyTWithContext
+// for details.
+func (out Int16MapOutput) ApplyTWithContext(ctx context.Context, applier interface{}) Output {
+	return applyT(ctx, Output(out), int16MapType, applier)
+}
+
+// MapIndex returns an output that resolves to the element of the map with the given key. If the key is not present,
+// the output resolves to the zero value.
+func (out Int16MapOutput) MapIndex(k StringInput) Int16Output {
+	return Int16Output(All(out, k).ApplyT(func(vs []interface{}) int16 {
+		m, key := convert(vs[0], int16MapType).(map[string]int16), convert(vs[1], stringType).(string)
+		return m[key]
+	}))
+}
+
+var int32ArrayType = reflect.TypeOf((*[]int32)(nil)).Elem()
+
+// Int32ArrayInput is an input type that accepts Int32Array and Int32ArrayOutput values.
+type Int32ArrayInput interface {
+	Input
+
+	ToInt32ArrayOutput() Int32ArrayOutput
+}
+
+// Int32Array is an input type for []int32 values.
+type Int32Array []Int32Input
+
+// ElementType returns the element type of this Input ([]int32).
+func (Int32Array) ElementType() reflect.Type {
+	return int32ArrayType
+}
+
+// ToInt32ArrayOutput converts this input to a Int32ArrayOutput.
+func (in Int32Array) ToInt32ArrayOutput() Int32ArrayOutput {
+	return Int32ArrayOutput(ToOutput(in))
+}
+
+// Int32ArrayOutput is an Output that is typed to return []int32 values.
+type Int32ArrayOutput Output
+
+// ElementType returns the element type of this Output ([]int32).
+func (Int32ArrayOutput) ElementType() reflect.Type {
+	return int32ArrayType
+}
+
+// ToInt32ArrayOutput returns this output.
+func (out Int32ArrayOutput) ToInt32ArrayOutput() Int32ArrayOutput {
+	return out
+}
+
+// Apply applies a transformation to the []int32 value when it is available.
+func (out Int32ArrayOutput) Apply(applier func([]int32) (interface{}, error)) Output {
+	return out.ApplyWithContext(context.Background(), func(_ context.Context, v []int32) (interface{}, error) {
+		return applier(v)
+	})
+}
+
+// ApplyWithContext applies a transformation to the []int32 value when it is available.
+func (out Int32ArrayOutput) ApplyWithContext(ctx context.Context, applier func(context.Context, []int32) (interface{}, error)) Output {
+	return Output(out).ApplyWithContext(ctx, func(ctx context.Context, v interface{}) (interface{}, error) {
+		return applier(ctx, convert(v, int32ArrayType).([]int32))
+	})
+}
+
+// ApplyT applies a transformation to the []int32 value when it is available. The applier must be a function that
+// accepts a []int32 and returns a single value, optionally followed by an error. See Output.ApplyT for details.
+func (out Int32ArrayOutput) ApplyT(applier interface{}) Output {
+	return out.ApplyTWithContext(context.Background(), applier)
+}
+
+// ApplyTWithContext applies a transformation to the []int32 value when it is available. See Output.ApplyTWithContext
+// for details.
+func (out Int32ArrayOutput) ApplyTWithContext(ctx context.Context, applier interface{}) Output {
+	return applyT(ctx, Output(out), int32ArrayType, applier)
+}
+
+// Index returns an output that resolves to the element of the array at the given index. If the index is out of
+// range, the output resolves to the zero value.
+func (out Int32ArrayOutput) Index(i IntInput) Int32Output {
+	return Int32Output(All(out, i).ApplyT(func(vs []interface{}) int32 {
+		arr, idx := convert(vs[0], int32ArrayType).([]int32), convert(vs[1], intType).(int)
+		if idx < 0 || idx >= len(arr) {
+			var zero int32
+			return zero
+		}
+		return arr[idx]
+	}))
+}
+
+var int32MapType = reflect.TypeOf((*map[string]int32)(nil)).Elem()
+
+// Int32MapInput is an input type that accepts Int32Map and Int32MapOutput values.
+type Int32MapInput interface {
+	Input
+
+	ToInt32MapOutput() Int32MapOutput
+}
+
+// Int32Map is an input type for map[string]int32 values.
+type Int32Map map[string]Int32Input
+
+// ElementType returns the element type of this Input (map[string]int32).
+func (Int32Map) ElementType() reflect.Type {
+	return int32MapType
+}
+
+// ToInt32MapOutput converts this input to a Int32MapOutput.
+func (in Int32Map) ToInt32MapOutput() Int32MapOutput {
+	return Int32MapOutput(ToOutput(in))
+}
+
+// Int32MapOutput is an Output that is typed to return map[string]int32 values.
+type Int32MapOutput Output
+
+// ElementType returns the element type of this Output (map[string]int32).
+func (Int32MapOutput) ElementType() reflect.Type {
+	return int32MapType
+}
+
+// ToInt32MapOutput returns this output.
+func (out Int32MapOutput) ToInt32MapOutput() Int32MapOutput {
+	return out
+}
+
+// Apply applies a transformation to the map[string]int32 value when it is available.
+func (out Int32MapOutput) Apply(applier func(map[string]int32) (interface{}, error)) Output {
+	return out.ApplyWithContext(context.Background(), func(_ context.Context, v map[string]int32) (interface{}, error) {
+		return applier(v)
+	})
+}
+
+// ApplyWithContext applies a transformation to the map[string]int32 value when it is available.
+func (out Int32MapOutput) ApplyWithContext(ctx context.Context, applier func(context.Context, map[string]int32) (interface{}, error)) Output {
+	return Output(out).ApplyWithContext(ctx, func(ctx context.Context, v interface{}) (interface{}, error) {
+		return applier(ctx, convert(v, int32MapType).(map[string]int32))
+	})
+}
+
+// ApplyT applies a transformation to the map[string]int32 value when it is available. The applier must be a function that
+// accepts a map[string]int32 and returns a single value, optionally followed by an error. See Output.ApplyT for details.
+func (out Int32MapOutput) ApplyT(applier interface{}) Output {
+	return out.ApplyTWithContext(context.Background(), applier)
+}
+
+// ApplyTWithContext applies a transformation to the map[string]int32 value when it is available. See Output.ApplyTWithContext
+// for details.
+func (out Int32MapOutput) ApplyTWithContext(ctx context.Context, applier interface{}) Output {
+	return applyT(ctx, Output(out), int32MapType, applier)
+}
+
+// MapIndex returns an output that resolves to the element of the map with the given key. If the key is not present,
+// the output resolves to the zero value.
+func (out Int32MapOutput) MapIndex(k StringInput) Int32Output {
+	return Int32Output(All(out, k).ApplyT(func(vs []interface{}) int32 {
+		m, key := convert(vs[0], int32MapType).(map[string]int32), convert(vs[1], stringType).(string)
+		return m[key]
+	}))
+}
+
+var int64ArrayType = reflect.TypeOf((*[]int64)(nil)).Elem()
+
+// Int64ArrayInput is an input type that accepts Int64Array and Int64ArrayOutput values.
+type Int64ArrayInput interface {
+	Input
+
+	ToInt64ArrayOutput() Int64ArrayOutput
+}
+
+// Int64Array is an input type for []int64 values.
+type Int64Array []Int64Input
+
+// ElementType returns the element type of this Input ([]int64).
+func (Int64Array) ElementType() reflect.Type {
+	return int64ArrayType
+}
+
+// ToInt64ArrayOutput converts this input to a Int64ArrayOutput.
+func (in Int64Array) ToInt64ArrayOutput() Int64ArrayOutput {
+	return Int64ArrayOutput(ToOutput(in))
+}
+
+// Int64ArrayOutput is an Output that is typed to return []int64 values.
+type Int64ArrayOutput Output
+
+// ElementType returns the element type of this Output ([]int64).
+func (Int64ArrayOutput) ElementType() reflect.Type {
+	return int64ArrayType
+}
+
+// ToInt64ArrayOutput returns this output.
+func (out Int64ArrayOutput) ToInt64ArrayOutput() Int64ArrayOutput {
+	return out
+}
+
+// Apply applies a transformation to the []int64 value when it is available.
+func (out Int64ArrayOutput) Apply(applier func([]int64) (interface{}, error)) Output {
+	return out.ApplyWithContext(context.Background(), func(_ context.Context, v []int64) (interface{}, error) {
+		return applier(v)
+	})
+}
+
+// ApplyWithContext applies a transformation to the []int64 value when it is available.
+func (out Int64ArrayOutput) ApplyWithContext(ctx context.Context, applier func(context.Context, []int64) (interface{}, error)) Output {
+	return Output(out).ApplyWithContext(ctx, func(ctx context.Context, v interface{}) (interface{}, error) {
+		return applier(ctx, convert(v, int64ArrayType).([]int64))
+	})
+}
+
+// ApplyT applies a transformation to the []int64 value when it is available. The applier must be a function that
+// accepts a []int64 and returns a single value, optionally followed by an error. See Output.ApplyT for details.
+func (out Int64ArrayOutput) ApplyT(applier interface{}) Output {
+	return out.ApplyTWithContext(context.Background(), applier)
+}
+
+// ApplyTWithContext applies a transformation to the []int64 value when it is available. See Output.ApplyTWithContext
+// for details.
+func (out Int64ArrayOutput) ApplyTWithContext(ctx context.Context, applier interface{}) Output {
+	return applyT(ctx, Output(out), int64ArrayType, applier)
+}
+
+// Index returns an output that resolves to the element of the array at the given index. If the index is out of
+// range, the output resolves to the zero value.
+func (out Int64ArrayOutput) Index(i IntInput) Int64Output {
+	return Int64Output(All(out, i).ApplyT(func(vs []interface{}) int64 {
+		arr, idx := convert(vs[0], int64ArrayType).([]int64), convert(vs[1], intType).(int)
+		if idx < 0 || idx >= len(arr) {
+			var zero int64
+			return zero
+		}
+		return arr[idx]
+	}))
+}
+
+var int64MapType = reflect.TypeOf((*map[string]int64)(nil)).Elem()
+
+// Int64MapInput is an input type that accepts Int64Map and Int64MapOutput values.
+type Int64MapInput interface {
+	Input
+
+	ToInt64MapOutput() Int64MapOutput
+}
+
+// Int64Map is an input type for map[string]int64 values.
+type Int64Map map[string]Int64Input
+
+// ElementType returns the element type of this Input (map[string]int64).
+func (Int64Map) ElementType() reflect.Type {
+	return int64MapType
+}
+
+// ToInt64MapOutput converts this input to a Int64MapOutput.
+func (in Int64Map) ToInt64MapOutput() Int64MapOutput {
+	return Int64MapOutput(ToOutput(in))
+}
+
+// Int64MapOutput is an Output that is typed to return map[string]int64 values.
+type Int64MapOutput Output
+
+// ElementType returns the element type of this Output (map[string]int64).
+func (Int64MapOutput) ElementType() reflect.Type {
+	return int64MapType
+}
+
+// ToInt64MapOutput returns this output.
+func (out Int64MapOutput) ToInt64MapOutput() Int64MapOutput {
+	return out
+}
+
+// Apply applies a transformation to the map[string]int64 value when it is available.
+func (out Int64MapOutput) Apply(applier func(map[string]int64) (interface{}, error)) Output {
+	return out.ApplyWithContext(context.Background(), func(_ context.Context, v map[string]int64) (interface{}, error) {
+		return applier(v)
+	})
+}
+
+// ApplyWithContext applies a transformation to the map[string]int64 value when it is available.
+func (out Int64MapOutput) ApplyWithContext(ctx context.Context, applier func(context.Context, map[string]int64) (interface{}, error)) Output {
+	return Output(out).ApplyWithContext(ctx, func(ctx context.Context, v interface{}) (interface{}, error) {
+		return applier(ctx, convert(v, int64MapType).(map[string]int64))
+	})
+}
+
+// ApplyT applies a transformation to the map[string]int64 value when it is available. The applier must be a function that
+// accepts a map[string]int64 and returns a single value, optionally followed by an error. See Output.ApplyT for details.
+func (out Int64MapOutput) ApplyT(applier interface{}) Output {
+	return out.ApplyTWithContext(context.Background(), applier)
+}
+
+// ApplyTWithContext applies a transformation to the map[string]int64 value when it is available. See Output.ApplyTWithContext
+// for details.
+func (out Int64MapOutput) ApplyTWithContext(ctx context.Context, applier interface{}) Output {
+	return applyT(ctx, Output(out), int64MapType, applier)
+}
+
+// MapIndex returns an output that resolves to the element of the map with the given key. If the key is not present,
+// the output resolves to the zero value.
+func (out Int64MapOutput) MapIndex(k StringInput) Int64Output {
+	return Int64Output(All(out, k).ApplyT(func(vs []interface{}) int64 {
+		m, key := convert(vs[0], int64MapType).(map[string]int64), convert(vs[1], stringType).(string)
+		return m[key]
+	}))
+}
+
+var stringArrayType = reflect.TypeOf((*[]string)(nil)).Elem()
+
+// StringArrayInput is an input type that accepts StringArray and StringArrayOutput values.
+type StringArrayInput interface {
+	Input
+
+	ToStringArrayOutput() StringArrayOutput
+}
+
+// StringArray is an input type for []string values.
+type StringArray []StringInput
+
+// ElementType returns the element type of this Input ([]string).
+func (StringArray) ElementType() reflect.Type {
+	return stringArrayType
+}
+
+// ToStringArrayOutput converts this input to a StringArrayOutput.
+func (in StringArray) ToStringArrayOutput() StringArrayOutput {
+	return StringArrayOutput(ToOutput(in))
+}
+
+// StringArrayOutput is an Output that is typed to return []string values.
+type StringArrayOutput Output
+
+// ElementType returns the element type of this Output ([]string).
+func (StringArrayOutput) ElementType() reflect.Type {
+	return stringArrayType
+}
+
+// ToStringArrayOutput returns this output.
+func (out StringArrayOutput) ToStringArrayOutput() StringArrayOutput {
+	return out
+}
+
+// Apply applies a transformation to the []string value when it is available.
+func (out StringArrayOutput) Apply(applier func([]string) (interface{}, error)) Output {
+	return out.ApplyWithContext(context.Background(), func(_ context.Context, v []string) (interface{}, error) {
+		return applier(v)
+	})
+}
+
+// ApplyWithContext applies a transformation to the []string value when it is available.
+func (out StringArrayOutput) ApplyWithContext(ctx context.Context, applier func(context.Context, []string) (interface{}, error)) Output {
+	return Output(out).ApplyWithContext(ctx, func(ctx context.Context, v interface{}) (interface{}, error) {
+		return applier(ctx, convert(v, stringArrayType).([]string))
+	})
+}
+
+// ApplyT applies a transformation to the []string value when it is available. The applier must be a function that
+// accepts a []string and returns a single value, optionally followed by an error. See Output.ApplyT for details.
+func (out StringArrayOutput) ApplyT(applier interface{}) Output {
+	return out.ApplyTWithContext(context.Background(), applier)
+}
+
+// ApplyTWithContext applies a transformation to the []string value when it is available. See Output.ApplyTWithContext
+// for details.
+func (out StringArrayOutput) ApplyTWithContext(ctx context.Context, applier interface{}) Output {
+	return applyT(ctx, Output(out), stringArrayType, applier)
+}
+
+// Index returns an output that resolves to the element of the array at the given index. If the index is out of
+// range, the output resolves to the zero value.
+func (out StringArrayOutput) Index(i IntInput) StringOutput {
+	return StringOutput(All(out, i).ApplyT(func(vs []interface{}) string {
+		arr, idx := convert(vs[0], stringArrayType).([]string), convert(vs[1], intType).(int)
+		if idx < 0 || idx >= len(arr) {
+			var zero string
+			return zero
+		}
+		return arr[idx]
+	}))
+}
+
+var stringMapType = reflect.TypeOf((*map[string]string)(nil)).Elem()
+
+// StringMapInput is an input type that accepts StringMap and StringMapOutput values.
+type StringMapInput interface {
+	Input
+
+	ToStringMapOutput() StringMapOutput
+}
+
+// StringMap is an input type for map[string]string values.
+type StringMap map[string]StringInput
+
+// ElementType returns the element type of this Input (map[string]string).
+func (StringMap) ElementType() reflect.Type {
+	return stringMapType
+}
+
+// ToStringMapOutput converts this input to a StringMapOutput.
+func (in StringMap) ToStringMapOutput() StringMapOutput {
+	return StringMapOutput(ToOutput(in))
+}
+
+// StringMapOutput is an Output that is typed to return map[string]string values.
+type StringMapOutput Output
+
+// ElementType returns the element type of this Output (map[string]string).
+func (StringMapOutput) ElementType() reflect.Type {
+	return stringMapType
+}
+
+// ToStringMapOutput returns this output.
+func (out StringMapOutput) ToStringMapOutput() StringMapOutput {
+	return out
+}
+
+// Apply applies a transformation to the map[string]string value when it is available.
+func (out StringMapOutput) Apply(applier func(map[string]string) (interface{}, error)) Output {
+	return out.ApplyWithContext(context.Background(), func(_ context.Context, v map[string]string) (interface{}, error) {
+		return applier(v)
+	})
+}
+
+// ApplyWithContext applies a transformation to the map[string]string value when it is available.
+func (out StringMapOutput) ApplyWithContext(ctx context.Context, applier func(context.Context, map[string]string) (interface{}, error)) Output {
+	return Output(out).ApplyWithContext(ctx, func(ctx context.Context, v interface{}) (interface{}, error) {
+		return applier(ctx, convert(v, stringMapType).(map[string]string))
+	})
+}
+
+// ApplyT applies a transformation to the map[string]string value when it is available. The applier must be a function that
+// accepts a map[string]string and returns a single value, optionally followed by an error. See Output.ApplyT for details.
+func (out StringMapOutput) ApplyT(applier interface{}) Output {
+	return out.ApplyTWithContext(context.Background(), applier)
+}
+
+// ApplyTWithContext applies a transformation to the map[string]string value when it is available. See Output.ApplyTWithContext
+// for details.
+func (out StringMapOutput) ApplyTWithContext(ctx context.Context, applier interface{}) Output {
+	return applyT(ctx, Output(out), stringMapType, applier)
+}
+
+// MapIndex returns an output that resolves to the element of the map with the given key. If the key is not present,
+// the output resolves to the zero value.
+func (out StringMapOutput) MapIndex(k StringInput) StringOutput {
+	return StringOutput(All(out, k).ApplyT(func(vs []interface{}) string {
+		m, key := convert(vs[0], stringMapType).(map[string]string), convert(vs[1], stringType).(string)
+		return m[key]
+	}))
+}
+
+var uintArrayType = reflect.TypeOf((*[]uint)(nil)).Elem()
+
+// UintArrayInput is an input type that accepts UintArray and UintArrayOutput values.
+type UintArrayInput interface {
+	Input
+
+	ToUintArrayOutput() UintArrayOutput
+}
+
+// UintArray is an input type for []uint values.
+type UintArray []UintInput
+
+// ElementType returns the element type of this Input ([]uint).
+func (UintArray) ElementType() reflect.Type {
+	return uintArrayType
+}
+
+// ToUintArrayOutput converts this input to a UintArrayOutput.
+func (in UintArray) ToUintArrayOutput() UintArrayOutput {
+	return UintArrayOutput(ToOutput(in))
+}
+
+// UintArrayOutput is an Output that is typed to return []uint values.
+type UintArrayOutput Output
+
+// ElementType returns the element type of this Output ([]uint).
+func (UintArrayOutput) ElementType() reflect.Type {
+	return uintArrayType
+}
+
+// ToUintArrayOutput returns this output.
+func (out UintArrayOutput) ToUintArrayOutput() UintArrayOutput {
+	return out
+}
+
+// Apply applies a transformation to the []uint value when it is available.
+func (out UintArrayOutput) Apply(applier func([]uint) (interface{}, error)) Output {
+	return out.ApplyWithContext(context.Background(), func(_ context.Context, v []uint) (interface{}, error) {
+		return applier(v)
+	})
+}
+
+// ApplyWithContext applies a transformation to the []uint value when it is available.
+func (out UintArrayOutput) ApplyWithContext(ctx context.Context, applier func(context.Context, []uint) (interface{}, error)) Output {
+	return Output(out).ApplyWithContext(ctx, func(ctx context.Context, v interface{}) (interface{}, error) {
+		return applier(ctx, convert(v, uintArrayType).([]uint))
+	})
+}
+
+// ApplyT applies a transformation to the []uint value when it is available. The applier must be a function that
+// accepts a []uint and returns a single value, optionally followed by an error. See Output.ApplyT for details.
+func (out UintArrayOutput) ApplyT(applier interface{}) Output {
+	return out.ApplyTWithContext(context.Background(), applier)
+}
+
+// ApplyTWithContext applies a transformation to the []uint value when it is available. See Output.ApplyTWithContext
+// for details.
+func (out UintArrayOutput) ApplyTWithContext(ctx context.Context, applier interface{}) Output {
+	return applyT(ctx, Output(out), uintArrayType, applier)
+}
+
+// Index returns an output that resolves to the element of the array at the given index. If the index is out of
+// range, the output resolves to the zero value.
+func (out UintArrayOutput) Index(i IntInput) UintOutput {
+	return UintOutput(All(out, i).ApplyT(func(vs []interface{}) uint {
+		arr, idx := convert(vs[0], uintArrayType).([]uint), convert(vs[1], intType).(int)
+		if idx < 0 || idx >= len(arr) {
+			var zero uint
+			return zero
+		}
+		return arr[idx]
+	}))
+}
+
+var uintMapType = reflect.TypeOf((*map[string]uint)(nil)).Elem()
+
+// UintMapInput is an input type that accepts UintMap and UintMapOutput values.
+type UintMapInput interface {
+	Input
+
+	ToUintMapOutput() UintMapOutput
+}
+
+// UintMap is an input type for map[string]uint values.
+type UintMap map[string]UintInput
+
+// ElementType returns the element type of this Input (map[string]uint).
+func (UintMap) ElementType() reflect.Type {
+	return uintMapType
+}
+
+// ToUintMapOutput converts this input to a UintMapOutput.
+func (in UintMap) ToUintMapOutput() UintMapOutput {
+	return UintMapOutput(ToOutput(in))
+}
+
+// UintMapOutput is an Output that is typed to return map[string]uint values.
+type UintMapOutput Output
+
+// ElementType returns the element type of this Output (map[string]uint).
+func (UintMapOutput) ElementType() reflect.Type {
+	return uintMapType
+}
+
+// ToUintMapOutput returns this output.
+func (out UintMapOutput) ToUintMapOutput() UintMapOutput {
+	return out
+}
+
+// Apply applies a transformation to the map[string]uint value when it is available.
+func (out UintMapOutput) Apply(applier func(map[string]uint) (interface{}, error)) Output {
+	return out.ApplyWithContext(context.Background(), func(_ context.Context, v map[string]uint) (interface{}, error) {
+		return applier(v)
+	})
+}
+
+// ApplyWithContext applies a transformation to the map[string]uint value when it is available.
+func (out UintMapOutput) ApplyWithContext(ctx context.Context, applier func(context.Context, map[string]uint) (interface{}, error)) Output {
+	return Output(out).ApplyWithContext(ctx, func(ctx context.Context, v interface{}) (interface{}, error) {
+		return applier(ctx, convert(v, uintMapType).(map[string]uint))
+	})
+}
+
+// ApplyT applies a transformation to the map[string]uint value when it is available. The applier must be a function that
+// accepts a map[string]uint and returns a single value, optionally followed by an error. See Output.ApplyT for details.
+func (out UintMapOutput) ApplyT(applier interface{}) Output {
+	return out.ApplyTWithContext(context.Background(), applier)
+}
+
+// ApplyTWithContext applies a transformation to the map[string]uint value when it is available. See Output.ApplyTWithContext
+// for details.
+func (out UintMapOutput) ApplyTWithContext(ctx context.Context, applier interface{}) Output {
+	return applyT(ctx, Output(out), uintMapType, applier)
+}
+
+// MapIndex returns an output that resolves to the element of the map with the given key. If the key is not present,
+// the output resolves to the zero value.
+func (out UintMapOutput) MapIndex(k StringInput) UintOutput {
+	return UintOutput(All(out, k).ApplyT(func(vs []interface{}) uint {
+		m, key := convert(vs[0], uintMapType).(map[string]uint), convert(vs[1], stringType).(string)
+		return m[key]
+	}))
+}
+
+var uint8ArrayType = reflect.TypeOf((*[]uint8)(nil)).Elem()
+
+// Uint8ArrayInput is an input type that accepts Uint8Array and Uint8ArrayOutput values.
+type Uint8ArrayInput interface {
+	Input
+
+	ToUint8ArrayOutput() Uint8ArrayOutput
+}
+
+// Uint8Array is an input type for []uint8 values.
+type Uint8Array []Uint8Input
+
+// ElementType returns the element type of this Input ([]uint8).
+func (Uint8Array) ElementType() reflect.Type {
+	return uint8ArrayType
+}
+
+// ToUint8ArrayOutput converts this input to a Uint8ArrayOutput.
+func (in Uint8Array) ToUint8ArrayOutput() Uint8ArrayOutput {
+	return Uint8ArrayOutput(ToOutput(in))
+}
+
+// Uint8ArrayOutput is an Output that is typed to return []uint8 values.
+type Uint8ArrayOutput Output
+
+// ElementType returns the element type of this Output ([]uint8).
+func (Uint8ArrayOutput) ElementType() reflect.Type {
+	return uint8ArrayType
+}
+
+// ToUint8ArrayOutput returns this output.
+func (out Uint8ArrayOutput) ToUint8ArrayOutput() Uint8ArrayOutput {
+	return out
+}
+
+// Apply applies a transformation to the []uint8 value when it is available.
+func (out Uint8ArrayOutput) Apply(applier func([]uint8) (interface{}, error)) Output {
+	return out.ApplyWithContext(context.Background(), func(_ context.Context, v []uint8) (interface{}, error) {
+		return applier(v)
+	})
+}
+
+// ApplyWithContext applies a transformation to the []uint8 value when it is available.
+func (out Uint8ArrayOutput) ApplyWithContext(ctx context.Context, applier func(context.Context, []uint8) (interface{}, error)) Output {
+	return Output(out).ApplyWithContext(ctx, func(ctx context.Context, v interface{}) (interface{}, error) {
+		return applier(ctx, convert(v, uint8ArrayType).([]uint8))
+	})
+}
+
+// ApplyT applies a transformation to the []uint8 value when it is available. The applier must be a function that
+// accepts a []uint8 and returns a single value, optionally followed by an error. See Output.ApplyT for details.
+func (out Uint8ArrayOutput) ApplyT(applier interface{}) Output {
+	return out.ApplyTWithContext(context.Background(), applier)
+}
+
+// ApplyTWithContext applies a transformation to the []uint8 value when it is available. See Output.ApplyTWithContext
+// for details.
+func (out Uint8ArrayOutput) ApplyTWithContext(ctx context.Context, applier interface{}) Output {
+	return applyT(ctx, Output(out), uint8ArrayType, applier)
+}
+
+// Index returns an output that resolves to the element of the array at the given index. If the index is out of
+// range, the output resolves to the zero value.
+func (out Uint8ArrayOutput) Index(i IntInput) Uint8Output {
+	return Uint8Output(All(out, i).ApplyT(func(vs []interface{}) uint8 {
+		arr, idx := convert(vs[0], uint8ArrayType).([]uint8), convert(vs[1], intType).(int)
+		if idx < 0 || idx >= len(arr) {
+			var zero uint8
+			return zero
+		}
+		return arr[idx]
+	}))
+}
+
+var uint8MapType = reflect.TypeOf((*map[string]uint8)(nil)).Elem()
+
+// Uint8MapInput is an input type that accepts Uint8Map and Uint8MapOutput values.
+type Uint8MapInput interface {
+	Input
+
+	ToUint8MapOutput() Uint8MapOutput
+}
+
+// Uint8Map is an input type for map[string]uint8 values.
+type Uint8Map map[string]Uint8Input
+
+// ElementType returns the element type of this Input (map[string]uint8).
+func (Uint8Map) ElementType() reflect.Type {
+	return uint8MapType
+}
+
+// ToUint8MapOutput converts this input to a Uint8MapOutput.
+func (in Uint8Map) ToUint8MapOutput() Uint8MapOutput {
+	return Uint8MapOutput(ToOutput(in))
+}
+
+// Uint8MapOutput is an Output that is typed to return map[string]uint8 values.
+type Uint8MapOutput Output
+
+// ElementType returns the element type of this Output (map[string]uint8).
+func (Uint8MapOutput) ElementType() reflect.Type {
+	return uint8MapType
+}
+
+// ToUint8MapOutput returns this output.
+func (out Uint8MapOutput) ToUint8MapOutput() Uint8MapOutput {
+	return out
+}
+
+// Apply applies a transformation to the map[string]uint8 value when it is available.
+func (out Uint8MapOutput) Apply(applier func(map[string]uint8) (interface{}, error)) Output {
+	return out.ApplyWithContext(context.Background(), func(_ context.Context, v map[string]uint8) (interface{}, error) {
+		return applier(v)
+	})
+}
+
+// ApplyWithContext applies a transformation to the map[string]uint8 value when it is available.
+func (out Uint8MapOutput) ApplyWithContext(ctx context.Context, applier func(context.Context, map[string]uint8) (interface{}, error)) Output {
+	return Output(out).ApplyWithContext(ctx, func(ctx context.Context, v interface{}) (interface{}, error) {
+		return applier(ctx, convert(v, uint8MapType).(map[string]uint8))
+	})
+}
+
+// ApplyT applies a transformation to the map[string]uint8 value when it is available. The applier must be a function that
+// accepts a map[string]uint8 and returns a single value, optionally followed by an error. See Output.ApplyT for details.
+func (out Uint8MapOutput) ApplyT(applier interface{}) Output {
+	return out.ApplyTWithContext(context.Background(), applier)
+}
+
+// ApplyTWithContext applies a transformation to the map[string]uint8 value when it is available. See Output.ApplyTWithContext
+// for details.
+func (out Uint8MapOutput) ApplyTWithContext(ctx context.Context, applier interface{}) Output {
+	return applyT(ctx, Output(out), uint8MapType, applier)
+}
+
+// MapIndex returns an output that resolves to the element of the map with the given key. If the key is not present,
+// the output resolves to the zero value.
+func (out Uint8MapOutput) MapIndex(k StringInput) Uint8Output {
+	return Uint8Output(All(out, k).ApplyT(func(vs []interface{}) uint8 {
+		m, key := convert(vs[0], uint8MapType).(map[string]uint8), convert(vs[1], stringType).(string)
+		return m[key]
+	}))
+}
+
+var uint16ArrayType = reflect.TypeOf((*[]uint16)(nil)).Elem()
+
+// Uint16ArrayInput is an input type that accepts Uint16Array and Uint16ArrayOutput values.
+type Uint16ArrayInput interface {
+	Input
+
+	ToUint16ArrayOutput() Uint16ArrayOutput
+}
+
+// Uint16Array is an input type for []uint16 values.
+type Uint16Array []Uint16Input
+
+// ElementType returns the element type of this Input ([]uint16).
+func (Uint16Array) ElementType() reflect.Type {
+	return uint16ArrayType
+}
+
+// ToUint16ArrayOutput converts this input to a Uint16ArrayOutput.
+func (in Uint16Array) ToUint16ArrayOutput() Uint16ArrayOutput {
+	return Uint16ArrayOutput(ToOutput(in))
+}
+
+// Uint16ArrayOutput is an Output that is typed to return []uint16 values.
+type Uint16ArrayOutput Output
+
+// ElementType returns the element type of this Output ([]uint16).
+func (Uint16ArrayOutput) ElementType() reflect.Type {
+	return uint16ArrayType
+}
+
+// ToUint16ArrayOutput returns this output.
+func (out Uint16ArrayOutput) ToUint16ArrayOutput() Uint16ArrayOutput {
+	return out
+}
+
+// Apply applies a transformation to the []uint16 value when it is available.
+func (out Uint16ArrayOutput) Apply(applier func([]uint16) (interface{}, error)) Output {
+	return out.ApplyWithContext(context.Background(), func(_ context.Context, v []uint16) (interface{}, error) {
+		return applier(v)
+	})
+}
+
+// ApplyWithContext applies a transformation to the []uint16 value when it is available.
+func (out Uint16ArrayOutput) ApplyWithContext(ctx context.Context, applier func(context.Context, []uint16) (interface{}, error)) Output {
+	return Output(out).ApplyWithContext(ctx, func(ctx context.Context, v interface{}) (interface{}, error) {
+		return applier(ctx, convert(v, uint16ArrayType).([]uint16))
+	})
+}
+
+// ApplyT applies a transformation to the []uint16 value when it is available. The applier must be a function that
+// accepts a []uint16 and returns a single value, optionally followed by an error. See Output.ApplyT for details.
+func (out Uint16ArrayOutput) ApplyT(applier interface{}) Output {
+	return out.ApplyTWithContext(context.Background(), applier)
+}
+
+// ApplyTWithContext applies a transformation to the []uint16 value when it is available. See Output.ApplyTWithContext
+// for details.
+func (out Uint16ArrayOutput) ApplyTWithContext(ctx context.Context, applier interface{}) Output {
+	return applyT(ctx, Output(out), uint16ArrayType, applier)
+}
+
+// Index returns an output that resolves to the element of the array at the given index. If the index is out of
+// range, the output resolves to the zero value.
+func (out Uint16ArrayOutput) Index(i IntInput) Uint16Output {
+	return Uint16Output(All(out, i).ApplyT(func(vs []interface{}) uint16 {
+		arr, idx := convert(vs[0], uint16ArrayType).([]uint16), convert(vs[1], intType).(int)
+		if idx < 0 || idx >= len(arr) {
+			var zero uint16
+			return zero
+		}
+		return arr[idx]
+	}))
+}
+
+var uint16MapType = reflect.TypeOf((*map[string]uint16)(nil)).Elem()
+
+// Uint16MapInput is an input type that accepts Uint16Map and Uint16MapOutput values.
+type Uint16MapInput interface {
+	Input
+
+	ToUint16MapOutput() Uint16MapOutput
+}
+
+// Uint16Map is an input type for map[string]uint16 values.
+type Uint16Map map[string]Uint16Input
+
+// ElementType returns the element type of this Input (map[string]uint16).
+func (Uint16Map) ElementType() reflect.Type {
+	return uint16MapType
+}
+
+// ToUint16MapOutput converts this input to a Uint16MapOutput.
+func (in Uint16Map) ToUint16MapOutput() Uint16MapOutput {
+	return Uint16MapOutput(ToOutput(in))
+}
+
+// Uint16MapOutput is an Output that is typed to return map[string]uint16 values.
+type Uint16MapOutput Output
+
+// ElementType returns the element type of this Output (map[string]uint16).
+func (Uint16MapOutput) ElementType() reflect.Type {
+	return uint16MapType
+}
+
+// ToUint16MapOutput returns this output.
+func (out Uint16MapOutput) ToUint16MapOutput() Uint16MapOutput {
+	return out
+}
+
+// Apply applies a transformation to the map[string]uint16 value when it is available.
+func (out Uint16MapOutput) Apply(applier func(map[string]uint16) (interface{}, error)) Output {
+	return out.ApplyWithContext(context.Background(), func(_ context.Context, v map[string]uint16) (interface{}, error) {
+		return applier(v)
+	})
+}
+
+// ApplyWithContext applies a transformation to the map[string]uint16 value when it is available.
+func (out Uint16MapOutput) ApplyWithContext(ctx context.Context, applier func(context.Context, map[string]uint16) (interface{}, error)) Output {
+	return Output(out).ApplyWithContext(ctx, func(ctx context.Context, v interface{}) (interface{}, error) {
+		return applier(ctx, convert(v, uint16MapType).(map[string]uint16))
+	})
+}
+
+// ApplyT applies a transformation to the map[string]uint16 value when it is available. The applier must be a function that
+// accepts a map[string]uint16 and returns a single value, optionally followed by an error. See Output.ApplyT for details.
+func (out Uint16MapOutput) ApplyT(applier interface{}) Output {
+	return out.ApplyTWithContext(context.Background(), applier)
+}
+
+// ApplyTWithContext applies a transformation to the map[string]uint16 value when it is available. See Output.ApplyTWithContext
+// for details.
+func (out Uint16MapOutput) ApplyTWithContext(ctx context.Context, applier interface{}) Output {
+	return applyT(ctx, Output(out), uint16MapType, applier)
+}
+
+// MapIndex returns an output that resolves to the element of the map with the given key. If the key is not present,
+// the output resolves to the zero value.
+func (out Uint16MapOutput) MapIndex(k StringInput) Uint16Output {
+	return Uint16Output(All(out, k).ApplyT(func(vs []interface{}) uint16 {
+		m, key := convert(vs[0], uint16MapType).(map[string]uint16), convert(vs[1], stringType).(string)
+		return m[key]
+	}))
+}
+
+var uint32ArrayType = reflect.TypeOf((*[]uint32)(nil)).Elem()
+
+// Uint32ArrayInput is an input type that accepts Uint32Array and Uint32ArrayOutput values.
+type Uint32ArrayInput interface {
+	Input
+
+	ToUint32ArrayOutput() Uint32ArrayOutput
+}
+
+// Uint32Array is an input type for []uint32 values.
+type Uint32Array []Uint32Input
+
+// ElementType returns the element type of this Input ([]uint32).
+func (Uint32Array) ElementType() reflect.Type {
+	return uint32ArrayType
+}
+
+// ToUint32ArrayOutput converts this input to a Uint32ArrayOutput.
+func (in Uint32Array) ToUint32ArrayOutput() Uint32ArrayOutput {
+	return Uint32ArrayOutput(ToOutput(in))
+}
+
+// Uint32ArrayOutput is an Output that is typed to return []uint32 values.
+type Uint32ArrayOutput Output
+
+// ElementType returns the element type of this Output ([]uint32).
+func (Uint32ArrayOutput) ElementType() reflect.Type {
+	return uint32ArrayType
+}
+
+// ToUint32ArrayOutput returns this output.
+func (out Uint32ArrayOutput) ToUint32ArrayOutput() Uint32ArrayOutput {
+	return out
+}
+
+// Apply applies a transformation to the []uint32 value when it is available.
+func (out Uint32ArrayOutput) Apply(applier func([]uint32) (interface{}, error)) Output {
+	return out.ApplyWithContext(context.Background(), func(_ context.Context, v []uint32) (interface{}, error) {
+		return applier(v)
+	})
+}
+
+// ApplyWithContext applies a transformation to the []uint32 value when it is available.
+func (out Uint32ArrayOutput) ApplyWithContext(ctx context.Context, applier func(context.Context, []uint32) (interface{}, error)) Output {
+	return Output(out).ApplyWithContext(ctx, func(ctx context.Context, v interface{}) (interface{}, error) {
+		return applier(ctx, convert(v, uint32ArrayType).([]uint32))
+	})
+}
+
+// ApplyT applies a transformation to the []uint32 value when it is available. The applier must be a function that
+// accepts a []uint32 and returns a single value, optionally followed by an error. See Output.ApplyT for details.
+func (out Uint32ArrayOutput) ApplyT(applier interface{}) Output {
+	return out.ApplyTWithContext(context.Background(), applier)
+}
+
+// ApplyTWithContext applies a transformation to the []uint32 value when it is available. See Output.ApplyTWithContext
+// for details.
+func (out Uint32ArrayOutput) ApplyTWithContext(ctx context.Context, applier interface{}) Output {
+	return applyT(ctx, Output(out), uint32ArrayType, applier)
+}
+
+// Index returns an output that resolves to the element of the array at the given index. If the index is out of
+// range, the output resolves to the zero value.
+func (out Uint32ArrayOutput) Index(i IntInput) Uint32Output {
+	return Uint32Output(All(out, i).ApplyT(func(vs []interface{}) uint32 {
+		arr, idx := convert(vs[0], uint32ArrayType).([]uint32), convert(vs[1], intType).(int)
+		if idx < 0 || idx >= len(arr) {
+			var zero uint32
+			return zero
+		}
+		return arr[idx]
+	}))
+}
+
+var uint32MapType = reflect.TypeOf((*map[string]uint32)(nil)).Elem()
+
+// Uint32MapInput is an input type that accepts Uint32Map and Uint32MapOutput values.
+type Uint32MapInput interface {
+	Input
+
+	ToUint32MapOutput() Uint32MapOutput
+}
+
+// Uint32Map is an input type for map[string]uint32 values.
+type Uint32Map map[string]Uint32Input
+
+// ElementType returns the element type of this Input (map[string]uint32).
+func (Uint32Map) ElementType() reflect.Type {
+	return uint32MapType
+}
+
+// ToUint32MapOutput converts this input to a Uint32MapOutput.
+func (in Uint32Map) ToUint32MapOutput() Uint32MapOutput {
+	return Uint32MapOutput(ToOutput(in))
+}
+
+// Uint32MapOutput is an Output that is typed to return map[string]uint32 values.
+type Uint32MapOutput Output
+
+// ElementType returns the element type of this Output (map[string]uint32).
+func (Uint32MapOutput) ElementType() reflect.Type {
+	return uint32MapType
+}
+
+// ToUint32MapOutput returns this output.
+func (out Uint32MapOutput) ToUint32MapOutput() Uint32MapOutput {
+	return out
+}
+
+// Apply applies a transformation to the map[string]uint32 value when it is available.
+func (out Uint32MapOutput) Apply(applier func(map[string]uint32) (interface{}, error)) Output {
+	return out.ApplyWithContext(context.Background(), func(_ context.Context, v map[string]uint32) (interface{}, error) {
+		return applier(v)
+	})
+}
+
+// ApplyWithContext applies a transformation to the map[string]uint32 value when it is available.
+func (out Uint32MapOutput) ApplyWithContext(ctx context.Context, applier func(context.Context, map[string]uint32) (interface{}, error)) Output {
+	return Output(out).ApplyWithContext(ctx, func(ctx context.Context, v interface{}) (interface{}, error) {
+		return applier(ctx, convert(v, uint32MapType).(map[string]uint32))
+	})
+}
+
+// ApplyT applies a transformation to the map[string]uint32 value when it is available. The applier must be a function that
+// accepts a map[string]uint32 and returns a single value, optionally followed by an error. See Output.ApplyT for details.
+func (out Uint32MapOutput) ApplyT(applier interface{}) Output {
+	return out.ApplyTWithContext(context.Background(), applier)
+}
+
+// ApplyTWithContext applies a transformation to the map[string]uint32 value when it is available. See Output.ApplyTWithContext
+// for details.
+func (out Uint32MapOutput) ApplyTWithContext(ctx context.Context, applier interface{}) Output {
+	return applyT(ctx, Output(out), uint32MapType, applier)
+}
+
+// MapIndex returns an output that resolves to the element of the map with the given key. If the key is not present,
+// the output resolves to the zero value.
+func (out Uint32MapOutput) MapIndex(k StringInput) Uint32Output {
+	return Uint32Output(All(out, k).ApplyT(func(vs []interface{}) uint32 {
+		m, key := convert(vs[0], uint32MapType).(map[string]uint32), convert(vs[1], stringType).(string)
+		return m[key]
+	}))
+}
+
+var uint64ArrayType = reflect.TypeOf((*[]uint64)(nil)).Elem()
+
+// Uint64ArrayInput is an input type that accepts Uint64Array and Uint64ArrayOutput values.
+type Uint64ArrayInput interface {
+	Input
+
+	ToUint64ArrayOutput() Uint64ArrayOutput
+}
+
+// Uint64Array is an input type for []uint64 values.
+type Uint64Array []Uint64Input
+
+// ElementType returns the element type of this Input ([]uint64).
+func (Uint64Array) ElementType() reflect.Type {
+	return uint64ArrayType
+}
+
+// ToUint64ArrayOutput converts this input to a Uint64ArrayOutput.
+func (in Uint64Array) ToUint64ArrayOutput() Uint64ArrayOutput {
+	return Uint64ArrayOutput(ToOutput(in))
+}
+
+// Uint64ArrayOutput is an Output that is typed to return []uint64 values.
+type Uint64ArrayOutput Output
+
+// ElementType returns the element type of this Output ([]uint64).
+func (Uint64ArrayOutput) ElementType() reflect.Type {
+	return uint64ArrayType
+}
+
+// ToUint64ArrayOutput returns this output.
+func (out Uint64ArrayOutput) ToUint64ArrayOutput() Uint64ArrayOutput {
+	return out
+}
+
+// Apply applies a transformation to the []uint64 value when it is available.
+func (out Uint64ArrayOutput) Apply(applier func([]uint64) (interface{}, error)) Output {
+	return out.ApplyWithContext(context.Background(), func(_ context.Context, v []uint64) (interface{}, error) {
+		return applier(v)
+	})
+}
+
+// ApplyWithContext applies a transformation to the []uint64 value when it is available.
+func (out Uint64ArrayOutput) ApplyWithContext(ctx context.Context, applier func(context.Context, []uint64) (interface{}, error)) Output {
+	return Output(out).ApplyWithContext(ctx, func(ctx context.Context, v interface{}) (interface{}, error) {
+		return applier(ctx, convert(v, uint64ArrayType).([]uint64))
+	})
+}
+
+// ApplyT applies a transformation to the []uint64 value when it is available. The applier must be a function that
+// accepts a []uint64 and returns a single value, optionally followed by an error. See Output.ApplyT for details.
+func (out Uint64ArrayOutput) ApplyT(applier interface{}) Output {
+	return out.ApplyTWithContext(context.Background(), applier)
+}
+
+// ApplyTWithContext applies a transformation to the []uint64 value when it is available. See Output.ApplyTWithContext
+// for details.
+func (out Uint64ArrayOutput) ApplyTWithContext(ctx context.Context, applier interface{}) Output {
+	return applyT(ctx, Output(out), uint64ArrayType, applier)
+}
+
+// Index returns an output that resolves to the element of the array at the given index. If the index is out of
+// range, the output resolves to the zero value.
+func (out Uint64ArrayOutput) Index(i IntInput) Uint64Output {
+	return Uint64Output(All(out, i).ApplyT(func(vs []interface{}) uint64 {
+		arr, idx := convert(vs[0], uint64ArrayType).([]uint64), convert(vs[1], intType).(int)
+		if idx < 0 || idx >= len(arr) {
+			var zero uint64
+			return zero
+		}
+		return arr[idx]
+	}))
+}
+
+var uint64MapType = reflect.TypeOf((*map[string]uint64)(nil)).Elem()
+
+// Uint64MapInput is an input type that accepts Uint64Map and Uint64MapOutput values.
+type Uint64MapInput interface {
+	Input
+
+	ToUint64MapOutput() Uint64MapOutput
+}
+
+// Uint64Map is an input type for map[string]uint64 values.
+type Uint64Map map[string]Uint64Input
+
+// ElementType returns the element type of this Input (map[string]uint64).
+func (Uint64Map) ElementType() reflect.Type {
+	return uint64MapType
+}
+
+// ToUint64MapOutput converts this input to a Uint64MapOutput.
+func (in Uint64Map) ToUint64MapOutput() Uint64MapOutput {
+	return Uint64MapOutput(ToOutput(in))
+}
+
+// Uint64MapOutput is an Output that is typed to return map[string]uint64 values.
+type Uint64MapOutput Output
+
+// ElementType returns the element type of this Output (map[string]uint64).
+func (Uint64MapOutput) ElementType() reflect.Type {
+	return uint64MapType
+}
+
+// ToUint64MapOutput returns this output.
+func (out Uint64MapOutput) ToUint64MapOutput() Uint64MapOutput {
+	return out
+}
+
+// Apply applies a transformation to the map[string]uint64 value when it is available.
+func (out Uint64MapOutput) Apply(applier func(map[string]uint64) (interface{}, error)) Output {
+	return out.ApplyWithContext(context.Background(), func(_ context.Context, v map[string]uint64) (interface{}, error) {
+		return applier(v)
+	})
+}
+
+// ApplyWithContext applies a transformation to the map[string]uint64 value when it is available.
+func (out Uint64MapOutput) ApplyWithContext(ctx context.Context, applier func(context.Context, map[string]uint64) (interface{}, error)) Output {
+	return Output(out).ApplyWithContext(ctx, func(ctx context.Context, v interface{}) (interface{}, error) {
+		return applier(ctx, convert(v, uint64MapType).(map[string]uint64))
+	})
+}
+
+// ApplyT applies a transformation to the map[string]uint64 value when it is available. The applier must be a function that
+// accepts a map[string]uint64 and returns a single value, optionally followed by an error. See Output.ApplyT for details.
+func (out Uint64MapOutput) ApplyT(applier interface{}) Output {
+	return out.ApplyTWithContext(context.Background(), applier)
+}
+
+// ApplyTWithContext applies a transformation to the map[string]uint64 value when it is available. See Output.ApplyTWithContext
+// for details.
+func (out Uint64MapOutput) ApplyTWithContext(ctx context.Context, applier interface{}) Output {
+	return applyT(ctx, Output(out), uint64MapType, applier)
+}
+
+// MapIndex returns an output that resolves to the element of the map with the given key. If the key is not present,
+// the output resolves to the zero value.
+func (out Uint64MapOutput) MapIndex(k StringInput) Uint64Output {
+	return Uint64Output(All(out, k).ApplyT(func(vs []interface{}) uint64 {
+		m, key := convert(vs[0], uint64MapType).(map[string]uint64), convert(vs[1], stringType).(string)
+		return m[key]
+	}))
+}
+
+var urnArrayType = reflect.TypeOf((*[]URN)(nil)).Elem()
+
+// URNArrayInput is an input type that accepts URNArray and URNArrayOutput values.
+type URNArrayInput interface {
+	Input
+
+	ToURNArrayOutput() URNArrayOutput
+}
+
+// URNArray is an input type for []URN values.
+type URNArray []URNInput
+
+// ElementType returns the element type of this Input ([]URN).
+func (URNArray) ElementType() reflect.Type {
+	return urnArrayType
+}
+
+// ToURNArrayOutput converts this input to a URNArrayOutput.
+func (in URNArray) ToURNArrayOutput() URNArrayOutput {
+	return URNArrayOutput(ToOutput(in))
+}
+
+// URNArrayOutput is an Output that is typed to return []URN values.
+type URNArrayOutput Output
+
+// ElementType returns the element type of this Output ([]URN).
+func (URNArrayOutput) ElementType() reflect.Type {
+	return urnArrayType
+}
+
+// ToURNArrayOutput returns this output.
+func (out URNArrayOutput) ToURNArrayOutput() URNArrayOutput {
+	return out
+}
+
+// Apply applies a transformation to the []URN value when it is available.
+func (out URNArrayOutput) Apply(applier func([]URN) (interface{}, error)) Output {
+	return out.ApplyWithContext(context.Background(), func(_ context.Context, v []URN) (interface{}, error) {
+		return applier(v)
+	})
+}
+
+// ApplyWithContext applies a transformation to the []URN value when it is available.
+func (out URNArrayOutput) ApplyWithContext(ctx context.Context, applier func(context.Context, []URN) (interface{}, error)) Output {
+	return Output(out).ApplyWithContext(ctx, func(ctx context.Context, v interface{}) (interface{}, error) {
+		return applier(ctx, convert(v, urnArrayType).([]URN))
+	})
+}
+
+// ApplyT applies a transformation to the []URN value when it is available. The applier must be a function that
+// accepts a []URN and returns a single value, optionally followed by an error. See Output.ApplyT for details.
+func (out URNArrayOutput) ApplyT(applier interface{}) Output {
+	return out.ApplyTWithContext(context.Background(), applier)
+}
+
+// ApplyTWithContext applies a transformation to the []URN value when it is available. See Output.ApplyTWithContext
+// for details.
+func (out URNArrayOutput) ApplyTWithContext(ctx context.Context, applier interface{}) Output {
+	return applyT(ctx, Output(out), urnArrayType, applier)
+}
+
+// Index returns an output that resolves to the element of the array at the given index. If the index is out of
+// range, the output resolves to the zero value.
+func (out URNArrayOutput) Index(i IntInput) URNOutput {
+	return URNOutput(All(out, i).ApplyT(func(vs []interface{}) URN {
+		arr, idx := convert(vs[0], urnArrayType).([]URN), convert(vs[1], intType).(int)
+		if idx < 0 || idx >= len(arr) {
+			var zero URN
+			return zero
+		}
+		return arr[idx]
+	}))
+}
+
+var urnMapType = reflect.TypeOf((*map[string]URN)(nil)).Elem()
+
+// URNMapInput is an input type that accepts URNMap and URNMapOutput values.
+type URNMapInput interface {
+	Input
+
+	ToURNMapOutput() URNMapOutput
+}
+
+// URNMap is an input type for map[string]URN values.
+type URNMap map[string]URNInput
+
+// ElementType returns the element type of this Input (map[string]URN).
+func (URNMap) ElementType() reflect.Type {
+	return urnMapType
+}
+
+// ToURNMapOutput converts this input to a URNMapOutput.
+func (in URNMap) ToURNMapOutput() URNMapOutput {
+	return URNMapOutput(ToOutput(in))
+}
+
+// URNMapOutput is an Output that is typed to return map[string]URN values.
+type URNMapOutput Output
+
+// ElementType returns the element type of this Output (map[string]URN).
+func (URNMapOutput) ElementType() reflect.Type {
+	return urnMapType
+}
+
+// ToURNMapOutput returns this output.
+func (out URNMapOutput) ToURNMapOutput() URNMapOutput {
+	return out
+}
+
+// Apply applies a transformation to the map[string]URN value when it is available.
+func (out URNMapOutput) Apply(applier func(map[string]URN) (interface{}, error)) Output {
+	return out.ApplyWithContext(context.Background(), func(_ context.Context, v map[string]URN) (interface{}, error) {
+		return applier(v)
+	})
+}
+
+// ApplyWithContext applies a transformation to the map[string]URN value when it is available.
+func (out URNMapOutput) ApplyWithContext(ctx context.Context, applier func(context.Context, map[string]URN) (interface{}, error)) Output {
+	return Output(out).ApplyWithContext(ctx, func(ctx context.Context, v interface{}) (interface{}, error) {
+		return applier(ctx, convert(v, urnMapType).(map[string]URN))
+	})
+}
+
+// ApplyT applies a transformation to the map[string]URN value when it is available. The applier must be a function that
+// accepts a map[string]URN and returns a single value, optionally followed by an error. See Output.ApplyT for details.
+func (out URNMapOutput) ApplyT(applier interface{}) Output {
+	return out.ApplyTWithContext(context.Background(), applier)
+}
+
+// ApplyTWithContext applies a transformation to the map[string]URN value when it is available. See Output.ApplyTWithContext
+// for details.
+func (out URNMapOutput) ApplyTWithContext(ctx context.Context, applier interface{}) Output {
+	return applyT(ctx, Output(out), urnMapType, applier)
+}
+
+// MapIndex returns an output that resolves to the element of the map with the given key. If the key is not present,
+// the output resolves to the zero value.
+func (out URNMapOutput) MapIndex(k StringInput) URNOutput {
+	return URNOutput(All(out, k).ApplyT(func(vs []interface{}) URN {
+		m, key := convert(vs[0], urnMapType).(map[string]URN), convert(vs[1], stringType).(string)
+		return m[key]
+	}))
+}
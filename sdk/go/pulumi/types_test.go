@@ -0,0 +1,247 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pulumi
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func awaitOutput(o Output) (interface{}, bool, error) {
+	return o.s.await(context.Background())
+}
+
+func TestApplyTSignatures(t *testing.T) {
+	out := IntOutput(ToOutput(Int(42)))
+
+	// All of the valid applier signatures should be accepted.
+	appliers := []interface{}{
+		func(v int) string { return strconv.Itoa(v) },
+		func(v int) (string, error) { return strconv.Itoa(v), nil },
+		func(_ context.Context, v int) string { return strconv.Itoa(v) },
+		func(_ context.Context, v int) (string, error) { return strconv.Itoa(v), nil },
+		func(v interface{}) string { return strconv.Itoa(v.(int)) },
+	}
+	for _, applier := range appliers {
+		v, known, err := awaitOutput(out.ApplyT(applier))
+		assert.NoError(t, err)
+		assert.True(t, known)
+		assert.Equal(t, "42", v)
+	}
+
+	// Invalid appliers should panic.
+	invalid := []interface{}{
+		42,
+		func() string { return "" },
+		func(v string) string { return v },
+		func(v int, w int) string { return "" },
+		func(v ...int) string { return "" },
+		func(v int) {},
+		func(v int) (string, string) { return "", "" },
+		func(v int) (string, error, bool) { return "", nil, false },
+	}
+	for _, applier := range invalid {
+		assert.Panics(t, func() { out.ApplyT(applier) }, "%T", applier)
+	}
+
+	// Errors returned by the applier should reject the output.
+	_, _, err := awaitOutput(out.ApplyT(func(v int) (string, error) { return "", errors.New("boom") }))
+	assert.EqualError(t, err, "boom")
+}
+
+func TestApplyTConversions(t *testing.T) {
+	// The untyped Output may be applied to any type to which its value can be converted.
+	out, resolve, _ := NewOutput()
+	go resolve([]interface{}{"a", "b"})
+
+	v, _, err := awaitOutput(out.ApplyT(func(v []string) int { return len(v) }))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, v)
+
+	_, _, err = awaitOutput(out.ApplyT(func(v []int) int { return len(v) }))
+	assert.Error(t, err)
+
+	// Typed outputs convert their values to their element types.
+	out, resolve, _ = NewOutput()
+	go resolve(map[string]interface{}{"x": 1.0, "y": 2.0})
+
+	v, _, err = awaitOutput(IntMapOutput(out).ApplyT(func(v map[string]int) int { return v["x"] + v["y"] }))
+	assert.NoError(t, err)
+	assert.Equal(t, 3, v)
+
+	// Unknown values skip the applier.
+	unknown := newOutput()
+	go unknown.s.fulfill(nil, false, nil)
+	_, known, err := awaitOutput(StringOutput(unknown).ApplyT(func(v string) string {
+		assert.Fail(t, "applier should not run")
+		return v
+	}))
+	assert.NoError(t, err)
+	assert.False(t, known)
+}
+
+func TestToOutput(t *testing.T) {
+	out, resolve, _ := NewOutput()
+	go resolve("b")
+
+	// Typed inputs resolve to their element types, even when they contain outputs.
+	v, known, err := awaitOutput(ToOutput(StringArray{String("a"), StringOutput(out)}))
+	assert.NoError(t, err)
+	assert.True(t, known)
+	assert.Equal(t, []string{"a", "b"}, v)
+
+	v, _, err = awaitOutput(ToOutput(IntMap{"x": Int(1)}))
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int{"x": 1}, v)
+
+	// Untyped values resolve to their plain values.
+	v, _, err = awaitOutput(ToOutput(map[string]interface{}{"x": StringOutput(out)}))
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"x": "b"}, v)
+
+	// Unknown outputs make the result unknown.
+	unknown := newOutput()
+	go unknown.s.fulfill(nil, false, nil)
+	_, known, err = awaitOutput(ToOutput(StringArray{String("a"), StringOutput(unknown)}))
+	assert.NoError(t, err)
+	assert.False(t, known)
+}
+
+func TestAll(t *testing.T) {
+	out, resolve, _ := NewOutput()
+	go resolve(42.0)
+
+	all := All(String("a"), IntOutput(out), BoolArray{Bool(true)})
+	v, known, err := awaitOutput(Output(all))
+	assert.NoError(t, err)
+	assert.True(t, known)
+	assert.Equal(t, []interface{}{"a", 42.0, []bool{true}}, v)
+
+	v, _, err = awaitOutput(all.ApplyT(func(vs []interface{}) string {
+		return vs[0].(string) + strconv.Itoa(convert(vs[1], intType).(int))
+	}))
+	assert.NoError(t, err)
+	assert.Equal(t, "a42", v)
+
+	// Rejected inputs reject the result.
+	rejected, _, reject := NewOutput()
+	go reject(errors.New("boom"))
+	_, _, err = awaitOutput(Output(All(String("a"), rejected)))
+	assert.EqualError(t, err, "boom")
+}
+
+func TestIndex(t *testing.T) {
+	arr := StringArray{String("a"), String("b")}.ToStringArrayOutput()
+	v, _, err := awaitOutput(Output(arr.Index(Int(1))))
+	assert.NoError(t, err)
+	assert.Equal(t, "b", v)
+
+	v, _, err = awaitOutput(Output(arr.Index(Int(2))))
+	assert.NoError(t, err)
+	assert.Equal(t, "", v)
+
+	m := StringMap{"x": String("y")}.ToStringMapOutput()
+	v, _, err = awaitOutput(Output(m.MapIndex(String("x"))))
+	assert.NoError(t, err)
+	assert.Equal(t, "y", v)
+}
+
+type testResource struct {
+	ResourceState
+
+	Foo    StringOutput    `pulumi:"foo"`
+	Bar    IntArrayOutput  `pulumi:"bar"`
+	Baz    StringMapOutput `pulumi:"baz"`
+	Ignore string
+}
+
+type testResourceArgs struct {
+	Foo StringInput   `pulumi:"foo"`
+	Bar IntArrayInput `pulumi:"bar"`
+	Qux StringInput   `pulumi:"qux"`
+}
+
+func TestMarshalArgs(t *testing.T) {
+	props, err := marshalArgs(&testResourceArgs{
+		Foo: String("hello"),
+		Bar: IntArray{Int(1), Int(2)},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"foo": String("hello"),
+		"bar": IntArray{Int(1), Int(2)},
+	}, props)
+
+	m, _, _, err := marshalInputs(props, true)
+	assert.NoError(t, err)
+	res, err := unmarshalOutputs(m)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"foo": "hello",
+		"bar": []interface{}{1.0, 2.0},
+	}, res)
+
+	props, err = marshalArgs(nil)
+	assert.NoError(t, err)
+	assert.Empty(t, props)
+
+	_, err = marshalArgs(42)
+	assert.Error(t, err)
+}
+
+func TestTypedResourceState(t *testing.T) {
+	var res testResource
+	state, err := makeTypedResourceState(true, &res)
+	assert.NoError(t, err)
+	assert.Len(t, state.State, 3)
+
+	s, _, _, err := marshalInputs(map[string]interface{}{
+		"foo": "qux",
+		"bar": []interface{}{1, 2, 3},
+		"baz": map[string]interface{}{"a": "b"},
+	}, true)
+	assert.NoError(t, err)
+	state.resolve(false, nil, nil, "urn", "id", s)
+
+	v, _, err := awaitOutput(res.Foo.ApplyT(func(v string) string { return v }))
+	assert.NoError(t, err)
+	assert.Equal(t, "qux", v)
+
+	v, _, err = awaitOutput(res.Bar.ApplyT(func(v []int) int { return v[0] + v[1] + v[2] }))
+	assert.NoError(t, err)
+	assert.Equal(t, 6, v)
+
+	v, _, err = awaitOutput(res.Baz.ApplyT(func(v map[string]string) string { return v["a"] }))
+	assert.NoError(t, err)
+	assert.Equal(t, "b", v)
+
+	// Outputs of typed resources carry the resource as a dependency.
+	_, deps, _, err := marshalInputs(map[string]interface{}{"foo": res.Foo}, true)
+	assert.NoError(t, err)
+	assert.Equal(t, []URN{"urn"}, deps["foo"])
+
+	// Resources must embed ResourceState, and their tagged fields must be outputs.
+	_, err = makeTypedResourceState(true, &struct{ ResourceState }{})
+	assert.NoError(t, err)
+	_, err = makeTypedResourceState(true, &struct {
+		ResourceState
+		Foo string `pulumi:"foo"`
+	}{})
+	assert.Error(t, err)
+}
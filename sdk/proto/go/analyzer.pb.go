@@ -50,13 +50,19 @@ func (EnforcementLevel) EnumDescriptor() ([]byte, []int) {
 }
 
 type AnalyzeRequest struct {
-	Type                 string          `protobuf:"bytes,1,opt,name=type" json:"type,omitempty"`
-	Properties           *_struct.Struct `protobuf:"bytes,2,opt,name=properties" json:"properties,omitempty"`
-	Urn                  string          `protobuf:"bytes,3,opt,name=urn" json:"urn,omitempty"`
-	Name                 string          `protobuf:"bytes,4,opt,name=name" json:"name,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
-	XXX_unrecognized     []byte          `json:"-"`
-	XXX_sizecache        int32           `json:"-"`
+	Type         string          `protobuf:"bytes,1,opt,name=type" json:"type,omitempty"`
+	Properties   *_struct.Struct `protobuf:"bytes,2,opt,name=properties" json:"properties,omitempty"`
+	Urn          string          `protobuf:"bytes,3,opt,name=urn" json:"urn,omitempty"`
+	Name         string          `protobuf:"bytes,4,opt,name=name" json:"name,omitempty"`
+	Parent       string          `protobuf:"bytes,5,opt,name=parent" json:"parent,omitempty"`
+	Dependencies []string        `protobuf:"bytes,6,rep,name=dependencies" json:"dependencies,omitempty"`
+	Provider     string          `protobuf:"bytes,7,opt,name=provider" json:"provider,omitempty"`
+	Protect      bool            `protobuf:"varint,8,opt,name=protect" json:"protect,omitempty"`
+	// the URNs of the resources that each of the resource's properties depend on.
+	PropertyDependencies map[string]*AnalyzerPropertyDependencies `protobuf:"bytes,9,rep,name=propertyDependencies" json:"propertyDependencies,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	XXX_NoUnkeyedLiteral struct{}                                 `json:"-"`
+	XXX_unrecognized     []byte                                   `json:"-"`
+	XXX_sizecache        int32                                    `json:"-"`
 }
 
 func (m *AnalyzeRequest) Reset()         { *m = AnalyzeRequest{} }
@@ -111,17 +117,58 @@ func (m *AnalyzeRequest) GetName() string {
 	return ""
 }
 
+func (m *AnalyzeRequest) GetParent() string {
+	if m != nil {
+		return m.Parent
+	}
+	return ""
+}
+
+func (m *AnalyzeRequest) GetDependencies() []string {
+	if m != nil {
+		return m.Dependencies
+	}
+	return nil
+}
+
+func (m *AnalyzeRequest) GetProvider() string {
+	if m != nil {
+		return m.Provider
+	}
+	return ""
+}
+
+func (m *AnalyzeRequest) GetProtect() bool {
+	if m != nil {
+		return m.Protect
+	}
+	return false
+}
+
+func (m *AnalyzeRequest) GetPropertyDependencies() map[string]*AnalyzerPropertyDependencies {
+	if m != nil {
+		return m.PropertyDependencies
+	}
+	return nil
+}
+
 // Resource defines the view of a Pulumi-managed resource as sent to Analyzers. The properties
 // of the resource are specific to the type of analysis being performed. See the Analyzer
 // service definition for more information.
 type AnalyzerResource struct {
-	Type                 string          `protobuf:"bytes,1,opt,name=type" json:"type,omitempty"`
-	Properties           *_struct.Struct `protobuf:"bytes,2,opt,name=properties" json:"properties,omitempty"`
-	Urn                  string          `protobuf:"bytes,3,opt,name=urn" json:"urn,omitempty"`
-	Name                 string          `protobuf:"bytes,4,opt,name=name" json:"name,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
-	XXX_unrecognized     []byte          `json:"-"`
-	XXX_sizecache        int32           `json:"-"`
+	Type         string          `protobuf:"bytes,1,opt,name=type" json:"type,omitempty"`
+	Properties   *_struct.Struct `protobuf:"bytes,2,opt,name=properties" json:"properties,omitempty"`
+	Urn          string          `protobuf:"bytes,3,opt,name=urn" json:"urn,omitempty"`
+	Name         string          `protobuf:"bytes,4,opt,name=name" json:"name,omitempty"`
+	Parent       string          `protobuf:"bytes,5,opt,name=parent" json:"parent,omitempty"`
+	Dependencies []string        `protobuf:"bytes,6,rep,name=dependencies" json:"dependencies,omitempty"`
+	Provider     string          `protobuf:"bytes,7,opt,name=provider" json:"provider,omitempty"`
+	Protect      bool            `protobuf:"varint,8,opt,name=protect" json:"protect,omitempty"`
+	// the URNs of the resources that each of the resource's properties depend on.
+	PropertyDependencies map[string]*AnalyzerPropertyDependencies `protobuf:"bytes,9,rep,name=propertyDependencies" json:"propertyDependencies,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	XXX_NoUnkeyedLiteral struct{}                                 `json:"-"`
+	XXX_unrecognized     []byte                                   `json:"-"`
+	XXX_sizecache        int32                                    `json:"-"`
 }
 
 func (m *AnalyzerResource) Reset()         { *m = AnalyzerResource{} }
@@ -176,6 +223,80 @@ func (m *AnalyzerResource) GetName() string {
 	return ""
 }
 
+func (m *AnalyzerResource) GetParent() string {
+	if m != nil {
+		return m.Parent
+	}
+	return ""
+}
+
+func (m *AnalyzerResource) GetDependencies() []string {
+	if m != nil {
+		return m.Dependencies
+	}
+	return nil
+}
+
+func (m *AnalyzerResource) GetProvider() string {
+	if m != nil {
+		return m.Provider
+	}
+	return ""
+}
+
+func (m *AnalyzerResource) GetProtect() bool {
+	if m != nil {
+		return m.Protect
+	}
+	return false
+}
+
+func (m *AnalyzerResource) GetPropertyDependencies() map[string]*AnalyzerPropertyDependencies {
+	if m != nil {
+		return m.PropertyDependencies
+	}
+	return nil
+}
+
+// AnalyzerPropertyDependencies describes the resources that a particular property depends on.
+type AnalyzerPropertyDependencies struct {
+	Urns                 []string `protobuf:"bytes,1,rep,name=urns" json:"urns,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AnalyzerPropertyDependencies) Reset()         { *m = AnalyzerPropertyDependencies{} }
+func (m *AnalyzerPropertyDependencies) String() string { return proto.CompactTextString(m) }
+func (*AnalyzerPropertyDependencies) ProtoMessage()    {}
+func (*AnalyzerPropertyDependencies) Descriptor() ([]byte, []int) {
+	return fileDescriptor_analyzer_a9c30ddfcaef9aa8, []int{2}
+}
+func (m *AnalyzerPropertyDependencies) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AnalyzerPropertyDependencies.Unmarshal(m, b)
+}
+func (m *AnalyzerPropertyDependencies) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AnalyzerPropertyDependencies.Marshal(b, m, deterministic)
+}
+func (dst *AnalyzerPropertyDependencies) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AnalyzerPropertyDependencies.Merge(dst, src)
+}
+func (m *AnalyzerPropertyDependencies) XXX_Size() int {
+	return xxx_messageInfo_AnalyzerPropertyDependencies.Size(m)
+}
+func (m *AnalyzerPropertyDependencies) XXX_DiscardUnknown() {
+	xxx_messageInfo_AnalyzerPropertyDependencies.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AnalyzerPropertyDependencies proto.InternalMessageInfo
+
+func (m *AnalyzerPropertyDependencies) GetUrns() []string {
+	if m != nil {
+		return m.Urns
+	}
+	return nil
+}
+
 type AnalyzeStackRequest struct {
 	Resources            []*AnalyzerResource `protobuf:"bytes,1,rep,name=resources" json:"resources,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
@@ -187,7 +308,7 @@ func (m *AnalyzeStackRequest) Reset()         { *m = AnalyzeStackRequest{} }
 func (m *AnalyzeStackRequest) String() string { return proto.CompactTextString(m) }
 func (*AnalyzeStackRequest) ProtoMessage()    {}
 func (*AnalyzeStackRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_analyzer_a9c30ddfcaef9aa8, []int{2}
+	return fileDescriptor_analyzer_a9c30ddfcaef9aa8, []int{3}
 }
 func (m *AnalyzeStackRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_AnalyzeStackRequest.Unmarshal(m, b)
@@ -225,7 +346,7 @@ func (m *AnalyzeResponse) Reset()         { *m = AnalyzeResponse{} }
 func (m *AnalyzeResponse) String() string { return proto.CompactTextString(m) }
 func (*AnalyzeResponse) ProtoMessage()    {}
 func (*AnalyzeResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_analyzer_a9c30ddfcaef9aa8, []int{3}
+	return fileDescriptor_analyzer_a9c30ddfcaef9aa8, []int{4}
 }
 func (m *AnalyzeResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_AnalyzeResponse.Unmarshal(m, b)
@@ -270,7 +391,7 @@ func (m *AnalyzeDiagnostic) Reset()         { *m = AnalyzeDiagnostic{} }
 func (m *AnalyzeDiagnostic) String() string { return proto.CompactTextString(m) }
 func (*AnalyzeDiagnostic) ProtoMessage()    {}
 func (*AnalyzeDiagnostic) Descriptor() ([]byte, []int) {
-	return fileDescriptor_analyzer_a9c30ddfcaef9aa8, []int{4}
+	return fileDescriptor_analyzer_a9c30ddfcaef9aa8, []int{5}
 }
 func (m *AnalyzeDiagnostic) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_AnalyzeDiagnostic.Unmarshal(m, b)
@@ -360,7 +481,7 @@ func (m *AnalyzerInfo) Reset()         { *m = AnalyzerInfo{} }
 func (m *AnalyzerInfo) String() string { return proto.CompactTextString(m) }
 func (*AnalyzerInfo) ProtoMessage()    {}
 func (*AnalyzerInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_analyzer_a9c30ddfcaef9aa8, []int{5}
+	return fileDescriptor_analyzer_a9c30ddfcaef9aa8, []int{6}
 }
 func (m *AnalyzerInfo) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_AnalyzerInfo.Unmarshal(m, b)
@@ -417,7 +538,7 @@ func (m *PolicyInfo) Reset()         { *m = PolicyInfo{} }
 func (m *PolicyInfo) String() string { return proto.CompactTextString(m) }
 func (*PolicyInfo) ProtoMessage()    {}
 func (*PolicyInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_analyzer_a9c30ddfcaef9aa8, []int{6}
+	return fileDescriptor_analyzer_a9c30ddfcaef9aa8, []int{7}
 }
 func (m *PolicyInfo) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PolicyInfo.Unmarshal(m, b)
@@ -474,7 +595,10 @@ func (m *PolicyInfo) GetEnforcementLevel() EnforcementLevel {
 
 func init() {
 	proto.RegisterType((*AnalyzeRequest)(nil), "pulumirpc.AnalyzeRequest")
+	proto.RegisterMapType((map[string]*AnalyzerPropertyDependencies)(nil), "pulumirpc.AnalyzeRequest.PropertyDependenciesEntry")
 	proto.RegisterType((*AnalyzerResource)(nil), "pulumirpc.AnalyzerResource")
+	proto.RegisterMapType((map[string]*AnalyzerPropertyDependencies)(nil), "pulumirpc.AnalyzerResource.PropertyDependenciesEntry")
+	proto.RegisterType((*AnalyzerPropertyDependencies)(nil), "pulumirpc.AnalyzerPropertyDependencies")
 	proto.RegisterType((*AnalyzeStackRequest)(nil), "pulumirpc.AnalyzeStackRequest")
 	proto.RegisterType((*AnalyzeResponse)(nil), "pulumirpc.AnalyzeResponse")
 	proto.RegisterType((*AnalyzeDiagnostic)(nil), "pulumirpc.AnalyzeDiagnostic")
@@ -671,43 +795,51 @@ var _Analyzer_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("analyzer.proto", fileDescriptor_analyzer_a9c30ddfcaef9aa8) }
 
 var fileDescriptor_analyzer_a9c30ddfcaef9aa8 = []byte{
-	// 593 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xc4, 0x54, 0xdf, 0x6e, 0xd3, 0x3e,
-	0x14, 0x6e, 0x9a, 0x6e, 0x6d, 0x4f, 0xbb, 0xae, 0xf3, 0x4f, 0x3f, 0x16, 0xba, 0x69, 0x8a, 0x72,
-	0x81, 0x2a, 0x84, 0x52, 0x51, 0x2e, 0x10, 0x37, 0x88, 0xa2, 0x4e, 0xd5, 0xa4, 0x31, 0x4a, 0x8a,
-	0x26, 0x71, 0x99, 0x65, 0x6e, 0x14, 0x2d, 0x8d, 0x8d, 0xed, 0x80, 0xca, 0x2d, 0xe2, 0x4d, 0x78,
-	0x04, 0xde, 0x81, 0xd7, 0x42, 0x76, 0xfe, 0x34, 0x4d, 0xaa, 0x21, 0xed, 0x86, 0x3b, 0xfb, 0x9c,
-	0xcf, 0x9f, 0x8f, 0xbf, 0xef, 0x1c, 0x43, 0xcf, 0x8d, 0xdc, 0x70, 0xfd, 0x0d, 0x33, 0x9b, 0x32,
-	0x22, 0x08, 0x6a, 0xd3, 0x38, 0x8c, 0x57, 0x01, 0xa3, 0xde, 0xa0, 0x4b, 0xc3, 0xd8, 0x0f, 0xa2,
-	0x24, 0x31, 0x38, 0xf1, 0x09, 0xf1, 0x43, 0x3c, 0x52, 0xbb, 0x9b, 0x78, 0x39, 0xc2, 0x2b, 0x2a,
-	0xd6, 0x69, 0xf2, 0xb4, 0x9c, 0xe4, 0x82, 0xc5, 0x9e, 0x48, 0xb2, 0xd6, 0x77, 0x0d, 0x7a, 0x93,
-	0xe4, 0x1a, 0x07, 0x7f, 0x8e, 0x31, 0x17, 0x08, 0x41, 0x43, 0xac, 0x29, 0x36, 0x34, 0x53, 0x1b,
-	0xb6, 0x1d, 0xb5, 0x46, 0x2f, 0x01, 0x28, 0x23, 0x14, 0x33, 0x11, 0x60, 0x6e, 0xd4, 0x4d, 0x6d,
-	0xd8, 0x19, 0x1f, 0xdb, 0x09, 0xb3, 0x9d, 0x31, 0xdb, 0x0b, 0xc5, 0xec, 0x14, 0xa0, 0xa8, 0x0f,
-	0x7a, 0xcc, 0x22, 0x43, 0x57, 0x5c, 0x72, 0x29, 0xe9, 0x23, 0x77, 0x85, 0x8d, 0x46, 0x42, 0x2f,
-	0xd7, 0xd6, 0x0f, 0x0d, 0xfa, 0x69, 0x15, 0xcc, 0xc1, 0x9c, 0xc4, 0xcc, 0xc3, 0xff, 0xa2, 0x8e,
-	0x39, 0xfc, 0x97, 0x96, 0xb1, 0x10, 0xae, 0x77, 0x97, 0x29, 0xf2, 0x0a, 0xda, 0x2c, 0xad, 0x8a,
-	0x1b, 0x9a, 0xa9, 0x0f, 0x3b, 0xe3, 0x13, 0x3b, 0x37, 0xc3, 0x2e, 0x57, 0xee, 0x6c, 0xd0, 0xd6,
-	0x07, 0x38, 0xcc, 0xe5, 0xe5, 0x94, 0x44, 0x1c, 0xa3, 0xd7, 0xd0, 0xb9, 0x0d, 0x5c, 0x3f, 0x22,
-	0x5c, 0x04, 0x9e, 0x7c, 0x84, 0xe4, 0x3b, 0xad, 0xf2, 0x4d, 0x73, 0x90, 0x53, 0x3c, 0x60, 0xfd,
-	0xaa, 0xc3, 0x51, 0x05, 0x82, 0xce, 0x00, 0x28, 0x09, 0x03, 0x6f, 0x7d, 0x25, 0x1f, 0x95, 0x68,
-	0x56, 0x88, 0xa0, 0x27, 0xd0, 0x4b, 0x76, 0x73, 0xd7, 0xbb, 0x53, 0x98, 0xba, 0xc2, 0x94, 0xa2,
-	0xe8, 0x19, 0x1c, 0x6d, 0x22, 0xd7, 0x98, 0xf1, 0x80, 0x64, 0xb2, 0x55, 0x13, 0xc8, 0x84, 0xce,
-	0x2d, 0xe6, 0x1e, 0x0b, 0xa8, 0x90, 0xb8, 0x44, 0xcb, 0x62, 0x08, 0x19, 0xd0, 0x5c, 0x61, 0xce,
-	0x5d, 0x1f, 0x1b, 0x7b, 0x2a, 0x9b, 0x6d, 0x95, 0xbf, 0xae, 0xcf, 0x8d, 0x7d, 0x53, 0x57, 0xfe,
-	0xba, 0x3e, 0x47, 0x33, 0xe8, 0xe3, 0x68, 0x49, 0x98, 0x87, 0x57, 0x38, 0x12, 0x97, 0xf8, 0x0b,
-	0x0e, 0x8d, 0xa6, 0xa9, 0x0d, 0x7b, 0x5b, 0x82, 0x9f, 0x97, 0x20, 0x4e, 0xe5, 0x50, 0xe6, 0x77,
-	0x2b, 0xf7, 0xdb, 0xfa, 0x0a, 0xdd, 0xcc, 0xa8, 0x8b, 0x68, 0x49, 0x72, 0xff, 0xb5, 0x8d, 0xff,
-	0xea, 0x39, 0x01, 0xa7, 0xa1, 0xbb, 0x2e, 0x28, 0x54, 0x0c, 0xa1, 0xe7, 0xd0, 0x52, 0x2a, 0xc8,
-	0xf6, 0xd3, 0x95, 0x73, 0xff, 0x17, 0x0a, 0x9b, 0x2b, 0x81, 0x24, 0xbd, 0x93, 0xc3, 0xac, 0xdf,
-	0x1a, 0xc0, 0x26, 0xf1, 0xc0, 0x7b, 0x4b, 0x42, 0xeb, 0xf7, 0x0a, 0xdd, 0xd8, 0x16, 0x7a, 0x97,
-	0xa8, 0x7b, 0x0f, 0x10, 0xf5, 0xe9, 0x08, 0xfa, 0x65, 0x14, 0xea, 0x42, 0x6b, 0x32, 0xbd, 0xbe,
-	0x58, 0xbc, 0x77, 0x3e, 0xf5, 0x6b, 0xe8, 0x00, 0xda, 0xef, 0x26, 0x57, 0xd3, 0xc9, 0x47, 0xb9,
-	0xd5, 0xc6, 0x3f, 0xeb, 0xd0, 0xca, 0x44, 0x47, 0x6f, 0xa1, 0x99, 0xae, 0xd1, 0xe3, 0x6a, 0xb7,
-	0xa7, 0xb3, 0x36, 0x18, 0xec, 0x4a, 0x25, 0x93, 0x63, 0xd5, 0xd0, 0x65, 0x6e, 0xa2, 0x1a, 0x50,
-	0x74, 0x56, 0x45, 0x17, 0x27, 0xf7, 0x2f, 0x6c, 0x53, 0x38, 0x9c, 0x61, 0xb1, 0xd5, 0x15, 0x8f,
-	0x2a, 0x9f, 0xc9, 0xb9, 0xfc, 0x4b, 0x07, 0xc7, 0x3b, 0xe6, 0x5d, 0x1e, 0xb0, 0x6a, 0xe8, 0x0d,
-	0x1c, 0xcc, 0xb0, 0x98, 0xab, 0x0f, 0xf9, 0x5e, 0x8e, 0xad, 0x4e, 0xc9, 0xe1, 0x56, 0xed, 0x66,
-	0x5f, 0x01, 0x5f, 0xfc, 0x09, 0x00, 0x00, 0xff, 0xff, 0x63, 0x35, 0xde, 0x7f, 0xf1, 0x05, 0x00,
-	0x00,
+	// 726 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe4, 0x56, 0xdb, 0x6e, 0xd3, 0x40,
+	0x10, 0x8d, 0xe3, 0x5e, 0x92, 0x49, 0x9a, 0xa6, 0x0b, 0xb4, 0x6e, 0x5a, 0x55, 0x91, 0x1f, 0x20,
+	0x42, 0x28, 0x15, 0xa9, 0x10, 0x17, 0x09, 0x44, 0x50, 0xaa, 0xaa, 0x52, 0x29, 0xc1, 0x45, 0x95,
+	0x78, 0x74, 0x9d, 0x69, 0x64, 0xd5, 0x59, 0x2f, 0xeb, 0x75, 0x91, 0xf9, 0x1e, 0x3e, 0x81, 0x7f,
+	0xe0, 0x95, 0x07, 0x3e, 0x08, 0xed, 0xfa, 0x52, 0x27, 0x4e, 0x83, 0xd4, 0x37, 0xc4, 0xdb, 0x5c,
+	0xce, 0x9c, 0x5d, 0x9f, 0x99, 0x49, 0x16, 0x1a, 0x36, 0xb5, 0xbd, 0xe8, 0x1b, 0xf2, 0x2e, 0xe3,
+	0xbe, 0xf0, 0x49, 0x95, 0x85, 0x5e, 0x38, 0x71, 0x39, 0x73, 0x5a, 0x75, 0xe6, 0x85, 0x63, 0x97,
+	0xc6, 0x89, 0xd6, 0xce, 0xd8, 0xf7, 0xc7, 0x1e, 0xee, 0x2b, 0xef, 0x22, 0xbc, 0xdc, 0xc7, 0x09,
+	0x13, 0x51, 0x92, 0xdc, 0x9d, 0x4d, 0x06, 0x82, 0x87, 0x8e, 0x88, 0xb3, 0xe6, 0x2f, 0x1d, 0x1a,
+	0xfd, 0xf8, 0x18, 0x0b, 0xbf, 0x84, 0x18, 0x08, 0x42, 0x60, 0x49, 0x44, 0x0c, 0x0d, 0xad, 0xad,
+	0x75, 0xaa, 0x96, 0xb2, 0xc9, 0x73, 0x00, 0xc6, 0x7d, 0x86, 0x5c, 0xb8, 0x18, 0x18, 0xe5, 0xb6,
+	0xd6, 0xa9, 0xf5, 0xb6, 0xba, 0x31, 0x73, 0x37, 0x65, 0xee, 0x9e, 0x29, 0x66, 0x2b, 0x07, 0x25,
+	0x4d, 0xd0, 0x43, 0x4e, 0x0d, 0x5d, 0x71, 0x49, 0x53, 0xd2, 0x53, 0x7b, 0x82, 0xc6, 0x52, 0x4c,
+	0x2f, 0x6d, 0xb2, 0x09, 0x2b, 0xcc, 0xe6, 0x48, 0x85, 0xb1, 0xac, 0xa2, 0x89, 0x47, 0x4c, 0xa8,
+	0x8f, 0x90, 0x21, 0x1d, 0x21, 0x75, 0xe4, 0xc1, 0x2b, 0x6d, 0xbd, 0x53, 0xb5, 0xa6, 0x62, 0xa4,
+	0x05, 0x15, 0xc6, 0xfd, 0x6b, 0x77, 0x84, 0xdc, 0x58, 0x55, 0xd5, 0x99, 0x4f, 0x0c, 0x58, 0x95,
+	0x97, 0x43, 0x47, 0x18, 0x95, 0xb6, 0xd6, 0xa9, 0x58, 0xa9, 0x4b, 0xc6, 0x70, 0x3f, 0xb9, 0x65,
+	0x34, 0xc8, 0x9f, 0x50, 0x6d, 0xeb, 0x9d, 0x5a, 0xef, 0xa0, 0x9b, 0x49, 0xdd, 0x9d, 0x56, 0xa7,
+	0x3b, 0x9c, 0x53, 0x75, 0x48, 0x05, 0x8f, 0xac, 0xb9, 0x84, 0x2d, 0x06, 0xdb, 0xb7, 0x96, 0x48,
+	0x75, 0xae, 0x30, 0x4a, 0x94, 0x96, 0x26, 0x79, 0x0d, 0xcb, 0xd7, 0xb6, 0x17, 0x62, 0xa2, 0xf1,
+	0xa3, 0xe2, 0x45, 0xf8, 0x3c, 0x3a, 0x2b, 0xae, 0x7a, 0x55, 0x7e, 0xa1, 0x99, 0xbf, 0x75, 0x68,
+	0xa6, 0x58, 0x0b, 0x03, 0x3f, 0xe4, 0x0e, 0xfe, 0x1f, 0x4d, 0x75, 0x17, 0x36, 0xf5, 0xd9, 0x1c,
+	0x2d, 0x53, 0x7d, 0xfe, 0x81, 0xb6, 0xf6, 0x60, 0x77, 0x11, 0x54, 0xb6, 0x20, 0xe4, 0x34, 0x30,
+	0x34, 0x25, 0xa7, 0xb2, 0xcd, 0x21, 0xdc, 0x4b, 0x6a, 0xce, 0x84, 0xed, 0x5c, 0xa5, 0x1b, 0xfe,
+	0x12, 0xaa, 0x3c, 0xf9, 0xf0, 0x18, 0x5f, 0xeb, 0xed, 0x2c, 0x10, 0xc7, 0xba, 0x41, 0x9b, 0x1f,
+	0x61, 0x3d, 0x5b, 0x88, 0x80, 0xf9, 0x34, 0x40, 0xf2, 0x06, 0x6a, 0x23, 0xd7, 0x1e, 0x53, 0x3f,
+	0x10, 0xae, 0x23, 0xe7, 0x48, 0xf2, 0xed, 0x16, 0xf9, 0x06, 0x19, 0xc8, 0xca, 0x17, 0x98, 0x3f,
+	0xca, 0xb0, 0x51, 0x80, 0x90, 0x3d, 0x00, 0xe6, 0x7b, 0xae, 0x13, 0x9d, 0xca, 0xb9, 0x8a, 0xa5,
+	0xcc, 0x45, 0xc8, 0x43, 0x68, 0xc4, 0xde, 0xd0, 0x76, 0xae, 0x14, 0xa6, 0xac, 0x30, 0x33, 0x51,
+	0xf2, 0x04, 0x36, 0x6e, 0x22, 0xe7, 0xc8, 0x03, 0xd7, 0x4f, 0x27, 0xb7, 0x98, 0x20, 0x6d, 0xa8,
+	0x8d, 0x30, 0x70, 0xb8, 0xcb, 0x84, 0xc4, 0xc5, 0xe3, 0x9c, 0x0f, 0xc9, 0xe9, 0x9b, 0x60, 0x10,
+	0xd8, 0x63, 0x4c, 0xc6, 0x3a, 0x75, 0xd5, 0x8a, 0xd9, 0xe3, 0x74, 0x9e, 0x95, 0x4d, 0x8e, 0xa0,
+	0x89, 0xf4, 0xd2, 0xe7, 0x0e, 0x4e, 0x90, 0x8a, 0x13, 0xbc, 0x46, 0x4f, 0xcd, 0x73, 0x63, 0x4a,
+	0xf0, 0xc3, 0x19, 0x88, 0x55, 0x28, 0x4a, 0x57, 0xae, 0x92, 0xad, 0x9c, 0xf9, 0x15, 0xea, 0x69,
+	0xa3, 0x8e, 0xe9, 0xa5, 0x9f, 0xad, 0xa0, 0x96, 0x5b, 0x41, 0xf9, 0x39, 0x6e, 0xc0, 0x3c, 0x3b,
+	0xca, 0x29, 0x94, 0x0f, 0x91, 0xa7, 0x50, 0x51, 0x2a, 0xc8, 0x35, 0xd1, 0x55, 0xe7, 0x1e, 0xe4,
+	0x2e, 0x36, 0x54, 0x02, 0x49, 0x7a, 0x2b, 0x83, 0x99, 0x3f, 0x35, 0x80, 0x9b, 0xc4, 0x1d, 0xcf,
+	0x9d, 0x11, 0x5a, 0x5f, 0x28, 0xf4, 0xd2, 0xb4, 0xd0, 0xf3, 0x44, 0x5d, 0xbe, 0x83, 0xa8, 0x8f,
+	0xf7, 0xa1, 0x39, 0x8b, 0x22, 0x75, 0xa8, 0xf4, 0x07, 0xe7, 0xc7, 0x67, 0x1f, 0xac, 0xcf, 0xcd,
+	0x12, 0x59, 0x83, 0xea, 0xfb, 0xfe, 0xe9, 0xa0, 0xff, 0x49, 0xba, 0x5a, 0xef, 0x7b, 0x19, 0x2a,
+	0xa9, 0xe8, 0xe4, 0x1d, 0xac, 0x26, 0x36, 0xd9, 0xbe, 0xf5, 0xff, 0xa2, 0xd5, 0x9a, 0x97, 0x8a,
+	0x37, 0xc7, 0x2c, 0x91, 0x13, 0xa8, 0xe7, 0x17, 0x94, 0xec, 0x15, 0xd1, 0xf9, 0xcd, 0xfd, 0x0b,
+	0xdb, 0x00, 0xd6, 0x8f, 0x50, 0x4c, 0x4d, 0xc5, 0x66, 0xe1, 0xf7, 0xfc, 0x50, 0xbe, 0x0d, 0x5a,
+	0x5b, 0x45, 0x22, 0x55, 0x60, 0x96, 0xc8, 0x5b, 0x58, 0x3b, 0x42, 0x31, 0x54, 0x0f, 0x8c, 0x85,
+	0x1c, 0x53, 0x93, 0x92, 0xc1, 0xcd, 0xd2, 0xc5, 0x8a, 0x02, 0x1e, 0xfc, 0x19, 0x00, 0xd1, 0x89,
+	0xc4, 0xd6, 0xc1, 0x08, 0x00, 0x00,
 }